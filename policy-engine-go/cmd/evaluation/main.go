@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/datacline/policy-engine/internal/api/evaluation"
@@ -9,6 +11,10 @@ import (
 	"github.com/datacline/policy-engine/internal/api/health"
 	"github.com/datacline/policy-engine/internal/clients/java_gateway"
 	"github.com/datacline/policy-engine/internal/config"
+	"github.com/datacline/policy-engine/internal/engine"
+	"github.com/datacline/policy-engine/internal/notify"
+	"github.com/datacline/policy-engine/internal/policyschema"
+	"github.com/datacline/policy-engine/internal/policytype"
 	evalService "github.com/datacline/policy-engine/internal/services/evaluation"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -47,15 +53,65 @@ func main() {
 	}
 
 	// Load policies from disk
-	policies, err := config.LoadPolicies(cfg.PolicyDir)
+	policies, err := config.LoadPolicies(cfg.PolicyDir, cfg)
 	if err != nil {
 		log.WithError(err).Fatal("Failed to load policies")
 	}
 
+	// Fail fast if a loaded policy references a missing policy type or fails
+	// its schema, rather than silently mis-evaluating it at request time -
+	// this deployment has no management API to reject a bad policy at save
+	// time, so the only checkpoint left is process startup.
+	policyTypes, err := config.LoadPolicyTypes(filepath.Join(cfg.PolicyDir, "policy-types"))
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load policy types")
+	}
+	if err := policytype.ValidateAll(policies, policyTypes); err != nil {
+		log.WithError(err).Fatal("Policy type validation failed")
+	}
+
 	// Initialize evaluation service
-	evalSvc := evalService.NewService(policies)
+	dispatcherOpts := notify.DefaultDispatcherOptions()
+	dispatcherOpts.AllowedHosts = cfg.WebhookAllowedHosts
+	dispatcher := notify.NewDispatcher(cfg.PolicyDir, dispatcherOpts)
+	rateLimiter, err := cfg.NewRateLimiter()
+	if err != nil {
+		log.WithError(err).Fatal("Failed to initialize rate limiter")
+	}
+	schemaRegistry, err := policyschema.NewRegistry(cfg.PolicyDir)
+	if err != nil {
+		log.WithError(err).Warn("Failed to initialize entity schema registry; schema-aware coercion disabled")
+	}
+
+	// ConditionTypeJWT verifier, built from JWT_PROVIDERS_FILE. Nil
+	// (JWTProvidersFile unset) makes ConditionTypeJWT conditions never
+	// match, same as an unset rate limiter would for ConditionTypeRate.
+	jwtVerifier, err := cfg.NewJWTVerifier()
+	if err != nil {
+		log.WithError(err).Warn("Failed to initialize jwt provider(s); JWT conditions disabled")
+	} else if jwtVerifier != nil {
+		jwtVerifier.Watch(context.Background())
+		log.Info("JWT provider JWKS refresh started")
+	}
+
+	evalSvc := evalService.NewServiceWithOptions(policies, evalService.ServiceOptions{
+		Dispatcher:  dispatcher,
+		RateLimiter: rateLimiter,
+		Schema:      schemaRegistry,
+		Mode:        engine.EvaluationMode(cfg.EvaluationMode),
+		JWTVerifier: jwtVerifier,
+	})
 	log.WithField("policy_count", len(policies)).Info("Evaluation service initialized")
 
+	// Watch the policy directory for changes so this read-only deployment
+	// can pick up new policies without an operator restarting the process
+	policyWatcher := config.NewWatcher(cfg.PolicyDir, cfg, evalSvc.Reload)
+	if err := policyWatcher.Start(context.Background()); err != nil {
+		log.WithError(err).Warn("Policy hot-reload watcher not started")
+	} else {
+		defer policyWatcher.Stop()
+	}
+
 	// Setup router
 	router := gin.Default()
 
@@ -75,14 +131,16 @@ func main() {
 
 	// API routes
 	api := router.Group("/api/v1")
-	
+
 	// Register gateway proxy endpoints
 	gatewayProxyHandler := gatewayProxy.NewHandler(gatewayClient)
 	gatewayProxyHandler.RegisterRoutes(api)
 	log.Info("Gateway proxy endpoints registered")
-	
+
 	// Register evaluation endpoints
 	evalHandler := evaluation.NewHandler(evalSvc)
+	evalHandler.SetWatcher(policyWatcher)
+	evalHandler.SetStreamWorkers(cfg.StreamWorkers)
 	evalHandler.RegisterRoutes(api)
 
 	log.WithField("port", cfg.Port).Info("Policy Evaluation Service ready (read-only)")
@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"os"
+	"strings"
 	"time"
 
 	gatewayProxy "github.com/datacline/policy-engine/internal/api/gateway_proxy"
 	"github.com/datacline/policy-engine/internal/api/health"
 	"github.com/datacline/policy-engine/internal/api/management"
+	"github.com/datacline/policy-engine/internal/api/unified"
 	"github.com/datacline/policy-engine/internal/clients/java_gateway"
+	"github.com/datacline/policy-engine/internal/cluster"
 	"github.com/datacline/policy-engine/internal/config"
+	"github.com/datacline/policy-engine/internal/notify"
+	"github.com/datacline/policy-engine/internal/schedule"
 	mgmtService "github.com/datacline/policy-engine/internal/services/management"
+	"github.com/datacline/policy-engine/internal/storage"
+	"github.com/datacline/policy-engine/internal/unifiedreplication"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
@@ -19,11 +28,22 @@ import (
 // This binary only handles policy CRUD operations, not evaluation
 // Suitable for administrative/control plane deployments
 func main() {
+	peersFlag := flag.String("peers", "", "comma-separated cluster peer base URLs for policy sync (overrides PEER_URLS)")
+	flag.Parse()
+
 	// Force management-only mode
 	os.Setenv("ENABLE_EVALUATION", "false")
 	os.Setenv("ENABLE_MANAGEMENT", "true")
 
 	cfg := config.LoadConfig()
+	if *peersFlag != "" {
+		cfg.PeerURLs = nil
+		for _, peer := range strings.Split(*peersFlag, ",") {
+			if trimmed := strings.TrimSpace(peer); trimmed != "" {
+				cfg.PeerURLs = append(cfg.PeerURLs, trimmed)
+			}
+		}
+	}
 	setupLogging(cfg.LogLevel)
 
 	log.WithFields(log.Fields{
@@ -47,7 +67,12 @@ func main() {
 	}
 
 	// Initialize management service
-	mgmtSvc, err := mgmtService.NewService(cfg.PolicyDir)
+	signingKey, err := cfg.SigningKey()
+	if err != nil {
+		log.WithError(err).Fatal("Invalid SIGNING_KEY")
+	}
+
+	mgmtSvc, err := mgmtService.NewServiceWithSigning(cfg.PolicyDir, signingKey, cfg.EnforceSignatures)
 	if err != nil {
 		log.WithError(err).Fatal("Failed to initialize management service")
 	}
@@ -72,16 +97,64 @@ func main() {
 
 	// API routes
 	api := router.Group("/api/v1")
-	
+
 	// Register gateway proxy endpoints
 	gatewayProxyHandler := gatewayProxy.NewHandler(gatewayClient)
 	gatewayProxyHandler.RegisterRoutes(api)
 	log.Info("Gateway proxy endpoints registered")
-	
+
 	// Register management endpoints
 	mgmtHandler := management.NewHandler(mgmtSvc, nil) // No evaluation service in this mode
 	mgmtHandler.RegisterRoutes(api)
 
+	// Initialize unified policy storage and handlers
+	unifiedStorage, err := storage.NewUnifiedStorage(cfg.PolicyDir)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to initialize unified policy storage")
+	}
+	webhookDispatcherOpts := notify.DefaultDispatcherOptions()
+	webhookDispatcherOpts.AllowedHosts = cfg.WebhookAllowedHosts
+	unifiedStorage.SetDispatcher(notify.NewDispatcher(cfg.PolicyDir, webhookDispatcherOpts))
+	unifiedStorage.SetSigning(signingKey, cfg.EnforceSignatures)
+	unifiedHandler := unified.NewHandler(unifiedStorage)
+
+	// ConditionTypeJWT verifier, reused here as the identity behind policy
+	// approvals - see unified.Handler.SetApprovalVerifier.
+	jwtVerifier, err := cfg.NewJWTVerifier()
+	if err != nil {
+		log.WithError(err).Warn("Failed to initialize jwt provider(s); policy approvals disabled")
+	} else if jwtVerifier != nil {
+		jwtVerifier.Watch(context.Background())
+		unifiedHandler.SetApprovalVerifier(jwtVerifier)
+		log.Info("JWT provider JWKS refresh started")
+	} else {
+		log.Warn("No JWT provider configured; policy approvals will be refused until JWT_PROVIDERS_FILE is set")
+	}
+
+	unifiedReplicationManager, err := unifiedreplication.NewManager(cfg.PolicyDir, unifiedStorage.ExportBundle)
+	if err != nil {
+		log.WithError(err).Warn("Failed to initialize unified policy replication manager")
+	} else {
+		unifiedReplicationManager.Start(context.Background())
+		unifiedHandler.SetReplicationManager(unifiedReplicationManager)
+		log.Info("Unified policy bundle replication enabled")
+	}
+
+	unifiedHandler.RegisterRoutes(api)
+	unifiedHandler.RegisterInternalRoutes(router)
+	log.WithField("policy_count", len(unifiedStorage.GetAll())).Info("Unified policy endpoints registered")
+
+	// Re-evaluate cron-based policy schedules every minute
+	scheduleEngine := schedule.NewEngine(unifiedStorage, time.Minute)
+	scheduleEngine.Start(context.Background())
+	log.Info("Policy schedule engine started")
+
+	if len(cfg.PeerURLs) > 0 {
+		notifier := cluster.NewPeerNotifier(cfg.PeerURLs, cfg.PeerQuorum)
+		unifiedHandler.SetNotifier(notifier)
+		log.WithFields(log.Fields{"peers": cfg.PeerURLs, "quorum": cfg.PeerQuorum}).Info("Cluster-wide policy sync enabled")
+	}
+
 	log.WithField("port", cfg.Port).Info("Policy Management Service ready (CRUD only)")
 	log.Warn("Evaluation endpoints are NOT available in this deployment")
 	log.Info("Policy changes are persisted but not evaluated by this service")
@@ -1,30 +1,58 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/datacline/policy-engine/internal/api/admission"
+	auditapi "github.com/datacline/policy-engine/internal/api/audit"
 	"github.com/datacline/policy-engine/internal/api/catalog"
 	"github.com/datacline/policy-engine/internal/api/evaluation"
+	gatewayProxy "github.com/datacline/policy-engine/internal/api/gateway_proxy"
 	"github.com/datacline/policy-engine/internal/api/health"
 	"github.com/datacline/policy-engine/internal/api/management"
 	"github.com/datacline/policy-engine/internal/api/unified"
 	"github.com/datacline/policy-engine/internal/api/users"
+	"github.com/datacline/policy-engine/internal/audit"
+	"github.com/datacline/policy-engine/internal/clients/java_gateway"
+	"github.com/datacline/policy-engine/internal/cluster"
 	"github.com/datacline/policy-engine/internal/config"
+	"github.com/datacline/policy-engine/internal/engine"
+	"github.com/datacline/policy-engine/internal/idp"
+	"github.com/datacline/policy-engine/internal/metrics"
 	"github.com/datacline/policy-engine/internal/models"
-	"github.com/datacline/policy-engine/internal/storage"
+	"github.com/datacline/policy-engine/internal/notify"
+	"github.com/datacline/policy-engine/internal/policyschema"
+	"github.com/datacline/policy-engine/internal/policytype"
+	"github.com/datacline/policy-engine/internal/replication"
+	"github.com/datacline/policy-engine/internal/schedule"
 	evalService "github.com/datacline/policy-engine/internal/services/evaluation"
 	mgmtService "github.com/datacline/policy-engine/internal/services/management"
+	"github.com/datacline/policy-engine/internal/storage"
+	"github.com/datacline/policy-engine/internal/unifiedreplication"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
-	gatewayProxy "github.com/datacline/policy-engine/internal/api/gateway_proxy"
-	"github.com/datacline/policy-engine/internal/clients/java_gateway"
 )
 
 func main() {
+	peersFlag := flag.String("peers", "", "comma-separated cluster peer base URLs for policy sync (overrides PEER_URLS)")
+	flag.Parse()
+
 	// Load configuration
 	cfg := config.LoadConfig()
+	if *peersFlag != "" {
+		cfg.PeerURLs = nil
+		for _, peer := range strings.Split(*peersFlag, ",") {
+			if trimmed := strings.TrimSpace(peer); trimmed != "" {
+				cfg.PeerURLs = append(cfg.PeerURLs, trimmed)
+			}
+		}
+	}
 
 	// Setup logging
 	setupLogging(cfg.LogLevel)
@@ -40,6 +68,8 @@ func main() {
 
 	// Setup router
 	router := gin.Default()
+	router.Use(metrics.Middleware())
+	router.GET("/metrics", metrics.Handler())
 
 	// Setup CORS middleware
 	router.Use(cors.New(cors.Config{
@@ -58,6 +88,8 @@ func main() {
 	// Initialize services based on configuration
 	var evalSvc *evalService.Service
 	var mgmtSvc *mgmtService.Service
+	var usersStorage *storage.UsersStorage
+	lastReloadAt := time.Now()
 
 	// Initialize Java gateway client
 	javaGatewayURL := os.Getenv("JAVA_GATEWAY_URL")
@@ -73,10 +105,49 @@ func main() {
 		log.WithField("url", javaGatewayURL).Info("Java gateway connected")
 	}
 
+	// Entity attribute schema registry, shared by the management service's
+	// ValidatePolicy and the evaluation engine's condition coercion
+	schemaRegistry, err := policyschema.NewRegistry(cfg.PolicyDir)
+	if err != nil {
+		log.WithError(err).Warn("Failed to initialize entity schema registry; schema-aware validation disabled")
+	}
+
+	// PolicyType registry, consulted by the management service's
+	// CreatePolicy/UpdatePolicy for any policy referencing a TypeID. Not
+	// needed by the evaluation engine - PolicyType only governs what gets
+	// saved, not how a saved policy is evaluated.
+	policyTypeRegistry, err := policytype.NewRegistry(cfg.PolicyDir)
+	if err != nil {
+		log.WithError(err).Warn("Failed to initialize policy type registry; policy type validation disabled")
+	}
+
+	// ConditionTypeJWT verifier, built from JWT_PROVIDERS_FILE. Nil
+	// (JWTProvidersFile unset) makes ConditionTypeJWT conditions never
+	// match, same as an unset rate limiter would for ConditionTypeRate.
+	// Also backs unified.Handler's approval endpoint, which requires a
+	// verified bearer identity rather than trusting the unauthenticated
+	// X-Actor header - see unifiedHandler.SetApprovalVerifier below.
+	jwtVerifier, err := cfg.NewJWTVerifier()
+	if err != nil {
+		log.WithError(err).Warn("Failed to initialize jwt provider(s); JWT conditions and approvals disabled")
+	} else if jwtVerifier != nil {
+		jwtVerifier.Watch(context.Background())
+		log.Info("JWT provider JWKS refresh started")
+	}
+
 	// Initialize management service if enabled
 	if cfg.EnableManagement {
-		var err error
-		mgmtSvc, err = mgmtService.NewService(cfg.PolicyDir)
+		signingKey, err := cfg.SigningKey()
+		if err != nil {
+			log.WithError(err).Fatal("Invalid SIGNING_KEY")
+		}
+
+		mgmtSvc, err = mgmtService.NewServiceWithOptions(cfg.PolicyDir, mgmtService.ServiceOptions{
+			SigningKey:        signingKey,
+			EnforceSignatures: cfg.EnforceSignatures,
+			Schema:            schemaRegistry,
+			PolicyTypes:       policyTypeRegistry,
+		})
 		if err != nil {
 			log.WithError(err).Fatal("Failed to initialize management service")
 		}
@@ -86,21 +157,57 @@ func main() {
 	// Initialize evaluation service if enabled
 	if cfg.EnableEvaluation {
 		var policies []*models.Policy
-		
+
 		if mgmtSvc != nil {
 			// Get policies from management service
 			policies = mgmtSvc.ListPolicies()
 		} else {
 			// Load policies directly from disk (evaluation-only mode)
 			var err error
-			policies, err = config.LoadPolicies(cfg.PolicyDir)
+			policies, err = config.LoadPolicies(cfg.PolicyDir, cfg)
 			if err != nil {
 				log.WithError(err).Fatal("Failed to load policies")
 			}
 		}
-		
-		evalSvc = evalService.NewService(policies)
-		log.WithField("policy_count", len(policies)).Info("Evaluation service initialized")
+
+		dispatcherOpts := notify.DefaultDispatcherOptions()
+		dispatcherOpts.AllowedHosts = cfg.WebhookAllowedHosts
+		dispatcher := notify.NewDispatcher(cfg.PolicyDir, dispatcherOpts)
+		rateLimiter, err := cfg.NewRateLimiter()
+		if err != nil {
+			log.WithError(err).Fatal("Failed to initialize rate limiter")
+		}
+
+		evalSvc = evalService.NewServiceWithOptions(policies, evalService.ServiceOptions{
+			Dispatcher:  dispatcher,
+			RateLimiter: rateLimiter,
+			Schema:      schemaRegistry,
+			Mode:        engine.EvaluationMode(cfg.EvaluationMode),
+			JWTVerifier: jwtVerifier,
+		})
+		log.WithFields(log.Fields{
+			"policy_count": len(policies),
+			"rate_limiter": cfg.RateLimiterBackend,
+		}).Info("Evaluation service initialized")
+	}
+
+	// Watch PolicyDir for changes so policies can be hot-reloaded without an
+	// operator calling POST /reload
+	var policyWatcher *config.Watcher
+	if cfg.EnableEvaluation && evalSvc != nil {
+		policyWatcher = config.NewWatcher(cfg.PolicyDir, cfg, func(policies []*models.Policy) {
+			if mgmtSvc != nil {
+				if _, err := mgmtSvc.ReloadFromDisk(); err != nil {
+					log.WithError(err).Warn("Management service failed to reload policies from disk")
+				}
+			}
+			evalSvc.Reload(policies)
+		})
+		if err := policyWatcher.Start(context.Background()); err != nil {
+			log.WithError(err).Warn("Policy hot-reload watcher not started")
+		} else {
+			defer policyWatcher.Stop()
+		}
 	}
 
 	// Setup API routes
@@ -114,8 +221,17 @@ func main() {
 	// Register evaluation endpoints
 	if cfg.EnableEvaluation && evalSvc != nil {
 		evalHandler := evaluation.NewHandler(evalSvc)
+		evalHandler.SetWatcher(policyWatcher)
+		evalHandler.SetStreamWorkers(cfg.StreamWorkers)
 		evalHandler.RegisterRoutes(api)
 		log.Info("Evaluation endpoints registered")
+
+		// Gatekeeper-style validating admission webhook, backed by the same
+		// evaluation engine as POST /evaluate
+		admissionHandler := admission.NewHandler(evalSvc)
+		admissionHandler.SetAuditOnly(cfg.AdmissionAuditOnly)
+		admissionHandler.RegisterRoutes(api)
+		log.WithField("audit_only", cfg.AdmissionAuditOnly).Info("Admission webhook endpoint registered")
 	}
 
 	// Register management endpoints
@@ -124,8 +240,18 @@ func main() {
 			// Management-only mode: no evaluation service to sync with
 			log.Warn("Running in management-only mode: policy changes will not be evaluated")
 		}
-		
+
 		mgmtHandler := management.NewHandler(mgmtSvc, evalSvc)
+
+		replicationManager, err := replication.NewManager(cfg.PolicyDir, mgmtSvc.ListPolicies)
+		if err != nil {
+			log.WithError(err).Warn("Failed to initialize policy replication manager")
+		} else {
+			replicationManager.Start(context.Background())
+			mgmtHandler.SetReplicationManager(replicationManager)
+			log.Info("Policy replication enabled")
+		}
+
 		mgmtHandler.RegisterRoutes(api)
 		log.Info("Management endpoints registered")
 	}
@@ -135,18 +261,94 @@ func main() {
 	if err != nil {
 		log.WithError(err).Fatal("Failed to initialize unified policy storage")
 	}
+	webhookDispatcherOpts := notify.DefaultDispatcherOptions()
+	webhookDispatcherOpts.AllowedHosts = cfg.WebhookAllowedHosts
+	webhookDispatcher := notify.NewDispatcher(cfg.PolicyDir, webhookDispatcherOpts)
+	unifiedStorage.SetDispatcher(webhookDispatcher)
+	if signingKey, err := cfg.SigningKey(); err != nil {
+		log.WithError(err).Fatal("Invalid SIGNING_KEY")
+	} else {
+		unifiedStorage.SetSigning(signingKey, cfg.EnforceSignatures)
+	}
+	if evalSvc != nil {
+		// So POST /evaluate/simulate can diff a proposed draft policy set
+		// against what's actually active today.
+		evalSvc.SetUnifiedPolicySource(unifiedStorage)
+	}
+
 	unifiedHandler := unified.NewHandler(unifiedStorage)
+	if jwtVerifier != nil {
+		unifiedHandler.SetApprovalVerifier(jwtVerifier)
+	} else {
+		log.Warn("No JWT provider configured; policy approvals will be refused until JWT_PROVIDERS_FILE is set")
+	}
+
+	unifiedReplicationManager, err := unifiedreplication.NewManager(cfg.PolicyDir, unifiedStorage.ExportBundle)
+	if err != nil {
+		log.WithError(err).Warn("Failed to initialize unified policy replication manager")
+	} else {
+		unifiedReplicationManager.Start(context.Background())
+		unifiedHandler.SetReplicationManager(unifiedReplicationManager)
+		log.Info("Unified policy bundle replication enabled")
+	}
+
 	unifiedHandler.RegisterRoutes(api)
+	unifiedHandler.RegisterInternalRoutes(router)
 	log.WithField("policy_count", len(unifiedStorage.GetAll())).Info("Unified policy endpoints registered")
 
+	// Tamper-evident audit log of live policy decisions. FileSink is the
+	// default backend; PostgresSink/KafkaSink are available to callers
+	// that construct their own *UnifiedStorage wiring rather than going
+	// through this binary.
+	auditSink, err := audit.NewFileSink(cfg.PolicyDir + "/../data/audit.jsonl")
+	if err != nil {
+		log.WithError(err).Warn("Failed to initialize audit log, decisions will not be recorded")
+	} else {
+		auditLogger, err := audit.NewLogger(auditSink)
+		if err != nil {
+			log.WithError(err).Warn("Failed to resume audit log chain, decisions will not be recorded")
+		} else {
+			unifiedHandler.SetAuditLogger(auditLogger)
+			auditapi.NewHandler(auditLogger).RegisterRoutes(api)
+			log.Info("Policy decision audit log enabled")
+		}
+	}
+
+	// Re-evaluate cron-based policy schedules every minute
+	scheduleEngine := schedule.NewEngine(unifiedStorage, time.Minute)
+	scheduleEngine.Start(context.Background())
+	log.Info("Policy schedule engine started")
+
+	if len(cfg.PeerURLs) > 0 {
+		notifier := cluster.NewPeerNotifier(cfg.PeerURLs, cfg.PeerQuorum)
+		unifiedHandler.SetNotifier(notifier)
+		log.WithFields(log.Fields{"peers": cfg.PeerURLs, "quorum": cfg.PeerQuorum}).Info("Cluster-wide policy sync enabled")
+	}
+
 	// Initialize users storage and handlers
 	// Users data is stored in the data/ directory relative to the policy dir's parent
 	dataDir := cfg.PolicyDir + "/../data"
-	usersStorage, err := storage.NewUsersStorage(dataDir)
-	if err != nil {
-		log.WithError(err).Warn("Failed to initialize users storage, using empty data")
+	var usersErr error
+	usersStorage, usersErr = storage.NewUsersStorage(dataDir)
+	if usersErr != nil {
+		log.WithError(usersErr).Warn("Failed to initialize users storage, using empty data")
 	} else {
 		usersHandler := users.NewHandler(usersStorage)
+
+		// Optionally sync principals from a SCIM identity provider
+		if scimBaseURL := os.Getenv("SCIM_BASE_URL"); scimBaseURL != "" {
+			scimSource := idp.NewSCIMSource(idp.SCIMConfig{
+				Name:    "scim",
+				BaseURL: scimBaseURL,
+				Token:   os.Getenv("SCIM_TOKEN"),
+			})
+			syncInterval := 5 * time.Minute
+			syncer := idp.NewSyncer(usersStorage, syncInterval, scimSource)
+			syncer.Start(context.Background())
+			usersHandler.SetSyncer(syncer)
+			log.WithField("base_url", scimBaseURL).Info("SCIM principal sync enabled")
+		}
+
 		usersHandler.RegisterRoutes(api)
 		log.Info("Users/principals endpoints registered")
 	}
@@ -156,12 +358,55 @@ func main() {
 	catalogHandler.RegisterRoutes(api)
 	log.Info("MCP Catalog endpoints registered")
 
+	// Register gauge metrics sourced from storage
+	metrics.RegisterGaugeFunc("policies_total", "Total unified policies currently loaded", func() float64 {
+		return float64(len(unifiedStorage.GetAll()))
+	})
+	metrics.RegisterGaugeFunc("seconds_since_last_reload", "Seconds since policies/principals were last (re)loaded", func() float64 {
+		return time.Since(lastReloadAt).Seconds()
+	})
+	metrics.RegisterLabeledGaugeFunc("principals_total", "Total principals loaded, labeled by kind", "kind", func() map[string]float64 {
+		if usersStorage == nil {
+			return map[string]float64{}
+		}
+		data := usersStorage.GetAllData()
+		return map[string]float64{
+			"user":  float64(len(data.Users)),
+			"group": float64(len(data.Groups)),
+			"role":  float64(len(data.Roles)),
+		}
+	})
+
+	// Named readiness checks
+	healthHandler.AddNamedCheck("java_gateway", gatewayClient.HealthCheck)
+	healthHandler.AddNamedCheck("policy_dir_readable", func() error {
+		_, err := os.ReadDir(cfg.PolicyDir)
+		return err
+	})
+	healthHandler.AddNamedCheck("users_data_loaded", func() error {
+		if usersStorage == nil {
+			return fmt.Errorf("users storage not initialized")
+		}
+		return nil
+	})
+	healthHandler.AddChecker(unifiedStorage)
+	healthHandler.AddChecker(storage.NewPolicyCompilerChecker(unifiedStorage))
+	healthHandler.AddChecker(webhookDispatcher)
+
 	// Start server
 	log.WithFields(log.Fields{
 		"port":    cfg.Port,
 		"service": cfg.GetServiceName(),
 	}).Info("Policy Engine ready")
 
+	if cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" {
+		log.WithField("port", cfg.Port).Info("Serving over TLS (required by Kubernetes for the admission webhook)")
+		if err := router.RunTLS(":"+cfg.Port, cfg.TLSCertFile, cfg.TLSKeyFile); err != nil {
+			log.WithError(err).Fatal("Failed to start TLS server")
+		}
+		return
+	}
+
 	if err := router.Run(":" + cfg.Port); err != nil {
 		log.WithError(err).Fatal("Failed to start server")
 	}
@@ -0,0 +1,122 @@
+package admission
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// templateFuncs is the same small, safe helper set storage.templateFuncs
+// offers UnifiedPolicy templates, reproduced here since a ConstraintTemplate
+// renders into a different target type ([]models.PolicyRule, not
+// []models.PolicyRuleDSL) and the two packages don't otherwise share code.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"default": func(def, val interface{}) interface{} {
+		if val == nil || val == "" {
+			return def
+		}
+		return val
+	},
+	"quote": func(v interface{}) string { return fmt.Sprintf("%q", fmt.Sprint(v)) },
+}
+
+// Compile renders tmpl's RulesTemplate with constraint's params and wraps
+// the result in a Policy, ready for management.Service to save and the
+// evaluation engine to enforce. It does not set Policy.ID - the caller
+// assigns one when persisting (or reuses constraint.PolicyID on re-render).
+func Compile(tmpl *models.ConstraintTemplate, constraint *models.Constraint) (*models.Policy, error) {
+	merged, err := mergeParams(tmpl.Parameters, constraint.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := template.New(tmpl.Name).Funcs(templateFuncs).Parse(tmpl.RulesTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, merged); err != nil {
+		return nil, fmt.Errorf("failed to render rules template: %w", err)
+	}
+
+	var rules []models.PolicyRule
+	if err := yaml.Unmarshal(buf.Bytes(), &rules); err != nil {
+		return nil, fmt.Errorf("rendered rules are not valid policy_rules YAML: %w", err)
+	}
+
+	enforcement := constraint.Enforcement
+	if enforcement == "" {
+		enforcement = "blocking"
+	}
+
+	return &models.Policy{
+		ID:          constraint.PolicyID,
+		Name:        constraint.Name,
+		Description: fmt.Sprintf("Compiled from constraint template %q", tmpl.Name),
+		Enabled:     true,
+		Rules:       rules,
+		Enforcement: enforcement,
+	}, nil
+}
+
+// mergeParams fills in declared defaults, enforces required parameters, and
+// type-checks supplied values against schema - the same validation
+// storage.mergeTemplateParams applies to a PolicyTemplate's parameters.
+func mergeParams(schema []models.TemplateParameter, supplied map[string]interface{}) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(schema))
+	for _, p := range schema {
+		val, ok := supplied[p.Name]
+		if !ok || val == nil {
+			if p.Required && p.Default == nil {
+				return nil, fmt.Errorf("missing required template parameter: %s", p.Name)
+			}
+			val = p.Default
+		}
+		if val != nil {
+			if err := checkParamType(p, val); err != nil {
+				return nil, err
+			}
+		}
+		merged[p.Name] = val
+	}
+	return merged, nil
+}
+
+func checkParamType(p models.TemplateParameter, val interface{}) error {
+	switch p.Type {
+	case models.TemplateParamString:
+		if _, ok := val.(string); !ok {
+			return fmt.Errorf("parameter %q must be a string", p.Name)
+		}
+	case models.TemplateParamBool:
+		if _, ok := val.(bool); !ok {
+			return fmt.Errorf("parameter %q must be a bool", p.Name)
+		}
+	case models.TemplateParamInt:
+		switch v := val.(type) {
+		case int, int64:
+		case float64:
+			if v != float64(int64(v)) {
+				return fmt.Errorf("parameter %q must be an int", p.Name)
+			}
+		default:
+			return fmt.Errorf("parameter %q must be an int", p.Name)
+		}
+	case models.TemplateParamFloat:
+		switch val.(type) {
+		case float64, float32, int, int64:
+		default:
+			return fmt.Errorf("parameter %q must be a number", p.Name)
+		}
+	default:
+		return fmt.Errorf("unknown parameter type %q for parameter %q", p.Type, p.Name)
+	}
+	return nil
+}
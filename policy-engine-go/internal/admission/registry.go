@@ -0,0 +1,212 @@
+// Package admission compiles Gatekeeper-style ConstraintTemplate/Constraint
+// pairs into models.Policy rows the existing evaluation engine already
+// understands, and translates a Kubernetes (or Kubernetes-compatible)
+// AdmissionReview into the models.PolicyEvaluationRequest shape
+// engine.Evaluate expects.
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/google/uuid"
+)
+
+// Registry persists ConstraintTemplates and Constraints to
+// policyDir/.admission/{templates,constraints}/, one file per record,
+// mirroring the replication package's targetStore/executionStore layout.
+type Registry struct {
+	templateDir   string
+	constraintDir string
+
+	mu          sync.RWMutex
+	templates   map[string]*models.ConstraintTemplate
+	constraints map[string]*models.Constraint
+}
+
+// NewRegistry loads a Registry's ConstraintTemplates and Constraints from
+// policyDir.
+func NewRegistry(policyDir string) (*Registry, error) {
+	r := &Registry{
+		templateDir:   filepath.Join(policyDir, ".admission", "templates"),
+		constraintDir: filepath.Join(policyDir, ".admission", "constraints"),
+		templates:     make(map[string]*models.ConstraintTemplate),
+		constraints:   make(map[string]*models.Constraint),
+	}
+	if err := r.loadTemplates(); err != nil {
+		return nil, fmt.Errorf("failed to load constraint templates: %w", err)
+	}
+	if err := r.loadConstraints(); err != nil {
+		return nil, fmt.Errorf("failed to load constraints: %w", err)
+	}
+	return r, nil
+}
+
+func (r *Registry) loadTemplates() error {
+	entries, err := os.ReadDir(r.templateDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(r.templateDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		var tmpl models.ConstraintTemplate
+		if err := json.Unmarshal(data, &tmpl); err != nil {
+			return err
+		}
+		r.templates[tmpl.ID] = &tmpl
+	}
+	return nil
+}
+
+func (r *Registry) loadConstraints() error {
+	entries, err := os.ReadDir(r.constraintDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(r.constraintDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		var c models.Constraint
+		if err := json.Unmarshal(data, &c); err != nil {
+			return err
+		}
+		r.constraints[c.ID] = &c
+	}
+	return nil
+}
+
+func writeJSON(dir, id string, v any) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, id+".json"), data, 0644)
+}
+
+// CreateTemplate registers a new ConstraintTemplate.
+func (r *Registry) CreateTemplate(req *models.ConstraintTemplateCreateRequest) (*models.ConstraintTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	tmpl := &models.ConstraintTemplate{
+		ID:            uuid.New().String(),
+		Name:          req.Name,
+		Description:   req.Description,
+		Version:       1,
+		Parameters:    req.Parameters,
+		RulesTemplate: req.RulesTemplate,
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	if err := writeJSON(r.templateDir, tmpl.ID, tmpl); err != nil {
+		return nil, fmt.Errorf("failed to save constraint template: %w", err)
+	}
+	r.templates[tmpl.ID] = tmpl
+	return tmpl, nil
+}
+
+// GetTemplate retrieves a ConstraintTemplate by ID.
+func (r *Registry) GetTemplate(id string) (*models.ConstraintTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tmpl, ok := r.templates[id]
+	if !ok {
+		return nil, fmt.Errorf("constraint template not found: %s", id)
+	}
+	return tmpl, nil
+}
+
+// ListTemplates returns every registered ConstraintTemplate.
+func (r *Registry) ListTemplates() []*models.ConstraintTemplate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]*models.ConstraintTemplate, 0, len(r.templates))
+	for _, tmpl := range r.templates {
+		out = append(out, tmpl)
+	}
+	return out
+}
+
+// SaveConstraint persists c (created or updated), letting the caller
+// compile its Policy and attach the resulting PolicyID first.
+func (r *Registry) SaveConstraint(c *models.Constraint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := writeJSON(r.constraintDir, c.ID, c); err != nil {
+		return fmt.Errorf("failed to save constraint: %w", err)
+	}
+	r.constraints[c.ID] = c
+	return nil
+}
+
+// GetConstraint retrieves a Constraint by ID.
+func (r *Registry) GetConstraint(id string) (*models.Constraint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.constraints[id]
+	if !ok {
+		return nil, fmt.Errorf("constraint not found: %s", id)
+	}
+	return c, nil
+}
+
+// ListConstraints returns every registered Constraint.
+func (r *Registry) ListConstraints() []*models.Constraint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]*models.Constraint, 0, len(r.constraints))
+	for _, c := range r.constraints {
+		out = append(out, c)
+	}
+	return out
+}
+
+// DeleteConstraint removes a Constraint. It does not delete the Policy the
+// constraint compiled to; callers that want the enforcement removed too
+// should also delete that Policy via management.Service.
+func (r *Registry) DeleteConstraint(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.constraints[id]; !ok {
+		return fmt.Errorf("constraint not found: %s", id)
+	}
+	if err := os.Remove(filepath.Join(r.constraintDir, id+".json")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove constraint: %w", err)
+	}
+	delete(r.constraints, id)
+	return nil
+}
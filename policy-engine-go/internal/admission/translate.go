@@ -0,0 +1,51 @@
+package admission
+
+import (
+	"encoding/json"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// objectMeta is the subset of a Kubernetes object's metadata this package
+// reads out of AdmissionRequest.Object to build a PolicyEvaluationRequest.
+type objectMeta struct {
+	Metadata struct {
+		Name      string            `json:"name"`
+		Namespace string            `json:"namespace"`
+		Labels    map[string]string `json:"labels"`
+	} `json:"metadata"`
+}
+
+// BuildEvaluationRequest translates a webhook-submitted AdmissionRequest
+// into the PolicyEvaluationRequest shape engine.Evaluate expects: the
+// admitted object's kind becomes the Tool dimension (there's no MCP tool
+// call here, but Tool is what every Condition type besides "resource"
+// matches policies against, so the object kind - e.g. "Pod", "Deployment" -
+// fills that role), the object's name becomes Resource, and Operation/
+// Namespace/labels/dry-run land in Context for condition rules to inspect.
+func BuildEvaluationRequest(req *models.AdmissionRequest) *models.PolicyEvaluationRequest {
+	var meta objectMeta
+	_ = json.Unmarshal(req.Object, &meta) // best-effort; a malformed Object just yields an empty name/labels
+
+	resource := meta.Metadata.Name
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = meta.Metadata.Namespace
+	}
+
+	dryRun := req.DryRun != nil && *req.DryRun
+
+	return &models.PolicyEvaluationRequest{
+		User:     req.UserInfo.Username,
+		Tool:     req.Kind.Kind,
+		Resource: resource,
+		Action:   req.Operation,
+		Context: map[string]interface{}{
+			"operation": req.Operation,
+			"namespace": namespace,
+			"labels":    meta.Metadata.Labels,
+			"dry_run":   dryRun,
+			"groups":    req.UserInfo.Groups,
+		},
+	}
+}
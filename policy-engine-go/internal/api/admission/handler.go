@@ -0,0 +1,77 @@
+package admission
+
+import (
+	"net/http"
+
+	"github.com/datacline/policy-engine/internal/admission"
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/services/evaluation"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// Handler exposes a Kubernetes-style validating admission webhook backed by
+// the existing evaluation engine: POST /admission/validate accepts an
+// AdmissionReview and returns one with Response populated.
+type Handler struct {
+	service   *evaluation.Service
+	auditOnly bool // mirrors Policy.Enforcement == "audit_only": always allow, only log what would have blocked
+}
+
+// NewHandler creates a new admission webhook handler.
+func NewHandler(service *evaluation.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// SetAuditOnly puts the webhook in dry-run mode: every AdmissionReview is
+// allowed regardless of the engine's decision, with the decision it would
+// have made logged instead. Useful for rolling out new constraints without
+// risking an outage from an overly broad rule.
+func (h *Handler) SetAuditOnly(auditOnly bool) {
+	h.auditOnly = auditOnly
+}
+
+// RegisterRoutes registers admission webhook routes.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/admission/validate", h.Validate)
+}
+
+// Validate handles an AdmissionReview from a validating webhook caller.
+func (h *Handler) Validate(c *gin.Context) {
+	var review models.AdmissionReview
+	if err := c.ShouldBindJSON(&review); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if review.Request == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "admission review is missing request"})
+		return
+	}
+
+	evalReq := admission.BuildEvaluationRequest(review.Request)
+	result, err := h.service.Evaluate(evalReq)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	allowed := !result.ShouldBlock
+	if h.auditOnly && !allowed {
+		log.WithFields(log.Fields{
+			"uid":    review.Request.UID,
+			"tool":   evalReq.Tool,
+			"action": result.Action,
+		}).Warn("Admission webhook running audit-only: request would have been blocked")
+		allowed = true
+	}
+
+	c.JSON(http.StatusOK, models.AdmissionReview{
+		APIVersion: review.APIVersion,
+		Kind:       review.Kind,
+		Response: &models.AdmissionResponse{
+			UID:     review.Request.UID,
+			Allowed: allowed,
+			Result:  &models.AdmissionStatus{Message: result.Message},
+		},
+	})
+}
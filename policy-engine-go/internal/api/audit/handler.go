@@ -0,0 +1,95 @@
+// Package audit exposes the tamper-evident policy decision audit log (see
+// internal/audit) over HTTP.
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/audit"
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler provides read-only API endpoints over an audit.Logger.
+type Handler struct {
+	logger *audit.Logger
+}
+
+// NewHandler creates a new audit log handler.
+func NewHandler(logger *audit.Logger) *Handler {
+	return &Handler{logger: logger}
+}
+
+// RegisterRoutes registers the audit log's endpoints.
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/audit/decisions", h.ListDecisions)
+	r.GET("/audit/verify", h.VerifyChain)
+}
+
+// ListDecisions returns recorded decisions matching the optional
+// user/resource/from/to query parameters.
+func (h *Handler) ListDecisions(c *gin.Context) {
+	var filter models.AuditQueryFilter
+	filter.UserID = c.Query("user")
+	filter.ResourceID = c.Query("resource")
+	if from := c.Query("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from: " + err.Error()})
+			return
+		}
+		filter.From = &t
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to: " + err.Error()})
+			return
+		}
+		filter.To = &t
+	}
+
+	records, err := h.logger.Decisions(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"decisions": records,
+		"count":     len(records),
+	})
+}
+
+// VerifyChain re-walks [from_seq, to_seq] (default the whole chain) and
+// reports the first broken link, if any.
+func (h *Handler) VerifyChain(c *gin.Context) {
+	fromSeq := int64(1)
+	if raw := c.Query("from_seq"); raw != "" {
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from_seq: " + err.Error()})
+			return
+		}
+		fromSeq = n
+	}
+	toSeq := int64(1<<63 - 1)
+	if raw := c.Query("to_seq"); raw != "" {
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to_seq: " + err.Error()})
+			return
+		}
+		toSeq = n
+	}
+
+	result, err := h.logger.Verify(fromSeq, toSeq)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,219 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// cacheSchemaVersion is bumped whenever TransformedMCPServer (or anything
+// else CacheSnapshot carries) changes shape incompatibly. Load refuses a
+// snapshot written under a different version instead of risking a partially
+// decoded, silently-wrong hydration.
+const cacheSchemaVersion = 1
+
+// CacheSnapshot is everything CatalogCache needs to skip a cold fetch on
+// boot: the last-served servers/quarantined lists, the raw items and
+// provenance quarantineAndCache needs to re-derive them without refetching,
+// and when it was last refreshed.
+type CacheSnapshot struct {
+	SchemaVersion int `json:"schema_version"`
+	// Servers and Quarantined are keyed by compositeKey rather than stored
+	// as slices, since compositeKey itself is unexported on
+	// TransformedMCPServer (deliberately, so it's never serialized) and the
+	// map key is what lets Load restore it after a round trip.
+	Servers     map[string]TransformedMCPServer  `json:"servers"`
+	Quarantined map[string]TransformedMCPServer  `json:"quarantined"`
+	RawItems    map[string]PostmanMCPCatalogItem `json:"raw_items"`
+	Provenance  map[string]string                `json:"provenance"`
+	LastUpdated time.Time                        `json:"last_updated"`
+	Version     int                              `json:"version"`
+	// Cursors reserves room for per-search-term pagination cursors so a
+	// future CatalogProvider.Fetch could resume an interrupted incremental
+	// crawl instead of starting over; no provider populates it yet, so it's
+	// always empty today.
+	Cursors map[string]string `json:"cursors,omitempty"`
+}
+
+// CacheStore persists a CacheSnapshot across restarts. The default
+// implementation is a JSON file rather than an embedded-KV engine like
+// bbolt or modernc.org/sqlite: this repo has no go.mod and vendors no
+// dependencies (every other catalog subsystem - search_index.go,
+// providers.go - is hand-rolled over the stdlib for the same reason), so a
+// real embedded-KV backend isn't buildable in this tree. The interface is
+// narrow enough that swapping in one later is a drop-in replacement.
+type CacheStore interface {
+	Load() (CacheSnapshot, bool, error)
+	Save(snapshot CacheSnapshot) error
+	Purge() error
+}
+
+// fileCacheStore is the default CacheStore: the whole snapshot lives in one
+// JSON file, rewritten atomically-enough under mu on every Save.
+type fileCacheStore struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64 // 0 disables LRU eviction
+
+	// accessedAt tracks each entry's last appearance in a saved snapshot, so
+	// evictToBudget can drop the least-recently-updated entries first rather
+	// than an arbitrary subset.
+	accessedAt map[string]time.Time
+}
+
+func newFileCacheStore(path string, maxBytes int64) *fileCacheStore {
+	return &fileCacheStore{path: path, maxBytes: maxBytes, accessedAt: make(map[string]time.Time)}
+}
+
+// Load reads the snapshot from disk, restoring each server's compositeKey
+// from its map key. A missing file, a corrupt file, or a schema version
+// mismatch all return ok=false rather than an error the caller must special
+// case - any of them means "start cold", not "crash".
+func (s *fileCacheStore) Load() (CacheSnapshot, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return CacheSnapshot{}, false, nil
+	}
+	if err != nil {
+		log.WithError(err).Warn("Failed to read catalog cache store, starting cold")
+		return CacheSnapshot{}, false, nil
+	}
+
+	var snapshot CacheSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		log.WithError(err).Warn("Failed to parse catalog cache store, starting cold")
+		return CacheSnapshot{}, false, nil
+	}
+	if snapshot.SchemaVersion != cacheSchemaVersion {
+		log.WithFields(log.Fields{"found": snapshot.SchemaVersion, "want": cacheSchemaVersion}).
+			Warn("Catalog cache store schema version mismatch, ignoring stale on-disk cache")
+		return CacheSnapshot{}, false, nil
+	}
+
+	now := time.Now()
+	for key, server := range snapshot.Servers {
+		server.compositeKey = key
+		snapshot.Servers[key] = server
+		s.accessedAt[key] = now
+	}
+	for key, server := range snapshot.Quarantined {
+		server.compositeKey = key
+		snapshot.Quarantined[key] = server
+	}
+
+	return snapshot, true, nil
+}
+
+// Save writes snapshot to disk, evicting the least-recently-updated entries
+// first if it would otherwise exceed maxBytes.
+func (s *fileCacheStore) Save(snapshot CacheSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot.SchemaVersion = cacheSchemaVersion
+
+	now := time.Now()
+	for key := range snapshot.Servers {
+		s.accessedAt[key] = now
+	}
+
+	s.evictToBudget(&snapshot)
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal catalog cache store: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write catalog cache store: %w", err)
+	}
+	return nil
+}
+
+// evictToBudget drops servers (and their corresponding rawItems/provenance
+// entries), oldest-accessed first, until snapshot's marshaled size fits
+// within maxBytes. A maxBytes of 0 disables eviction entirely.
+func (s *fileCacheStore) evictToBudget(snapshot *CacheSnapshot) {
+	if s.maxBytes <= 0 {
+		return
+	}
+	for len(snapshot.Servers) > 0 {
+		data, err := json.Marshal(snapshot)
+		if err != nil || int64(len(data)) <= s.maxBytes {
+			return
+		}
+
+		var oldestKey string
+		var oldestTime time.Time
+		for key := range snapshot.Servers {
+			t, ok := s.accessedAt[key]
+			if !ok {
+				t = time.Time{}
+			}
+			if oldestKey == "" || t.Before(oldestTime) {
+				oldestKey, oldestTime = key, t
+			}
+		}
+
+		delete(snapshot.Servers, oldestKey)
+		delete(snapshot.RawItems, oldestKey)
+		delete(snapshot.Provenance, oldestKey)
+		delete(s.accessedAt, oldestKey)
+	}
+}
+
+// Purge deletes the on-disk cache store entirely, for POST /mcp-catalog/purge.
+func (s *fileCacheStore) Purge() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessedAt = make(map[string]time.Time)
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to purge catalog cache store: %w", err)
+	}
+	return nil
+}
+
+// newCacheStore builds the CacheStore NewHandler hydrates from and
+// fetchAllCatalogItems persists to, configured via CATALOG_CACHE_STORE_PATH
+// (default "catalog-cache.json") and CATALOG_CACHE_STORE_MAX_BYTES (default
+// disabled, i.e. no eviction).
+func newCacheStore() CacheStore {
+	path := os.Getenv("CATALOG_CACHE_STORE_PATH")
+	if path == "" {
+		path = "catalog-cache.json"
+	}
+	var maxBytes int64
+	if raw := os.Getenv("CATALOG_CACHE_STORE_MAX_BYTES"); raw != "" {
+		if parsed, err := strconv.ParseInt(raw, 10, 64); err == nil && parsed > 0 {
+			maxBytes = parsed
+		}
+	}
+	return newFileCacheStore(path, maxBytes)
+}
+
+// cacheStoreTTL is how long a hydrated on-disk snapshot is trusted to serve
+// "stale" results before SearchCatalog should treat it as too old to serve
+// at all, read from CATALOG_CACHE_STORE_TTL (a Go duration string, e.g.
+// "1h"); zero/unset disables the check, serving a hydrated snapshot
+// indefinitely while a background refresh is pending.
+func cacheStoreTTL() time.Duration {
+	raw := os.Getenv("CATALOG_CACHE_STORE_TTL")
+	if raw == "" {
+		return 0
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil {
+		log.WithError(err).Warn("Invalid CATALOG_CACHE_STORE_TTL, ignoring")
+		return 0
+	}
+	return ttl
+}
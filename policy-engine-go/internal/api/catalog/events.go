@@ -0,0 +1,114 @@
+package catalog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CatalogEvent is a single change GetEvents streams to subscribers over SSE.
+type CatalogEvent struct {
+	Type      string                `json:"type"` // "added", "updated", "removed", "refresh_started", "refresh_completed"
+	Server    *TransformedMCPServer `json:"server,omitempty"`
+	Version   int                   `json:"version"`
+	Timestamp time.Time             `json:"timestamp"`
+}
+
+// eventBusQueueSize bounds each subscriber's buffered channel; a subscriber
+// that falls this far behind is assumed gone and dropped rather than
+// blocking quarantineAndCache's publishers.
+const eventBusQueueSize = 64
+
+// eventBus is an in-process publish/subscribe bus for CatalogEvents, fanning
+// every publish out to every currently-registered GetEvents SSE stream.
+type eventBus struct {
+	mu          sync.Mutex
+	nextID      int
+	subscribers map[int]chan CatalogEvent
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subscribers: make(map[int]chan CatalogEvent)}
+}
+
+// subscribe registers a new listener and returns its channel plus an
+// unsubscribe func the caller must invoke when it stops reading.
+func (b *eventBus) subscribe() (<-chan CatalogEvent, func()) {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	ch := make(chan CatalogEvent, eventBusQueueSize)
+	b.subscribers[id] = ch
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		if ch, ok := b.subscribers[id]; ok {
+			delete(b.subscribers, id)
+			close(ch)
+		}
+		b.mu.Unlock()
+	}
+}
+
+// publish fans event out to every subscriber. A subscriber whose buffer is
+// full is skipped for this event rather than blocking the publisher -
+// GetEvents streams are best-effort, not a durable log.
+func (b *eventBus) publish(event CatalogEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for id, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.WithField("subscriber", id).Warn("Catalog event subscriber is falling behind, dropping event")
+		}
+	}
+}
+
+// contentSignature hashes the fields of s that represent a "real" change to
+// a catalog entry, deliberately excluding Verification.VerifiedAt so a mere
+// re-verification of an unchanged entry doesn't bump its version.
+func contentSignature(s TransformedMCPServer) string {
+	stable := struct {
+		Name        string
+		Description string
+		Publisher   PostmanPublisher
+		Type        string
+		Config      MCPServerConfig
+		Tags        []string
+		Category    string
+		Categories  []string
+		Official    bool
+		Featured    bool
+		Provider    string
+		VerifStatus string
+	}{
+		Name:        s.Name,
+		Description: s.Description,
+		Publisher:   s.Publisher,
+		Type:        s.Type,
+		Config:      s.Config,
+		Tags:        s.Tags,
+		Category:    s.Category,
+		Categories:  s.Categories,
+		Official:    s.Official,
+		Featured:    s.Featured,
+		Provider:    s.Provider,
+		VerifStatus: s.Verification.Status,
+	}
+	raw, err := json.Marshal(stable)
+	if err != nil {
+		// Unreachable for a struct of plain marshalable fields, but fall
+		// back to a signature that forces an "updated" event rather than
+		// panicking if it ever happens.
+		return fmt.Sprintf("unmarshalable:%v", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
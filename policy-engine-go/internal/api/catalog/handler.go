@@ -2,11 +2,12 @@ package catalog
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"os"
 	"sort"
 	"strconv"
@@ -32,17 +33,88 @@ type Handler struct {
 	apiKey     string
 	httpClient *http.Client
 	cache      *CatalogCache
+
+	// providers are the catalog sources fetchAllCatalogItems fans out
+	// across and merges, in addition to (or instead of) a signed offline
+	// bundle. Postman is just one of these now, not a hardcoded source.
+	providers      []CatalogProvider
+	providerMu     sync.RWMutex
+	providerStatus map[string]ProviderStatus
+
+	// events publishes added/updated/removed/refresh_* notifications for
+	// GetEvents' SSE stream, so frontends can stop polling GetCacheStatus.
+	events *eventBus
+
+	// searchIdx is the BM25 full-text index SearchCatalog ranks queries
+	// against; rebuilt atomically at the end of every quarantineAndCache.
+	searchIdx *searchIndex
+
+	// installs tracks every catalog entry InstallServer has materialized
+	// into a live gateway upstream.
+	installs *installManager
+
+	// cacheStore persists CatalogCache to disk so NewHandler can hydrate
+	// synchronously on boot instead of blocking on a cold fetch.
+	cacheStore    CacheStore
+	cacheStoreTTL time.Duration
+
+	// Supply-chain verification
+	verifiers         VerifierChain
+	verifications     *verificationCache
+	trustPolicyMu     sync.RWMutex
+	trustPolicy       TrustPolicy
+	adminToken        string
+	offlineBundlePath string
+	offlineBundleKey  ed25519.PublicKey
+}
+
+// ProviderStatus reports a single CatalogProvider's last fetch outcome, for
+// the /mcp-catalog/providers health endpoint.
+type ProviderStatus struct {
+	Name        string    `json:"name"`
+	LastFetchAt time.Time `json:"last_fetch_at"`
+	ItemCount   int       `json:"item_count"`
+	Error       string    `json:"error,omitempty"`
 }
 
 // CatalogCache stores all fetched MCP servers
 type CatalogCache struct {
-	mu           sync.RWMutex
-	servers      []TransformedMCPServer
-	serversByID  map[string]TransformedMCPServer
+	mu          sync.RWMutex
+	servers     []TransformedMCPServer
+	serversByID map[string]TransformedMCPServer
+	quarantined []TransformedMCPServer
+	// rawItems holds the last fetch's raw catalog items, keyed by the same
+	// composite (provider, id) key quarantineAndCache uses, so a
+	// trust-policy change can re-derive servers/quarantined without a full
+	// re-fetch.
+	rawItems     map[string]PostmanMCPCatalogItem
+	provenance   map[string]string // composite key -> provider name
 	lastUpdated  time.Time
 	isLoading    bool
 	loadError    error
 	totalFetched int
+
+	// version is a monotonic counter bumped once per real (non-cosmetic)
+	// entry change; entryHash/entryVersion track each entry's last content
+	// signature and the version it last changed at, so SearchCatalog's
+	// ?since= can return only what changed.
+	version      int
+	entryHash    map[string]string
+	entryVersion map[string]int
+
+	// hydratedFromDisk is true from NewHandler's synchronous CacheStore.Load
+	// until the first live fetchAllCatalogItems completes, so GetCacheStatus/
+	// SearchCatalog can report "stale" (serving a disk-hydrated cache while a
+	// background refresh is in flight) instead of "loading" (nothing to
+	// serve yet) or "ready" (already confirmed current).
+	hydratedFromDisk bool
+}
+
+// providerKey builds the composite (provider, id) key quarantineAndCache,
+// the verification cache, and CatalogCache.rawItems all use to dedup items
+// across providers that might otherwise collide on a bare item ID.
+func providerKey(provider, id string) string {
+	return provider + ":" + id
 }
 
 // PostmanMCPServerEntry represents the config for an MCP server
@@ -50,8 +122,8 @@ type CatalogCache struct {
 type PostmanMCPServerEntry struct {
 	// For stdio/local servers
 	Command string          `json:"command,omitempty"`
-	Args    json.RawMessage `json:"args,omitempty"`    // Can be []string or other types
-	Env     json.RawMessage `json:"env,omitempty"`     // Can be map[string]string or []interface{}
+	Args    json.RawMessage `json:"args,omitempty"` // Can be []string or other types
+	Env     json.RawMessage `json:"env,omitempty"`  // Can be map[string]string or []interface{}
 	// For HTTP/remote servers
 	URL     string          `json:"url,omitempty"`
 	Headers json.RawMessage `json:"headers,omitempty"` // Can be map[string]string or other types
@@ -104,13 +176,13 @@ func parseEnvMap(raw json.RawMessage) map[string]string {
 	if len(raw) == 0 {
 		return nil
 	}
-	
+
 	// Try parsing as map first
 	var envMap map[string]string
 	if err := json.Unmarshal(raw, &envMap); err == nil {
 		return envMap
 	}
-	
+
 	// Try parsing as array of objects with name/value
 	var envArray []map[string]string
 	if err := json.Unmarshal(raw, &envArray); err == nil {
@@ -124,7 +196,7 @@ func parseEnvMap(raw json.RawMessage) map[string]string {
 		}
 		return result
 	}
-	
+
 	// Try parsing as array of strings (key=value format)
 	var stringArray []string
 	if err := json.Unmarshal(raw, &stringArray); err == nil {
@@ -136,7 +208,7 @@ func parseEnvMap(raw json.RawMessage) map[string]string {
 		}
 		return result
 	}
-	
+
 	return nil
 }
 
@@ -145,12 +217,12 @@ func parseArgs(raw json.RawMessage) []string {
 	if len(raw) == 0 {
 		return nil
 	}
-	
+
 	var args []string
 	if err := json.Unmarshal(raw, &args); err == nil {
 		return args
 	}
-	
+
 	return nil
 }
 
@@ -159,12 +231,12 @@ func parseHeaders(raw json.RawMessage) map[string]string {
 	if len(raw) == 0 {
 		return nil
 	}
-	
+
 	var headers map[string]string
 	if err := json.Unmarshal(raw, &headers); err == nil {
 		return headers
 	}
-	
+
 	return nil
 }
 
@@ -180,9 +252,9 @@ type MCPServerConfig struct {
 	URL     string            `json:"url,omitempty"`
 	Headers map[string]string `json:"headers,omitempty"`
 	// Auth info extracted from headers/env
-	AuthMethod      string `json:"auth_method,omitempty"`       // "bearer", "api_key", "none"
-	AuthHeaderName  string `json:"auth_header_name,omitempty"`  // e.g., "Authorization", "X-API-Key"
-	AuthEnvVar      string `json:"auth_env_var,omitempty"`      // Environment variable for credentials
+	AuthMethod     string `json:"auth_method,omitempty"`      // "bearer", "api_key", "none"
+	AuthHeaderName string `json:"auth_header_name,omitempty"` // e.g., "Authorization", "X-API-Key"
+	AuthEnvVar     string `json:"auth_env_var,omitempty"`     // Environment variable for credentials
 }
 
 // PostmanPublisher represents publisher info
@@ -227,17 +299,27 @@ type PostmanMCPCatalogResponse struct {
 
 // TransformedMCPServer represents the transformed server for frontend
 type TransformedMCPServer struct {
-	ID          string          `json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	Publisher   PostmanPublisher `json:"publisher"`
-	Type        string          `json:"type"`        // "stdio" or "http"
-	ServerType  string          `json:"server_type"` // Alias for backward compat: "stdio" or "sse"
-	Config      MCPServerConfig `json:"config"`
-	Tags        []string        `json:"tags"`
-	Category    string          `json:"category"`
-	Official    bool            `json:"official"`
-	Featured    bool            `json:"featured"`
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	Publisher    PostmanPublisher `json:"publisher"`
+	Type         string           `json:"type"`        // "stdio" or "http"
+	ServerType   string           `json:"server_type"` // Alias for backward compat: "stdio" or "sse"
+	Config       MCPServerConfig  `json:"config"`
+	Tags         []string         `json:"tags"`
+	Category     string           `json:"category"` // PrimaryCategory(Categories), kept for backward compat
+	Categories   []string         `json:"categories"`
+	Official     bool             `json:"official"`
+	Featured     bool             `json:"featured"`
+	Verification Verification     `json:"verification"`
+	Provider     string           `json:"provider"`
+	// Score is this entry's BM25 relevance score, set only when SearchCatalog
+	// is called with a non-empty query.
+	Score float64 `json:"score,omitempty"`
+	// compositeKey is the (provider, id) key this entry was cached under.
+	// Unexported, so it's never serialized; used internally to look up its
+	// CatalogCache.entryVersion for ?since= filtering.
+	compositeKey string
 }
 
 // CatalogSearchResponse is the response sent to frontend
@@ -252,6 +334,7 @@ type CatalogSearchResponse struct {
 	TotalPages    int                    `json:"total_pages"`
 	HasMore       bool                   `json:"has_more"`
 	CacheStatus   string                 `json:"cache_status"`
+	Version       int                    `json:"version"`
 }
 
 // CatalogStatusResponse provides cache status info
@@ -259,6 +342,7 @@ type CatalogStatusResponse struct {
 	TotalServers int       `json:"total_servers"`
 	LastUpdated  time.Time `json:"last_updated"`
 	IsLoading    bool      `json:"is_loading"`
+	Stale        bool      `json:"stale"` // serving a disk-hydrated cache while a background refresh is in flight
 	Error        string    `json:"error,omitempty"`
 }
 
@@ -277,25 +361,156 @@ func NewHandler() *Handler {
 		log.WithField("api_key", maskedKey).Info("Postman API key configured")
 	}
 
+	var trustedPublishers []string
+	if raw := os.Getenv("TRUSTED_PUBLISHERS"); raw != "" {
+		trustedPublishers = strings.Split(raw, ",")
+	}
+
+	var offlineBundleKey ed25519.PublicKey
+	if keyHex := os.Getenv("OFFLINE_CATALOG_BUNDLE_KEY"); keyHex != "" {
+		if keyBytes, err := hex.DecodeString(keyHex); err != nil || len(keyBytes) != ed25519.PublicKeySize {
+			log.WithError(err).Warn("Invalid OFFLINE_CATALOG_BUNDLE_KEY, offline bundle verification will fail")
+		} else {
+			offlineBundleKey = ed25519.PublicKey(keyBytes)
+		}
+	}
+
+	httpClient := &http.Client{Timeout: 60 * time.Second}
+
+	var providers []CatalogProvider
+	if apiKey != "" {
+		providers = append(providers, NewPostmanProvider(apiKey, httpClient))
+	}
+	if repos := os.Getenv("GITHUB_CATALOG_REPOS"); repos != "" {
+		providers = append(providers, NewGitHubManifestProvider(strings.Split(repos, ","), os.Getenv("GITHUB_TOKEN"), httpClient))
+	}
+	if dir := os.Getenv("LOCAL_CATALOG_DIR"); dir != "" {
+		providers = append(providers, NewFilesystemProvider(dir))
+	}
+	if registry := os.Getenv("OCI_CATALOG_REGISTRY"); registry != "" {
+		providers = append(providers, NewOCIArtifactProvider(registry, os.Getenv("OCI_CATALOG_REPOSITORY"), os.Getenv("OCI_CATALOG_TAG"), httpClient))
+	}
+	if endpoints := os.Getenv("HTTP_CATALOG_ENDPOINTS"); endpoints != "" {
+		for _, endpoint := range strings.Split(endpoints, ",") {
+			if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+				providers = append(providers, NewHTTPEndpointProvider(endpoint, httpClient))
+			}
+		}
+	}
+
 	h := &Handler{
-		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
-		},
+		apiKey:     apiKey,
+		httpClient: httpClient,
 		cache: &CatalogCache{
 			servers:     make([]TransformedMCPServer, 0),
 			serversByID: make(map[string]TransformedMCPServer),
 		},
+		providers:      providers,
+		providerStatus: make(map[string]ProviderStatus),
+		events:         newEventBus(),
+		searchIdx:      newSearchIndex(),
+		installs:       newInstallManager(newSecretStore()),
+		verifiers: VerifierChain{
+			NewPublisherAllowlistVerifier(trustedPublishers),
+			&SigstoreBundleVerifier{},
+		},
+		verifications:     newVerificationCache(),
+		trustPolicy:       TrustPolicy(os.Getenv("CATALOG_TRUST_POLICY")),
+		adminToken:        os.Getenv("MCP_CATALOG_ADMIN_TOKEN"),
+		offlineBundlePath: os.Getenv("OFFLINE_CATALOG_BUNDLE_PATH"),
+		offlineBundleKey:  offlineBundleKey,
+		cacheStore:        newCacheStore(),
+		cacheStoreTTL:     cacheStoreTTL(),
+	}
+	if h.trustPolicy == "" {
+		h.trustPolicy = TrustPolicyPreferVerified
 	}
 
-	// Start background fetch if API key is configured
-	if apiKey != "" {
+	h.hydrateFromCacheStore()
+	h.searchIdx.Build(h.cache.servers)
+
+	// Start background fetch if at least one provider or an offline bundle
+	// is configured.
+	if len(h.providers) > 0 || h.offlineBundlePath != "" {
 		go h.fetchAllCatalogItems()
 	}
 
 	return h
 }
 
+// hydrateFromCacheStore loads a previously-persisted CacheSnapshot into
+// h.cache synchronously, so SearchCatalog has something to serve
+// (cache_status "stale") the moment NewHandler returns instead of blocking
+// every request through fetchAllCatalogItems' cold, multi-minute crawl. A
+// missing/corrupt/too-old snapshot just leaves the cache empty - identical
+// to today's behavior before a first fetch completes.
+func (h *Handler) hydrateFromCacheStore() {
+	snapshot, ok, err := h.cacheStore.Load()
+	if err != nil {
+		log.WithError(err).Warn("Failed to hydrate catalog cache from disk")
+		return
+	}
+	if !ok {
+		return
+	}
+	if h.cacheStoreTTL > 0 && time.Since(snapshot.LastUpdated) > h.cacheStoreTTL {
+		log.WithField("age", time.Since(snapshot.LastUpdated)).Info("On-disk catalog cache is older than CATALOG_CACHE_STORE_TTL, starting cold")
+		return
+	}
+
+	serverList := make([]TransformedMCPServer, 0, len(snapshot.Servers))
+	for _, server := range snapshot.Servers {
+		serverList = append(serverList, server)
+	}
+	sort.Slice(serverList, func(i, j int) bool {
+		vi, vj := serverList[i].Verification.Status == "verified", serverList[j].Verification.Status == "verified"
+		if vi != vj {
+			return vi
+		}
+		return strings.ToLower(serverList[i].Name) < strings.ToLower(serverList[j].Name)
+	})
+	quarantinedList := make([]TransformedMCPServer, 0, len(snapshot.Quarantined))
+	for _, server := range snapshot.Quarantined {
+		quarantinedList = append(quarantinedList, server)
+	}
+
+	h.cache.mu.Lock()
+	h.cache.servers = serverList
+	h.cache.serversByID = snapshot.Servers
+	h.cache.quarantined = quarantinedList
+	h.cache.rawItems = snapshot.RawItems
+	h.cache.provenance = snapshot.Provenance
+	h.cache.lastUpdated = snapshot.LastUpdated
+	h.cache.version = snapshot.Version
+	h.cache.hydratedFromDisk = true
+	h.cache.mu.Unlock()
+
+	log.WithFields(log.Fields{"servers": len(serverList), "age": time.Since(snapshot.LastUpdated)}).Info("Hydrated MCP catalog cache from disk")
+}
+
+// persistToCacheStore saves the current cache contents for the next boot's
+// hydrateFromCacheStore to pick up. Failures are logged, not fatal - the
+// in-memory cache this process is serving is unaffected either way.
+func (h *Handler) persistToCacheStore() {
+	h.cache.mu.RLock()
+	snapshot := CacheSnapshot{
+		Servers:     h.cache.serversByID,
+		RawItems:    h.cache.rawItems,
+		Provenance:  h.cache.provenance,
+		LastUpdated: h.cache.lastUpdated,
+		Version:     h.cache.version,
+	}
+	snapshot.Quarantined = make(map[string]TransformedMCPServer, len(h.cache.quarantined))
+	for _, server := range h.cache.quarantined {
+		snapshot.Quarantined[server.compositeKey] = server
+	}
+	h.cache.mu.RUnlock()
+
+	if err := h.cacheStore.Save(snapshot); err != nil {
+		log.WithError(err).Warn("Failed to persist catalog cache to disk")
+	}
+}
+
 // RegisterRoutes registers the catalog routes
 func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	catalog := router.Group("/mcp-catalog")
@@ -304,176 +519,239 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		catalog.GET("/categories", h.GetCategories)
 		catalog.GET("/status", h.GetCacheStatus)
 		catalog.POST("/refresh", h.RefreshCache)
+		catalog.GET("/verification", h.GetVerificationReport)
+		catalog.POST("/trust-policy", h.SetTrustPolicyEndpoint)
+		catalog.GET("/providers", h.GetProviders)
+		catalog.GET("/events", h.GetEvents)
+		catalog.POST("/install/:id", h.InstallServer)
+		catalog.GET("/install/:id/preview", h.PreviewInstall)
+		catalog.POST("/purge", h.PurgeCache)
 	}
 }
 
-// fetchAllCatalogItems fetches all MCP servers from Postman API at startup
+// fetchAllCatalogItems populates the cache by fanning out across every
+// configured CatalogProvider and, when OFFLINE_CATALOG_BUNDLE_PATH is set,
+// a signed JSON bundle on disk - so a gateway that can't or won't reach any
+// single catalog source at runtime can still serve a vetted catalog from
+// whatever sources it does have.
 func (h *Handler) fetchAllCatalogItems() {
 	h.cache.mu.Lock()
 	h.cache.isLoading = true
 	h.cache.loadError = nil
 	h.cache.mu.Unlock()
 
-	log.Info("Starting MCP Catalog fetch from Postman API...")
+	h.events.publish(CatalogEvent{Type: "refresh_started", Timestamp: time.Now()})
 
-	allServers := make(map[string]TransformedMCPServer)
-	searchTerms := strings.Split(defaultSearchTerms, ",")
+	items := make(map[string]PostmanMCPCatalogItem)
+	provenance := make(map[string]string)
+	var anySucceeded bool
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	for _, term := range searchTerms {
-		term = strings.TrimSpace(term)
-		if term == "" {
+	for _, provider := range h.providers {
+		fetched, err := provider.Fetch(ctx)
+		h.recordProviderStatus(provider.Name(), len(fetched), err)
+		if err != nil {
+			log.WithError(err).WithField("provider", provider.Name()).Warn("Catalog provider fetch failed")
 			continue
 		}
+		anySucceeded = true
+		for _, item := range fetched {
+			key := providerKey(provider.Name(), item.ID)
+			items[key] = item
+			provenance[key] = provider.Name()
+		}
+	}
 
-		offset := 0
-		for {
-			servers, hasMore, err := h.fetchPage(ctx, term, fetchBatchSize, offset)
-			if err != nil {
-				log.WithError(err).WithField("term", term).Warn("Failed to fetch catalog page")
-				break
+	if h.offlineBundlePath != "" {
+		bundleItems, err := loadOfflineBundle(h.offlineBundlePath, h.offlineBundleKey)
+		h.recordProviderStatus(offlineBundleProviderName, len(bundleItems), err)
+		if err != nil {
+			log.WithError(err).Warn("Failed to load offline MCP catalog bundle")
+		} else {
+			anySucceeded = true
+			for _, item := range bundleItems {
+				key := providerKey(offlineBundleProviderName, item.ID)
+				items[key] = item
+				provenance[key] = offlineBundleProviderName
 			}
+		}
+	}
 
-			for _, server := range servers {
-				allServers[server.ID] = server
-			}
+	if !anySucceeded && (len(h.providers) > 0 || h.offlineBundlePath != "") {
+		h.cache.mu.Lock()
+		h.cache.isLoading = false
+		h.cache.loadError = fmt.Errorf("every configured catalog source failed to fetch")
+		h.cache.mu.Unlock()
+		log.Warn("Failed to populate MCP Catalog cache: every source failed")
+		return
+	}
 
-			log.WithFields(log.Fields{
-				"term":       term,
-				"offset":     offset,
-				"fetched":    len(servers),
-				"total_so_far": len(allServers),
-			}).Debug("Fetched catalog page")
+	version, totalServers := h.quarantineAndCache(items, provenance)
+	h.events.publish(CatalogEvent{Type: "refresh_completed", Version: version, Timestamp: time.Now(), Server: nil})
+	log.WithField("total_servers", totalServers).Debug("Catalog refresh_completed event published")
+}
 
-			if !hasMore {
-				break
+// offlineBundleProviderName is the pseudo-provider name a signed offline
+// bundle is tracked under in providerStatus and the provenance map, since it
+// isn't a CatalogProvider (it's loaded synchronously, not via Fetch).
+const offlineBundleProviderName = "offline-bundle"
+
+// recordProviderStatus records provider's latest fetch outcome for
+// GetProviders to report.
+func (h *Handler) recordProviderStatus(provider string, itemCount int, err error) {
+	status := ProviderStatus{Name: provider, LastFetchAt: time.Now(), ItemCount: itemCount}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	h.providerMu.Lock()
+	h.providerStatus[provider] = status
+	h.providerMu.Unlock()
+}
+
+// quarantineAndCache runs items (keyed by the composite providerKey) through
+// h.verifiers, splits them into servers vs quarantined according to the
+// current TrustPolicy, and installs the result as the new cache contents.
+// provenance maps each item's composite key back to the provider name it
+// came from, surfaced on TransformedMCPServer.Provider. It bumps
+// CatalogCache.version for every entry whose contentSignature actually
+// changed (or that's new, or was removed), publishes an added/updated/
+// removed CatalogEvent for each, and returns the resulting version and
+// served-server count.
+func (h *Handler) quarantineAndCache(items map[string]PostmanMCPCatalogItem, provenance map[string]string) (int, int) {
+	policy := h.GetTrustPolicy()
+
+	h.cache.mu.RLock()
+	version := h.cache.version
+	prevHash := h.cache.entryHash
+	prevEntryVersion := h.cache.entryVersion
+	prevServersByID := h.cache.serversByID
+	prevQuarantined := h.cache.quarantined
+	prevRawItems := h.cache.rawItems
+	h.cache.mu.RUnlock()
+
+	served := make(map[string]TransformedMCPServer, len(items))
+	var quarantined []TransformedMCPServer
+	entryHash := make(map[string]string, len(items))
+	entryVersion := make(map[string]int, len(items))
+	var changeEvents []CatalogEvent
+
+	for key, item := range items {
+		transformed := transformMCPServer(item)
+		transformed.Provider = provenance[key]
+		transformed.compositeKey = key
+
+		verification, ok := h.verifications.get(key, item.MCPServers)
+		if !ok {
+			if provenance[key] == offlineBundleProviderName {
+				// A signed bundle's signature covers every item at once,
+				// not each item individually - its verifiers don't apply.
+				verification = Verification{Status: "verified", Attestation: "signed-bundle", Algorithm: "ed25519", VerifiedAt: time.Now()}
+			} else {
+				verification = h.verifiers.Verify(item, item.MCPServers)
 			}
-			offset += fetchBatchSize
+			h.verifications.put(key, item.MCPServers, verification)
+		}
+		transformed.Verification = verification
+
+		if policy == TrustPolicyRequireVerified && verification.Status != "verified" {
+			transformed.Verification.Status = "quarantined"
+			quarantined = append(quarantined, transformed)
+		} else {
+			served[key] = transformed
+		}
+
+		sig := contentSignature(transformed)
+		oldSig, existed := prevHash[key]
+		thisVersion := prevEntryVersion[key]
+		eventType := ""
+		switch {
+		case !existed:
+			eventType = "added"
+		case oldSig != sig:
+			eventType = "updated"
+		}
+		if eventType != "" {
+			version++
+			thisVersion = version
+			entryCopy := transformed
+			changeEvents = append(changeEvents, CatalogEvent{Type: eventType, Server: &entryCopy, Version: thisVersion})
+		}
+		entryHash[key] = sig
+		entryVersion[key] = thisVersion
+	}
 
-			// Rate limiting - be nice to the API
-			time.Sleep(200 * time.Millisecond)
+	// Anything that was cached before but isn't in this fetch's items has
+	// been removed from every provider that used to report it.
+	for key := range prevRawItems {
+		if _, stillPresent := items[key]; stillPresent {
+			continue
+		}
+		version++
+		var removedServer *TransformedMCPServer
+		if s, ok := prevServersByID[key]; ok {
+			sCopy := s
+			removedServer = &sCopy
+		} else {
+			for _, s := range prevQuarantined {
+				if s.compositeKey == key {
+					sCopy := s
+					removedServer = &sCopy
+					break
+				}
+			}
 		}
+		changeEvents = append(changeEvents, CatalogEvent{Type: "removed", Server: removedServer, Version: version})
 	}
 
-	// Convert map to slice and sort by name
-	serverList := make([]TransformedMCPServer, 0, len(allServers))
-	for _, server := range allServers {
+	serverList := make([]TransformedMCPServer, 0, len(served))
+	for _, server := range served {
 		serverList = append(serverList, server)
 	}
+	// Verified entries first regardless of policy, so an operator browsing
+	// the catalog sees attested servers ahead of unverified ones even under
+	// prefer-verified/allow-all.
 	sort.Slice(serverList, func(i, j int) bool {
+		vi, vj := serverList[i].Verification.Status == "verified", serverList[j].Verification.Status == "verified"
+		if vi != vj {
+			return vi
+		}
 		return strings.ToLower(serverList[i].Name) < strings.ToLower(serverList[j].Name)
 	})
 
-	// Update cache
 	h.cache.mu.Lock()
 	h.cache.servers = serverList
-	h.cache.serversByID = allServers
+	h.cache.serversByID = served
+	h.cache.quarantined = quarantined
+	h.cache.rawItems = items
+	h.cache.provenance = provenance
 	h.cache.lastUpdated = time.Now()
 	h.cache.isLoading = false
 	h.cache.totalFetched = len(serverList)
+	h.cache.version = version
+	h.cache.entryHash = entryHash
+	h.cache.entryVersion = entryVersion
+	h.cache.hydratedFromDisk = false
 	h.cache.mu.Unlock()
 
-	log.WithFields(log.Fields{
-		"total_servers": len(serverList),
-		"search_terms":  len(searchTerms),
-	}).Info("MCP Catalog cache populated")
-}
-
-// fetchPage fetches a single page from Postman API
-func (h *Handler) fetchPage(ctx context.Context, query string, limit, offset int) ([]TransformedMCPServer, bool, error) {
-	apiURL, err := url.Parse(postmanAPIBaseURL + "/mcp-servers")
-	if err != nil {
-		return nil, false, err
-	}
-
-	params := url.Values{}
-	params.Set("q", query)
-	params.Set("limit", strconv.Itoa(limit))
-	params.Set("offset", strconv.Itoa(offset))
-	apiURL.RawQuery = params.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL.String(), nil)
-	if err != nil {
-		return nil, false, err
-	}
-
-	req.Header.Set("x-api-key", h.apiKey)
-	req.Header.Set("Accept", "application/json")
+	h.searchIdx.Build(serverList)
+	h.persistToCacheStore()
 
-	resp, err := h.httpClient.Do(req)
-	if err != nil {
-		return nil, false, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, false, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, false, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Log raw response for debugging (first request only)
-	if offset == 0 && len(body) > 0 {
-		// Try to parse as generic JSON to see the structure
-		var rawResp map[string]interface{}
-		if err := json.Unmarshal(body, &rawResp); err == nil {
-			if data, ok := rawResp["data"].([]interface{}); ok && len(data) > 0 {
-				if firstItem, ok := data[0].(map[string]interface{}); ok {
-					mcpServersRaw := firstItem["mcpServers"]
-					mcpServersJSON, _ := json.Marshal(mcpServersRaw)
-					log.WithFields(log.Fields{
-						"query":           query,
-						"first_item_name": firstItem["name"],
-						"mcpServers_raw":  string(mcpServersJSON),
-						"mcpServers_type": fmt.Sprintf("%T", mcpServersRaw),
-					}).Info("Postman API first item structure")
-				}
-			}
-		}
+	for _, event := range changeEvents {
+		event.Timestamp = time.Now()
+		h.events.publish(event)
 	}
 
-	var postmanResp PostmanMCPCatalogResponse
-	if err := json.Unmarshal(body, &postmanResp); err != nil {
-		return nil, false, err
-	}
-
-	servers := make([]TransformedMCPServer, 0, len(postmanResp.Data))
-	httpCount := 0
-	stdioCount := 0
-	for _, item := range postmanResp.Data {
-		transformed := transformMCPServer(item)
-		if transformed.Type == "http" {
-			httpCount++
-		} else {
-			stdioCount++
-		}
-		// Log first few items for debugging
-		if len(servers) < 5 {
-			log.WithFields(log.Fields{
-				"name":        item.Name,
-				"parsed_type": transformed.Type,
-				"config_url":  transformed.Config.URL,
-				"config_cmd":  transformed.Config.Command,
-			}).Info("Transformed MCP server")
-		}
-		servers = append(servers, transformed)
-	}
-	if len(servers) > 0 {
-		log.WithFields(log.Fields{
-			"query":       query,
-			"total":       len(servers),
-			"http_count":  httpCount,
-			"stdio_count": stdioCount,
-		}).Info("Batch type distribution")
-	}
+	log.WithFields(log.Fields{
+		"total_servers": len(serverList),
+		"quarantined":   len(quarantined),
+		"trust_policy":  policy,
+		"version":       version,
+		"changes":       len(changeEvents),
+	}).Info("MCP Catalog cache populated")
 
-	hasMore := len(servers) >= limit
-	return servers, hasMore, nil
+	return version, len(serverList)
 }
 
 // SearchCatalog searches the cached MCP catalog
@@ -495,11 +773,11 @@ func (h *Handler) SearchCatalog(c *gin.Context) {
 		offset = 0
 	}
 
-	// Check if API key is configured
-	if h.apiKey == "" {
+	// Check if a catalog source is configured
+	if !h.hasAnySource() {
 		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"error":   "Postman API key not configured",
-			"message": "Set POSTMAN_API_KEY environment variable to enable catalog search",
+			"error":   "no catalog source configured",
+			"message": "Set POSTMAN_API_KEY, GITHUB_CATALOG_REPOS, LOCAL_CATALOG_DIR, OCI_CATALOG_REGISTRY, HTTP_CATALOG_ENDPOINTS, or OFFLINE_CATALOG_BUNDLE_PATH to enable catalog search",
 		})
 		return
 	}
@@ -508,26 +786,65 @@ func (h *Handler) SearchCatalog(c *gin.Context) {
 	isLoading := h.cache.isLoading
 	allServers := h.cache.servers
 	lastUpdated := h.cache.lastUpdated
+	currentVersion := h.cache.version
+	entryVersion := h.cache.entryVersion
+	hydratedFromDisk := h.cache.hydratedFromDisk
 	h.cache.mu.RUnlock()
 
 	cacheStatus := "ready"
 	if isLoading {
 		cacheStatus = "loading"
+		if hydratedFromDisk {
+			// A disk-hydrated cache is being served while the background
+			// refresh that'll confirm/replace it is still in flight.
+			cacheStatus = "stale"
+		}
 	}
 	if lastUpdated.IsZero() {
 		cacheStatus = "empty"
 	}
 
-	// Filter servers based on query
-	// Initialize as empty slice to ensure JSON marshals as [] not null
-	filtered := make([]TransformedMCPServer, 0)
+	// Rank servers against the query via the BM25 search index. With no
+	// query, every cached server passes through unranked (Score stays 0).
+	var ranked []TransformedMCPServer
 	if query == "" {
-		filtered = allServers
+		ranked = allServers
 	} else {
+		scores := h.searchIdx.Score(query)
+		ranked = make([]TransformedMCPServer, 0, len(scores))
 		for _, server := range allServers {
-			if matchesQuery(server, query) {
-				filtered = append(filtered, server)
+			if score, ok := scores[server.compositeKey]; ok && score > 0 {
+				server.Score = score
+				ranked = append(ranked, server)
+			}
+		}
+		sort.Slice(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	}
+
+	// category/type/publisher/official/tag filters apply as a post-filter
+	// over the ranked list, so they narrow results without perturbing BM25
+	// ranking order.
+	filters := parseSearchFilters(c)
+	// Initialize as empty slice to ensure JSON marshals as [] not null
+	filtered := make([]TransformedMCPServer, 0, len(ranked))
+	for _, server := range ranked {
+		if filters.matches(server) {
+			filtered = append(filtered, server)
+		}
+	}
+
+	// ?since=<version> restricts results to entries that changed after that
+	// version - for thick clients maintaining a local mirror instead of
+	// re-downloading the whole catalog on every poll.
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		if since, err := strconv.Atoi(sinceStr); err == nil {
+			sinceFiltered := make([]TransformedMCPServer, 0, len(filtered))
+			for _, server := range filtered {
+				if entryVersion[server.compositeKey] > since {
+					sinceFiltered = append(sinceFiltered, server)
+				}
 			}
+			filtered = sinceFiltered
 		}
 	}
 
@@ -559,34 +876,76 @@ func (h *Handler) SearchCatalog(c *gin.Context) {
 		TotalPages:    totalPages,
 		HasMore:       hasMore,
 		CacheStatus:   cacheStatus,
+		Version:       currentVersion,
 	})
 }
 
-// matchesQuery checks if a server matches the search query
-func matchesQuery(server TransformedMCPServer, query string) bool {
-	// Check name
-	if strings.Contains(strings.ToLower(server.Name), query) {
-		return true
+// searchFilters are the category/type/publisher/official/tag query
+// parameters SearchCatalog applies as a post-filter over the BM25-ranked
+// result list.
+type searchFilters struct {
+	category   string
+	serverType string
+	publisher  string
+	official   *bool
+	tags       []string
+}
+
+// parseSearchFilters reads category/type/publisher/official/tag from c's
+// query string. tag may repeat (?tag=foo&tag=bar); a server must carry every
+// requested tag to match.
+func parseSearchFilters(c *gin.Context) searchFilters {
+	f := searchFilters{
+		category:   strings.ToLower(strings.TrimSpace(c.Query("category"))),
+		serverType: strings.ToLower(strings.TrimSpace(c.Query("type"))),
+		publisher:  strings.ToLower(strings.TrimSpace(c.Query("publisher"))),
+		tags:       c.QueryArray("tag"),
 	}
-	// Check description
-	if strings.Contains(strings.ToLower(server.Description), query) {
-		return true
+	if raw := c.Query("official"); raw != "" {
+		if official, err := strconv.ParseBool(raw); err == nil {
+			f.official = &official
+		}
 	}
-	// Check category
-	if strings.Contains(strings.ToLower(server.Category), query) {
-		return true
+	return f
+}
+
+// matches reports whether server passes every filter f has set.
+func (f searchFilters) matches(server TransformedMCPServer) bool {
+	if f.category != "" {
+		found := false
+		for _, cat := range server.Categories {
+			if strings.ToLower(cat) == f.category {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
 	}
-	// Check publisher name
-	if strings.Contains(strings.ToLower(server.Publisher.Name), query) {
-		return true
+	if f.serverType != "" && strings.ToLower(server.Type) != f.serverType {
+		return false
 	}
-	// Check tags
-	for _, tag := range server.Tags {
-		if strings.Contains(strings.ToLower(tag), query) {
-			return true
+	if f.publisher != "" && !strings.Contains(strings.ToLower(server.Publisher.Name), f.publisher) {
+		return false
+	}
+	if f.official != nil && server.Official != *f.official {
+		return false
+	}
+	for _, want := range f.tags {
+		want = strings.ToLower(strings.TrimSpace(want))
+		found := false
+		for _, tag := range server.Tags {
+			if strings.ToLower(tag) == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
 		}
 	}
-	return false
+	return true
 }
 
 // GetCacheStatus returns the current cache status
@@ -603,15 +962,52 @@ func (h *Handler) GetCacheStatus(c *gin.Context) {
 		TotalServers: len(h.cache.servers),
 		LastUpdated:  h.cache.lastUpdated,
 		IsLoading:    h.cache.isLoading,
+		Stale:        h.cache.isLoading && h.cache.hydratedFromDisk,
 		Error:        errMsg,
 	})
 }
 
+// PurgeCache deletes the on-disk catalog cache store and clears the
+// in-memory cache, forcing the next request to see an empty catalog until a
+// fresh fetchAllCatalogItems completes. Requires X-Admin-Token, same as
+// SetTrustPolicyEndpoint.
+func (h *Handler) PurgeCache(c *gin.Context) {
+	if h.adminToken == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "MCP_CATALOG_ADMIN_TOKEN not configured"})
+		return
+	}
+	if c.GetHeader("X-Admin-Token") != h.adminToken {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or missing X-Admin-Token"})
+		return
+	}
+
+	if err := h.cacheStore.Purge(); err != nil {
+		log.WithError(err).Error("Failed to purge on-disk catalog cache store")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to purge cache store", "details": err.Error()})
+		return
+	}
+
+	h.cache.mu.Lock()
+	h.cache.servers = make([]TransformedMCPServer, 0)
+	h.cache.serversByID = make(map[string]TransformedMCPServer)
+	h.cache.quarantined = nil
+	h.cache.rawItems = nil
+	h.cache.provenance = nil
+	h.cache.lastUpdated = time.Time{}
+	h.cache.hydratedFromDisk = false
+	h.cache.mu.Unlock()
+
+	h.searchIdx.Build(nil)
+
+	log.Info("Catalog cache purged via admin endpoint")
+	c.JSON(http.StatusOK, gin.H{"message": "cache purged"})
+}
+
 // RefreshCache triggers a cache refresh
 func (h *Handler) RefreshCache(c *gin.Context) {
-	if h.apiKey == "" {
+	if !h.hasAnySource() {
 		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"error": "Postman API key not configured",
+			"error": "no catalog source configured",
 		})
 		return
 	}
@@ -634,6 +1030,146 @@ func (h *Handler) RefreshCache(c *gin.Context) {
 	})
 }
 
+// GetTrustPolicy returns the TrustPolicy SearchCatalog/quarantineAndCache
+// currently enforce.
+func (h *Handler) GetTrustPolicy() TrustPolicy {
+	h.trustPolicyMu.RLock()
+	defer h.trustPolicyMu.RUnlock()
+	return h.trustPolicy
+}
+
+// GetVerificationReport returns the current TrustPolicy plus every cached
+// server's Verification, including anything quarantineAndCache quarantined
+// under require-verified.
+func (h *Handler) GetVerificationReport(c *gin.Context) {
+	h.cache.mu.RLock()
+	servers := h.cache.servers
+	quarantined := h.cache.quarantined
+	h.cache.mu.RUnlock()
+
+	type entry struct {
+		ID           string       `json:"id"`
+		Name         string       `json:"name"`
+		Publisher    string       `json:"publisher"`
+		Verification Verification `json:"verification"`
+	}
+	toEntries := func(list []TransformedMCPServer) []entry {
+		out := make([]entry, 0, len(list))
+		for _, s := range list {
+			out = append(out, entry{ID: s.ID, Name: s.Name, Publisher: s.Publisher.Name, Verification: s.Verification})
+		}
+		return out
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"trust_policy": h.GetTrustPolicy(),
+		"servers":      toEntries(servers),
+		"quarantined":  toEntries(quarantined),
+	})
+}
+
+// SetTrustPolicyEndpoint changes the enforced TrustPolicy at runtime.
+// Requires X-Admin-Token to match MCP_CATALOG_ADMIN_TOKEN; if that env var
+// isn't set, the endpoint is disabled rather than accepting an unauthenticated
+// write.
+func (h *Handler) SetTrustPolicyEndpoint(c *gin.Context) {
+	if h.adminToken == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "MCP_CATALOG_ADMIN_TOKEN not configured"})
+		return
+	}
+	if c.GetHeader("X-Admin-Token") != h.adminToken {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or missing X-Admin-Token"})
+		return
+	}
+
+	var req struct {
+		TrustPolicy TrustPolicy `json:"trust_policy" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch req.TrustPolicy {
+	case TrustPolicyRequireVerified, TrustPolicyPreferVerified, TrustPolicyAllowAll:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown trust_policy %q", req.TrustPolicy)})
+		return
+	}
+
+	h.trustPolicyMu.Lock()
+	h.trustPolicy = req.TrustPolicy
+	h.trustPolicyMu.Unlock()
+
+	// Re-apply the new policy to the already-fetched items without a full
+	// re-fetch.
+	h.cache.mu.RLock()
+	rawItems := h.cache.rawItems
+	provenance := h.cache.provenance
+	h.cache.mu.RUnlock()
+	if rawItems != nil {
+		h.quarantineAndCache(rawItems, provenance)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"trust_policy": req.TrustPolicy})
+}
+
+// hasAnySource reports whether at least one CatalogProvider or a signed
+// offline bundle is configured, i.e. whether SearchCatalog/RefreshCache have
+// anything to serve or refresh.
+func (h *Handler) hasAnySource() bool {
+	return len(h.providers) > 0 || h.offlineBundlePath != ""
+}
+
+// GetProviders returns each configured CatalogProvider's last fetch status,
+// plus the signed offline bundle's if one is configured, so an operator can
+// see at a glance which catalog sources are healthy.
+func (h *Handler) GetProviders(c *gin.Context) {
+	h.providerMu.RLock()
+	statuses := make([]ProviderStatus, 0, len(h.providerStatus))
+	for _, status := range h.providerStatus {
+		statuses = append(statuses, status)
+	}
+	h.providerMu.RUnlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	c.JSON(http.StatusOK, gin.H{"providers": statuses})
+}
+
+// GetEvents streams CatalogEvents as Server-Sent Events until the client
+// disconnects, so a frontend can react to added/updated/removed entries and
+// refresh_started/refresh_completed instead of polling GetCacheStatus.
+func (h *Handler) GetEvents(c *gin.Context) {
+	ch, unsubscribe := h.events.subscribe()
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // disable proxy buffering so events flush immediately
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return false
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				log.WithError(err).Warn("Failed to marshal catalog event for SSE")
+				return true
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
 // GetCategories returns available search categories
 func (h *Handler) GetCategories(c *gin.Context) {
 	categories := []map[string]string{
@@ -670,8 +1206,18 @@ func transformMCPServer(item PostmanMCPCatalogItem) TransformedMCPServer {
 	// Generate tags from name and description
 	tags := generateTags(item.Name, item.Description)
 
-	// Infer category from name/description
-	category := inferCategory(item.Name, item.Description)
+	// Infer every category this server legitimately spans, scored by
+	// InferCategories, and keep PrimaryCategory for callers that only want
+	// one string.
+	categoryScores := taxonomy.InferCategories(item.Name, item.Description)
+	categories := make([]string, 0, len(categoryScores))
+	for _, cs := range categoryScores {
+		categories = append(categories, cs.Name)
+	}
+	category := PrimaryCategory(categoryScores)
+	if len(categories) == 0 {
+		categories = []string{category}
+	}
 
 	// ServerType for backward compatibility (sse = http)
 	serverType := config.Type
@@ -689,6 +1235,7 @@ func transformMCPServer(item PostmanMCPCatalogItem) TransformedMCPServer {
 		Config:      config,
 		Tags:        tags,
 		Category:    category,
+		Categories:  categories,
 		Official:    item.Publisher.Verified,
 		Featured:    item.Publisher.Verified,
 	}
@@ -706,7 +1253,7 @@ func parseMCPServerConfig(raw PostmanMCPServerEntry) MCPServerConfig {
 	// Log what we received for debugging
 	hasURL := raw.URL != ""
 	hasCommand := raw.Command != ""
-	
+
 	// Determine type based on presence of url vs command
 	if hasURL {
 		// HTTP/SSE based server
@@ -798,101 +1345,9 @@ func extractEnvVarName(value string) string {
 	return ""
 }
 
-// generateTags extracts tags from name and description
+// generateTags extracts tags from name and description via the package's
+// taxonomy (see taxonomy.go), falling back to "mcp" when nothing matches.
 func generateTags(name, description string) []string {
-	tags := []string{}
-
-	keywords := map[string]string{
-		"database":   "database",
-		"sql":        "sql",
-		"postgres":   "postgres",
-		"mysql":      "mysql",
-		"mongodb":    "mongodb",
-		"redis":      "redis",
-		"api":        "api",
-		"rest":       "rest",
-		"graphql":    "graphql",
-		"stripe":     "payments",
-		"slack":      "messaging",
-		"github":     "git",
-		"gitlab":     "git",
-		"docker":     "containers",
-		"kubernetes": "k8s",
-		"aws":        "aws",
-		"azure":      "azure",
-		"gcp":        "gcp",
-		"google":     "google",
-		"openai":     "ai",
-		"anthropic":  "ai",
-		"claude":     "ai",
-		"llm":        "ai",
-		"notion":     "productivity",
-		"jira":       "project-management",
-		"confluence": "documentation",
-		"figma":      "design",
-		"browser":    "browser",
-		"playwright": "automation",
-		"puppeteer":  "automation",
-		"scrape":     "scraping",
-		"crawl":      "scraping",
-		"search":     "search",
-		"file":       "files",
-		"storage":    "storage",
-		"s3":         "storage",
-	}
-
-	combined := fmt.Sprintf("%s %s", name, description)
-	for keyword, tag := range keywords {
-		if containsIgnoreCase(combined, keyword) {
-			tags = append(tags, tag)
-		}
-	}
-
-	if len(tags) == 0 {
-		tags = append(tags, "mcp")
-	}
-
-	// Dedupe and limit tags
-	seen := make(map[string]bool)
-	uniqueTags := []string{}
-	for _, tag := range tags {
-		if !seen[tag] && len(uniqueTags) < 5 {
-			seen[tag] = true
-			uniqueTags = append(uniqueTags, tag)
-		}
-	}
-
-	return uniqueTags
-}
-
-// inferCategory infers a category from name and description
-func inferCategory(name, description string) string {
-	combined := fmt.Sprintf("%s %s", name, description)
-
-	categoryPatterns := map[string][]string{
-		"database":     {"database", "sql", "postgres", "mysql", "mongodb", "redis", "dynamodb"},
-		"ai-ml":        {"openai", "anthropic", "claude", "llm", "ai", "ml", "gpt"},
-		"cloud":        {"aws", "azure", "gcp", "cloudflare", "vercel", "netlify"},
-		"productivity": {"notion", "slack", "email", "calendar", "todo", "task"},
-		"development":  {"github", "gitlab", "code", "build", "deploy", "ci/cd"},
-		"web-scraping": {"browser", "scrape", "crawl", "playwright", "puppeteer"},
-		"search":       {"search", "elasticsearch", "algolia"},
-		"file-system":  {"file", "storage", "s3", "drive"},
-		"communication": {"slack", "discord", "teams", "chat", "message"},
-	}
-
-	for category, patterns := range categoryPatterns {
-		for _, pattern := range patterns {
-			if containsIgnoreCase(combined, pattern) {
-				return category
-			}
-		}
-	}
-
-	return "other"
-}
-
-// containsIgnoreCase checks if s contains substr (case-insensitive)
-func containsIgnoreCase(s, substr string) bool {
-	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+	_, tags := taxonomy.Classify(name, description)
+	return tags
 }
@@ -0,0 +1,406 @@
+package catalog
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// InstalledUpstream is a catalog entry materialized into a gateway-managed
+// upstream - a persisted child-process config for stdio servers, or a
+// registered URL+headers for http/sse servers. Config.Env/Config.Headers
+// hold resolved values (secrets substituted in), but the secrets themselves
+// live only in the installManager's SecretStore, never inline here, so an
+// InstalledUpstream is safe to log or return over a trusted admin API
+// without also dumping every secret for every future read.
+type InstalledUpstream struct {
+	ID          string          `json:"id"`
+	ServerID    string          `json:"server_id"` // compositeKey of the catalog entry this came from
+	Name        string          `json:"name"`
+	Type        string          `json:"type"` // "stdio" or "http"
+	Config      MCPServerConfig `json:"config"`
+	InstalledAt time.Time       `json:"installed_at"`
+}
+
+// SecretStore persists the secret values an InstalledUpstream's config
+// placeholders were resolved against, keyed by install ID. Implementations
+// are swappable via INSTALL_SECRET_STORE so a deployment can start with the
+// in-memory default and move to file-backed or KMS-backed storage without
+// touching the install flow itself.
+type SecretStore interface {
+	Name() string
+	Save(installID string, secrets map[string]string) error
+	Load(installID string) (map[string]string, error)
+	Delete(installID string) error
+}
+
+// memorySecretStore is the default SecretStore: secrets live only for the
+// process lifetime, same tradeoff CatalogCache and verificationCache already
+// make in the absence of a real database.
+type memorySecretStore struct {
+	mu      sync.RWMutex
+	secrets map[string]map[string]string
+}
+
+func newMemorySecretStore() *memorySecretStore {
+	return &memorySecretStore{secrets: make(map[string]map[string]string)}
+}
+
+func (s *memorySecretStore) Name() string { return "memory" }
+
+func (s *memorySecretStore) Save(installID string, secrets map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets[installID] = secrets
+	return nil
+}
+
+func (s *memorySecretStore) Load(installID string) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.secrets[installID], nil
+}
+
+func (s *memorySecretStore) Delete(installID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.secrets, installID)
+	return nil
+}
+
+// fileSecretStore persists every install's secrets to a single JSON file,
+// encrypted with AES-256-GCM under a key from INSTALL_SECRET_STORE_KEY
+// (hex-encoded, 32 bytes). Every Save/Delete reads, mutates, and rewrites
+// the whole file under mu - simple and correct at the scale a single
+// gateway's installed upstreams live at, matching this package's existing
+// "no real database available" convention.
+type fileSecretStore struct {
+	mu   sync.Mutex
+	path string
+	key  []byte
+}
+
+func newFileSecretStore(path string, key []byte) *fileSecretStore {
+	return &fileSecretStore{path: path, key: key}
+}
+
+func (s *fileSecretStore) Name() string { return "file-encrypted" }
+
+func (s *fileSecretStore) Save(installID string, secrets map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.loadAll()
+	if err != nil {
+		return err
+	}
+	all[installID] = secrets
+	return s.writeAll(all)
+}
+
+func (s *fileSecretStore) Load(installID string) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	return all[installID], nil
+}
+
+func (s *fileSecretStore) Delete(installID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.loadAll()
+	if err != nil {
+		return err
+	}
+	delete(all, installID)
+	return s.writeAll(all)
+}
+
+func (s *fileSecretStore) loadAll() (map[string]map[string]string, error) {
+	ciphertext, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]map[string]string), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret store file: %w", err)
+	}
+
+	plaintext, err := decryptAESGCM(s.key, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret store file: %w", err)
+	}
+
+	all := make(map[string]map[string]string)
+	if len(plaintext) > 0 {
+		if err := json.Unmarshal(plaintext, &all); err != nil {
+			return nil, fmt.Errorf("failed to parse secret store file: %w", err)
+		}
+	}
+	return all, nil
+}
+
+func (s *fileSecretStore) writeAll(all map[string]map[string]string) error {
+	plaintext, err := json.Marshal(all)
+	if err != nil {
+		return fmt.Errorf("failed to marshal secret store contents: %w", err)
+	}
+	ciphertext, err := encryptAESGCM(s.key, plaintext)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secret store contents: %w", err)
+	}
+	return os.WriteFile(s.path, ciphertext, 0600)
+}
+
+// encryptAESGCM seals plaintext with a random nonce prepended to the
+// ciphertext, so decryptAESGCM needs only the key to reverse it.
+func encryptAESGCM(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decryptAESGCM(key, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext shorter than nonce size")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+// kmsSecretStore is a stub for an external KMS-backed SecretStore (AWS KMS,
+// GCP Cloud KMS, Vault, etc). Wiring a real one needs a client SDK this repo
+// doesn't vendor, so it fails loudly instead of silently degrading to
+// in-memory storage - an operator who set INSTALL_SECRET_STORE=kms should
+// find out immediately, not discover secrets were dropped on restart.
+type kmsSecretStore struct {
+	endpoint string
+}
+
+func (s *kmsSecretStore) Name() string { return "kms" }
+
+func (s *kmsSecretStore) Save(installID string, secrets map[string]string) error {
+	return fmt.Errorf("kms secret store not implemented (endpoint %q); set INSTALL_SECRET_STORE=memory or file", s.endpoint)
+}
+
+func (s *kmsSecretStore) Load(installID string) (map[string]string, error) {
+	return nil, fmt.Errorf("kms secret store not implemented (endpoint %q)", s.endpoint)
+}
+
+func (s *kmsSecretStore) Delete(installID string) error {
+	return fmt.Errorf("kms secret store not implemented (endpoint %q)", s.endpoint)
+}
+
+// newSecretStore builds the SecretStore INSTALL_SECRET_STORE selects,
+// defaulting to memorySecretStore when unset or misconfigured.
+func newSecretStore() SecretStore {
+	switch strings.ToLower(os.Getenv("INSTALL_SECRET_STORE")) {
+	case "file":
+		path := os.Getenv("INSTALL_SECRET_STORE_PATH")
+		if path == "" {
+			path = "install-secrets.enc"
+		}
+		keyBytes, err := hex.DecodeString(os.Getenv("INSTALL_SECRET_STORE_KEY"))
+		if err != nil || len(keyBytes) != 32 {
+			log.Warn("INSTALL_SECRET_STORE=file requires a 32-byte hex INSTALL_SECRET_STORE_KEY, falling back to in-memory secret storage")
+			return newMemorySecretStore()
+		}
+		return newFileSecretStore(path, keyBytes)
+	case "kms":
+		return &kmsSecretStore{endpoint: os.Getenv("KMS_ENDPOINT")}
+	default:
+		return newMemorySecretStore()
+	}
+}
+
+// installManager tracks every InstalledUpstream this gateway has
+// provisioned and the SecretStore backing it.
+type installManager struct {
+	mu        sync.RWMutex
+	upstreams map[string]InstalledUpstream
+	secrets   SecretStore
+	seq       int
+}
+
+func newInstallManager(secrets SecretStore) *installManager {
+	return &installManager{upstreams: make(map[string]InstalledUpstream), secrets: secrets}
+}
+
+// create records a new InstalledUpstream and assigns it an ID derived from
+// the source catalog entry, so two installs of the same entry don't collide
+// even if issued in the same instant.
+func (m *installManager) create(serverID, name, serverType string, config MCPServerConfig) InstalledUpstream {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.seq++
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%d", serverID, m.seq, time.Now().UnixNano())))
+	upstream := InstalledUpstream{
+		ID:          "upstream-" + hex.EncodeToString(sum[:])[:16],
+		ServerID:    serverID,
+		Name:        name,
+		Type:        serverType,
+		Config:      config,
+		InstalledAt: time.Now(),
+	}
+	m.upstreams[upstream.ID] = upstream
+	return upstream
+}
+
+// resolvePlaceholder substitutes value with secrets[envName] when value
+// references an env var (via the same ${VAR}/$VAR patterns extractEnvVarName
+// already recognizes) and that name is present in secrets; otherwise it
+// returns value unchanged.
+func resolvePlaceholder(value string, secrets map[string]string) string {
+	if !strings.Contains(value, "$") {
+		return value
+	}
+	envName := extractEnvVarName(value)
+	if envName == "" {
+		return value
+	}
+	if resolved, ok := secrets[envName]; ok {
+		return resolved
+	}
+	return value
+}
+
+// resolveInstallConfig returns a copy of config with every Env/Header value
+// that references an env var placeholder resolved against secrets, covering
+// both the AuthEnvVar-detected credential and any other placeholder-shaped
+// value the catalog entry happens to carry.
+func resolveInstallConfig(config MCPServerConfig, secrets map[string]string) MCPServerConfig {
+	resolved := config
+	if len(config.Env) > 0 {
+		env := make(map[string]string, len(config.Env))
+		for k, v := range config.Env {
+			env[k] = resolvePlaceholder(v, secrets)
+		}
+		resolved.Env = env
+	}
+	if len(config.Headers) > 0 {
+		headers := make(map[string]string, len(config.Headers))
+		for k, v := range config.Headers {
+			headers[k] = resolvePlaceholder(v, secrets)
+		}
+		resolved.Headers = headers
+	}
+	return resolved
+}
+
+// installRequest is POST /mcp-catalog/install/:id and GET .../preview's
+// request body: secret values keyed by the env var / header-placeholder
+// name they resolve (e.g. config.AuthEnvVar).
+type installRequest struct {
+	Secrets map[string]string `json:"secrets"`
+}
+
+// lookupInstallSource resolves :id against the cached catalog, parses an
+// optional installRequest body, and returns the resolved config both
+// InstallServer and PreviewInstall need - shared so the two endpoints can't
+// drift on what "the exact config" means.
+func (h *Handler) lookupInstallSource(c *gin.Context) (TransformedMCPServer, MCPServerConfig, installRequest, bool) {
+	id := c.Param("id")
+
+	h.cache.mu.RLock()
+	server, ok := h.cache.serversByID[id]
+	h.cache.mu.RUnlock()
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "catalog entry not found", "id": id})
+		return TransformedMCPServer{}, MCPServerConfig{}, installRequest{}, false
+	}
+
+	var req installRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+			return TransformedMCPServer{}, MCPServerConfig{}, installRequest{}, false
+		}
+	}
+
+	return server, resolveInstallConfig(server.Config, req.Secrets), req, true
+}
+
+// PreviewInstall renders the exact config InstallServer would persist,
+// without persisting it or storing any secrets, so a user can review what
+// will run before committing to it.
+func (h *Handler) PreviewInstall(c *gin.Context) {
+	server, resolved, _, ok := h.lookupInstallSource(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"server_id": server.compositeKey,
+		"name":      server.Name,
+		"type":      resolved.Type,
+		"config":    resolved,
+	})
+}
+
+// InstallServer materializes a cached catalog entry into a live gateway
+// upstream: a persisted managed child-process config for stdio servers, or a
+// registered URL+headers for http/sse servers. Secret values are stored via
+// the configured SecretStore, keyed by the generated upstream ID, never
+// inline in the returned InstalledUpstream.
+func (h *Handler) InstallServer(c *gin.Context) {
+	server, resolved, req, ok := h.lookupInstallSource(c)
+	if !ok {
+		return
+	}
+
+	upstream := h.installs.create(server.compositeKey, server.Name, resolved.Type, resolved)
+
+	if len(req.Secrets) > 0 {
+		if err := h.installs.secrets.Save(upstream.ID, req.Secrets); err != nil {
+			log.WithError(err).WithField("install_id", upstream.ID).Error("Failed to persist installed upstream secrets")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to persist secrets", "details": err.Error()})
+			return
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"install_id": upstream.ID,
+		"server_id":  server.compositeKey,
+		"type":       upstream.Type,
+		"store":      h.installs.secrets.Name(),
+	}).Info("Installed catalog entry as gateway upstream")
+
+	c.JSON(http.StatusCreated, upstream)
+}
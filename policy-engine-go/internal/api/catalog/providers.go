@@ -0,0 +1,471 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RawCatalogItem is the provider-agnostic shape every CatalogProvider
+// returns. It's deliberately identical to PostmanMCPCatalogItem so
+// transformMCPServer and parseMCPServersField keep working unchanged
+// regardless of which provider an item came from.
+type RawCatalogItem = PostmanMCPCatalogItem
+
+// CatalogProvider is a source of MCP server catalog entries. PostmanProvider
+// was the only implementation until pluggable providers were added; a
+// deployment now composes whichever mix of these NewHandler wires in from
+// its environment.
+type CatalogProvider interface {
+	Name() string
+	// SupportsIncremental reports whether Fetch can be asked for only
+	// what's changed since a prior fetch. None of the current providers do,
+	// so fetchAllCatalogItems always asks for everything - documented here
+	// rather than silently assumed, so a future incremental-capable
+	// provider has a real signal to hook into.
+	SupportsIncremental() bool
+	Fetch(ctx context.Context) ([]RawCatalogItem, error)
+}
+
+// PostmanProvider fetches from the Postman MCP Catalog API, the original
+// (and still default) catalog source.
+type PostmanProvider struct {
+	apiKey     string
+	httpClient *http.Client
+
+	pageCacheMu sync.Mutex
+	pageCache   map[string]postmanPageCacheEntry
+}
+
+// postmanPageCacheEntry remembers a page's validators and last result, so
+// the next Fetch can send If-None-Match/If-Modified-Since and skip
+// re-parsing a page Postman reports as unchanged (304).
+type postmanPageCacheEntry struct {
+	etag         string
+	lastModified string
+	items        []RawCatalogItem
+	hasMore      bool
+}
+
+// NewPostmanProvider builds a PostmanProvider. apiKey must be non-empty;
+// NewHandler only registers this provider when POSTMAN_API_KEY is set.
+func NewPostmanProvider(apiKey string, httpClient *http.Client) *PostmanProvider {
+	return &PostmanProvider{apiKey: apiKey, httpClient: httpClient, pageCache: make(map[string]postmanPageCacheEntry)}
+}
+
+func (p *PostmanProvider) Name() string              { return "postman" }
+func (p *PostmanProvider) SupportsIncremental() bool { return false }
+
+// Fetch runs the same multi-term crawl across every defaultSearchTerms
+// term, paging until each term is exhausted, since Postman's API doesn't
+// support an unfiltered "list everything" query.
+func (p *PostmanProvider) Fetch(ctx context.Context) ([]RawCatalogItem, error) {
+	seen := make(map[string]RawCatalogItem)
+	searchTerms := strings.Split(defaultSearchTerms, ",")
+
+	for _, term := range searchTerms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		offset := 0
+		for {
+			items, hasMore, err := p.fetchPage(ctx, term, fetchBatchSize, offset)
+			if err != nil {
+				log.WithError(err).WithField("term", term).Warn("Failed to fetch Postman catalog page")
+				break
+			}
+			for _, item := range items {
+				seen[item.ID] = item
+			}
+			if !hasMore {
+				break
+			}
+			offset += fetchBatchSize
+			time.Sleep(200 * time.Millisecond) // rate limiting - be nice to the API
+		}
+	}
+
+	results := make([]RawCatalogItem, 0, len(seen))
+	for _, item := range seen {
+		results = append(results, item)
+	}
+	return results, nil
+}
+
+func (p *PostmanProvider) fetchPage(ctx context.Context, query string, limit, offset int) ([]RawCatalogItem, bool, error) {
+	pageKey := fmt.Sprintf("%s:%d:%d", query, limit, offset)
+
+	apiURL, err := url.Parse(postmanAPIBaseURL + "/mcp-servers")
+	if err != nil {
+		return nil, false, err
+	}
+
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+	apiURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	p.pageCacheMu.Lock()
+	cached, hadCached := p.pageCache[pageKey]
+	p.pageCacheMu.Unlock()
+	if hadCached {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		if hadCached {
+			return cached.items, cached.hasMore, nil
+		}
+		// A 304 with nothing cached (e.g. cache evicted mid-run) has no
+		// body to fall back on - treat as an empty, terminal page rather
+		// than erroring the whole crawl.
+		return nil, false, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var postmanResp PostmanMCPCatalogResponse
+	if err := json.Unmarshal(body, &postmanResp); err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(postmanResp.Data) >= limit
+
+	p.pageCacheMu.Lock()
+	p.pageCache[pageKey] = postmanPageCacheEntry{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		items:        postmanResp.Data,
+		hasMore:      hasMore,
+	}
+	p.pageCacheMu.Unlock()
+
+	return postmanResp.Data, hasMore, nil
+}
+
+// GitHubManifestProvider reads an `mcp.json` manifest out of each configured
+// GitHub repository via the contents API, for teams that publish their MCP
+// server catalog alongside their code instead of through Postman.
+type GitHubManifestProvider struct {
+	// repos are "owner/repo" or "owner/repo:path/to/manifest.json" (path
+	// defaults to "mcp.json").
+	repos      []string
+	token      string
+	httpClient *http.Client
+}
+
+// NewGitHubManifestProvider builds a GitHubManifestProvider over repos
+// (comma-split already done by the caller). token is an optional GitHub API
+// token for private repos / higher rate limits.
+func NewGitHubManifestProvider(repos []string, token string, httpClient *http.Client) *GitHubManifestProvider {
+	return &GitHubManifestProvider{repos: repos, token: token, httpClient: httpClient}
+}
+
+func (p *GitHubManifestProvider) Name() string              { return "github" }
+func (p *GitHubManifestProvider) SupportsIncremental() bool { return false }
+
+// manifestDocument is the shape an mcp.json manifest (or an HTTP/OCI/
+// filesystem-provided one) is expected to contain: either a single server
+// entry or a list of them.
+type manifestDocument struct {
+	Servers []RawCatalogItem `json:"servers"`
+}
+
+func (p *GitHubManifestProvider) Fetch(ctx context.Context) ([]RawCatalogItem, error) {
+	var results []RawCatalogItem
+	for _, repo := range p.repos {
+		repo = strings.TrimSpace(repo)
+		if repo == "" {
+			continue
+		}
+		ownerRepo, path, found := strings.Cut(repo, ":")
+		if !found {
+			path = "mcp.json"
+		}
+
+		items, err := p.fetchManifest(ctx, ownerRepo, path)
+		if err != nil {
+			log.WithError(err).WithField("repo", repo).Warn("Failed to fetch GitHub MCP manifest")
+			continue
+		}
+		results = append(results, items...)
+	}
+	return results, nil
+}
+
+func (p *GitHubManifestProvider) fetchManifest(ctx context.Context, ownerRepo, path string) ([]RawCatalogItem, error) {
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/contents/%s", ownerRepo, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.raw+json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub contents API returned status %d for %s: %s", resp.StatusCode, ownerRepo, string(body))
+	}
+
+	// The contents API can return either the raw file (when Accept is
+	// ".raw+json") or a JSON envelope with a base64 "content" field,
+	// depending on GitHub's mood; handle both.
+	var envelope struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	manifestBytes := body
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Content != "" {
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(envelope.Content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to base64-decode GitHub manifest content: %w", err)
+		}
+		manifestBytes = decoded
+	}
+
+	return parseManifestBytes(manifestBytes, ownerRepo)
+}
+
+// parseManifestBytes parses raw's mcp.json-style manifest, accepting either
+// a bare list of server entries or a {"servers": [...]} envelope.
+func parseManifestBytes(raw []byte, source string) ([]RawCatalogItem, error) {
+	var doc manifestDocument
+	if err := json.Unmarshal(raw, &doc); err == nil && len(doc.Servers) > 0 {
+		return doc.Servers, nil
+	}
+
+	var list []RawCatalogItem
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, nil
+	}
+
+	var single RawCatalogItem
+	if err := json.Unmarshal(raw, &single); err == nil && single.ID != "" {
+		return []RawCatalogItem{single}, nil
+	}
+
+	return nil, fmt.Errorf("%s: manifest is neither a server list nor a single server entry", source)
+}
+
+// FilesystemProvider walks a local directory for MCP server manifest files,
+// for air-gapped deployments that can't reach any remote catalog source.
+// Each *.json file under dir is parsed the same way a GitHub manifest is.
+type FilesystemProvider struct {
+	dir string
+}
+
+// NewFilesystemProvider builds a FilesystemProvider rooted at dir.
+func NewFilesystemProvider(dir string) *FilesystemProvider {
+	return &FilesystemProvider{dir: dir}
+}
+
+func (p *FilesystemProvider) Name() string              { return "filesystem" }
+func (p *FilesystemProvider) SupportsIncremental() bool { return false }
+
+func (p *FilesystemProvider) Fetch(_ context.Context) ([]RawCatalogItem, error) {
+	var results []RawCatalogItem
+	err := filepath.Walk(p.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(strings.ToLower(path), ".json") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.WithError(err).WithField("path", path).Warn("Failed to read local catalog manifest")
+			return nil
+		}
+		items, err := parseManifestBytes(data, path)
+		if err != nil {
+			log.WithError(err).WithField("path", path).Warn("Failed to parse local catalog manifest")
+			return nil
+		}
+		results = append(results, items...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk local catalog directory %s: %w", p.dir, err)
+	}
+	return results, nil
+}
+
+// OCIArtifactProvider pulls an MCP catalog manifest published as an OCI
+// artifact, talking to the registry's plain HTTP Distribution API directly
+// (GET manifest, then GET the one config blob it references) rather than
+// pulling in a full OCI client library. This only handles a
+// single-layer/single-blob manifest holding the catalog JSON - enough for a
+// registry-published catalog, not a general-purpose OCI client.
+type OCIArtifactProvider struct {
+	registry   string // e.g. "ghcr.io"
+	repository string // e.g. "myorg/mcp-catalog"
+	tag        string
+	httpClient *http.Client
+}
+
+// NewOCIArtifactProvider builds an OCIArtifactProvider. tag defaults to
+// "latest" if empty.
+func NewOCIArtifactProvider(registry, repository, tag string, httpClient *http.Client) *OCIArtifactProvider {
+	if tag == "" {
+		tag = "latest"
+	}
+	return &OCIArtifactProvider{registry: registry, repository: repository, tag: tag, httpClient: httpClient}
+}
+
+func (p *OCIArtifactProvider) Name() string              { return "oci" }
+func (p *OCIArtifactProvider) SupportsIncremental() bool { return false }
+
+type ociManifest struct {
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+	Layers []struct {
+		Digest    string `json:"digest"`
+		MediaType string `json:"mediaType"`
+	} `json:"layers"`
+}
+
+func (p *OCIArtifactProvider) Fetch(ctx context.Context) ([]RawCatalogItem, error) {
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", p.registry, p.repository, p.tag)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OCI manifest: %w", err)
+	}
+	defer resp.Body.Close()
+	manifestBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCI registry returned status %d fetching manifest: %s", resp.StatusCode, string(manifestBody))
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(manifestBody, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse OCI manifest: %w", err)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, fmt.Errorf("OCI manifest for %s:%s has no layers", p.repository, p.tag)
+	}
+
+	// The catalog JSON is expected to be the first layer's blob; a
+	// multi-layer catalog artifact isn't something this provider supports.
+	digest := manifest.Layers[0].Digest
+	blobURL := fmt.Sprintf("https://%s/v2/%s/blobs/%s", p.registry, p.repository, digest)
+	blobReq, err := http.NewRequestWithContext(ctx, http.MethodGet, blobURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	blobResp, err := p.httpClient.Do(blobReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OCI blob %s: %w", digest, err)
+	}
+	defer blobResp.Body.Close()
+	blobBody, err := io.ReadAll(blobResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if blobResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCI registry returned status %d fetching blob %s", blobResp.StatusCode, digest)
+	}
+
+	return parseManifestBytes(blobBody, fmt.Sprintf("%s/%s@%s", p.registry, p.repository, digest))
+}
+
+// HTTPEndpointProvider fetches a catalog document from an arbitrary HTTP
+// endpoint, for teams that already run an internal MCP registry and just
+// want to expose it in the documented {"servers": [...]} schema
+// manifestDocument expects.
+type HTTPEndpointProvider struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewHTTPEndpointProvider builds an HTTPEndpointProvider over endpointURL.
+func NewHTTPEndpointProvider(endpointURL string, httpClient *http.Client) *HTTPEndpointProvider {
+	return &HTTPEndpointProvider{url: endpointURL, httpClient: httpClient}
+}
+
+func (p *HTTPEndpointProvider) Name() string              { return "http:" + p.url }
+func (p *HTTPEndpointProvider) SupportsIncremental() bool { return false }
+
+func (p *HTTPEndpointProvider) Fetch(ctx context.Context) ([]RawCatalogItem, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, resp.Body); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("catalog endpoint %s returned status %d", p.url, resp.StatusCode)
+	}
+
+	return parseManifestBytes(buf.Bytes(), p.url)
+}
@@ -0,0 +1,244 @@
+package catalog
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	// bm25K1 and bm25B are the standard Okapi BM25 tuning constants.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// fuzzyBigramThreshold is the minimum character-bigram Jaccard
+	// similarity for a vocabulary term to stand in for a query term that
+	// has no exact match (typo tolerance, e.g. "postgers" -> "postgres").
+	fuzzyBigramThreshold = 0.6
+)
+
+// fieldBoosts controls how many times a field's tokens are counted when
+// building a document's term vector - a cheap approximation of per-field
+// BM25F weighting without a separate per-field IDF model.
+var fieldBoosts = map[string]int{
+	"name":        3,
+	"tags":        2,
+	"category":    2,
+	"publisher":   1,
+	"description": 1,
+}
+
+// stopwords are dropped during tokenization so they don't dilute scoring
+// with near-universal terms.
+var stopwords = map[string]bool{
+	"the": true, "a": true, "an": true, "of": true, "and": true, "or": true,
+	"in": true, "on": true, "for": true, "to": true, "with": true,
+	"is": true, "are": true, "by": true, "at": true, "as": true,
+}
+
+// tokenize splits s on runs of non-letter/non-digit characters, lowercases,
+// and drops stopwords. This approximates Unicode NFKC-normalized folding by
+// comparing letters/digits post-lowercasing rather than running a full
+// normalization pass - the stdlib has no NFKC implementation and no
+// normalization package is imported here, so exotic compatibility-equivalent
+// characters (e.g. full-width forms) aren't folded together.
+func tokenize(s string) []string {
+	var tokens []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			tok := cur.String()
+			if !stopwords[tok] {
+				tokens = append(tokens, tok)
+			}
+			cur.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			cur.WriteRune(r)
+		} else {
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// charBigrams returns the set of overlapping two-rune substrings of s, used
+// as the similarity basis for fuzzy term matching.
+func charBigrams(s string) map[string]bool {
+	runes := []rune(s)
+	bigrams := make(map[string]bool)
+	if len(runes) < 2 {
+		bigrams[s] = true
+		return bigrams
+	}
+	for i := 0; i < len(runes)-1; i++ {
+		bigrams[string(runes[i:i+2])] = true
+	}
+	return bigrams
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for k := range a {
+		if b[k] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+// searchDoc is one catalog entry's term vector, keyed by its composite
+// (provider, id) cache key.
+type searchDoc struct {
+	termFreq map[string]int
+	length   int
+}
+
+// searchIndex is an in-memory inverted index over the cached catalog,
+// scored with BM25 and with a character-bigram fallback for typo-tolerant
+// matching. It's rebuilt from scratch on every quarantineAndCache, which is
+// cheap enough at catalog scale (thousands, not millions, of entries) to
+// avoid incremental-index bookkeeping.
+type searchIndex struct {
+	mu          sync.RWMutex
+	docs        map[string]*searchDoc // compositeKey -> term vector
+	docFreq     map[string]int        // term -> number of docs containing it
+	totalDocs   int
+	totalLength int
+	bigramIndex map[string]map[string]bool // bigram -> set of vocabulary terms containing it
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		docs:        make(map[string]*searchDoc),
+		docFreq:     make(map[string]int),
+		bigramIndex: make(map[string]map[string]bool),
+	}
+}
+
+// Build replaces the index's contents with one built fresh over servers.
+func (idx *searchIndex) Build(servers []TransformedMCPServer) {
+	docs := make(map[string]*searchDoc, len(servers))
+	docFreq := make(map[string]int)
+	totalLength := 0
+
+	for _, s := range servers {
+		termFreq := make(map[string]int)
+		addTokens := func(text string, boost int) {
+			for _, tok := range tokenize(text) {
+				termFreq[tok] += boost
+			}
+		}
+		addTokens(s.Name, fieldBoosts["name"])
+		addTokens(s.Description, fieldBoosts["description"])
+		addTokens(s.Publisher.Name, fieldBoosts["publisher"])
+		for _, cat := range s.Categories {
+			addTokens(cat, fieldBoosts["category"])
+		}
+		for _, tag := range s.Tags {
+			addTokens(tag, fieldBoosts["tags"])
+		}
+
+		length := 0
+		for _, tf := range termFreq {
+			length += tf
+		}
+		docs[s.compositeKey] = &searchDoc{termFreq: termFreq, length: length}
+		totalLength += length
+
+		for term := range termFreq {
+			docFreq[term]++
+		}
+	}
+
+	bigramIndex := make(map[string]map[string]bool, len(docFreq))
+	for term := range docFreq {
+		for bg := range charBigrams(term) {
+			if bigramIndex[bg] == nil {
+				bigramIndex[bg] = make(map[string]bool)
+			}
+			bigramIndex[bg][term] = true
+		}
+	}
+
+	idx.mu.Lock()
+	idx.docs = docs
+	idx.docFreq = docFreq
+	idx.totalDocs = len(docs)
+	idx.totalLength = totalLength
+	idx.bigramIndex = bigramIndex
+	idx.mu.Unlock()
+}
+
+// expandFuzzy returns term itself when it's already in the vocabulary, or
+// every vocabulary term whose bigram-Jaccard similarity to term meets
+// fuzzyBigramThreshold otherwise - must be called with idx.mu held.
+func (idx *searchIndex) expandFuzzy(term string) []string {
+	if idx.docFreq[term] > 0 {
+		return []string{term}
+	}
+	termBigrams := charBigrams(term)
+	var matches []string
+	seen := make(map[string]bool)
+	for bg := range termBigrams {
+		for candidate := range idx.bigramIndex[bg] {
+			if seen[candidate] {
+				continue
+			}
+			seen[candidate] = true
+			if jaccard(termBigrams, charBigrams(candidate)) >= fuzzyBigramThreshold {
+				matches = append(matches, candidate)
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return []string{term}
+	}
+	return matches
+}
+
+// Score ranks every indexed document against query using BM25, expanding
+// any query term with no exact vocabulary match via expandFuzzy. It returns
+// compositeKey -> score for documents with a positive score only.
+func (idx *searchIndex) Score(query string) map[string]float64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if idx.totalDocs == 0 {
+		return nil
+	}
+	avgDocLen := float64(idx.totalLength) / float64(idx.totalDocs)
+
+	var terms []string
+	for _, tok := range tokenize(query) {
+		terms = append(terms, idx.expandFuzzy(tok)...)
+	}
+
+	scores := make(map[string]float64)
+	for _, term := range terms {
+		df := idx.docFreq[term]
+		if df == 0 {
+			continue
+		}
+		idf := math.Log(1 + (float64(idx.totalDocs)-float64(df)+0.5)/(float64(df)+0.5))
+		for key, doc := range idx.docs {
+			tf := doc.termFreq[term]
+			if tf == 0 {
+				continue
+			}
+			denom := float64(tf) + bm25K1*(1-bm25B+bm25B*float64(doc.length)/avgDocLen)
+			scores[key] += idf * (float64(tf) * (bm25K1 + 1)) / denom
+		}
+	}
+	return scores
+}
@@ -0,0 +1,441 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// maxTaxonomyTags caps how many tags Classify returns for a single server,
+// matching the limit the old hardcoded generateTags enforced.
+const maxTaxonomyTags = 5
+
+// TaxonomyEntry is one classification rule: every catalog entry whose
+// combined "name description" matches any of Patterns is tagged with Tags
+// and, if Category is set, counted as a match for that category. Patterns
+// are matched as literal substrings by default; a pattern ending in a glob
+// metacharacter (*, ?, [) is matched with path.Match against each
+// alphanumeric/dash token of the combined text, and a pattern wrapped as
+// "/regex/flags" is compiled once and matched against the whole combined
+// text (the only supported flag today is "i" for case-insensitive).
+type TaxonomyEntry struct {
+	Category string   `json:"category,omitempty"`
+	Patterns []string `json:"patterns"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+type taxonomyMatcherKind int
+
+const (
+	taxonomyMatcherLiteral taxonomyMatcherKind = iota
+	taxonomyMatcherGlob
+	taxonomyMatcherRegex
+)
+
+type compiledPattern struct {
+	kind    taxonomyMatcherKind
+	literal string // lowercased, for literal and glob kinds
+	regex   *regexp.Regexp
+	raw     string // original pattern text, reported as a CategoryScore.MatchedTerms entry
+}
+
+// matches reports whether compiled pattern p matches combined (already
+// lowercased) - tokens is combined's alphanumeric/dash tokens, precomputed
+// once per Classify call since glob patterns match token-by-token rather
+// than the whole string.
+func (p compiledPattern) matches(combined string, tokens []string) bool {
+	switch p.kind {
+	case taxonomyMatcherRegex:
+		return p.regex.MatchString(combined)
+	case taxonomyMatcherGlob:
+		for _, tok := range tokens {
+			if ok, err := path.Match(p.literal, tok); ok && err == nil {
+				return true
+			}
+		}
+		return false
+	default:
+		return strings.Contains(combined, p.literal)
+	}
+}
+
+// compilePattern compiles one TaxonomyEntry.Patterns string into a
+// compiledPattern, picking literal/glob/regex per the syntax documented on
+// TaxonomyEntry.
+func compilePattern(raw string) (compiledPattern, error) {
+	if strings.HasPrefix(raw, "/") {
+		if end := strings.LastIndex(raw[1:], "/"); end >= 0 {
+			body := raw[1 : end+1]
+			flags := raw[end+2:]
+			expr := body
+			if strings.Contains(flags, "i") {
+				expr = "(?i)" + expr
+			}
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return compiledPattern{}, fmt.Errorf("invalid regex pattern %q: %w", raw, err)
+			}
+			return compiledPattern{kind: taxonomyMatcherRegex, regex: re, raw: raw}, nil
+		}
+	}
+
+	lower := foldForMatch(raw)
+	if strings.ContainsAny(raw, "*?[") {
+		if _, err := path.Match(lower, ""); err != nil {
+			return compiledPattern{}, fmt.Errorf("invalid glob pattern %q: %w", raw, err)
+		}
+		return compiledPattern{kind: taxonomyMatcherGlob, literal: lower, raw: raw}, nil
+	}
+
+	return compiledPattern{kind: taxonomyMatcherLiteral, literal: lower, raw: raw}, nil
+}
+
+type compiledEntry struct {
+	category string
+	tags     []string
+	patterns []compiledPattern
+}
+
+func compileTaxonomyEntries(raw []TaxonomyEntry) ([]compiledEntry, error) {
+	compiled := make([]compiledEntry, 0, len(raw))
+	for _, entry := range raw {
+		patterns := make([]compiledPattern, 0, len(entry.Patterns))
+		for _, p := range entry.Patterns {
+			cp, err := compilePattern(p)
+			if err != nil {
+				return nil, fmt.Errorf("category %q: %w", entry.Category, err)
+			}
+			patterns = append(patterns, cp)
+		}
+		compiled = append(compiled, compiledEntry{category: entry.Category, tags: entry.Tags, patterns: patterns})
+	}
+	return compiled, nil
+}
+
+// taxonomyTokens splits combined into runs of letters/digits/dashes, the
+// unit glob patterns like "s3-*" or "*-mcp-*" match against.
+func taxonomyTokens(combined string) []string {
+	return strings.FieldsFunc(combined, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-')
+	})
+}
+
+// Taxonomy classifies a catalog entry's categories and tags from its name
+// and description. It replaces the categoryPatterns/keywords maps that used
+// to be baked directly into this file: adding a new ecosystem is now a data
+// change to a taxonomy config file (loaded via Reload) rather than a code
+// change and rebuild.
+type Taxonomy struct {
+	mu      sync.RWMutex
+	entries []compiledEntry
+}
+
+// newDefaultTaxonomy builds a Taxonomy from defaultTaxonomyEntries, the
+// embedded fallback used when no taxonomy config file is configured (or
+// while one fails to load).
+func newDefaultTaxonomy() *Taxonomy {
+	compiled, err := compileTaxonomyEntries(defaultTaxonomyEntries())
+	if err != nil {
+		// The embedded default is a static literal, so a compile failure
+		// here is a programming error, not a runtime condition - fail fast
+		// instead of silently starting with a taxonomy that classifies
+		// nothing.
+		panic(fmt.Sprintf("embedded default taxonomy failed to compile: %v", err))
+	}
+	return &Taxonomy{entries: compiled}
+}
+
+// Reload replaces t's entries with the taxonomy config at path, which is a
+// JSON document containing a []TaxonomyEntry (the request that introduced
+// this called the file "taxonomy.yaml", but this repo has no YAML parser
+// vendored and adds no new dependencies - see search_index.go's tokenize
+// for the same stdlib-only tradeoff - so the on-disk format is JSON).
+// A reload that fails to read, parse, or compile leaves t's current
+// entries untouched, so a bad config push doesn't blank out classification.
+func (t *Taxonomy) Reload(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read taxonomy config %q: %w", path, err)
+	}
+
+	var raw []TaxonomyEntry
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse taxonomy config %q: %w", path, err)
+	}
+
+	compiled, err := compileTaxonomyEntries(raw)
+	if err != nil {
+		return fmt.Errorf("failed to compile taxonomy config %q: %w", path, err)
+	}
+
+	t.mu.Lock()
+	t.entries = compiled
+	t.mu.Unlock()
+
+	log.WithFields(log.Fields{"path": path, "entries": len(compiled)}).Info("Loaded taxonomy config")
+	return nil
+}
+
+// foldForMatch is the single case-folding primitive Classify and
+// InferCategories apply once per server, replacing the old per-keyword
+// containsIgnoreCase, which lowercased both operands on every single
+// keyword/category comparison. Folding the combined "name description"
+// exactly once before the pattern scan (rather than per pattern) is both
+// the allocation win and the prerequisite for the scan to see a consistent
+// string.
+//
+// It's a deliberately incomplete substitute for true Unicode-correct
+// folding, documented rather than left silent: golang.org/x/text/cases'
+// cases.Fold() also gets locale-dependent casing right (Turkish dotless
+// i/İ, German ß expanding to "ss") and golang.org/x/text/unicode/norm's NFC
+// normalization would make a decomposed "é" match a precomposed "é",
+// but both require golang.org/x/text, which this repo doesn't vendor - see
+// search_index.go's tokenize for the same NFKC tradeoff. strings.ToLower is
+// still Unicode-aware per rune (unicode.ToLower, not a byte-wise ASCII
+// fold), so this is correct for the common case; it just doesn't close the
+// locale-casing or combining-mark gaps above.
+func foldForMatch(s string) string {
+	return strings.ToLower(s)
+}
+
+// Classify returns every category whose patterns match name+description
+// (in taxonomy order, each category at most once) and up to
+// maxTaxonomyTags deduplicated tags from every matching entry, falling back
+// to ["mcp"] when nothing matched at all.
+func (t *Taxonomy) Classify(name, description string) (categories []string, tags []string) {
+	combined := foldForMatch(name + " " + description)
+	tokens := taxonomyTokens(combined)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	seenCategory := make(map[string]bool)
+	seenTag := make(map[string]bool)
+	for _, entry := range t.entries {
+		matched := false
+		for _, p := range entry.patterns {
+			if p.matches(combined, tokens) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		if entry.category != "" && !seenCategory[entry.category] {
+			seenCategory[entry.category] = true
+			categories = append(categories, entry.category)
+		}
+		for _, tag := range entry.tags {
+			if !seenTag[tag] && len(tags) < maxTaxonomyTags {
+				seenTag[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	if len(tags) == 0 {
+		tags = []string{"mcp"}
+	}
+	return categories, tags
+}
+
+// categoryScoreThreshold is the minimum CategoryScore.Score InferCategories
+// keeps, configurable via CATEGORY_SCORE_THRESHOLD.
+func categoryScoreThreshold() float64 {
+	if raw := os.Getenv("CATEGORY_SCORE_THRESHOLD"); raw != "" {
+		if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+			return parsed
+		}
+	}
+	return 1.0
+}
+
+// maxCategoryResults caps how many CategoryScores InferCategories returns,
+// configurable via CATEGORY_MAX_RESULTS.
+func maxCategoryResults() int {
+	if raw := os.Getenv("CATEGORY_MAX_RESULTS"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return 3
+}
+
+// shortDescriptionMinLength and shortDescriptionPenalty implement
+// InferCategories' "small penalty for very short descriptions": a
+// description this short carries little real signal, so categories scored
+// mostly (or only) from name matches are more likely to be a false positive
+// than a genuine multi-category match.
+const (
+	shortDescriptionMinLength = 20
+	shortDescriptionPenalty   = 0.5
+)
+
+// CategoryScore is one category InferCategories assigned to a server, with
+// the weighted score that earned it and the distinct pattern terms that
+// matched.
+type CategoryScore struct {
+	Name         string   `json:"name"`
+	Score        float64  `json:"score"`
+	MatchedTerms []string `json:"matched_terms"`
+}
+
+// InferCategories scores every category-bearing taxonomy entry against
+// name and description independently: +2 for each distinct pattern that
+// matches in name, +1 for each distinct pattern that matches in
+// description (a pattern matching both counts for both), then subtracts
+// shortDescriptionPenalty from every category's score when description is
+// shorter than shortDescriptionMinLength. Categories scoring at least
+// categoryScoreThreshold are kept, sorted by score descending, and capped
+// at maxCategoryResults - replacing the old inferCategory's first-match-
+// wins behavior with one that can assign a server to several categories it
+// legitimately spans (e.g. a Slack bot is both "communication" and
+// "productivity").
+func (t *Taxonomy) InferCategories(name, description string) []CategoryScore {
+	nameLower := foldForMatch(name)
+	descLower := foldForMatch(description)
+	nameTokens := taxonomyTokens(nameLower)
+	descTokens := taxonomyTokens(descLower)
+
+	t.mu.RLock()
+	entries := t.entries
+	t.mu.RUnlock()
+
+	byCategory := make(map[string]*CategoryScore)
+	var order []string
+	for _, entry := range entries {
+		if entry.category == "" {
+			continue
+		}
+		for _, p := range entry.patterns {
+			hitName := p.matches(nameLower, nameTokens)
+			hitDesc := p.matches(descLower, descTokens)
+			if !hitName && !hitDesc {
+				continue
+			}
+
+			cs, ok := byCategory[entry.category]
+			if !ok {
+				cs = &CategoryScore{Name: entry.category}
+				byCategory[entry.category] = cs
+				order = append(order, entry.category)
+			}
+			if hitName {
+				cs.Score += 2
+			}
+			if hitDesc {
+				cs.Score += 1
+			}
+			alreadyMatched := false
+			for _, term := range cs.MatchedTerms {
+				if term == p.raw {
+					alreadyMatched = true
+					break
+				}
+			}
+			if !alreadyMatched {
+				cs.MatchedTerms = append(cs.MatchedTerms, p.raw)
+			}
+		}
+	}
+
+	shortDescription := len(strings.TrimSpace(description)) < shortDescriptionMinLength
+	threshold := categoryScoreThreshold()
+
+	results := make([]CategoryScore, 0, len(order))
+	for _, category := range order {
+		cs := byCategory[category]
+		if shortDescription {
+			cs.Score -= shortDescriptionPenalty
+		}
+		if cs.Score >= threshold {
+			results = append(results, *cs)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+
+	if limit := maxCategoryResults(); len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// PrimaryCategory returns the highest-scoring category from scores (which
+// InferCategories already returns sorted descending), or "other" if scores
+// is empty - for callers that need a single category string rather than
+// the full ranked list.
+func PrimaryCategory(scores []CategoryScore) string {
+	if len(scores) == 0 {
+		return "other"
+	}
+	return scores[0].Name
+}
+
+// taxonomy is the package-level Taxonomy transformMCPServer classifies
+// every catalog entry against. It's a package global rather than a Handler
+// field because transformMCPServer (and the generateTags/inferCategory
+// wrappers around it) are free functions with no Handler in scope; loaded
+// once at package init time, optionally reloaded from TAXONOMY_CONFIG_PATH.
+var taxonomy = newDefaultTaxonomy()
+
+func init() {
+	if configPath := os.Getenv("TAXONOMY_CONFIG_PATH"); configPath != "" {
+		if err := taxonomy.Reload(configPath); err != nil {
+			log.WithError(err).Warn("Failed to load TAXONOMY_CONFIG_PATH, using embedded default taxonomy")
+		}
+	}
+}
+
+type legacyKeywordTag struct{ keyword, tag string }
+
+// legacyKeywordTags is the old generateTags keyword->tag map, ported
+// verbatim (as one literal-pattern entry each) into defaultTaxonomyEntries
+// so the default taxonomy's tag vocabulary is unchanged.
+var legacyKeywordTags = []legacyKeywordTag{
+	{"database", "database"}, {"sql", "sql"}, {"postgres", "postgres"}, {"mysql", "mysql"},
+	{"mongodb", "mongodb"}, {"redis", "redis"}, {"api", "api"}, {"rest", "rest"},
+	{"graphql", "graphql"}, {"stripe", "payments"}, {"slack", "messaging"}, {"github", "git"},
+	{"gitlab", "git"}, {"docker", "containers"}, {"kubernetes", "k8s"}, {"aws", "aws"},
+	{"azure", "azure"}, {"gcp", "gcp"}, {"google", "google"}, {"openai", "ai"},
+	{"anthropic", "ai"}, {"claude", "ai"}, {"llm", "ai"}, {"notion", "productivity"},
+	{"jira", "project-management"}, {"confluence", "documentation"}, {"figma", "design"},
+	{"browser", "browser"}, {"playwright", "automation"}, {"puppeteer", "automation"},
+	{"scrape", "scraping"}, {"crawl", "scraping"}, {"search", "search"}, {"file", "files"},
+	{"storage", "storage"}, {"s3", "storage"},
+}
+
+// defaultTaxonomyEntries is the embedded default taxonomy, ported verbatim
+// from the categoryPatterns and keywords maps this file used to hardcode,
+// for backwards compatibility until an operator points TAXONOMY_CONFIG_PATH
+// at a real config.
+func defaultTaxonomyEntries() []TaxonomyEntry {
+	entries := []TaxonomyEntry{
+		{Category: "database", Patterns: []string{"database", "sql", "postgres", "mysql", "mongodb", "redis", "dynamodb"}},
+		{Category: "ai-ml", Patterns: []string{"openai", "anthropic", "claude", "llm", "ai", "ml", "gpt"}},
+		{Category: "cloud", Patterns: []string{"aws", "azure", "gcp", "cloudflare", "vercel", "netlify"}},
+		{Category: "productivity", Patterns: []string{"notion", "slack", "email", "calendar", "todo", "task"}},
+		{Category: "development", Patterns: []string{"github", "gitlab", "code", "build", "deploy", "ci/cd"}},
+		{Category: "web-scraping", Patterns: []string{"browser", "scrape", "crawl", "playwright", "puppeteer"}},
+		{Category: "search", Patterns: []string{"search", "elasticsearch", "algolia"}},
+		{Category: "file-system", Patterns: []string{"file", "storage", "s3", "drive"}},
+		{Category: "communication", Patterns: []string{"slack", "discord", "teams", "chat", "message"}},
+	}
+
+	for _, kt := range legacyKeywordTags {
+		entries = append(entries, TaxonomyEntry{Patterns: []string{kt.keyword}, Tags: []string{kt.tag}})
+	}
+
+	return entries
+}
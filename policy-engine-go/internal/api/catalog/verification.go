@@ -0,0 +1,208 @@
+package catalog
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TrustPolicy controls whether SearchCatalog serves a catalog entry based on
+// its Verification outcome.
+type TrustPolicy string
+
+const (
+	// TrustPolicyRequireVerified hides any entry whose Verification.Status
+	// isn't "verified" - safest, but only useful once a Verifier is
+	// actually configured, since an unconfigured chain leaves everything
+	// unverified.
+	TrustPolicyRequireVerified TrustPolicy = "require-verified"
+	// TrustPolicyPreferVerified (the default) serves every entry but sorts
+	// verified ones first, so a compromised upstream response isn't
+	// silently hidden - it's surfaced, ranked last, for an operator to
+	// notice via /mcp-catalog/verification.
+	TrustPolicyPreferVerified TrustPolicy = "prefer-verified"
+	// TrustPolicyAllowAll serves every entry regardless of verification
+	// outcome, identical to the pre-verification behavior.
+	TrustPolicyAllowAll TrustPolicy = "allow-all"
+)
+
+// Verification records the supply-chain attestation outcome computed for a
+// single catalog entry's mcpServers payload.
+type Verification struct {
+	Status      string    `json:"status"` // "verified", "unverified", "quarantined"
+	Algorithm   string    `json:"algorithm,omitempty"`
+	Signer      string    `json:"signer,omitempty"`
+	Attestation string    `json:"attestation_type,omitempty"` // name of the Verifier that produced this result, or "none"
+	VerifiedAt  time.Time `json:"verified_at,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// unverifiedResult is what VerifierChain.Verify returns when every Verifier
+// in the chain declines an item.
+var unverifiedResult = Verification{Status: "unverified", Attestation: "none"}
+
+// Verifier attests a single catalog entry's mcpServers config before it's
+// cached. A Verifier that has no opinion about item (e.g. the publisher
+// isn't in its allowlist) returns an error so VerifierChain falls through to
+// the next one, rather than a negative Verification.
+type Verifier interface {
+	Name() string
+	Verify(item PostmanMCPCatalogItem, rawConfig json.RawMessage) (Verification, error)
+}
+
+// VerifierChain runs Verifiers in order and takes the first positive result.
+type VerifierChain []Verifier
+
+// Verify runs vc's Verifiers in order, returning the first one that doesn't
+// decline. An empty or entirely-declining chain returns unverifiedResult,
+// not an error - a missing attestation is something TrustPolicy decides how
+// to treat, not a processing failure.
+func (vc VerifierChain) Verify(item PostmanMCPCatalogItem, rawConfig json.RawMessage) Verification {
+	for _, v := range vc {
+		result, err := v.Verify(item, rawConfig)
+		if err == nil {
+			result.VerifiedAt = time.Now()
+			return result
+		}
+	}
+	return unverifiedResult
+}
+
+// PublisherAllowlistVerifier trusts an entry outright when its Publisher.ID
+// is in a configured set of trusted publisher IDs. Postman's catalog API
+// carries no per-item signature, so this is the practical trust anchor for
+// live (non-offline-bundle) entries: the operator curates which publishers
+// they trust, the same way they'd pin a package registry's allowed scopes.
+type PublisherAllowlistVerifier struct {
+	trusted map[string]bool
+}
+
+// NewPublisherAllowlistVerifier builds a PublisherAllowlistVerifier from a
+// comma-separated list of trusted Postman publisher IDs (e.g. the
+// TRUSTED_PUBLISHERS env var).
+func NewPublisherAllowlistVerifier(publisherIDs []string) *PublisherAllowlistVerifier {
+	trusted := make(map[string]bool, len(publisherIDs))
+	for _, id := range publisherIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			trusted[id] = true
+		}
+	}
+	return &PublisherAllowlistVerifier{trusted: trusted}
+}
+
+func (v *PublisherAllowlistVerifier) Name() string { return "publisher-allowlist" }
+
+func (v *PublisherAllowlistVerifier) Verify(item PostmanMCPCatalogItem, _ json.RawMessage) (Verification, error) {
+	if !v.trusted[item.Publisher.ID] {
+		return Verification{}, fmt.Errorf("publisher %q is not in the trusted allowlist", item.Publisher.ID)
+	}
+	return Verification{
+		Status:      "verified",
+		Signer:      item.Publisher.ID,
+		Attestation: v.Name(),
+	}, nil
+}
+
+// SigstoreBundleVerifier verifies a keyless Sigstore/cosign-style bundle
+// when Postman (or a future catalog source) attaches one to an entry. The
+// live Postman MCP catalog API doesn't carry this field today, so this
+// verifier declines every current response; it exists so a bundle can be
+// recognized the moment the upstream API adds one, without a second
+// migration of the verification plumbing.
+type SigstoreBundleVerifier struct{}
+
+func (v *SigstoreBundleVerifier) Name() string { return "sigstore-keyless" }
+
+func (v *SigstoreBundleVerifier) Verify(item PostmanMCPCatalogItem, _ json.RawMessage) (Verification, error) {
+	return Verification{}, fmt.Errorf("item %s carries no sigstore attestation bundle", item.ID)
+}
+
+// verificationCache caches a Verifier chain's result for an item keyed by
+// (composite provider:id key, contentHash(rawConfig)), so a periodic
+// RefreshCache that re-fetches an unchanged entry doesn't re-run
+// verification, and so two providers that happen to reuse the same item ID
+// don't share a cached result.
+type verificationCache struct {
+	mu      sync.RWMutex
+	results map[string]Verification
+}
+
+func newVerificationCache() *verificationCache {
+	return &verificationCache{results: make(map[string]Verification)}
+}
+
+func contentHash(raw json.RawMessage) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func cacheKey(key string, raw json.RawMessage) string {
+	return key + ":" + contentHash(raw)
+}
+
+func (vc *verificationCache) get(key string, raw json.RawMessage) (Verification, bool) {
+	vc.mu.RLock()
+	defer vc.mu.RUnlock()
+	result, ok := vc.results[cacheKey(key, raw)]
+	return result, ok
+}
+
+func (vc *verificationCache) put(key string, raw json.RawMessage, result Verification) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	vc.results[cacheKey(key, raw)] = result
+}
+
+// offlineBundle is the signed JSON document OFFLINE_CATALOG_BUNDLE_PATH
+// points at, used in place of live Postman fetches when set.
+type offlineBundle struct {
+	Items     []PostmanMCPCatalogItem `json:"items"`
+	Signature string                  `json:"signature"` // base64 ed25519 signature over Items, re-marshaled
+}
+
+// loadOfflineBundle reads and verifies a signed catalog bundle from disk,
+// returning its items. verifyKey is the publisher's ed25519 public key
+// (OFFLINE_CATALOG_BUNDLE_KEY, hex-encoded); every item in a bundle that
+// verifies is marked Verification{Status: "verified", Attestation:
+// "signed-bundle"} by the caller, since the signature covers the whole
+// bundle rather than each item individually.
+func loadOfflineBundle(path string, verifyKey ed25519.PublicKey) ([]PostmanMCPCatalogItem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offline catalog bundle: %w", err)
+	}
+
+	var bundle offlineBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse offline catalog bundle: %w", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(bundle.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid offline catalog bundle signature encoding: %w", err)
+	}
+
+	itemsJSON, err := json.Marshal(bundle.Items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-marshal offline catalog bundle items: %w", err)
+	}
+
+	if len(verifyKey) == 0 {
+		return nil, fmt.Errorf("OFFLINE_CATALOG_BUNDLE_KEY not configured, refusing to load unverifiable bundle")
+	}
+	if !ed25519.Verify(verifyKey, itemsJSON, sig) {
+		return nil, fmt.Errorf("offline catalog bundle signature verification failed")
+	}
+
+	log.WithField("items", len(bundle.Items)).Info("Loaded signed offline MCP catalog bundle")
+	return bundle.Items, nil
+}
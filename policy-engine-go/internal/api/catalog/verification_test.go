@@ -0,0 +1,122 @@
+package catalog
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPublisherAllowlistVerifierTrustsOnlyListedPublishers(t *testing.T) {
+	v := NewPublisherAllowlistVerifier([]string{"acme", " trusted-co "})
+
+	trusted := PostmanMCPCatalogItem{ID: "1", Publisher: PostmanPublisher{ID: "trusted-co"}}
+	result, err := v.Verify(trusted, nil)
+	if err != nil {
+		t.Fatalf("expected a trusted publisher to verify, got error: %v", err)
+	}
+	if result.Status != "verified" || result.Signer != "trusted-co" {
+		t.Fatalf("unexpected verification result: %+v", result)
+	}
+
+	untrusted := PostmanMCPCatalogItem{ID: "2", Publisher: PostmanPublisher{ID: "unknown"}}
+	if _, err := v.Verify(untrusted, nil); err == nil {
+		t.Fatal("expected an unlisted publisher to be declined, not verified")
+	}
+}
+
+func TestSigstoreBundleVerifierDeclinesEveryItem(t *testing.T) {
+	v := &SigstoreBundleVerifier{}
+	if _, err := v.Verify(PostmanMCPCatalogItem{ID: "1"}, nil); err == nil {
+		t.Fatal("expected SigstoreBundleVerifier to decline, since the live catalog carries no bundle today")
+	}
+}
+
+func TestVerifierChainFallsThroughToFirstNonDecliningVerifier(t *testing.T) {
+	declining := &stubVerifier{name: "declines", err: errors.New("no opinion")}
+	accepting := &stubVerifier{name: "accepts", result: Verification{Status: "verified", Attestation: "accepts"}}
+	chain := VerifierChain{declining, accepting}
+
+	result := chain.Verify(PostmanMCPCatalogItem{ID: "1"}, nil)
+	if result.Attestation != "accepts" {
+		t.Fatalf("expected the chain to fall through to the accepting verifier, got %+v", result)
+	}
+	if result.VerifiedAt.IsZero() {
+		t.Fatal("expected VerifiedAt to be stamped by VerifierChain.Verify")
+	}
+}
+
+func TestVerifierChainReturnsUnverifiedWhenEveryVerifierDeclines(t *testing.T) {
+	chain := VerifierChain{&stubVerifier{name: "declines", err: errors.New("no opinion")}}
+
+	result := chain.Verify(PostmanMCPCatalogItem{ID: "1"}, nil)
+	if result != unverifiedResult {
+		t.Fatalf("expected an all-declining chain to return unverifiedResult, got %+v", result)
+	}
+}
+
+type stubVerifier struct {
+	name   string
+	result Verification
+	err    error
+}
+
+func (v *stubVerifier) Name() string { return v.name }
+
+func (v *stubVerifier) Verify(PostmanMCPCatalogItem, json.RawMessage) (Verification, error) {
+	return v.result, v.err
+}
+
+func TestLoadOfflineBundleRejectsTamperedSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey: %v", err)
+	}
+
+	items := []PostmanMCPCatalogItem{{ID: "1", Name: "test-server"}}
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	sig := ed25519.Sign(priv, itemsJSON)
+
+	bundle := offlineBundle{Items: items, Signature: base64.StdEncoding.EncodeToString(sig)}
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	loaded, err := loadOfflineBundle(path, pub)
+	if err != nil {
+		t.Fatalf("expected a correctly signed bundle to load, got: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].ID != "1" {
+		t.Fatalf("unexpected loaded items: %+v", loaded)
+	}
+
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey: %v", err)
+	}
+	if _, err := loadOfflineBundle(path, otherPub); err == nil {
+		t.Fatal("expected a bundle verified against the wrong public key to be rejected")
+	}
+}
+
+func TestLoadOfflineBundleRefusesWhenNoKeyConfigured(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	if err := os.WriteFile(path, []byte(`{"items":[],"signature":""}`), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := loadOfflineBundle(path, nil); err == nil {
+		t.Fatal("expected loadOfflineBundle to refuse an unverifiable bundle when no key is configured")
+	}
+}
@@ -1,9 +1,13 @@
 package enhanced
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/datacline/policy-engine/internal/engine"
+	"github.com/datacline/policy-engine/internal/enhancedhistory"
 	"github.com/datacline/policy-engine/internal/models"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
@@ -20,11 +24,47 @@ func NewHandler(storage *EnhancedStorage) *Handler {
 	// Load all policies into engine
 	policies := storage.ListPolicies(models.PolicyListFilter{})
 	eng := engine.NewEnhancedEngine(policies)
-	
-	return &Handler{
+
+	h := &Handler{
 		storage: storage,
 		engine:  eng,
 	}
+	h.watchForChanges()
+	return h
+}
+
+// SetHistory attaches a versioned revision store to h's storage, enabling
+// the history/revision/rollback routes and ?as_of= reads below. Without it,
+// those routes report "revision history is not configured".
+func (h *Handler) SetHistory(history *enhancedhistory.Store) {
+	h.storage.SetHistory(history)
+}
+
+// actor resolves who is performing a mutation, from the X-Actor header,
+// matching the unified policy handler's convention.
+func actor(c *gin.Context) string {
+	if a := c.GetHeader("X-Actor"); a != "" {
+		return a
+	}
+	return "unknown"
+}
+
+// watchForChanges starts EnhancedStorage.Watch in the background so edits to
+// policy YAML files on disk reach h.engine without a restart. Runs for the
+// process lifetime - the enhanced policy API has no existing shutdown hook
+// to bind a context to - and simply logs if the watcher can't start.
+func (h *Handler) watchForChanges() {
+	changes, err := h.storage.Watch(context.Background())
+	if err != nil {
+		log.WithError(err).Warn("Failed to start enhanced policy hot-reload watcher")
+		return
+	}
+	go func() {
+		for policies := range changes {
+			h.engine.Reload(policies)
+			log.WithField("count", len(policies)).Info("Enhanced engine hot-reloaded from disk change")
+		}
+	}()
 }
 
 // RegisterRoutes registers the enhanced policy routes
@@ -37,10 +77,27 @@ func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 	r.DELETE("/enhanced/policies/:id", h.DeletePolicy)
 	r.POST("/enhanced/policies/:id/enable", h.EnablePolicy)
 	r.POST("/enhanced/policies/:id/disable", h.DisablePolicy)
-	
+	r.GET("/enhanced/policies/status", h.PolicyStatus)
+	r.POST("/admin/policies/reload", h.ReloadPolicies)
+
+	// Revision history, requires SetHistory to have been called
+	r.GET("/enhanced/policies/:id/history", h.PolicyHistory)
+	r.GET("/enhanced/policies/:id/revisions/:rev", h.GetPolicyRevision)
+	r.POST("/enhanced/policies/:id/rollback/:rev", h.RollbackPolicy)
+
+	// Bulk scope assign/unassign - set union/difference, not a full PUT, so
+	// concurrent edits to different values can't clobber one another
+	r.POST("/enhanced/policies/:id/subjects/assign", h.AssignSubjects)
+	r.POST("/enhanced/policies/:id/subjects/unassign", h.UnassignSubjects)
+	r.POST("/enhanced/policies/:id/scope/servers/assign", h.AssignScopeServers)
+	r.POST("/enhanced/policies/:id/scope/servers/unassign", h.UnassignScopeServers)
+	r.POST("/enhanced/policies/:id/scope/tools/assign", h.AssignScopeTools)
+	r.POST("/enhanced/policies/:id/scope/tools/unassign", h.UnassignScopeTools)
+
 	// Policy evaluation
 	r.POST("/enhanced/evaluate", h.EvaluatePolicy)
-	
+	r.POST("/enhanced/evaluate/batch", h.BatchEvaluate)
+
 	log.Info("Enhanced policy routes registered")
 }
 
@@ -73,16 +130,34 @@ func (h *Handler) ListPolicies(c *gin.Context) {
 	})
 }
 
-// GetPolicy gets a policy by ID
+// GetPolicy gets a policy by ID. With ?as_of=<RFC3339 timestamp>, it
+// instead returns the policy as it existed at that instant, reconstructed
+// from recorded revision history (see EnhancedStorage.AsOf) rather than the
+// live in-memory copy.
 func (h *Handler) GetPolicy(c *gin.Context) {
 	id := c.Param("id")
-	
+
+	if asOf := c.Query("as_of"); asOf != "" {
+		at, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "as_of must be an RFC3339 timestamp"})
+			return
+		}
+		policy, err := h.storage.AsOf(id, at)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, policy)
+		return
+	}
+
 	policy, err := h.storage.GetPolicy(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Policy not found"})
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, policy)
 }
 
@@ -95,7 +170,7 @@ func (h *Handler) CreatePolicy(c *gin.Context) {
 		return
 	}
 	
-	created, err := h.storage.CreatePolicy(&policy)
+	created, err := h.storage.CreatePolicy(&policy, actor(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -118,7 +193,7 @@ func (h *Handler) UpdatePolicy(c *gin.Context) {
 	}
 	
 	policy.ID = id
-	updated, err := h.storage.UpdatePolicy(&policy)
+	updated, err := h.storage.UpdatePolicy(&policy, actor(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -134,7 +209,7 @@ func (h *Handler) UpdatePolicy(c *gin.Context) {
 func (h *Handler) DeletePolicy(c *gin.Context) {
 	id := c.Param("id")
 	
-	if err := h.storage.DeletePolicy(id); err != nil {
+	if err := h.storage.DeletePolicy(id, actor(c)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -145,6 +220,173 @@ func (h *Handler) DeletePolicy(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Policy deleted successfully"})
 }
 
+// PolicyHistory returns a policy's recorded revision history, oldest first.
+func (h *Handler) PolicyHistory(c *gin.Context) {
+	id := c.Param("id")
+
+	revisions, err := h.storage.History(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"revisions": revisions,
+		"count":     len(revisions),
+	})
+}
+
+// GetPolicyRevision returns a single recorded revision, including its full
+// policy snapshot and the diff against the revision immediately before it.
+func (h *Handler) GetPolicyRevision(c *gin.Context) {
+	id := c.Param("id")
+	rev := c.Param("rev")
+
+	revision, err := h.storage.GetRevision(id, rev)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// RollbackPolicy restores a policy to a previously recorded revision,
+// recording the restore itself as a new revision so nothing is lost from
+// history.
+func (h *Handler) RollbackPolicy(c *gin.Context) {
+	id := c.Param("id")
+	rev := c.Param("rev")
+
+	policy, err := h.storage.Rollback(id, rev, actor(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Reload engine
+	h.reloadEngine()
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// scopeValues binds the {"values": [...]} body shared by the bulk
+// assign/unassign endpoints below.
+type scopeValues struct {
+	Values []string `json:"values" binding:"required"`
+}
+
+// AssignSubjects adds values to a policy's AppliesTo.Values (set union).
+func (h *Handler) AssignSubjects(c *gin.Context) {
+	var req scopeValues
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.storage.AssignSubjects(c.Param("id"), req.Values, actor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.reloadEngine()
+	c.JSON(http.StatusOK, updated)
+}
+
+// UnassignSubjects removes values from a policy's AppliesTo.Values (set
+// difference).
+func (h *Handler) UnassignSubjects(c *gin.Context) {
+	var req scopeValues
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.storage.UnassignSubjects(c.Param("id"), req.Values, actor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.reloadEngine()
+	c.JSON(http.StatusOK, updated)
+}
+
+// AssignScopeServers adds values to a policy's Scope.ServerIDs (set union).
+func (h *Handler) AssignScopeServers(c *gin.Context) {
+	var req scopeValues
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.storage.AssignScopeServers(c.Param("id"), req.Values, actor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.reloadEngine()
+	c.JSON(http.StatusOK, updated)
+}
+
+// UnassignScopeServers removes values from a policy's Scope.ServerIDs (set
+// difference).
+func (h *Handler) UnassignScopeServers(c *gin.Context) {
+	var req scopeValues
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.storage.UnassignScopeServers(c.Param("id"), req.Values, actor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.reloadEngine()
+	c.JSON(http.StatusOK, updated)
+}
+
+// AssignScopeTools adds values to a policy's Scope.ToolNames (set union).
+func (h *Handler) AssignScopeTools(c *gin.Context) {
+	var req scopeValues
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.storage.AssignScopeTools(c.Param("id"), req.Values, actor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.reloadEngine()
+	c.JSON(http.StatusOK, updated)
+}
+
+// UnassignScopeTools removes values from a policy's Scope.ToolNames (set
+// difference).
+func (h *Handler) UnassignScopeTools(c *gin.Context) {
+	var req scopeValues
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.storage.UnassignScopeTools(c.Param("id"), req.Values, actor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.reloadEngine()
+	c.JSON(http.StatusOK, updated)
+}
+
 // EnablePolicy enables a policy
 func (h *Handler) EnablePolicy(c *gin.Context) {
 	id := c.Param("id")
@@ -175,6 +417,28 @@ func (h *Handler) DisablePolicy(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Policy disabled successfully"})
 }
 
+// PolicyStatus reports the hot-reload watcher's current state - whether
+// it's running, and the last file change it saw, applied, or rejected.
+func (h *Handler) PolicyStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.storage.Status())
+}
+
+// ReloadPolicies re-lists policies from storage and reloads h.engine,
+// regardless of the fsnotify watcher's state. A manual fallback for
+// environments where the watcher hasn't picked up an edit - e.g. the policy
+// directory lives on a filesystem that doesn't deliver fs events reliably.
+func (h *Handler) ReloadPolicies(c *gin.Context) {
+	h.reloadEngine()
+	count := len(h.storage.ListPolicies(models.PolicyListFilter{}))
+
+	log.WithField("policies", count).Info("Enhanced policies reloaded via /admin/policies/reload")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Policies reloaded successfully",
+		"policies": count,
+	})
+}
+
 // EvaluatePolicy evaluates a policy request
 func (h *Handler) EvaluatePolicy(c *gin.Context) {
 	var req models.EnhancedEvaluationRequest
@@ -196,6 +460,91 @@ func (h *Handler) EvaluatePolicy(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// BatchEvaluateRequest is POST /enhanced/evaluate/batch's body: contexts to
+// evaluate, in order, either against the live engine ("enforce", the
+// default) or against DraftPolicies layered over the live policy set for
+// this request only ("dry_run", which never touches EnhancedStorage or the
+// live engine).
+type BatchEvaluateRequest struct {
+	Contexts      []models.EnhancedEvaluationRequest `json:"contexts" binding:"required"`
+	DraftPolicies []*models.EnhancedPolicy           `json:"draft_policies,omitempty"`
+	Mode          string                             `json:"mode"` // "enforce" (default) or "dry_run"
+	Explain       bool                               `json:"explain"`
+}
+
+// BatchEvaluate evaluates every entry in req.Contexts and streams one
+// engine.EnhancedExplainResult per line back as application/x-ndjson, so a
+// caller previewing hundreds of tool invocations sees results as they're
+// computed instead of waiting for the whole batch to finish - the same
+// shape as evaluation.Handler.EvaluateStream. mode="dry_run" builds a
+// one-off engine from DraftPolicies merged over the live policy set
+// (mergeDraftPolicies) and discards it once the request completes; any
+// other mode (including the default, unset mode) evaluates against
+// h.engine itself, with the same rate-limit/match-count effects as a plain
+// POST /enhanced/evaluate.
+func (h *Handler) BatchEvaluate(c *gin.Context) {
+	var req BatchEvaluateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	eng := h.engine
+	if req.Mode == "dry_run" {
+		live := h.storage.ListPolicies(models.PolicyListFilter{})
+		eng = engine.NewEnhancedEngine(mergeDraftPolicies(live, req.DraftPolicies))
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	flusher, canFlush := c.Writer.(http.Flusher)
+	encoder := json.NewEncoder(c.Writer)
+
+	for i := range req.Contexts {
+		result := eng.EvaluateWithTrace(&req.Contexts[i], req.Explain)
+		encoder.Encode(result)
+		if canFlush {
+			flusher.Flush()
+		}
+	}
+}
+
+// mergeDraftPolicies layers draft over live for BatchEvaluate's dry_run
+// mode: a draft policy whose ID matches a live one replaces it in place
+// (previewing an edit), and a draft policy with a new or empty ID is
+// appended (previewing a brand-new policy) - live itself is never mutated.
+func mergeDraftPolicies(live, draft []*models.EnhancedPolicy) []*models.EnhancedPolicy {
+	if len(draft) == 0 {
+		return live
+	}
+
+	byID := make(map[string]*models.EnhancedPolicy, len(draft))
+	for _, p := range draft {
+		if p.ID != "" {
+			byID[p.ID] = p
+		}
+	}
+
+	merged := make([]*models.EnhancedPolicy, 0, len(live)+len(draft))
+	replaced := make(map[string]bool, len(byID))
+	for _, p := range live {
+		if replacement, ok := byID[p.ID]; ok {
+			merged = append(merged, replacement)
+			replaced[p.ID] = true
+			continue
+		}
+		merged = append(merged, p)
+	}
+	for _, p := range draft {
+		if p.ID != "" && replaced[p.ID] {
+			continue
+		}
+		merged = append(merged, p)
+	}
+	return merged
+}
+
 // reloadEngine reloads the evaluation engine with current policies
 func (h *Handler) reloadEngine() {
 	policies := h.storage.ListPolicies(models.PolicyListFilter{})
@@ -0,0 +1,207 @@
+package enhanced
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicySource is a pluggable origin of the full EnhancedPolicy set, so
+// EnhancedStorage's hot-reload (see WatchSource) can pull from somewhere
+// other than policyDir itself - a Git repo or an object store - using the
+// same validate-then-atomic-swap path LocalDir already uses for plain
+// on-disk YAML.
+type PolicySource interface {
+	// Name identifies the source for logging and WatchStatus.
+	Name() string
+
+	// Load returns every currently-known policy. Called on startup and on
+	// every reload tick; the result is treated as the complete desired
+	// set, not a delta.
+	Load(ctx context.Context) ([]*models.EnhancedPolicy, error)
+}
+
+// LocalDir is the PolicySource ReloadFromSource falls back to when
+// EnhancedStorage has no source configured (see SetSource): every *.yaml
+// file directly under Dir, the same files LoadAll and Watch read.
+type LocalDir struct {
+	Dir string
+}
+
+// Name identifies this source by directory path.
+func (l *LocalDir) Name() string {
+	return "local:" + l.Dir
+}
+
+// Load reads and parses every *.yaml file in Dir.
+func (l *LocalDir) Load(ctx context.Context) ([]*models.EnhancedPolicy, error) {
+	files, err := filepath.Glob(filepath.Join(l.Dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]*models.EnhancedPolicy, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var policy models.EnhancedPolicy
+		if err := yaml.Unmarshal(data, &policy); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		policies = append(policies, &policy)
+	}
+	return policies, nil
+}
+
+// CommitVerifier checks a Git commit's signature. It's injected rather
+// than linked directly so GitRepo doesn't force a GPG/Sigstore dependency
+// onto every build that doesn't use it - the same pattern audit.Producer
+// uses to keep KafkaSink's dependency optional.
+type CommitVerifier interface {
+	VerifyCommit(ctx context.Context, repoDir, commitHash string) error
+}
+
+// GitRepo is a PolicySource that keeps CheckoutDir synced to RepoURL and
+// reads its policies the same way LocalDir does. RepoURL is cloned into
+// CheckoutDir on first Load if it isn't already a checkout; every
+// subsequent Load runs a fast-forward-only pull first, so Load never
+// silently rewrites local history. When Verifier is set, Load refuses to
+// return policies from a commit that doesn't verify.
+type GitRepo struct {
+	RepoURL     string
+	Branch      string // empty uses the remote's default branch
+	CheckoutDir string
+	Verifier    CommitVerifier // optional; nil skips signature verification
+
+	// runGit executes a git command in dir (empty for clone, which then
+	// runs in the process's working directory; CheckoutDir itself
+	// otherwise); overridable so tests don't
+	// need a real git binary or network access.
+	runGit func(ctx context.Context, dir string, args ...string) ([]byte, error)
+}
+
+// NewGitRepo creates a GitRepo pulling repoURL into checkoutDir, optionally
+// verifying each pulled commit with verifier.
+func NewGitRepo(repoURL, branch, checkoutDir string, verifier CommitVerifier) *GitRepo {
+	return &GitRepo{RepoURL: repoURL, Branch: branch, CheckoutDir: checkoutDir, Verifier: verifier}
+}
+
+// Name identifies this source by repo URL.
+func (g *GitRepo) Name() string {
+	return "git:" + g.RepoURL
+}
+
+// Load syncs CheckoutDir to RepoURL's latest commit (verifying it, if
+// Verifier is configured) and then reads its policies via LocalDir.
+func (g *GitRepo) Load(ctx context.Context) ([]*models.EnhancedPolicy, error) {
+	if err := g.sync(ctx); err != nil {
+		return nil, err
+	}
+	return (&LocalDir{Dir: g.CheckoutDir}).Load(ctx)
+}
+
+func (g *GitRepo) git() func(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	if g.runGit != nil {
+		return g.runGit
+	}
+	return runGitCommand
+}
+
+func (g *GitRepo) sync(ctx context.Context) error {
+	run := g.git()
+
+	if _, err := os.Stat(filepath.Join(g.CheckoutDir, ".git")); os.IsNotExist(err) {
+		args := []string{"clone"}
+		if g.Branch != "" {
+			args = append(args, "--branch", g.Branch)
+		}
+		args = append(args, g.RepoURL, g.CheckoutDir)
+		if _, err := run(ctx, "", args...); err != nil {
+			return fmt.Errorf("failed to clone %s: %w", g.RepoURL, err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to stat checkout dir %s: %w", g.CheckoutDir, err)
+	} else {
+		if _, err := run(ctx, g.CheckoutDir, "pull", "--ff-only"); err != nil {
+			return fmt.Errorf("failed to pull %s: %w", g.RepoURL, err)
+		}
+	}
+
+	if g.Verifier == nil {
+		return nil
+	}
+
+	out, err := run(ctx, g.CheckoutDir, "rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD of %s: %w", g.CheckoutDir, err)
+	}
+	commit := strings.TrimSpace(string(out))
+	if err := g.Verifier.VerifyCommit(ctx, g.CheckoutDir, commit); err != nil {
+		return fmt.Errorf("commit %s failed signature verification: %w", commit, err)
+	}
+	return nil
+}
+
+func runGitCommand(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	return cmd.CombinedOutput()
+}
+
+// S3Client is the minimal object-store surface S3Bucket needs, injected so
+// this package doesn't pull the AWS SDK into every build that doesn't use
+// S3Bucket - the same pattern GitRepo's CommitVerifier and
+// audit.KafkaSink's Producer use to keep an optional backend's dependency
+// out of the default build.
+type S3Client interface {
+	ListObjectKeys(ctx context.Context, bucket, prefix string) ([]string, error)
+	GetObject(ctx context.Context, bucket, key string) ([]byte, error)
+}
+
+// S3Bucket is a PolicySource that reads every .yaml/.yml object under
+// Prefix in Bucket via Client.
+type S3Bucket struct {
+	Client S3Client
+	Bucket string
+	Prefix string
+}
+
+// Name identifies this source by bucket and prefix.
+func (b *S3Bucket) Name() string {
+	return fmt.Sprintf("s3://%s/%s", b.Bucket, b.Prefix)
+}
+
+// Load lists and parses every .yaml/.yml object under Prefix.
+func (b *S3Bucket) Load(ctx context.Context) ([]*models.EnhancedPolicy, error) {
+	keys, err := b.Client.ListObjectKeys(ctx, b.Bucket, b.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", b.Name(), err)
+	}
+
+	policies := make([]*models.EnhancedPolicy, 0, len(keys))
+	for _, key := range keys {
+		if !strings.HasSuffix(key, ".yaml") && !strings.HasSuffix(key, ".yml") {
+			continue
+		}
+		data, err := b.Client.GetObject(ctx, b.Bucket, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s/%s: %w", b.Bucket, key, err)
+		}
+		var policy models.EnhancedPolicy
+		if err := yaml.Unmarshal(data, &policy); err != nil {
+			return nil, fmt.Errorf("failed to parse %s/%s: %w", b.Bucket, key, err)
+		}
+		policies = append(policies, &policy)
+	}
+	return policies, nil
+}
@@ -1,24 +1,53 @@
 package enhanced
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/datacline/policy-engine/internal/engine"
+	"github.com/datacline/policy-engine/internal/enhancedhistory"
+	"github.com/datacline/policy-engine/internal/metrics"
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/fsnotify/fsnotify"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
 
+// watchDebounce coalesces bursts of fs events from a single edit (e.g. an
+// editor's write-temp-then-rename) into one reload per changed file,
+// matching storage.Storage.Watch's debounce.
+const watchDebounce = 250 * time.Millisecond
+
 // EnhancedStorage handles persistence of enhanced policies
 type EnhancedStorage struct {
 	policyDir string
 	policies  map[string]*models.EnhancedPolicy
 	mu        sync.RWMutex
+
+	watchStatus atomic.Pointer[WatchStatus]
+
+	// history records a versioned, queryable revision for every write when
+	// configured (see SetHistory); nil means only the best-effort
+	// policy_history/<id>.jsonl log from appendHistory is available, and
+	// History/GetRevision/Rollback/AsOf all report "not configured".
+	history *enhancedhistory.Store
+
+	// source is where WatchSource/ReloadFromSource reads the full policy
+	// set from (see SetSource). nil falls back to a LocalDir over
+	// policyDir, the same files LoadAll/Watch already read.
+	source PolicySource
+
+	// parseErrors counts ReloadFromSource failures cumulatively across the
+	// process's lifetime, surfaced via RegisterMetrics.
+	parseErrors atomic.Int64
 }
 
 // NewEnhancedStorage creates a new enhanced storage
@@ -154,98 +183,263 @@ func (s *EnhancedStorage) GetPolicy(id string) (*models.EnhancedPolicy, error) {
 	return policy, nil
 }
 
-// CreatePolicy creates a new policy
-func (s *EnhancedStorage) CreatePolicy(policy *models.EnhancedPolicy) (*models.EnhancedPolicy, error) {
+// SetHistory attaches a versioned revision store, enabling
+// History/GetRevision/Rollback/AsOf and the handler routes backed by them.
+// Without it, EnhancedStorage still writes the best-effort
+// policy_history/<id>.jsonl log via appendHistory, but that log has no
+// revision IDs and nothing can be rolled back to it.
+func (s *EnhancedStorage) SetHistory(history *enhancedhistory.Store) {
+	s.history = history
+}
+
+// SetSource attaches source, the origin WatchSource and ReloadFromSource
+// read the full policy set from. Without it, ReloadFromSource falls back
+// to a LocalDir over policyDir - the same files LoadAll/Watch already
+// read.
+func (s *EnhancedStorage) SetSource(source PolicySource) {
+	s.source = source
+}
+
+// RegisterMetrics registers Prometheus gauges reporting s's hot-reload
+// health: the most recent reload's duration, cumulative parse errors, and
+// seconds since the last successful reload. Call once after construction;
+// wiring it into a given binary is left to the caller, the same as
+// SetSource/SetHistory.
+func (s *EnhancedStorage) RegisterMetrics() {
+	metrics.RegisterGaugeFunc("enhanced_policy_reload_duration_seconds", "Duration of the most recent enhanced policy source reload", func() float64 {
+		return time.Duration(s.Status().LastReloadDurationMS * int64(time.Millisecond)).Seconds()
+	})
+	metrics.RegisterGaugeFunc("enhanced_policy_parse_errors_total", "Cumulative enhanced policy source reload failures", func() float64 {
+		return float64(s.parseErrors.Load())
+	})
+	metrics.RegisterGaugeFunc("enhanced_policy_seconds_since_last_reload", "Seconds since enhanced policies were last successfully reloaded from their source, or -1 if never", func() float64 {
+		last := s.Status().LastSuccessAt
+		if last.IsZero() {
+			return -1
+		}
+		return time.Since(last).Seconds()
+	})
+}
+
+// ReloadFromSource loads the full policy set from s.source (or a LocalDir
+// over policyDir, if none is configured), validates it as a whole in a
+// shadow map - rejecting a duplicate policy ID or anything that fails
+// Validate - and only then atomically replaces s.policies under s.mu. A
+// rejected reload leaves the previously-loaded set live and returns the
+// rejection reason; it never partially applies.
+//
+// EnhancedPolicy has no field that references another policy by ID, so
+// the only "dangling reference" ReloadFromSource can check for is the
+// shadow set being internally consistent (no duplicate IDs); each policy's
+// own schema/CEL/Rego-adjacent expressions are covered by Validate.
+func (s *EnhancedStorage) ReloadFromSource(ctx context.Context) error {
+	source := s.source
+	if source == nil {
+		source = &LocalDir{Dir: s.policyDir}
+	}
+
+	start := time.Now()
+	policies, err := source.Load(ctx)
+	if err != nil {
+		return s.rejectReload(fmt.Errorf("failed to load policies from %s: %w", source.Name(), err))
+	}
+
+	shadow := make(map[string]*models.EnhancedPolicy, len(policies))
+	for _, policy := range policies {
+		if policy.ID == "" {
+			return s.rejectReload(fmt.Errorf("policy from %s has no ID", source.Name()))
+		}
+		if _, dup := shadow[policy.ID]; dup {
+			return s.rejectReload(fmt.Errorf("duplicate policy ID %q loaded from %s", policy.ID, source.Name()))
+		}
+		if err := s.Validate(policy); err != nil {
+			return s.rejectReload(fmt.Errorf("policy %q failed validation: %w", policy.ID, err))
+		}
+		shadow[policy.ID] = policy
+	}
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	
-	// Generate ID if not provided
-	if policy.ID == "" {
-		policy.ID = s.generateID(policy.Name)
+	s.policies = shadow
+	s.mu.Unlock()
+
+	duration := time.Since(start)
+	s.watchStatus.Store(&WatchStatus{
+		Watching:             true,
+		LastSuccessAt:        time.Now(),
+		LastReloadDurationMS: duration.Milliseconds(),
+		ParseErrors:          s.parseErrors.Load(),
+	})
+	log.WithFields(log.Fields{"source": source.Name(), "count": len(shadow), "duration": duration}).Info("Enhanced policies reloaded from source")
+	return nil
+}
+
+// rejectReload records a ReloadFromSource failure (incrementing
+// parseErrors and updating WatchStatus) and returns it unchanged, so every
+// ReloadFromSource error path shares the same bookkeeping.
+func (s *EnhancedStorage) rejectReload(err error) error {
+	s.parseErrors.Add(1)
+	s.watchStatus.Store(&WatchStatus{
+		Watching:    true,
+		LastError:   err.Error(),
+		LastErrorAt: time.Now(),
+		ParseErrors: s.parseErrors.Load(),
+	})
+	return err
+}
+
+// WatchSource periodically reloads the full policy set from s.source (see
+// SetSource) every interval via ReloadFromSource, until ctx is canceled.
+// Unlike Watch (fsnotify-driven, one changed file at a time), WatchSource
+// polls and works for any PolicySource, including GitRepo and S3Bucket,
+// which can't be fsnotify-watched directly. A rejected reload is logged
+// and the previously-loaded policies stay live.
+func (s *EnhancedStorage) WatchSource(ctx context.Context, interval time.Duration) {
+	if err := s.ReloadFromSource(ctx); err != nil {
+		log.WithError(err).Warn("Initial policy source reload failed, keeping previously loaded policies live")
 	}
-	
-	// Check if policy already exists
-	if _, exists := s.policies[policy.ID]; exists {
-		return nil, fmt.Errorf("policy already exists")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.ReloadFromSource(ctx); err != nil {
+				log.WithError(err).Warn("Policy source reload rejected, keeping previous version live")
+			}
+		}
 	}
-	
-	// Set metadata
-	now := time.Now()
-	policy.CreatedAt = &now
-	policy.UpdatedAt = &now
-	policy.Version = 1
-	
-	// Save to disk
-	if err := s.savePolicyFile(policy); err != nil {
-		return nil, err
+}
+
+// Validate validates a policy without saving it. It only checks what
+// LoadAll/CreatePolicy/UpdatePolicy can't recover from on their own: that
+// PayloadSchema (if set) compiles as a JSON Schema, and that every
+// ConditionOpExpression/ConditionOpCEL condition's CEL expression compiles.
+// All three would otherwise fail silently at evaluation time (see
+// compilePayloadSchemas, compileExpressionConditions,
+// engine.evaluateCELCondition) instead of being rejected before deploy.
+func (s *EnhancedStorage) Validate(policy *models.EnhancedPolicy) error {
+	if _, err := engine.PreparePayloadSchema(policy.PayloadSchema); err != nil {
+		return err
 	}
-	
-	// Store in memory
-	s.policies[policy.ID] = policy
-	
-	log.WithFields(log.Fields{
-		"id":   policy.ID,
-		"name": policy.Name,
-	}).Info("Enhanced policy created")
-	
-	return policy, nil
+
+	for i, condition := range policy.Conditions {
+		switch condition.Operator {
+		case models.ConditionOpExpression:
+			expr, ok := condition.Value.(string)
+			if !ok {
+				return fmt.Errorf("condition %d: expression value must be a string", i)
+			}
+			if _, err := engine.PrepareExpression(expr); err != nil {
+				return fmt.Errorf("condition %d: invalid expression: %w", i, err)
+			}
+		case models.ConditionOpCEL:
+			expr, ok := condition.Value.(string)
+			if !ok {
+				return fmt.Errorf("condition %d: cel value must be a string", i)
+			}
+			if _, err := engine.PrepareCELExpression(expr); err != nil {
+				return fmt.Errorf("condition %d: invalid CEL expression: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// CreatePolicy creates a new policy. author is stamped on the recorded
+// revision (see SetHistory) and is independent of policy.CreatedBy, which
+// the caller may set on the payload itself.
+func (s *EnhancedStorage) CreatePolicy(policy *models.EnhancedPolicy, author string) (*models.EnhancedPolicy, error) {
+	return s.writePolicy(policy, "create", author, true)
+}
+
+// UpdatePolicy updates an existing policy. author is stamped on the
+// recorded revision (see SetHistory).
+func (s *EnhancedStorage) UpdatePolicy(policy *models.EnhancedPolicy, author string) (*models.EnhancedPolicy, error) {
+	return s.writePolicy(policy, "update", author, false)
 }
 
-// UpdatePolicy updates an existing policy
-func (s *EnhancedStorage) UpdatePolicy(policy *models.EnhancedPolicy) (*models.EnhancedPolicy, error) {
+// writePolicy is the shared create/update/rollback path: it validates,
+// stamps metadata, persists to disk and memory, and records exactly one
+// revision tagged with op - so Rollback (which reuses this with op
+// "rollback") doesn't also leave behind a spurious "update"/"create"
+// revision the way calling UpdatePolicy/CreatePolicy directly would.
+func (s *EnhancedStorage) writePolicy(policy *models.EnhancedPolicy, op, author string, isNew bool) (*models.EnhancedPolicy, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	
-	// Check if policy exists
-	existing, exists := s.policies[policy.ID]
-	if !exists {
-		return nil, fmt.Errorf("policy not found")
+
+	if err := s.Validate(policy); err != nil {
+		return nil, err
 	}
-	
-	// Update metadata
+
+	var old *models.EnhancedPolicy
 	now := time.Now()
+	if isNew {
+		if policy.ID == "" {
+			policy.ID = s.generateID(policy.Name)
+		}
+		if _, exists := s.policies[policy.ID]; exists {
+			return nil, fmt.Errorf("policy already exists")
+		}
+		policy.CreatedAt = &now
+		policy.Version = 1
+	} else {
+		existing, exists := s.policies[policy.ID]
+		if !exists {
+			return nil, fmt.Errorf("policy not found")
+		}
+		old = existing
+		policy.Version = existing.Version + 1
+		policy.CreatedAt = existing.CreatedAt
+		policy.CreatedBy = existing.CreatedBy
+	}
 	policy.UpdatedAt = &now
-	policy.Version = existing.Version + 1
-	policy.CreatedAt = existing.CreatedAt
-	policy.CreatedBy = existing.CreatedBy
-	
+
 	// Save to disk
 	if err := s.savePolicyFile(policy); err != nil {
 		return nil, err
 	}
-	
-	// Update in memory
+
+	// Store in memory
 	s.policies[policy.ID] = policy
-	
+	s.appendHistory(old, policy)
+	s.recordRevision(policy, op, author)
+
 	log.WithFields(log.Fields{
 		"id":      policy.ID,
 		"name":    policy.Name,
 		"version": policy.Version,
-	}).Info("Enhanced policy updated")
-	
+		"op":      op,
+	}).Info("Enhanced policy written")
+
 	return policy, nil
 }
 
-// DeletePolicy deletes a policy
-func (s *EnhancedStorage) DeletePolicy(id string) error {
+// DeletePolicy deletes a policy. author is stamped on the recorded
+// "delete" revision (see SetHistory), which is what makes the deletion
+// recoverable via Rollback despite the policy no longer being in s.policies.
+func (s *EnhancedStorage) DeletePolicy(id string, author string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	
+
 	// Check if policy exists
-	_, exists := s.policies[id]
+	existing, exists := s.policies[id]
 	if !exists {
 		return fmt.Errorf("policy not found")
 	}
-	
+
 	// Delete file
 	filename := filepath.Join(s.policyDir, id+".yaml")
 	if err := os.Remove(filename); err != nil {
 		return err
 	}
-	
+
 	// Remove from memory
 	delete(s.policies, id)
-	
+	s.recordRevision(existing, "delete", author)
+
 	log.WithField("id", id).Info("Enhanced policy deleted")
 	return nil
 }
@@ -296,6 +490,116 @@ func (s *EnhancedStorage) DisablePolicy(id string) error {
 	return nil
 }
 
+// AssignSubjects adds values to AppliesTo.Values (set union).
+func (s *EnhancedStorage) AssignSubjects(id string, values []string, author string) (*models.EnhancedPolicy, error) {
+	return s.mutateSet(id, values, author, "assign_subjects", true, func(p *models.EnhancedPolicy) *[]string { return &p.AppliesTo.Values })
+}
+
+// UnassignSubjects removes values from AppliesTo.Values (set difference).
+func (s *EnhancedStorage) UnassignSubjects(id string, values []string, author string) (*models.EnhancedPolicy, error) {
+	return s.mutateSet(id, values, author, "unassign_subjects", false, func(p *models.EnhancedPolicy) *[]string { return &p.AppliesTo.Values })
+}
+
+// AssignScopeServers adds values to Scope.ServerIDs (set union).
+func (s *EnhancedStorage) AssignScopeServers(id string, values []string, author string) (*models.EnhancedPolicy, error) {
+	return s.mutateSet(id, values, author, "assign_scope_servers", true, func(p *models.EnhancedPolicy) *[]string { return &p.Scope.ServerIDs })
+}
+
+// UnassignScopeServers removes values from Scope.ServerIDs (set difference).
+func (s *EnhancedStorage) UnassignScopeServers(id string, values []string, author string) (*models.EnhancedPolicy, error) {
+	return s.mutateSet(id, values, author, "unassign_scope_servers", false, func(p *models.EnhancedPolicy) *[]string { return &p.Scope.ServerIDs })
+}
+
+// AssignScopeTools adds values to Scope.ToolNames (set union).
+func (s *EnhancedStorage) AssignScopeTools(id string, values []string, author string) (*models.EnhancedPolicy, error) {
+	return s.mutateSet(id, values, author, "assign_scope_tools", true, func(p *models.EnhancedPolicy) *[]string { return &p.Scope.ToolNames })
+}
+
+// UnassignScopeTools removes values from Scope.ToolNames (set difference).
+func (s *EnhancedStorage) UnassignScopeTools(id string, values []string, author string) (*models.EnhancedPolicy, error) {
+	return s.mutateSet(id, values, author, "unassign_scope_tools", false, func(p *models.EnhancedPolicy) *[]string { return &p.Scope.ToolNames })
+}
+
+// mutateSet is the shared implementation behind Assign/Unassign{Subjects,
+// ScopeServers,ScopeTools}: it takes field's string slice under s.mu (the
+// same lock CreatePolicy/UpdatePolicy/DeletePolicy already serialize on, so
+// two admins editing a policy's scope concurrently can't silently lose one
+// another's change the way a full PUT of the document can), applies a set
+// union (assign=true) or set difference (assign=false) against values,
+// bumps Version, and records the change as a new revision tagged op.
+func (s *EnhancedStorage) mutateSet(id string, values []string, author, op string, assign bool, field func(*models.EnhancedPolicy) *[]string) (*models.EnhancedPolicy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, exists := s.policies[id]
+	if !exists {
+		return nil, fmt.Errorf("policy not found")
+	}
+
+	before := *policy
+	target := field(policy)
+	if assign {
+		*target = unionStrings(*target, values)
+	} else {
+		*target = differenceStrings(*target, values)
+	}
+
+	now := time.Now()
+	policy.UpdatedAt = &now
+	policy.Version++
+
+	if err := s.savePolicyFile(policy); err != nil {
+		return nil, err
+	}
+
+	s.appendHistory(&before, policy)
+	s.recordRevision(policy, op, author)
+
+	log.WithFields(log.Fields{
+		"id":      id,
+		"op":      op,
+		"values":  values,
+		"version": policy.Version,
+	}).Info("Enhanced policy scope updated")
+
+	return policy, nil
+}
+
+// unionStrings returns a, deduplicated, with every value in b appended that
+// a didn't already contain.
+func unionStrings(a, b []string) []string {
+	seen := make(map[string]bool, len(a))
+	result := make([]string, 0, len(a)+len(b))
+	for _, v := range a {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	for _, v := range b {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// differenceStrings returns a with every value in b removed.
+func differenceStrings(a, b []string) []string {
+	remove := make(map[string]bool, len(b))
+	for _, v := range b {
+		remove[v] = true
+	}
+	result := make([]string, 0, len(a))
+	for _, v := range a {
+		if !remove[v] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 // savePolicyFile saves a policy to disk
 func (s *EnhancedStorage) savePolicyFile(policy *models.EnhancedPolicy) error {
 	filename := filepath.Join(s.policyDir, policy.ID+".yaml")
@@ -308,6 +612,291 @@ func (s *EnhancedStorage) savePolicyFile(policy *models.EnhancedPolicy) error {
 	return os.WriteFile(filename, data, 0644)
 }
 
+// WatchStatus reports EnhancedStorage's current hot-reload watcher state,
+// surfaced by Handler's GET /enhanced/policies/status so an operator can
+// tell a silently-stalled watcher apart from a policy file that's failing
+// validation on every edit.
+type WatchStatus struct {
+	Watching      bool      `json:"watching"`
+	LastFile      string    `json:"last_file,omitempty"`
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorAt   time.Time `json:"last_error_at,omitempty"`
+	LastSuccessAt time.Time `json:"last_success_at,omitempty"`
+
+	// LastReloadDurationMS and ParseErrors are only set by
+	// ReloadFromSource (WatchSource's full-set atomic reload path); Watch's
+	// per-file fsnotify reload doesn't populate them.
+	LastReloadDurationMS int64 `json:"last_reload_duration_ms,omitempty"`
+	ParseErrors          int64 `json:"parse_errors,omitempty"`
+}
+
+// Status returns a snapshot of s's hot-reload watcher state. The zero value
+// (Watching: false) means Watch has never been called.
+func (s *EnhancedStorage) Status() WatchStatus {
+	if status := s.watchStatus.Load(); status != nil {
+		return *status
+	}
+	return WatchStatus{}
+}
+
+// Watch watches s.policyDir for .yaml changes with fsnotify, debouncing
+// bursts into one reload per changed file (watchDebounce) instead of
+// re-parsing every file on every event. A changed file that fails
+// Validate is logged and skipped - the policy already in memory stays
+// live - rather than tearing down the whole policy set over one bad edit.
+// Every successfully-applied change also appends an entry to
+// policyDir/policy_history/<id>.jsonl - see appendHistory.
+func (s *EnhancedStorage) Watch(ctx context.Context) (<-chan []*models.EnhancedPolicy, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy file watcher: %w", err)
+	}
+	if err := fsWatcher.Add(s.policyDir); err != nil {
+		fsWatcher.Close()
+		return nil, fmt.Errorf("failed to watch policy directory %s: %w", s.policyDir, err)
+	}
+
+	s.watchStatus.Store(&WatchStatus{Watching: true})
+
+	out := make(chan []*models.EnhancedPolicy)
+	go func() {
+		defer fsWatcher.Close()
+		defer close(out)
+		defer func() {
+			status := s.Status()
+			status.Watching = false
+			s.watchStatus.Store(&status)
+		}()
+
+		var (
+			debounceTimer *time.Timer
+			pendingMu     sync.Mutex
+			pendingFile   string
+		)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-fsWatcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Ext(event.Name) != ".yaml" {
+					continue
+				}
+
+				pendingMu.Lock()
+				pendingFile = event.Name
+				pendingMu.Unlock()
+
+				if debounceTimer != nil {
+					debounceTimer.Stop()
+				}
+				debounceTimer = time.AfterFunc(watchDebounce, func() {
+					pendingMu.Lock()
+					file := pendingFile
+					pendingMu.Unlock()
+
+					policies, err := s.reloadChangedFile(file)
+					if err != nil {
+						log.WithError(err).WithField("file", file).Warn("Hot-reload: policy file change rejected, keeping previous version live")
+						s.watchStatus.Store(&WatchStatus{Watching: true, LastFile: file, LastError: err.Error(), LastErrorAt: time.Now()})
+						return
+					}
+					s.watchStatus.Store(&WatchStatus{Watching: true, LastFile: file, LastSuccessAt: time.Now()})
+
+					select {
+					case out <- policies:
+					case <-ctx.Done():
+					}
+				})
+			case err, ok := <-fsWatcher.Errors:
+				if !ok {
+					return
+				}
+				log.WithError(err).Warn("Enhanced policy directory watch error")
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// reloadChangedFile re-parses and validates a single policy file after a
+// fsnotify event, applies it to s.policies if it passes, and returns the
+// resulting full policy list. A missing file (deleted or renamed away) just
+// removes that entry. A parse or Validate failure is returned to the caller
+// without mutating s.policies, so the previously-loaded version stays live.
+func (s *EnhancedStorage) reloadChangedFile(path string) ([]*models.EnhancedPolicy, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		s.mu.Lock()
+		for id := range s.policies {
+			if filepath.Join(s.policyDir, id+".yaml") == path {
+				delete(s.policies, id)
+				break
+			}
+		}
+		result := make([]*models.EnhancedPolicy, 0, len(s.policies))
+		for _, p := range s.policies {
+			result = append(result, p)
+		}
+		s.mu.Unlock()
+		return result, nil
+	}
+
+	policy, err := s.loadPolicyFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := s.Validate(policy); err != nil {
+		return nil, fmt.Errorf("%s failed validation: %w", path, err)
+	}
+
+	s.mu.Lock()
+	old := s.policies[policy.ID]
+	if old != nil {
+		policy.Version = old.Version + 1
+	} else if policy.Version == 0 {
+		policy.Version = 1
+	}
+	now := time.Now()
+	policy.UpdatedAt = &now
+	if old != nil {
+		policy.CreatedAt = old.CreatedAt
+	} else if policy.CreatedAt == nil {
+		policy.CreatedAt = &now
+	}
+	s.policies[policy.ID] = policy
+	result := make([]*models.EnhancedPolicy, 0, len(s.policies))
+	for _, p := range s.policies {
+		result = append(result, p)
+	}
+	s.mu.Unlock()
+
+	s.appendHistory(old, policy)
+
+	return result, nil
+}
+
+// PolicyChangeRecord is one line of a policy's append-only
+// policy_history/<id>.jsonl audit log: the policy's state before (nil for a
+// create) and after a change, similar in spirit to Ladon's manager tracking
+// policy revisions.
+type PolicyChangeRecord struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Version   int                    `json:"version"`
+	Old       *models.EnhancedPolicy `json:"old,omitempty"`
+	New       *models.EnhancedPolicy `json:"new"`
+}
+
+// appendHistory records one PolicyChangeRecord line to
+// policyDir/policy_history/<id>.jsonl for every policy write, so operators
+// can audit or manually reconstruct a prior version. Failures are logged
+// rather than returned - a history write failure shouldn't roll back an
+// otherwise successful save, matching storage.Storage's recordHistory.
+func (s *EnhancedStorage) appendHistory(old, updated *models.EnhancedPolicy) {
+	dir := filepath.Join(s.policyDir, "policy_history")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.WithError(err).WithField("id", updated.ID).Warn("Failed to create policy history directory")
+		return
+	}
+
+	line, err := json.Marshal(PolicyChangeRecord{Timestamp: time.Now(), Version: updated.Version, Old: old, New: updated})
+	if err != nil {
+		log.WithError(err).WithField("id", updated.ID).Warn("Failed to marshal policy history record")
+		return
+	}
+
+	path := filepath.Join(dir, updated.ID+".jsonl")
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.WithError(err).WithField("id", updated.ID).Warn("Failed to open policy history file")
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		log.WithError(err).WithField("id", updated.ID).Warn("Failed to append policy history record")
+	}
+}
+
+// recordRevision appends a versioned revision to s.history for op on
+// policy, when a history store is configured (see SetHistory). Failures are
+// logged rather than returned, matching appendHistory - a history-write
+// failure shouldn't roll back an otherwise successful save.
+func (s *EnhancedStorage) recordRevision(policy *models.EnhancedPolicy, op, author string) {
+	if s.history == nil {
+		return
+	}
+	if _, err := s.history.Record(policy, op, author); err != nil {
+		log.WithError(err).WithField("id", policy.ID).Warn("Failed to record enhanced policy revision history")
+	}
+}
+
+// History returns the recorded revision history for a policy, oldest first.
+// It errors if no history store is configured (see SetHistory).
+func (s *EnhancedStorage) History(id string) ([]*models.EnhancedPolicyRevision, error) {
+	if s.history == nil {
+		return nil, fmt.Errorf("revision history is not configured")
+	}
+	return s.history.History(id)
+}
+
+// GetRevision returns a single recorded revision for a policy. It errors if
+// no history store is configured (see SetHistory).
+func (s *EnhancedStorage) GetRevision(id, revisionID string) (*models.EnhancedPolicyRevision, error) {
+	if s.history == nil {
+		return nil, fmt.Errorf("revision history is not configured")
+	}
+	return s.history.GetRevision(id, revisionID)
+}
+
+// AsOf returns the policy snapshot in effect for id at instant at, read
+// from recorded revision history rather than the live in-memory copy. It
+// errors if no history store is configured (see SetHistory) or the policy
+// had no recorded revision yet at that instant.
+func (s *EnhancedStorage) AsOf(id string, at time.Time) (*models.EnhancedPolicy, error) {
+	if s.history == nil {
+		return nil, fmt.Errorf("revision history is not configured")
+	}
+	rev, err := s.history.AsOf(id, at)
+	if err != nil {
+		return nil, err
+	}
+	if rev.Snapshot == nil {
+		return nil, fmt.Errorf("policy %s was deleted as of %s", id, at.Format(time.RFC3339))
+	}
+	return rev.Snapshot, nil
+}
+
+// Rollback restores policy id to the snapshot captured in revisionID,
+// recording the restore itself as a new "rollback" revision so nothing is
+// lost from history. It errors if no history store is configured (see
+// SetHistory) or the revision has no stored snapshot (a "delete" revision
+// can still be rolled back from, since DeletePolicy's revision keeps the
+// deleted snapshot - see recordRevision's caller in DeletePolicy).
+func (s *EnhancedStorage) Rollback(id, revisionID, author string) (*models.EnhancedPolicy, error) {
+	if s.history == nil {
+		return nil, fmt.Errorf("revision history is not configured")
+	}
+	rev, err := s.history.GetRevision(id, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if rev.Snapshot == nil {
+		return nil, fmt.Errorf("revision %s has no stored snapshot to roll back to", revisionID)
+	}
+
+	restored := *rev.Snapshot
+	restored.ID = id
+
+	s.mu.RLock()
+	_, exists := s.policies[id]
+	s.mu.RUnlock()
+
+	return s.writePolicy(&restored, "rollback", author, !exists)
+}
+
 // generateID generates a unique ID for a policy
 func (s *EnhancedStorage) generateID(name string) string {
 	// Create slug from name
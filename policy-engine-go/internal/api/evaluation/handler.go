@@ -1,17 +1,32 @@
 package evaluation
 
 import (
+	"bufio"
+	"encoding/json"
 	"net/http"
+	"runtime"
+	"sync"
+	"time"
 
+	"github.com/datacline/policy-engine/internal/augment"
+	"github.com/datacline/policy-engine/internal/config"
 	"github.com/datacline/policy-engine/internal/models"
 	"github.com/datacline/policy-engine/internal/services/evaluation"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
 
+// streamIdleFlushInterval bounds how long a slow producer's already-computed
+// results can sit unflushed in the response buffer: the writer goroutine
+// flushes on this tick even with no new result to encode.
+const streamIdleFlushInterval = 50 * time.Millisecond
+
 // Handler handles HTTP requests for policy evaluation
 type Handler struct {
-	service *evaluation.Service
+	service       *evaluation.Service
+	watcher       *config.Watcher   // nil when hot-reload watching isn't enabled
+	augmenter     *augment.Pipeline // nil when no augmenters are configured
+	streamWorkers int               // <= 0 means runtime.GOMAXPROCS(0); see SetStreamWorkers
 }
 
 // NewHandler creates a new evaluation handler
@@ -21,10 +36,32 @@ func NewHandler(service *evaluation.Service) *Handler {
 	}
 }
 
+// SetWatcher attaches the hot-reload file watcher, enabling the
+// GET /reload/status endpoint.
+func (h *Handler) SetWatcher(watcher *config.Watcher) {
+	h.watcher = watcher
+}
+
+// SetAugmenters configures the pipeline Evaluate/BatchEvaluate/EvaluateStream
+// run against incoming requests before handing them to the engine. Passing no
+// augmenters leaves augmentation disabled.
+func (h *Handler) SetAugmenters(augmenters ...augment.Augmenter) {
+	h.augmenter = augment.NewPipeline(augmenters...)
+}
+
+// SetStreamWorkers bounds EvaluateStream's concurrent worker pool. n <= 0
+// (the default) falls back to runtime.GOMAXPROCS(0) at request time.
+func (h *Handler) SetStreamWorkers(n int) {
+	h.streamWorkers = n
+}
+
 // RegisterRoutes registers evaluation routes
 func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/evaluate", h.Evaluate)
 	router.POST("/evaluate/batch", h.BatchEvaluate)
+	router.POST("/evaluate/stream", h.EvaluateStream)
+	router.POST("/evaluate/simulate", h.SimulateDraft)
+	router.GET("/reload/status", h.ReloadStatus)
 }
 
 // Evaluate handles single policy evaluation
@@ -35,11 +72,17 @@ func (h *Handler) Evaluate(c *gin.Context) {
 		return
 	}
 
+	var augErrs []string
+	if h.augmenter != nil {
+		augErrs = h.augmenter.Run(&req)
+	}
+
 	result, err := h.service.Evaluate(&req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	result.AugmentationErrors = augErrs
 
 	c.JSON(http.StatusOK, result)
 }
@@ -52,15 +95,197 @@ func (h *Handler) BatchEvaluate(c *gin.Context) {
 		return
 	}
 
+	var augErrs [][]string
+	if h.augmenter != nil {
+		augErrs = make([][]string, len(req.Requests))
+		for i := range req.Requests {
+			augErrs[i] = h.augmenter.Run(&req.Requests[i])
+		}
+	}
+
 	response, err := h.service.BatchEvaluate(&req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	for i := range augErrs {
+		response.Results[i].AugmentationErrors = augErrs[i]
+	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// StreamResult is one line of EvaluateStream's NDJSON output, always keyed
+// by its request's client-supplied CorrelationID so a caller can match
+// responses back to requests despite the worker pool completing them out of
+// input order. Exactly one of Result and Error is set.
+type StreamResult struct {
+	CorrelationID string                         `json:"correlation_id,omitempty"`
+	Result        *models.PolicyEvaluationResult `json:"result,omitempty"`
+	Error         string                         `json:"error,omitempty"`
+}
+
+// EvaluateStream handles application/x-ndjson batch evaluation: one
+// PolicyEvaluationRequest per line in, one StreamResult per line out,
+// written as each evaluation completes rather than buffered into a single
+// JSON array. This keeps memory flat and lets the caller start consuming
+// results before the request body has even finished arriving, unlike
+// BatchEvaluate which allocates one slice for the whole batch.
+//
+// Lines are evaluated concurrently across a bounded worker pool (see
+// SetStreamWorkers), with the jobs channel sized to the pool to bound how
+// far the reader can run ahead of evaluation - the same back-pressure a
+// channel capacity of 0 would give a fully synchronous stream, just wide
+// enough to keep every worker fed. A malformed input line or evaluation
+// error is written inline as that line's StreamResult.Error rather than
+// aborting the stream. A single writer goroutine owns c.Writer (gin's
+// ResponseWriter isn't safe for concurrent writes) and flushes after every
+// result plus on streamIdleFlushInterval, so a producer trickling requests
+// in slowly still gets each response promptly instead of waiting for the
+// writer's buffer to fill.
+func (h *Handler) EvaluateStream(c *gin.Context) {
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	flusher, canFlush := c.Writer.(http.Flusher)
+
+	workers := h.streamWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan models.PolicyEvaluationRequest, workers)
+	results := make(chan StreamResult, workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for req := range jobs {
+				results <- h.evaluateStreamLine(req)
+			}
+		}()
+	}
+
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		encoder := json.NewEncoder(c.Writer)
+		ticker := time.NewTicker(streamIdleFlushInterval)
+		defer ticker.Stop()
+
+		pending := false
+		for {
+			select {
+			case result, ok := <-results:
+				if !ok {
+					if pending && canFlush {
+						flusher.Flush()
+					}
+					return
+				}
+				if err := encoder.Encode(result); err != nil {
+					log.WithError(err).Warn("Failed to write evaluation stream result")
+				}
+				pending = true
+			case <-ticker.C:
+				if pending && canFlush {
+					flusher.Flush()
+					pending = false
+				}
+			}
+		}
+	}()
+
+	scanner := bufio.NewScanner(c.Request.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var req models.PolicyEvaluationRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			results <- StreamResult{CorrelationID: req.CorrelationID, Error: err.Error()}
+			continue
+		}
+		jobs <- req
+	}
+	if err := scanner.Err(); err != nil {
+		log.WithError(err).Warn("Error reading NDJSON evaluation stream")
+	}
+
+	close(jobs)
+	wg.Wait()
+	close(results)
+	<-writerDone
+}
+
+// evaluateStreamLine runs one EvaluateStream request through the
+// augmenter pipeline (if any) and the engine, returning its StreamResult.
+func (h *Handler) evaluateStreamLine(req models.PolicyEvaluationRequest) StreamResult {
+	var augErrs []string
+	if h.augmenter != nil {
+		augErrs = h.augmenter.Run(&req)
+	}
+
+	result, err := h.service.Evaluate(&req)
+	if err != nil {
+		return StreamResult{CorrelationID: req.CorrelationID, Error: err.Error()}
+	}
+	result.AugmentationErrors = augErrs
+	return StreamResult{CorrelationID: req.CorrelationID, Result: result}
+}
+
+// SimulateDraftRequest is SimulateDraft's request body. Either Inputs is
+// non-empty, or the service has replay sampling enabled (see
+// ServiceOptions.ReplayCapacity) and ReplaySampleSize real, recent,
+// PII-scrubbed inputs are drawn from it instead.
+type SimulateDraftRequest struct {
+	Proposed         []*models.UnifiedPolicy        `json:"proposed" binding:"required"`
+	Inputs           []models.PolicyEvaluationInput `json:"inputs,omitempty"`
+	ReplaySampleSize int                            `json:"replay_sample_size,omitempty"`
+}
+
+// SimulateDraft dry-runs a proposed (not-yet-promoted) set of UnifiedPolicy
+// objects - typically one or more PolicyStatusDraft policies under review -
+// against Inputs, or against a sample drawn from the replay store when
+// Inputs is omitted, and reports how each decision compares to what the
+// currently active policy set would decide. No side effects: proposed is
+// never written to storage and no notification/history entries are
+// recorded.
+func (h *Handler) SimulateDraft(c *gin.Context) {
+	var req SimulateDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	inputs := req.Inputs
+	if len(inputs) == 0 {
+		inputs = h.service.ReplaySample(req.ReplaySampleSize)
+	}
+	if len(inputs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no inputs supplied and no replay samples available"})
+		return
+	}
+
+	result := h.service.SimulateDraft(req.Proposed, inputs)
+	c.JSON(http.StatusOK, result)
+}
+
+// ReloadStatus reports the outcome of the most recent hot-reload attempt, or
+// an empty status if no file watcher is configured for this deployment.
+func (h *Handler) ReloadStatus(c *gin.Context) {
+	if h.watcher == nil {
+		c.JSON(http.StatusOK, config.ReloadStatus{})
+		return
+	}
+	c.JSON(http.StatusOK, h.watcher.Status())
+}
+
 // HealthCheck handles health check requests
 func (h *Handler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
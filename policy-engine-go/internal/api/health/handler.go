@@ -2,22 +2,60 @@ package health
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ReadinessChecker is a named dependency probe ReadinessCheck reports on.
+// Implementing it directly - rather than wrapping a func() error via
+// AddNamedCheck - lets a dependency that already knows its own identity
+// (storage.UnifiedStorage, the rule compiler, a notify.Dispatcher) be wired
+// in at NewHandler construction time without an extra closure.
+type ReadinessChecker interface {
+	Name() string
+	CheckReady() error
+}
+
+// funcChecker adapts AddNamedCheck's (name, func() error) shape into a
+// ReadinessChecker so ReadinessCheck only has one kind of check to run.
+type funcChecker struct {
+	name string
+	fn   func() error
+}
+
+func (f funcChecker) Name() string      { return f.name }
+func (f funcChecker) CheckReady() error { return f.fn() }
+
 // Handler handles health check requests
 type Handler struct {
 	serviceName string
+	checkers    []ReadinessChecker
 }
 
-// NewHandler creates a new health handler
-func NewHandler(serviceName string) *Handler {
+// NewHandler creates a new health handler. checkers are probed by
+// ReadinessCheck in addition to anything registered later via AddNamedCheck
+// or AddChecker.
+func NewHandler(serviceName string, checkers ...ReadinessChecker) *Handler {
 	return &Handler{
 		serviceName: serviceName,
+		checkers:    checkers,
 	}
 }
 
+// AddNamedCheck registers a dependency probe that ReadinessCheck reports on,
+// e.g. ("java_gateway", gatewayClient.HealthCheck).
+func (h *Handler) AddNamedCheck(name string, fn func() error) {
+	h.checkers = append(h.checkers, funcChecker{name: name, fn: fn})
+}
+
+// AddChecker registers a ReadinessChecker that ReadinessCheck reports on,
+// the interface-based counterpart to AddNamedCheck for a dependency that
+// already knows its own name.
+func (h *Handler) AddChecker(rc ReadinessChecker) {
+	h.checkers = append(h.checkers, rc)
+}
+
 // RegisterRoutes registers health routes
 func (h *Handler) RegisterRoutes(router *gin.Engine) {
 	router.GET("/health", h.HealthCheck)
@@ -33,12 +71,49 @@ func (h *Handler) HealthCheck(c *gin.Context) {
 	})
 }
 
-// ReadinessCheck handles readiness check requests
+// ReadinessCheck runs every registered checker and reports per-check
+// status, returning 503 if any check fails. With ?verbose=true, each check's
+// entry becomes an object with its error (if any) and how long CheckReady
+// took, instead of a bare status string.
 func (h *Handler) ReadinessCheck(c *gin.Context) {
-	// Add checks for dependencies (storage, etc.)
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "ready",
+	verbose := c.Query("verbose") == "true"
+
+	checks := make(gin.H, len(h.checkers))
+	allOK := true
+
+	for _, rc := range h.checkers {
+		start := time.Now()
+		err := rc.CheckReady()
+		elapsed := time.Since(start)
+
+		if err != nil {
+			allOK = false
+			if verbose {
+				checks[rc.Name()] = gin.H{"status": "degraded", "error": err.Error(), "duration_ms": elapsed.Milliseconds()}
+			} else {
+				checks[rc.Name()] = "degraded: " + err.Error()
+			}
+			continue
+		}
+
+		if verbose {
+			checks[rc.Name()] = gin.H{"status": "ok", "duration_ms": elapsed.Milliseconds()}
+		} else {
+			checks[rc.Name()] = "ok"
+		}
+	}
+
+	status := "ready"
+	httpStatus := http.StatusOK
+	if !allOK {
+		status = "not_ready"
+		httpStatus = http.StatusServiceUnavailable
+	}
+
+	c.JSON(httpStatus, gin.H{
+		"status":  status,
 		"service": h.serviceName,
+		"checks":  checks,
 	})
 }
 
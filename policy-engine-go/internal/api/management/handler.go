@@ -1,9 +1,16 @@
 package management
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/policyhcl"
+	"github.com/datacline/policy-engine/internal/policyschema"
+	"github.com/datacline/policy-engine/internal/policytype"
+	"github.com/datacline/policy-engine/internal/replication"
 	"github.com/datacline/policy-engine/internal/services/evaluation"
 	"github.com/datacline/policy-engine/internal/services/management"
 	"github.com/gin-gonic/gin"
@@ -12,8 +19,9 @@ import (
 
 // Handler handles HTTP requests for policy management (CRUD)
 type Handler struct {
-	managementService *management.Service
-	evaluationService *evaluation.Service
+	managementService  *management.Service
+	evaluationService  *evaluation.Service
+	replicationManager *replication.Manager
 }
 
 // NewHandler creates a new management handler
@@ -24,6 +32,13 @@ func NewHandler(managementService *management.Service, evaluationService *evalua
 	}
 }
 
+// SetReplicationManager attaches a replication.Manager, enabling the
+// /replication/* endpoints and pushing CRUD events to it so immediate and
+// cron replication targets stay in sync.
+func (h *Handler) SetReplicationManager(manager *replication.Manager) {
+	h.replicationManager = manager
+}
+
 // RegisterRoutes registers management routes
 func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	// CRUD operations
@@ -32,75 +47,230 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/policies", h.CreatePolicy)
 	router.PUT("/policies/:id", h.UpdatePolicy)
 	router.DELETE("/policies/:id", h.DeletePolicy)
-	
+
 	// Policy operations
 	router.POST("/policies/:id/enable", h.EnablePolicy)
 	router.POST("/policies/:id/disable", h.DisablePolicy)
 	router.POST("/policies/validate", h.ValidatePolicy)
-	
+	router.POST("/policies/test", h.TestPolicy)
+	router.POST("/policies/simulate", h.SimulatePolicy)
+
 	// Reload
 	router.POST("/reload", h.Reload)
+
+	// Signed revision history and rollback
+	router.GET("/policies/:id/history", h.PolicyHistory)
+	router.POST("/policies/:id/rollback/:revision", h.RollbackPolicy)
+
+	// Webhook notification subscriptions
+	router.POST("/policies/:id/subscriptions", h.AddSubscription)
+	router.DELETE("/policies/:id/subscriptions/:sub_id", h.RemoveSubscription)
+
+	// Rate-limit bucket inspection/reset for ConditionTypeRate conditions
+	router.GET("/policies/rate/:key", h.GetRateLimit)
+	router.DELETE("/policies/rate/:key", h.ResetRateLimit)
+
+	// Policy replication to peer gateways
+	router.POST("/replication/targets", h.CreateReplicationTarget)
+	router.GET("/replication/targets", h.ListReplicationTargets)
+	router.PUT("/replication/targets/:id", h.UpdateReplicationTarget)
+	router.DELETE("/replication/targets/:id", h.DeleteReplicationTarget)
+	router.POST("/replication/targets/:id/trigger", h.TriggerReplicationTarget)
+	router.GET("/replication/executions/:id", h.GetReplicationExecution)
+
+	// Entity attribute schema (user/tool/resource/context), consulted by
+	// ValidatePolicy and the evaluation engine
+	router.GET("/schema/:entity", h.GetSchema)
+	router.PUT("/schema/:entity", h.PutSchema)
+
+	// PolicyType JSON Schema registry, consulted by CreatePolicy/UpdatePolicy
+	// for any policy referencing a TypeID
+	router.GET("/policy-types", h.ListPolicyTypes)
+	router.PUT("/policy-types/:id", h.PutPolicyType)
+	router.DELETE("/policy-types/:id", h.DeletePolicyType)
+
+	// Gatekeeper-style ConstraintTemplate/Constraint CRUD for the admission
+	// webhook (see internal/api/admission)
+	router.GET("/admission/templates", h.ListConstraintTemplates)
+	router.POST("/admission/templates", h.CreateConstraintTemplate)
+	router.GET("/admission/constraints", h.ListConstraints)
+	router.POST("/admission/constraints", h.CreateConstraint)
+	router.DELETE("/admission/constraints/:id", h.DeleteConstraint)
+
+	// Scoped policy CRUD (authority → provisioner → account hierarchy)
+	router.GET("/scopes/global/policies", h.scopedList(models.ScopeGlobal))
+	router.POST("/scopes/global/policies", h.scopedCreate(models.ScopeGlobal))
+	router.GET("/scopes/tenant/:scopeID/policies", h.scopedList(models.ScopeTenant))
+	router.POST("/scopes/tenant/:scopeID/policies", h.scopedCreate(models.ScopeTenant))
+	router.GET("/scopes/principal/:scopeID/policies", h.scopedList(models.ScopePrincipal))
+	router.POST("/scopes/principal/:scopeID/policies", h.scopedCreate(models.ScopePrincipal))
 }
 
 // ListPolicies returns all policies
 func (h *Handler) ListPolicies(c *gin.Context) {
 	policies := h.managementService.ListPolicies()
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"policies": policies,
 		"count":    len(policies),
 	})
 }
 
-// GetPolicy returns a specific policy by ID
+// GetPolicy returns a specific policy by ID. With ?format=hcl it renders the
+// policy as HCL (see internal/policyhcl) instead of the native JSON shape.
 func (h *Handler) GetPolicy(c *gin.Context) {
 	id := c.Param("id")
-	
+
 	policy, err := h.managementService.GetPolicy(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
-	
+
+	if c.Query("format") == "hcl" {
+		c.Data(http.StatusOK, "application/hcl", policyhcl.Render(policy))
+		return
+	}
+
 	c.JSON(http.StatusOK, policy)
 }
 
-// CreatePolicy creates a new policy
+// CreatePolicy creates a new policy. A request with Content-Type
+// application/hcl is parsed as an HCL policy document (see
+// internal/policyhcl) instead of the native JSON shape.
 func (h *Handler) CreatePolicy(c *gin.Context) {
-	var policy models.Policy
-	if err := c.ShouldBindJSON(&policy); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	policy, diags, err := h.policyFromRequest(c)
+	if err != nil {
+		writePolicyParseError(c, diags, err)
 		return
 	}
 
-	if err := h.managementService.CreatePolicy(&policy); err != nil {
-		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	if err := h.managementService.CreatePolicy(policy, actor(c)); err != nil {
+		writePolicyError(c, err, http.StatusConflict)
 		return
 	}
 
 	// Reload evaluation engine
 	h.reloadEvaluationEngine()
+	h.notifyReplication("upsert", policy)
 
 	c.JSON(http.StatusCreated, policy)
 }
 
+// policyFromRequest reads a *models.Policy from the request body, parsing it
+// as HCL when Content-Type is application/hcl and as JSON otherwise. The
+// HCL policy's name comes from the "name" query parameter. diags is only
+// populated (and only meaningful) for the HCL path.
+func (h *Handler) policyFromRequest(c *gin.Context) (*models.Policy, policyhcl.Diagnostics, error) {
+	if c.GetHeader("Content-Type") != "application/hcl" {
+		var policy models.Policy
+		if err := c.ShouldBindJSON(&policy); err != nil {
+			return nil, nil, err
+		}
+		return &policy, nil, nil
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return policyhcl.Parse(body, "policy.hcl", c.Query("name"))
+}
+
+// writePolicyParseError responds to a failed policyFromRequest call, using
+// the structured line/column diagnostics when they're available (the HCL
+// path) and a plain error message otherwise (the JSON path).
+func writePolicyParseError(c *gin.Context, diags policyhcl.Diagnostics, err error) {
+	if len(diags) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "diagnostics": diags})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+// writePolicyError responds to a failed CreatePolicy/UpdatePolicy call,
+// returning a structured 400 listing every schema violation when err is a
+// *policytype.ValidationError, and falling back to status with a plain
+// error message otherwise (the existing, less precise behavior these call
+// sites had before PolicyType validation existed).
+func writePolicyError(c *gin.Context, err error, status int) {
+	var verr *policytype.ValidationError
+	if errors.As(err, &verr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":          err.Error(),
+			"policy_type_id": verr.PolicyTypeID,
+			"violations":     verr.Violations,
+		})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
+
+// actor resolves who is performing a mutation, from the X-Actor header.
+func actor(c *gin.Context) string {
+	if a := c.GetHeader("X-Actor"); a != "" {
+		return a
+	}
+	return "unknown"
+}
+
+// scopedList returns a handler that lists all policies bound to scopeType.
+// For models.ScopeGlobal the route has no :scopeID param.
+func (h *Handler) scopedList(scopeType models.PolicyScopeType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policies := h.managementService.ListPoliciesByScope(scopeType, c.Param("scopeID"))
+
+		c.JSON(http.StatusOK, gin.H{
+			"policies": policies,
+			"count":    len(policies),
+		})
+	}
+}
+
+// scopedCreate returns a handler that creates a new policy bound to
+// scopeType and the request's :scopeID param (ignored for models.ScopeGlobal).
+func (h *Handler) scopedCreate(scopeType models.PolicyScopeType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var policy models.Policy
+		if err := c.ShouldBindJSON(&policy); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		policy.ScopeType = scopeType
+		policy.ScopeID = c.Param("scopeID")
+
+		if err := h.managementService.CreatePolicy(&policy, actor(c)); err != nil {
+			writePolicyError(c, err, http.StatusConflict)
+			return
+		}
+
+		// Reload evaluation engine
+		h.reloadEvaluationEngine()
+		h.notifyReplication("upsert", &policy)
+
+		c.JSON(http.StatusCreated, policy)
+	}
+}
+
 // UpdatePolicy updates an existing policy
 func (h *Handler) UpdatePolicy(c *gin.Context) {
 	id := c.Param("id")
-	
+
 	var policy models.Policy
 	if err := c.ShouldBindJSON(&policy); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := h.managementService.UpdatePolicy(id, &policy); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	if err := h.managementService.UpdatePolicy(id, &policy, actor(c)); err != nil {
+		writePolicyError(c, err, http.StatusNotFound)
 		return
 	}
 
 	// Reload evaluation engine
 	h.reloadEvaluationEngine()
+	h.notifyReplication("upsert", &policy)
 
 	c.JSON(http.StatusOK, policy)
 }
@@ -108,14 +278,21 @@ func (h *Handler) UpdatePolicy(c *gin.Context) {
 // DeletePolicy deletes a policy
 func (h *Handler) DeletePolicy(c *gin.Context) {
 	id := c.Param("id")
-	
-	if err := h.managementService.DeletePolicy(id); err != nil {
+
+	// Fetched before the delete so notifyReplication still has the policy's
+	// name/tags/org to match replication target filters against.
+	policy, _ := h.managementService.GetPolicy(id)
+
+	if err := h.managementService.DeletePolicy(id, actor(c)); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Reload evaluation engine
 	h.reloadEvaluationEngine()
+	if policy != nil {
+		h.notifyReplication("delete", policy)
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"status":    "deleted",
@@ -126,7 +303,7 @@ func (h *Handler) DeletePolicy(c *gin.Context) {
 // EnablePolicy enables a policy
 func (h *Handler) EnablePolicy(c *gin.Context) {
 	id := c.Param("id")
-	
+
 	if err := h.managementService.EnablePolicy(id); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -144,7 +321,7 @@ func (h *Handler) EnablePolicy(c *gin.Context) {
 // DisablePolicy disables a policy
 func (h *Handler) DisablePolicy(c *gin.Context) {
 	id := c.Param("id")
-	
+
 	if err := h.managementService.DisablePolicy(id); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -159,15 +336,21 @@ func (h *Handler) DisablePolicy(c *gin.Context) {
 	})
 }
 
-// ValidatePolicy validates a policy without saving it
+// ValidatePolicy validates a policy without saving it. A request with
+// Content-Type application/hcl is parsed as an HCL policy document first;
+// a parse failure reports the same line/column diagnostics as CreatePolicy.
 func (h *Handler) ValidatePolicy(c *gin.Context) {
-	var policy models.Policy
-	if err := c.ShouldBindJSON(&policy); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	policy, diags, err := h.policyFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"valid":       false,
+			"error":       err.Error(),
+			"diagnostics": diags,
+		})
 		return
 	}
 
-	if err := h.managementService.ValidatePolicy(&policy); err != nil {
+	if err := h.managementService.ValidatePolicy(policy); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"valid": false,
 			"error": err.Error(),
@@ -181,6 +364,151 @@ func (h *Handler) ValidatePolicy(c *gin.Context) {
 	})
 }
 
+// TestPolicy runs a candidate policy against a set of request/expected-decision
+// fixtures and returns a per-case pass/fail report, without creating the
+// policy or touching the running evaluation engine.
+func (h *Handler) TestPolicy(c *gin.Context) {
+	var req models.PolicyTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.managementService.ValidatePolicy(&req.Policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report := h.managementService.TestPolicy(&req.Policy, req.TestCases)
+	c.JSON(http.StatusOK, report)
+}
+
+// SimulatePolicy compiles the currently-loaded policies in scope for a
+// request into an internal/chain.Chain and returns the full ordered trace of
+// entries checked (matched, skipped, decisive), regardless of whether the
+// engine's effective mode for the request is "priority" or "chain" - for
+// debugging exactly why a decision was, or would be, reached.
+func (h *Handler) SimulatePolicy(c *gin.Context) {
+	var req models.PolicyEvaluationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := h.evaluationService.Simulate(&req)
+	c.JSON(http.StatusOK, result)
+}
+
+// PolicyHistory returns the recorded revision history for a policy.
+func (h *Handler) PolicyHistory(c *gin.Context) {
+	id := c.Param("id")
+
+	revisions, err := h.managementService.PolicyHistory(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"policy_id": id,
+		"revisions": revisions,
+		"count":     len(revisions),
+	})
+}
+
+// RollbackPolicy restores a policy to a prior revision, recording the
+// rollback itself as a new revision.
+func (h *Handler) RollbackPolicy(c *gin.Context) {
+	id := c.Param("id")
+	revision := c.Param("revision")
+
+	policy, err := h.managementService.RollbackPolicy(id, revision, actor(c))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Reload evaluation engine
+	h.reloadEvaluationEngine()
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// AddSubscription registers a webhook subscription on a policy.
+func (h *Handler) AddSubscription(c *gin.Context) {
+	id := c.Param("id")
+
+	var sub models.NotificationSubscription
+	if err := c.ShouldBindJSON(&sub); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.managementService.AddSubscription(id, sub, actor(c))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Reload evaluation engine so the new subscription takes effect
+	h.reloadEvaluationEngine()
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// RemoveSubscription removes a webhook subscription from a policy.
+func (h *Handler) RemoveSubscription(c *gin.Context) {
+	id := c.Param("id")
+	subID := c.Param("sub_id")
+
+	if err := h.managementService.RemoveSubscription(id, subID, actor(c)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Reload evaluation engine so the removed subscription stops firing
+	h.reloadEvaluationEngine()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":          "removed",
+		"policy_id":       id,
+		"subscription_id": subID,
+	})
+}
+
+// GetRateLimit reports the current count for a rate-limit bucket, keyed the
+// same way the engine builds it internally: "<rule_id>:<resolved_key_value>".
+func (h *Handler) GetRateLimit(c *gin.Context) {
+	key := c.Param("key")
+
+	count, err := h.evaluationService.RateLimitStatus(key)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"key":   key,
+		"count": count,
+	})
+}
+
+// ResetRateLimit clears a rate-limit bucket, e.g. to manually lift a
+// condition that tripped incorrectly.
+func (h *Handler) ResetRateLimit(c *gin.Context) {
+	key := c.Param("key")
+
+	if err := h.evaluationService.ResetRateLimit(key); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "reset",
+		"key":    key,
+	})
+}
+
 // Reload reloads policies from disk
 func (h *Handler) Reload(c *gin.Context) {
 	policies, err := h.managementService.ReloadFromDisk()
@@ -214,6 +542,295 @@ func (h *Handler) reloadEvaluationEngine() {
 	log.WithField("count", len(policies)).Debug("Evaluation engine reloaded after management operation")
 }
 
+// notifyReplication forwards a policy CRUD event (op is "upsert" or
+// "delete") to the replication.Manager, a no-op when replication isn't
+// configured.
+func (h *Handler) notifyReplication(op string, policy *models.Policy) {
+	if h.replicationManager == nil {
+		return
+	}
+	h.replicationManager.OnPolicyEvent(op, policy)
+}
+
+// CreateReplicationTarget registers a new peer gateway to replicate
+// policies to.
+func (h *Handler) CreateReplicationTarget(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "replication is not configured"})
+		return
+	}
+
+	var target models.ReplicationTarget
+	if err := c.ShouldBindJSON(&target); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.replicationManager.CreateTarget(&target); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, target)
+}
+
+// ListReplicationTargets returns every configured replication target.
+func (h *Handler) ListReplicationTargets(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "replication is not configured"})
+		return
+	}
+
+	targets := h.replicationManager.ListTargets()
+	c.JSON(http.StatusOK, gin.H{
+		"targets": targets,
+		"count":   len(targets),
+	})
+}
+
+// UpdateReplicationTarget replaces an existing replication target.
+func (h *Handler) UpdateReplicationTarget(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "replication is not configured"})
+		return
+	}
+
+	id := c.Param("id")
+	var target models.ReplicationTarget
+	if err := c.ShouldBindJSON(&target); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.replicationManager.UpdateTarget(id, &target); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+// DeleteReplicationTarget removes a replication target.
+func (h *Handler) DeleteReplicationTarget(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "replication is not configured"})
+		return
+	}
+
+	id := c.Param("id")
+	if err := h.replicationManager.DeleteTarget(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "deleted",
+		"id":     id,
+	})
+}
+
+// TriggerReplicationTarget replicates every currently matching policy to a
+// target immediately, regardless of its configured trigger mode.
+func (h *Handler) TriggerReplicationTarget(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "replication is not configured"})
+		return
+	}
+
+	id := c.Param("id")
+	execution, err := h.replicationManager.TriggerTarget(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, execution)
+}
+
+// GetReplicationExecution returns a previously recorded replication run.
+func (h *Handler) GetReplicationExecution(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "replication is not configured"})
+		return
+	}
+
+	id := c.Param("id")
+	execution, err := h.replicationManager.GetExecution(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, execution)
+}
+
+// GetSchema returns the registered attribute schema for entity ("user",
+// "tool", "resource", or "context").
+func (h *Handler) GetSchema(c *gin.Context) {
+	registry := h.managementService.Schema()
+	if registry == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "schema validation is not configured"})
+		return
+	}
+
+	entity := c.Param("entity")
+	schema, ok := registry.Get(entity)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("no schema registered for entity %q", entity)})
+		return
+	}
+
+	c.JSON(http.StatusOK, schema)
+}
+
+// PutSchema registers the attribute schema for entity, replacing any
+// previously registered one.
+func (h *Handler) PutSchema(c *gin.Context) {
+	registry := h.managementService.Schema()
+	if registry == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "schema validation is not configured"})
+		return
+	}
+
+	entity := c.Param("entity")
+	var schema policyschema.EntitySchema
+	if err := c.ShouldBindJSON(&schema); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := registry.Set(entity, &schema); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schema)
+}
+
+// ListPolicyTypes returns every registered PolicyType.
+func (h *Handler) ListPolicyTypes(c *gin.Context) {
+	registry := h.managementService.PolicyTypes()
+	if registry == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "policy type validation is not configured"})
+		return
+	}
+
+	types := registry.List()
+	c.JSON(http.StatusOK, gin.H{
+		"policy_types": types,
+		"count":        len(types),
+	})
+}
+
+// PutPolicyType registers the PolicyType with the given id, replacing any
+// previously registered one with that ID.
+func (h *Handler) PutPolicyType(c *gin.Context) {
+	if h.managementService.PolicyTypes() == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "policy type validation is not configured"})
+		return
+	}
+
+	var pt models.PolicyTypeDef
+	if err := c.ShouldBindJSON(&pt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	pt.ID = c.Param("id")
+
+	if err := h.managementService.SetPolicyType(&pt); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, pt)
+}
+
+// DeletePolicyType removes a registered PolicyType.
+func (h *Handler) DeletePolicyType(c *gin.Context) {
+	if h.managementService.PolicyTypes() == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "policy type validation is not configured"})
+		return
+	}
+
+	if err := h.managementService.DeletePolicyType(c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// CreateConstraintTemplate registers a new ConstraintTemplate.
+func (h *Handler) CreateConstraintTemplate(c *gin.Context) {
+	var req models.ConstraintTemplateCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tmpl, err := h.managementService.CreateConstraintTemplate(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tmpl)
+}
+
+// ListConstraintTemplates returns every registered ConstraintTemplate.
+func (h *Handler) ListConstraintTemplates(c *gin.Context) {
+	templates := h.managementService.ListConstraintTemplates()
+	c.JSON(http.StatusOK, gin.H{
+		"templates": templates,
+		"count":     len(templates),
+	})
+}
+
+// CreateConstraint instantiates a ConstraintTemplate into a Constraint and
+// its compiled Policy, then reloads the evaluation engine so it's
+// enforced immediately.
+func (h *Handler) CreateConstraint(c *gin.Context) {
+	var req models.ConstraintCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	constraint, err := h.managementService.CreateConstraint(&req, actor(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.reloadEvaluationEngine()
+	c.JSON(http.StatusCreated, constraint)
+}
+
+// ListConstraints returns every instantiated Constraint.
+func (h *Handler) ListConstraints(c *gin.Context) {
+	constraints := h.managementService.ListConstraints()
+	c.JSON(http.StatusOK, gin.H{
+		"constraints": constraints,
+		"count":       len(constraints),
+	})
+}
+
+// DeleteConstraint removes a Constraint and its compiled Policy.
+func (h *Handler) DeleteConstraint(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.managementService.DeleteConstraint(id, actor(c)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.reloadEvaluationEngine()
+	c.JSON(http.StatusOK, gin.H{
+		"status":        "deleted",
+		"constraint_id": id,
+	})
+}
+
 // LogManagement logs management operations (can be used as middleware)
 func LogManagement(c *gin.Context) {
 	log.WithFields(log.Fields{
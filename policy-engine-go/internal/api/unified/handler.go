@@ -1,17 +1,38 @@
 package unified
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"sort"
-
+	"strings"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/audit"
+	"github.com/datacline/policy-engine/internal/cluster"
+	"github.com/datacline/policy-engine/internal/engine"
+	regoengine "github.com/datacline/policy-engine/internal/engine/rego"
+	"github.com/datacline/policy-engine/internal/jwtauth"
+	"github.com/datacline/policy-engine/internal/labels"
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/policyformat"
+	"github.com/datacline/policy-engine/internal/reconcile"
+	"github.com/datacline/policy-engine/internal/schedule"
 	"github.com/datacline/policy-engine/internal/storage"
+	"github.com/datacline/policy-engine/internal/unifiedreplication"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
 )
 
 // Handler provides API endpoints for unified policy management
 type Handler struct {
-	storage *storage.UnifiedStorage
+	storage            *storage.UnifiedStorage
+	notifier           *cluster.PeerNotifier       // nil when no cluster peers are configured
+	reconciler         *reconcile.Reconciler       // nil when no external policy sources are configured
+	replicationManager *unifiedreplication.Manager // nil when cross-cluster replication isn't configured
+	auditLogger        *audit.Logger               // nil when the audit log subsystem isn't configured
+	approvalVerifier   jwtauth.Verifier            // nil refuses every approval-workflow request, see authenticatedActor
 }
 
 // NewHandler creates a new unified policy handler
@@ -19,6 +40,90 @@ func NewHandler(storage *storage.UnifiedStorage) *Handler {
 	return &Handler{storage: storage}
 }
 
+// actor resolves who is performing a mutation, from the X-Actor header.
+func actor(c *gin.Context) string {
+	if a := c.GetHeader("X-Actor"); a != "" {
+		return a
+	}
+	return "unknown"
+}
+
+// SetNotifier attaches a PeerNotifier, enabling the cluster-wide reload-all
+// admin endpoint and pushing it down to storage so write operations notify
+// peers.
+func (h *Handler) SetNotifier(notifier *cluster.PeerNotifier) {
+	h.notifier = notifier
+	h.storage.SetNotifier(notifier)
+}
+
+// SetReconciler attaches a Reconciler, enabling the GET /unified/sources
+// status endpoint.
+func (h *Handler) SetReconciler(reconciler *reconcile.Reconciler) {
+	h.reconciler = reconciler
+}
+
+// SetReplicationManager attaches a unifiedreplication.Manager, enabling the
+// /unified/replication/targets, /unified/replication/trigger/:target_id, and
+// /unified/replication/executions endpoints.
+func (h *Handler) SetReplicationManager(manager *unifiedreplication.Manager) {
+	h.replicationManager = manager
+}
+
+// SetAuditLogger attaches an audit.Logger, so EvaluatePolicy records every
+// live decision into the tamper-evident audit log. Recording is
+// best-effort: a logging failure is warned about, not returned, since the
+// decision itself has already been reached and returned to the caller by
+// the time Record runs.
+func (h *Handler) SetAuditLogger(logger *audit.Logger) {
+	h.auditLogger = logger
+}
+
+// SetApprovalVerifier attaches the jwtauth.Verifier AddPolicyApproval and
+// SetApprovalQuorum authenticate their caller against. Unlike actor(), which
+// trusts a client-supplied X-Actor header for audit attribution, the
+// approval quorum is a security control - a caller who could supply any
+// identity it wants would defeat it by approving twice under two made-up
+// names. Without a verifier configured, both endpoints refuse every
+// request rather than silently falling back to an unauthenticated identity.
+func (h *Handler) SetApprovalVerifier(verifier jwtauth.Verifier) {
+	h.approvalVerifier = verifier
+}
+
+// authenticatedActor resolves the caller's verified identity from an
+// "Authorization: Bearer <jwt>" header against h.approvalVerifier's
+// configured jwt_providers, returning the token's sub claim. Used by
+// AddPolicyApproval and SetApprovalQuorum in place of actor(), since those
+// two endpoints need a principal an attacker can't just claim to be.
+func (h *Handler) authenticatedActor(c *gin.Context) (string, error) {
+	if h.approvalVerifier == nil {
+		return "", fmt.Errorf("approval workflow is disabled: no JWT provider is configured")
+	}
+
+	header := c.GetHeader("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return "", fmt.Errorf("missing or malformed Authorization: Bearer <token> header")
+	}
+
+	claims, _, err := h.approvalVerifier.Verify(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid bearer token: %w", err)
+	}
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return "", fmt.Errorf("verified token has no sub claim to identify the approver")
+	}
+	return sub, nil
+}
+
+// SetOperatorPlugin registers a custom RuleOperator evaluator, forwarded to
+// engine.RegisterOperatorPlugin so policy simulation recognizes operators
+// beyond the built-in set (equals, cel, cidr, ...). Call before serving
+// traffic - plugins aren't safe to register concurrently with evaluation.
+func (h *Handler) SetOperatorPlugin(op models.RuleOperator, fn engine.OperatorPlugin) {
+	engine.RegisterOperatorPlugin(op, fn)
+}
+
 // RegisterRoutes registers all unified policy API routes
 func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 	// Policy CRUD
@@ -39,8 +144,76 @@ func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 	r.POST("/unified/policies/:id/resources", h.AddResourceBinding)
 	r.DELETE("/unified/policies/:id/resources/:type/:resourceId", h.RemoveResourceBinding)
 
+	// Priority-aware conflict detection
+	r.GET("/unified/resources/:type/:id/conflicts", h.GetResourceConflicts)
+	r.GET("/unified/policies/conflicts", h.GetAllConflicts)
+
+	// Revision history, diff and rollback
+	r.GET("/unified/policies/:id/revisions", h.ListPolicyRevisions)
+	r.GET("/unified/policies/:id/revisions/:rev", h.GetPolicyRevision)
+	r.GET("/unified/policies/:id/revisions/:rev/diff/:other", h.DiffPolicyRevisions)
+	r.POST("/unified/policies/:id/revisions/:rev/rollback", h.RollbackPolicy)
+
+	// Notification webhook subscriptions
+	r.POST("/unified/policies/:id/notifications", h.AddNotificationSubscription)
+	r.GET("/unified/policies/:id/notifications", h.ListNotificationSubscriptions)
+
+	// Approval workflow (two-person rule quorum gating activate)
+	r.POST("/unified/policies/:id/approvals", h.AddPolicyApproval)
+	r.GET("/unified/policies/:id/approvals", h.ListPolicyApprovals)
+	r.POST("/unified/orgs/:org_id/approval-quorum", h.SetApprovalQuorum)
+
+	// Dry-run / simulation
+	r.POST("/unified/policies/:id/simulate", h.SimulatePolicy)
+	r.POST("/unified/policies/simulate", h.SimulatePolicyInline)
+
+	// Live evaluation - language-agnostic (dsl or rego, per PolicyLanguage)
+	r.POST("/unified/policies/:id/evaluate", h.EvaluatePolicy)
+
+	// Cron-based effective schedules
+	r.GET("/unified/policies/schedule/upcoming", h.GetUpcomingSchedules)
+	r.GET("/unified/policies/:id/schedule", h.GetPolicySchedule)
+	r.POST("/unified/policies/:id/schedule/dry-run", h.DryRunPolicySchedule)
+
+	// Policy templates (parameterized policy_rules bodies)
+	r.GET("/unified/templates", h.ListTemplates)
+	r.POST("/unified/templates", h.CreateTemplate)
+	r.POST("/unified/templates/:id/instantiate", h.InstantiateTemplate)
+	r.POST("/unified/templates/:id/rollout", h.RolloutTemplate)
+
+	// Alternate policy rules formats (IAM/S3-bucket-policy-style JSON)
+	r.POST("/unified/policies/import", h.ImportPolicy)
+	r.GET("/unified/policies/:id/export", h.ExportPolicy)
+
+	// Bulk bundle export/import and cross-cluster replication. Namespaced
+	// under /unified/replication/ rather than /unified/policies/export|import
+	// to avoid colliding with the single-policy IAM import/export pair above.
+	r.POST("/unified/replication/export", h.ExportBundle)
+	r.POST("/unified/replication/import", h.ImportBundle)
+	r.POST("/unified/replication/targets", h.CreateReplicationTarget)
+	r.GET("/unified/replication/targets", h.ListReplicationTargets)
+	r.PUT("/unified/replication/targets/:id", h.UpdateReplicationTarget)
+	r.DELETE("/unified/replication/targets/:id", h.DeleteReplicationTarget)
+	r.POST("/unified/replication/trigger/:target_id", h.TriggerReplicationTarget)
+	r.GET("/unified/replication/executions", h.ListReplicationExecutions)
+
 	// Reload from disk
 	r.POST("/unified/reload", h.Reload)
+
+	// Admin endpoint that triggers a full reload across every cluster peer
+	r.POST("/unified/cluster/reload-all", h.ClusterReloadAll)
+
+	// External policy source reconciliation status
+	r.GET("/unified/sources", h.ListSources)
+}
+
+// RegisterInternalRoutes registers the peer-to-peer cluster sync endpoints
+// that a PeerNotifier on another node calls directly (outside /api/v1, since
+// they're node-internal rather than part of the public API).
+func (h *Handler) RegisterInternalRoutes(router *gin.Engine) {
+	internal := router.Group("/internal/v1/policies")
+	internal.POST("/reload", h.ReloadOne)
+	internal.POST("/reload-all", h.Reload)
 }
 
 // ListPolicies returns all policies matching optional filters
@@ -56,6 +229,27 @@ func (h *Handler) ListPolicies(c *gin.Context) {
 	filter.OwnerID = c.Query("owner_id")
 	filter.ResourceType = models.ResourceType(c.Query("resource_type"))
 	filter.ResourceID = c.Query("resource_id")
+	if selector := c.Query("selector"); selector != "" {
+		parsed, err := models.LabelMapFromString(selector)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filter.Selector = parsed
+	}
+	// label_selector filters by the policy's own Labels (see
+	// UnifiedPolicy.Labels), distinct from selector above which filters by
+	// resource-binding ResourceLabels. Only the MatchLabels form is
+	// query-string addressable today; MatchExpressions has no query-string
+	// encoding yet.
+	if labelSelector := c.Query("label_selector"); labelSelector != "" {
+		parsed, err := models.LabelMapFromString(labelSelector)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filter.LabelSelector = &labels.LabelSelector{MatchLabels: parsed}
+	}
 
 	policies := h.storage.List(&filter)
 
@@ -112,8 +306,14 @@ func (h *Handler) CreatePolicy(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if req.PolicyLanguage == models.PolicyLanguageRego {
+		if err := regoengine.Validate(req.PolicyCode, req.RegoSource); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
 
-	policy, err := h.storage.Create(&req)
+	policy, err := h.storage.Create(&req, actor(c))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -139,8 +339,14 @@ func (h *Handler) UpdatePolicy(c *gin.Context) {
 			return
 		}
 	}
+	if req.PolicyLanguage == models.PolicyLanguageRego && req.RegoSource != "" {
+		if err := regoengine.Validate(id, req.RegoSource); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
 
-	policy, err := h.storage.Update(id, &req)
+	policy, err := h.storage.Update(id, &req, actor(c))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -161,11 +367,27 @@ func (h *Handler) DeletePolicy(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Policy deleted"})
 }
 
-// ActivatePolicy activates a policy
+// ActivatePolicy activates a policy. Unless force=true, activation is
+// refused with 409 Conflict when it would introduce an unresolved (no
+// priority/deny_override tiebreak) conflict against another already-active
+// policy sharing one of its resource bindings.
 func (h *Handler) ActivatePolicy(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.storage.Activate(id); err != nil {
+	if c.Query("force") != "true" {
+		if conflicts, err := h.unresolvedActivationConflicts(id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		} else if len(conflicts) > 0 {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":     "activation would introduce unresolved policy conflicts; retry with force=true to override",
+				"conflicts": conflicts,
+			})
+			return
+		}
+	}
+
+	if err := h.storage.Activate(id, actor(c)); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -174,11 +396,58 @@ func (h *Handler) ActivatePolicy(c *gin.Context) {
 	c.JSON(http.StatusOK, policy)
 }
 
+// unresolvedActivationConflicts reports every conflict that activating
+// policyID would introduce, simulating it as Active against the currently
+// active (plus global) policies on each of its resource bindings.
+func (h *Handler) unresolvedActivationConflicts(policyID string) ([]engine.RuleConflict, error) {
+	policy, err := h.storage.GetByID(policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := *policy
+	candidate.Status = models.PolicyStatusActive
+
+	var unresolved []engine.RuleConflict
+	seen := make(map[string]bool)
+	for _, r := range policy.Resources {
+		if len(r.ResourceLabels) > 0 {
+			continue
+		}
+		key := string(r.ResourceType) + ":" + r.ResourceID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		peers, err := h.storage.GetActiveByResource(r.ResourceType, r.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+		peers = append(peers, h.storage.GetGlobalPolicies()...)
+
+		group := make([]*models.UnifiedPolicy, 0, len(peers)+1)
+		for _, p := range peers {
+			if p.PolicyID != policyID {
+				group = append(group, p)
+			}
+		}
+		group = append(group, &candidate)
+
+		for _, conflict := range engine.DetectConflicts(r.ResourceType, r.ResourceID, group) {
+			if !conflict.Resolved {
+				unresolved = append(unresolved, conflict)
+			}
+		}
+	}
+	return unresolved, nil
+}
+
 // SuspendPolicy suspends a policy
 func (h *Handler) SuspendPolicy(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.storage.Suspend(id); err != nil {
+	if err := h.storage.Suspend(id, actor(c)); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -191,7 +460,7 @@ func (h *Handler) SuspendPolicy(c *gin.Context) {
 func (h *Handler) RetirePolicy(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.storage.Retire(id); err != nil {
+	if err := h.storage.Retire(id, actor(c)); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -249,6 +518,81 @@ func (h *Handler) GetPoliciesByResource(c *gin.Context) {
 	})
 }
 
+// GetResourceConflicts detects unresolved priority conflicts among the
+// active (plus global) policies bound to a single resource.
+func (h *Handler) GetResourceConflicts(c *gin.Context) {
+	resourceType := models.ResourceType(c.Param("type"))
+	resourceID := c.Param("id")
+
+	if resourceType != models.ResourceTypeMCPServer &&
+		resourceType != models.ResourceTypeTool &&
+		resourceType != models.ResourceTypeResource {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid resource type"})
+		return
+	}
+
+	policies, err := h.storage.GetActiveByResource(resourceType, resourceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	policies = append(policies, h.storage.GetGlobalPolicies()...)
+
+	conflicts := engine.DetectConflicts(resourceType, resourceID, policies)
+
+	c.JSON(http.StatusOK, gin.H{
+		"conflicts":     conflicts,
+		"count":         len(conflicts),
+		"resource_type": resourceType,
+		"resource_id":   resourceID,
+	})
+}
+
+// GetAllConflicts detects unresolved priority conflicts across every
+// resource that has two or more active policies bound to it by exact
+// (type, id). Label-selector bindings aren't expanded against live
+// resources here, the same scope GetByResource's resourceMap fast path
+// covers.
+func (h *Handler) GetAllConflicts(c *gin.Context) {
+	grouped := make(map[string][]*models.UnifiedPolicy)
+	for _, p := range h.storage.GetAll() {
+		if !p.IsActive() || !p.IsScheduledEffective() {
+			continue
+		}
+		for _, r := range p.Resources {
+			if len(r.ResourceLabels) > 0 {
+				continue
+			}
+			key := string(r.ResourceType) + ":" + r.ResourceID
+			grouped[key] = append(grouped[key], p)
+		}
+	}
+
+	var conflicts []engine.RuleConflict
+	for key, group := range grouped {
+		if len(group) < 2 {
+			continue
+		}
+		resourceType, resourceID := splitResourceKey(key)
+		conflicts = append(conflicts, engine.DetectConflicts(resourceType, resourceID, group)...)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"conflicts": conflicts,
+		"count":     len(conflicts),
+	})
+}
+
+// splitResourceKey reverses "type:id" keys built from makeResourceKey-style
+// concatenation for GetAllConflicts' report.
+func splitResourceKey(key string) (models.ResourceType, string) {
+	idx := strings.IndexByte(key, ':')
+	if idx < 0 {
+		return models.ResourceType(key), ""
+	}
+	return models.ResourceType(key[:idx]), key[idx+1:]
+}
+
 // AddResourceBinding adds a resource binding to a policy
 func (h *Handler) AddResourceBinding(c *gin.Context) {
 	policyID := c.Param("id")
@@ -263,7 +607,7 @@ func (h *Handler) AddResourceBinding(c *gin.Context) {
 		return
 	}
 
-	if err := h.storage.AddResource(policyID, req.ResourceType, req.ResourceID); err != nil {
+	if err := h.storage.AddResource(policyID, req.ResourceType, req.ResourceID, actor(c)); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -278,7 +622,7 @@ func (h *Handler) RemoveResourceBinding(c *gin.Context) {
 	resourceType := models.ResourceType(c.Param("type"))
 	resourceID := c.Param("resourceId")
 
-	if err := h.storage.RemoveResource(policyID, resourceType, resourceID); err != nil {
+	if err := h.storage.RemoveResource(policyID, resourceType, resourceID, actor(c)); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -287,87 +631,880 @@ func (h *Handler) RemoveResourceBinding(c *gin.Context) {
 	c.JSON(http.StatusOK, policy)
 }
 
-// Reload reloads all policies from disk
-func (h *Handler) Reload(c *gin.Context) {
-	if err := h.storage.LoadAll(); err != nil {
+// ListPolicyRevisions returns a policy's revision history, oldest first.
+func (h *Handler) ListPolicyRevisions(c *gin.Context) {
+	id := c.Param("id")
+
+	revisions, err := h.storage.History(id)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	policies := h.storage.GetAll()
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Policies reloaded",
-		"count":   len(policies),
+		"revisions": revisions,
+		"count":     len(revisions),
 	})
 }
 
-// validatePolicyRules validates the policy rules DSL
-func validatePolicyRules(rules []models.PolicyRuleDSL) error {
-	for _, rule := range rules {
-		if rule.RuleID == "" {
-			return &ValidationError{Field: "rule_id", Message: "rule_id is required"}
-		}
-		if len(rule.Actions) == 0 {
-			return &ValidationError{Field: "actions", Message: "at least one action is required"}
-		}
-		for _, action := range rule.Actions {
-			if !isValidActionType(action.Type) {
-				return &ValidationError{Field: "action.type", Message: "invalid action type: " + string(action.Type)}
-			}
-		}
-		if rule.Conditions != nil {
-			if err := validateCondition(rule.Conditions); err != nil {
-				return err
-			}
-		}
+// GetPolicyRevision returns a single recorded revision, including its full
+// policy snapshot.
+func (h *Handler) GetPolicyRevision(c *gin.Context) {
+	id := c.Param("id")
+	rev := c.Param("rev")
+
+	revision, err := h.storage.GetRevision(id, rev)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
 	}
-	return nil
+
+	c.JSON(http.StatusOK, revision)
 }
 
-func validateCondition(cond *models.RuleCondition) error {
-	// Check if it's a boolean composition
-	if len(cond.All) > 0 || len(cond.Any) > 0 {
-		for _, c := range cond.All {
-			if err := validateCondition(&c); err != nil {
-				return err
-			}
-		}
-		for _, c := range cond.Any {
-			if err := validateCondition(&c); err != nil {
-				return err
-			}
-		}
-		return nil
+// DiffPolicyRevisions reports the field-by-field changes between two
+// recorded revisions of a policy.
+func (h *Handler) DiffPolicyRevisions(c *gin.Context) {
+	id := c.Param("id")
+	rev := c.Param("rev")
+	other := c.Param("other")
+
+	ops, err := h.storage.Diff(id, rev, other)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	// It's a leaf condition
-	if cond.Field == "" {
-		return &ValidationError{Field: "field", Message: "field is required in condition"}
+	c.JSON(http.StatusOK, gin.H{
+		"from": rev,
+		"to":   other,
+		"diff": ops,
+	})
+}
+
+// RollbackPolicy restores a policy to a previously recorded revision,
+// recording the restore itself as a new revision.
+func (h *Handler) RollbackPolicy(c *gin.Context) {
+	id := c.Param("id")
+	rev := c.Param("rev")
+
+	policy, err := h.storage.Rollback(id, rev, actor(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
-	if !isValidOperator(cond.Operator) {
-		return &ValidationError{Field: "operator", Message: "invalid operator: " + string(cond.Operator)}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// AddNotificationSubscription registers a webhook destination that receives
+// a UnifiedPolicyNotification whenever this policy's lifecycle status
+// changes, a resource binding is added or removed, or its computed
+// EnforceState changes as a result.
+func (h *Handler) AddNotificationSubscription(c *gin.Context) {
+	id := c.Param("id")
+
+	var sub models.UnifiedNotificationSubscription
+	if err := c.ShouldBindJSON(&sub); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
-	return nil
+
+	created, err := h.storage.AddSubscription(id, sub)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
 }
 
-func isValidActionType(t models.RuleActionType) bool {
-	switch t {
-	case models.RuleActionAllow, models.RuleActionDeny, models.RuleActionRedact,
-		models.RuleActionTransform, models.RuleActionAudit:
-		return true
+// ListNotificationSubscriptions lists the webhook subscriptions registered
+// on a policy.
+func (h *Handler) ListNotificationSubscriptions(c *gin.Context) {
+	id := c.Param("id")
+
+	subs, err := h.storage.ListSubscriptions(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
 	}
-	return false
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscriptions": subs,
+		"count":         len(subs),
+	})
 }
 
-func isValidOperator(op models.RuleOperator) bool {
-	switch op {
-	case models.RuleOpEquals, models.RuleOpNotEquals, models.RuleOpContains,
-		models.RuleOpNotContains, models.RuleOpMatches, models.RuleOpIn,
-		models.RuleOpNotIn, models.RuleOpGt, models.RuleOpLt,
-		models.RuleOpGte, models.RuleOpLte, models.RuleOpExists, models.RuleOpNotExists:
-		return true
+// AddPolicyApprovalRequest is AddPolicyApproval's request body. The
+// approver's identity comes from authenticatedActor's verified bearer
+// token, not the body or the unauthenticated X-Actor header actor() reads -
+// either of those would let an unauthenticated caller approve its own
+// activation twice under two made-up identities.
+type AddPolicyApprovalRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+// ApproverQuorumRequest is SetApprovalQuorum's request body.
+type ApproverQuorumRequest struct {
+	Quorum int `json:"quorum" binding:"required"`
+}
+
+// AddPolicyApproval records a two-person-rule sign-off on a policy, refusing
+// self-approval (the verified approver == the policy's OwnerID) and signing
+// the approval when the storage layer has a signing key configured. Enough
+// distinct approvals at the policy's current Version let ActivatePolicy
+// proceed - see UnifiedStorage.Activate.
+func (h *Handler) AddPolicyApproval(c *gin.Context) {
+	id := c.Param("id")
+
+	approverID, err := h.authenticatedActor(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
 	}
-	return false
+
+	var req AddPolicyApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	approval, err := h.storage.AddApproval(id, approverID, req.Comment)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, approval)
+}
+
+// SetApprovalQuorum sets orgID's approval quorum to any positive value,
+// overriding storage's default of two - see UnifiedStorage.SetApprovalQuorum. Like
+// AddPolicyApproval, it requires an authenticatedActor rather than trusting
+// X-Actor, since an unauthenticated caller able to raise or lower its own
+// quorum at will would make the control meaningless.
+func (h *Handler) SetApprovalQuorum(c *gin.Context) {
+	orgID := c.Param("org_id")
+
+	if _, err := h.authenticatedActor(c); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req ApproverQuorumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Quorum <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "quorum must be positive"})
+		return
+	}
+
+	h.storage.SetApprovalQuorum(orgID, req.Quorum)
+	c.JSON(http.StatusOK, gin.H{"org_id": orgID, "quorum": req.Quorum})
+}
+
+// ListPolicyApprovals lists the approvals recorded on a policy.
+func (h *Handler) ListPolicyApprovals(c *gin.Context) {
+	id := c.Param("id")
+
+	approvals, err := h.storage.ListApprovals(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"approvals": approvals,
+		"count":     len(approvals),
+	})
+}
+
+// SimulatePolicy dry-runs an existing policy's rules against a
+// caller-supplied request context with no side effects - no resource
+// binding, rate-limit, or subscriber notification effects - and returns the
+// per-rule decision trace. Pass ?explain=true for a step-by-step reason
+// string per condition leaf.
+func (h *Handler) SimulatePolicy(c *gin.Context) {
+	id := c.Param("id")
+
+	policy, err := h.storage.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	var ctx engine.SimulationContext
+	if err := c.ShouldBindJSON(&ctx); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := engine.Simulate(policy.PolicyID, policy.Version, policy.PolicyRules, &ctx, c.Query("explain") == "true")
+	c.JSON(http.StatusOK, result)
+}
+
+// SimulatePolicyInline dry-runs an inline policy_rules body that hasn't been
+// created as a policy yet, against a caller-supplied request context.
+// Useful for CI pipelines validating a rules document before it's ever
+// saved. Pass ?explain=true for a step-by-step reason string per condition
+// leaf.
+func (h *Handler) SimulatePolicyInline(c *gin.Context) {
+	var req struct {
+		engine.SimulationContext
+		PolicyRules []models.PolicyRuleDSL `json:"policy_rules" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validatePolicyRules(req.PolicyRules); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := engine.Simulate("", 0, req.PolicyRules, &req.SimulationContext, c.Query("explain") == "true")
+	c.JSON(http.StatusOK, result)
+}
+
+// EvaluatePolicy evaluates a policy against a PolicyEvaluationInput. Unlike
+// SimulatePolicy, it is language-agnostic: a PolicyLanguageDSL policy runs
+// through engine.Simulate (rendered into a single PolicyDecision), while a
+// PolicyLanguageRego policy compiles (on first use, then cache-hits by
+// policy ID and Version) and runs its RegoSource through
+// internal/engine/rego instead. Either way the response shape is the same
+// models.PolicyDecision, so callers don't need to know which language a
+// policy was authored in.
+func (h *Handler) EvaluatePolicy(c *gin.Context) {
+	id := c.Param("id")
+
+	policy, err := h.storage.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	var input models.PolicyEvaluationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if policy.PolicyLanguage == models.PolicyLanguageRego {
+		module, err := regoengine.Compile(policy.PolicyID, policy.Version, policy.RegoSource)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		decision, err := regoengine.Evaluate(module, input)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.recordAuditDecision(decision, input, map[string]int{policy.PolicyID: policy.Version})
+		c.JSON(http.StatusOK, decision)
+		return
+	}
+
+	ctx := &engine.SimulationContext{
+		ResourceType: input.ResourceType,
+		ResourceID:   input.ResourceID,
+		Principal: map[string]interface{}{
+			"user_id": input.UserID,
+			"roles":   input.Roles,
+			"org_ids": input.OrgIDs,
+		},
+		Arguments: input.Context,
+	}
+
+	result := engine.Simulate(policy.PolicyID, policy.Version, policy.PolicyRules, ctx, false)
+	decision := &models.PolicyDecision{
+		PolicyIDs: []string{policy.PolicyID},
+		Timestamp: time.Now(),
+	}
+	if result.Matched && len(result.Actions) > 0 {
+		decision.Decision = result.Actions[0].Type
+		for _, rule := range result.Rules {
+			if rule.Matched {
+				decision.MatchedRule = rule.RuleID
+				break
+			}
+		}
+	} else {
+		decision.Decision = models.RuleActionDeny
+		decision.Reason = "no rule matched"
+	}
+
+	h.recordAuditDecision(decision, input, map[string]int{policy.PolicyID: policy.Version})
+	c.JSON(http.StatusOK, decision)
+}
+
+// recordAuditDecision appends decision to the audit log, if one is
+// configured. This is best-effort: the HTTP response has already been
+// decided by the time this runs, so a logging failure is warned about
+// rather than surfaced to the caller.
+func (h *Handler) recordAuditDecision(decision *models.PolicyDecision, input models.PolicyEvaluationInput, policyVersions map[string]int) {
+	if h.auditLogger == nil {
+		return
+	}
+	if _, err := h.auditLogger.Record(decision, input, policyVersions); err != nil {
+		log.WithFields(log.Fields{"user_id": input.UserID, "resource_id": input.ResourceID}).WithError(err).Warn("Failed to record policy decision to audit log")
+	}
+}
+
+// GetPolicySchedule returns the recurring activation schedule for a policy.
+func (h *Handler) GetPolicySchedule(c *gin.Context) {
+	id := c.Param("id")
+
+	policy, err := h.storage.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if policy.Schedule == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "policy has no schedule configured"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"policy_id":        policy.PolicyID,
+		"schedule":         policy.Schedule,
+		"is_effective_now": policy.IsEffectiveNow,
+	})
+}
+
+// GetUpcomingSchedules lists scheduled policies whose next firing falls
+// within the requested window (default 24h).
+func (h *Handler) GetUpcomingSchedules(c *gin.Context) {
+	windowStr := c.DefaultQuery("window", "24h")
+	window, err := time.ParseDuration(windowStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid window: " + err.Error()})
+		return
+	}
+
+	now := time.Now()
+	cutoff := now.Add(window)
+
+	type upcoming struct {
+		PolicyID   string                 `json:"policy_id"`
+		PolicyCode string                 `json:"policy_code"`
+		Schedule   *models.PolicySchedule `json:"schedule"`
+	}
+
+	results := make([]upcoming, 0)
+	for _, p := range h.storage.GetScheduled() {
+		if p.Schedule.NextRunAt == nil {
+			continue
+		}
+		if p.Schedule.NextRunAt.After(cutoff) {
+			continue
+		}
+		results = append(results, upcoming{PolicyID: p.PolicyID, PolicyCode: p.PolicyCode, Schedule: p.Schedule})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Schedule.NextRunAt.Before(*results[j].Schedule.NextRunAt)
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"window":   windowStr,
+		"count":    len(results),
+		"policies": results,
+	})
+}
+
+// DryRunPolicySchedule computes the next N activation windows a policy's
+// schedule would open, without mutating any stored state.
+func (h *Handler) DryRunPolicySchedule(c *gin.Context) {
+	id := c.Param("id")
+
+	policy, err := h.storage.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if policy.Schedule == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "policy has no schedule configured"})
+		return
+	}
+
+	var req struct {
+		Count int `json:"count"`
+	}
+	_ = c.ShouldBindJSON(&req)
+	if req.Count <= 0 {
+		req.Count = 5
+	}
+
+	windows, err := schedule.DryRun(policy.Schedule, time.Now(), req.Count)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"policy_id": policy.PolicyID,
+		"windows":   windows,
+	})
+}
+
+// ListTemplates returns every registered policy template
+func (h *Handler) ListTemplates(c *gin.Context) {
+	templates := h.storage.GetTemplates()
+	c.JSON(http.StatusOK, gin.H{
+		"templates": templates,
+		"count":     len(templates),
+	})
+}
+
+// CreateTemplate registers a new policy template
+func (h *Handler) CreateTemplate(c *gin.Context) {
+	var req models.PolicyTemplateCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tmpl, err := h.storage.CreateTemplate(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tmpl)
+}
+
+// InstantiateTemplate renders a template's rules with the supplied
+// parameters and creates a concrete policy from the result.
+func (h *Handler) InstantiateTemplate(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.PolicyTemplateInstantiateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.storage.Instantiate(id, &req, actor(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, policy)
+}
+
+// RolloutTemplate re-renders every policy bound to a template with its
+// originally-supplied params and persists the result with a bumped version.
+func (h *Handler) RolloutTemplate(c *gin.Context) {
+	id := c.Param("id")
+
+	updated, err := h.storage.Rollout(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":          "Template rolled out",
+		"updated_policies": updated,
+		"count":            len(updated),
+	})
+}
+
+// ImportPolicy creates a policy from an IAM/S3-bucket-policy-style JSON
+// document (see internal/policyformat), rather than the native
+// UnifiedPolicyCreateRequest body CreatePolicy expects.
+func (h *Handler) ImportPolicy(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.importIAMPolicy(body, c.Query("policy_code"), actor(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, policy)
+}
+
+// importIAMPolicy parses and converts an IAM policy document, then creates
+// a policy from it through the normal storage.Create path so duplicate-code
+// checks and resource indexing stay in one place.
+func (h *Handler) importIAMPolicy(data []byte, policyCode, author string) (*models.UnifiedPolicy, error) {
+	doc, err := policyformat.ParseIAMDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IAM policy document: %w", err)
+	}
+	if err := policyformat.ValidateActions(doc, policyformat.DefaultActionCatalog()); err != nil {
+		return nil, err
+	}
+
+	rules, err := policyformat.ToRules(doc)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := policyformat.ResolveResourceBindings(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if policyCode == "" {
+		policyCode = "imported-" + uuid.New().String()
+	}
+
+	return h.storage.Create(&models.UnifiedPolicyCreateRequest{
+		PolicyCode:  policyCode,
+		PolicyRules: rules,
+		RulesFormat: models.PolicyFormatIAMJSON,
+		Status:      models.PolicyStatusDraft,
+		Resources:   resources,
+	}, author)
+}
+
+// ExportPolicy renders a policy as an IAM/S3-bucket-policy-style JSON
+// document when called with ?format=iam-json; otherwise it returns the
+// policy in its native JSON representation, same as GetPolicy.
+func (h *Handler) ExportPolicy(c *gin.Context) {
+	id := c.Param("id")
+
+	policy, err := h.storage.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("format") != "iam-json" {
+		c.JSON(http.StatusOK, policy)
+		return
+	}
+
+	doc, err := policyformat.FromRules(policy.PolicyRules, policy.Resources)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, doc)
+}
+
+// unifiedBundleExportRequest is ExportBundle's optional body; an empty or
+// absent Filters slice exports every policy.
+type unifiedBundleExportRequest struct {
+	Filters []models.UnifiedBundleFilter `json:"filters,omitempty"`
+}
+
+// ExportBundle returns a versioned UnifiedPolicyBundle of every policy
+// matching the request's filters (or every policy, if none are given), for
+// bulk transfer or as the payload a unifiedreplication.Manager pushes to a
+// target.
+func (h *Handler) ExportBundle(c *gin.Context) {
+	var req unifiedBundleExportRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	bundle, err := h.storage.ExportBundle(req.Filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bundle)
+}
+
+// unifiedBundleImportRequest is ImportBundle's body, also posted by a
+// unifiedreplication.Manager pushing to this gateway as a target.
+type unifiedBundleImportRequest struct {
+	Mode   models.UnifiedPolicyImportMode `json:"mode" binding:"required"`
+	Bundle models.UnifiedPolicyBundle     `json:"bundle" binding:"required"`
+}
+
+// ImportBundle reconciles a UnifiedPolicyBundle's policies into this
+// storage, per the request's Mode (create_only, upsert, or replace).
+func (h *Handler) ImportBundle(c *gin.Context) {
+	var req unifiedBundleImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch req.Mode {
+	case models.UnifiedImportCreateOnly, models.UnifiedImportUpsert, models.UnifiedImportReplace:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid mode: %s", req.Mode)})
+		return
+	}
+
+	result, err := h.storage.ImportBundle(&req.Bundle, req.Mode)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CreateReplicationTarget registers a new peer gateway to push unified
+// policy bundles to.
+func (h *Handler) CreateReplicationTarget(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "unified replication is not configured"})
+		return
+	}
+
+	var target models.UnifiedReplicationTarget
+	if err := c.ShouldBindJSON(&target); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.replicationManager.CreateTarget(&target); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, target)
+}
+
+// ListReplicationTargets returns every configured unified replication
+// target.
+func (h *Handler) ListReplicationTargets(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "unified replication is not configured"})
+		return
+	}
+
+	targets := h.replicationManager.ListTargets()
+	c.JSON(http.StatusOK, gin.H{"targets": targets, "count": len(targets)})
+}
+
+// UpdateReplicationTarget replaces an existing unified replication target.
+func (h *Handler) UpdateReplicationTarget(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "unified replication is not configured"})
+		return
+	}
+
+	id := c.Param("id")
+	var target models.UnifiedReplicationTarget
+	if err := c.ShouldBindJSON(&target); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.replicationManager.UpdateTarget(id, &target); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+// DeleteReplicationTarget removes a unified replication target.
+func (h *Handler) DeleteReplicationTarget(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "unified replication is not configured"})
+		return
+	}
+
+	id := c.Param("id")
+	if err := h.replicationManager.DeleteTarget(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Unified replication target deleted"})
+}
+
+// TriggerReplicationTarget exports and pushes the bundle matching
+// target_id's filters immediately.
+func (h *Handler) TriggerReplicationTarget(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "unified replication is not configured"})
+		return
+	}
+
+	id := c.Param("target_id")
+	execution, err := h.replicationManager.TriggerTarget(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, execution)
+}
+
+// ListReplicationExecutions returns every recorded unified replication run,
+// plus a per-target success/failure summary.
+func (h *Handler) ListReplicationExecutions(c *gin.Context) {
+	if h.replicationManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "unified replication is not configured"})
+		return
+	}
+
+	executions := h.replicationManager.ListExecutions()
+	c.JSON(http.StatusOK, gin.H{
+		"executions": executions,
+		"summary":    h.replicationManager.Summary(),
+	})
+}
+
+// Reload reloads all policies from disk
+func (h *Handler) Reload(c *gin.Context) {
+	if err := h.storage.LoadAll(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	policies := h.storage.GetAll()
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Policies reloaded",
+		"count":   len(policies),
+	})
+}
+
+// ReloadOne reloads a single policy from disk into the in-memory cache (or
+// evicts it if the file was deleted). Called by peers in response to a
+// PeerNotifier event.
+func (h *Handler) ReloadOne(c *gin.Context) {
+	var event cluster.PolicyEvent
+	if err := c.ShouldBindJSON(&event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.ReloadOne(event.PolicyID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Policy reloaded", "policy_id": event.PolicyID})
+}
+
+// ClusterReloadAll triggers a full LoadAll on this node and every configured
+// cluster peer.
+func (h *Handler) ClusterReloadAll(c *gin.Context) {
+	if err := h.storage.LoadAll(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if h.notifier == nil {
+		c.JSON(http.StatusOK, gin.H{"message": "Policies reloaded locally, no cluster peers configured"})
+		return
+	}
+
+	if err := h.notifier.TriggerReloadAll(); err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "reloaded locally but one or more peers failed: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Policies reloaded across cluster", "peers": h.notifier.Peers()})
+}
+
+// ListSources reports the sync status of every configured external policy
+// source (see internal/reconcile), or an empty list if none are configured.
+func (h *Handler) ListSources(c *gin.Context) {
+	if h.reconciler == nil {
+		c.JSON(http.StatusOK, gin.H{"sources": []reconcile.SourceStatus{}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sources": h.reconciler.Statuses()})
+}
+
+// validatePolicyRules validates the policy rules DSL
+func validatePolicyRules(rules []models.PolicyRuleDSL) error {
+	for _, rule := range rules {
+		if rule.RuleID == "" {
+			return &ValidationError{Field: "rule_id", Message: "rule_id is required"}
+		}
+		if len(rule.Actions) == 0 {
+			return &ValidationError{Field: "actions", Message: "at least one action is required"}
+		}
+		for _, action := range rule.Actions {
+			if !isValidActionType(action.Type) {
+				return &ValidationError{Field: "action.type", Message: "invalid action type: " + string(action.Type)}
+			}
+		}
+		if rule.Conditions != nil {
+			if err := validateCondition(rule.Conditions); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateCondition(cond *models.RuleCondition) error {
+	// Check if it's a boolean composition
+	if len(cond.All) > 0 || len(cond.Any) > 0 {
+		for _, c := range cond.All {
+			if err := validateCondition(&c); err != nil {
+				return err
+			}
+		}
+		for _, c := range cond.Any {
+			if err := validateCondition(&c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// A leaf condition with Expression set bypasses Field/Operator/Value
+	// entirely - validate it compiles as CEL and stop, rather than also
+	// requiring a field/operator this style of leaf doesn't use.
+	if cond.Expression != "" {
+		if err := engine.ValidateConditionExpression(cond.Expression); err != nil {
+			return &ValidationError{Field: "expression", Message: err.Error()}
+		}
+		return nil
+	}
+
+	// It's a leaf condition
+	if cond.Field == "" && cond.Operator != models.RuleOpCEL {
+		return &ValidationError{Field: "field", Message: "field is required in condition"}
+	}
+	if !isValidOperator(cond.Operator) {
+		return &ValidationError{Field: "operator", Message: "invalid operator: " + string(cond.Operator)}
+	}
+	if err := engine.CompileRuleExpression(cond); err != nil {
+		return &ValidationError{Field: "value", Message: err.Error()}
+	}
+	return nil
+}
+
+func isValidActionType(t models.RuleActionType) bool {
+	switch t {
+	case models.RuleActionAllow, models.RuleActionDeny, models.RuleActionRedact,
+		models.RuleActionTransform, models.RuleActionAudit:
+		return true
+	}
+	return false
+}
+
+func isValidOperator(op models.RuleOperator) bool {
+	switch op {
+	case models.RuleOpEquals, models.RuleOpNotEquals, models.RuleOpContains,
+		models.RuleOpNotContains, models.RuleOpMatches, models.RuleOpIn,
+		models.RuleOpNotIn, models.RuleOpGt, models.RuleOpLt,
+		models.RuleOpGte, models.RuleOpLte, models.RuleOpExists, models.RuleOpNotExists,
+		models.RuleOpCEL, models.RuleOpJSONPath, models.RuleOpCIDR, models.RuleOpSemverRange:
+		return true
+	}
+	return engine.IsOperatorPluginRegistered(op)
 }
 
 // ValidationError represents a validation error
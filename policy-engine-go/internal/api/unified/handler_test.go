@@ -0,0 +1,148 @@
+package unified
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// stubVerifier is a jwtauth.Verifier test double that accepts a fixed set of
+// bearer tokens, each mapped to the identity it should resolve to - standing
+// in for a real JWKS-backed ProviderSet so these tests don't need to mint
+// signed JWTs.
+type stubVerifier struct {
+	identities map[string]string // token -> sub claim
+}
+
+func (v *stubVerifier) Verify(tokenString string) (map[string]interface{}, string, error) {
+	sub, ok := v.identities[tokenString]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown token")
+	}
+	return map[string]interface{}{"sub": sub}, "stub", nil
+}
+
+func newTestHandler(t *testing.T, verifier *stubVerifier) (*Handler, *storage.UnifiedStorage) {
+	t.Helper()
+	s, err := storage.NewUnifiedStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewUnifiedStorage: %v", err)
+	}
+	h := NewHandler(s)
+	if verifier != nil {
+		h.SetApprovalVerifier(verifier)
+	}
+	return h, s
+}
+
+func newTestRouter(h *Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h.RegisterRoutes(r.Group("/api/v1"))
+	return r
+}
+
+func postApproval(r *gin.Engine, policyID, bearer string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/unified/policies/"+policyID+"/approvals", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestAddPolicyApprovalRejectsUnauthenticatedCaller proves that a caller
+// with no Authorization header - or a made-up X-Actor header, the bypass
+// the previous fix left open - cannot record an approval at all, since
+// authenticatedActor no longer trusts X-Actor.
+func TestAddPolicyApprovalRejectsUnauthenticatedCaller(t *testing.T) {
+	verifier := &stubVerifier{identities: map[string]string{"token-a": "alice", "token-b": "bob"}}
+	h, s := newTestHandler(t, verifier)
+	r := newTestRouter(h)
+
+	policy, err := s.Create(&models.UnifiedPolicyCreateRequest{
+		PolicyCode: "test-policy",
+		Status:     models.PolicyStatusDraft,
+		OwnerID:    "owner",
+		OrgID:      "org-1",
+	}, "author")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/unified/policies/"+policy.PolicyID+"/approvals", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Actor", "spoofed-approver")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected a spoofed X-Actor header with no bearer token to be rejected with 401, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestAddPolicyApprovalQuorumResistsSingleCallerSpoofing proves that one
+// caller cannot satisfy a quorum of two by presenting two different bearer
+// tokens it doesn't actually hold valid credentials for - only tokens the
+// configured verifier actually accepts count as distinct approvers.
+func TestAddPolicyApprovalQuorumResistsSingleCallerSpoofing(t *testing.T) {
+	verifier := &stubVerifier{identities: map[string]string{"token-a": "alice"}}
+	h, s := newTestHandler(t, verifier)
+	r := newTestRouter(h)
+
+	policy, err := s.Create(&models.UnifiedPolicyCreateRequest{
+		PolicyCode: "test-policy",
+		Status:     models.PolicyStatusDraft,
+		OwnerID:    "owner",
+		OrgID:      "org-1",
+	}, "author")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if w := postApproval(r, policy.PolicyID, "token-a"); w.Code != http.StatusCreated {
+		t.Fatalf("expected a valid bearer token to record an approval, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// A second request with a bearer token the verifier doesn't recognize -
+	// i.e. an attacker guessing at a second identity - must be rejected
+	// rather than silently accepted as a second distinct approver.
+	if w := postApproval(r, policy.PolicyID, "made-up-token"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected an unverifiable bearer token to be rejected with 401, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if err := s.Activate(policy.PolicyID, "author"); err == nil {
+		t.Fatal("expected Activate to refuse a quorum of two satisfied by only one verified approver")
+	}
+}
+
+// TestApprovalEndpointsRefuseRequestsWithNoVerifierConfigured proves the
+// fail-closed default: until an operator configures JWT_PROVIDERS_FILE,
+// approval endpoints refuse every request rather than falling back to an
+// unauthenticated identity.
+func TestApprovalEndpointsRefuseRequestsWithNoVerifierConfigured(t *testing.T) {
+	h, s := newTestHandler(t, nil)
+	r := newTestRouter(h)
+
+	policy, err := s.Create(&models.UnifiedPolicyCreateRequest{
+		PolicyCode: "test-policy",
+		Status:     models.PolicyStatusDraft,
+		OwnerID:    "owner",
+		OrgID:      "org-1",
+	}, "author")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if w := postApproval(r, policy.PolicyID, "token-a"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected approvals to be refused with no verifier configured, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -1,21 +1,47 @@
 package users
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/datacline/policy-engine/internal/idp"
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/queryparams"
 	"github.com/datacline/policy-engine/internal/storage"
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 )
 
 // Handler handles users API requests
 type Handler struct {
 	storage *storage.UsersStorage
+	syncer  *idp.Syncer // nil when no IdP sources are configured
 }
 
 // NewHandler creates a new users handler
 func NewHandler(storage *storage.UsersStorage) *Handler {
-	return &Handler{storage: storage}
+	h := &Handler{storage: storage}
+	h.watchForChanges()
+	return h
+}
+
+// watchForChanges starts UsersStorage.Watch in the background so edits to
+// users.json on disk reach subsequent requests without a restart. Runs for
+// the process lifetime - the users API has no existing shutdown hook to
+// bind a context to - and simply logs if the watcher can't start.
+func (h *Handler) watchForChanges() {
+	if err := h.storage.Watch(context.Background()); err != nil {
+		log.WithError(err).Warn("Failed to start users data hot-reload watcher")
+	}
+}
+
+// SetSyncer attaches an IdP syncer, enabling the /principals/sources and
+// /principals/sync endpoints.
+func (h *Handler) SetSyncer(syncer *idp.Syncer) {
+	h.syncer = syncer
 }
 
 // RegisterRoutes registers the users API routes
@@ -27,21 +53,160 @@ func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 	r.GET("/users", h.ListUsers)
 	r.GET("/users/:id", h.GetUser)
 	r.GET("/users/search", h.SearchUsers)
+	r.POST("/users", h.CreateUser)
+	r.PUT("/users/:id", h.UpdateUser)
+	r.DELETE("/users/:id", h.DeleteUser)
+	r.POST("/users/:id/roles", h.AssignUserRole)
+	r.DELETE("/users/:id/roles/:roleId", h.RemoveUserRole)
+	r.GET("/users/:id/effective-permissions", h.GetEffectivePermissions)
+	r.POST("/users/:id/can", h.CanUser)
 
 	// Groups endpoints
 	r.GET("/groups", h.ListGroups)
 	r.GET("/groups/:id", h.GetGroup)
 	r.GET("/groups/:id/members", h.GetGroupMembers)
 	r.GET("/groups/search", h.SearchGroups)
+	r.POST("/groups", h.CreateGroup)
+	r.PUT("/groups/:id", h.UpdateGroup)
+	r.DELETE("/groups/:id", h.DeleteGroup)
+	r.POST("/groups/:id/members", h.AddGroupMember)
+	r.DELETE("/groups/:id/members/:userId", h.RemoveGroupMember)
 
 	// Roles endpoints
 	r.GET("/roles", h.ListRoles)
 	r.GET("/roles/:id", h.GetRole)
 	r.GET("/roles/:id/users", h.GetRoleUsers)
 	r.GET("/roles/search", h.SearchRoles)
+	r.POST("/roles", h.CreateRole)
+	r.PUT("/roles/:id", h.UpdateRole)
+	r.DELETE("/roles/:id", h.DeleteRole)
 
 	// Reload data
 	r.POST("/principals/reload", h.Reload)
+	r.POST("/admin/users/reload", h.Reload)
+	r.GET("/admin/users/reload/status", h.ReloadStatus)
+
+	// Bulk import/export
+	r.POST("/principals/import", h.ImportPrincipals)
+	r.GET("/principals/export", h.ExportPrincipals)
+
+	// IdP sync
+	r.GET("/principals/sources", h.ListSources)
+	r.POST("/principals/sources/:source/sync", h.SyncSource)
+}
+
+// actor resolves who is performing a mutation, from the X-Actor header
+func actor(c *gin.Context) string {
+	if a := c.GetHeader("X-Actor"); a != "" {
+		return a
+	}
+	return "unknown"
+}
+
+// applyListParams filters, sorts, paginates and projects a list of
+// principals according to the shared ?filter=/?sort=/?page=/?page_size=/
+// ?fields= query grammar, setting X-Total-Count and Link (RFC 5988)
+// response headers. items must be a slice of the concrete entity type
+// (e.g. []models.User), not []interface{}.
+func applyListParams(c *gin.Context, items interface{}) (interface{}, error) {
+	boxed, err := toInterfaceSlice(items)
+	if err != nil {
+		return nil, err
+	}
+
+	if filterExpr := c.Query("filter"); filterExpr != "" {
+		predicates, err := queryparams.ParseFilter(filterExpr)
+		if err != nil {
+			return nil, err
+		}
+		filtered := make([]interface{}, 0, len(boxed))
+		for _, item := range boxed {
+			if queryparams.Match(item, predicates) {
+				filtered = append(filtered, item)
+			}
+		}
+		boxed = filtered
+	}
+
+	if sortParam := c.Query("sort"); sortParam != "" {
+		field, desc := queryparams.ParseSort(sortParam)
+		queryparams.Sort(boxed, field, desc)
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	paged, total := queryparams.Paginate(boxed, page, pageSize)
+
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	setLinkHeader(c, page, pageSize, total)
+
+	if fieldsParam := c.Query("fields"); fieldsParam != "" {
+		fields := strings.Split(fieldsParam, ",")
+		return queryparams.Project(paged, fields), nil
+	}
+
+	return paged, nil
+}
+
+// setLinkHeader emits an RFC 5988 Link header with next/prev/first/last
+// relations when pagination parameters are present.
+func setLinkHeader(c *gin.Context, page, pageSize, total int) {
+	if pageSize <= 0 {
+		return
+	}
+	if page <= 0 {
+		page = 1
+	}
+	lastPage := (total + pageSize - 1) / pageSize
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	base := c.Request.URL.Path
+	query := c.Request.URL.Query()
+	linkFor := func(p int) string {
+		query.Set("page", strconv.Itoa(p))
+		query.Set("page_size", strconv.Itoa(pageSize))
+		return fmt.Sprintf("<%s?%s>", base, query.Encode())
+	}
+
+	var links []string
+	links = append(links, linkFor(1)+`; rel="first"`)
+	if page > 1 {
+		links = append(links, linkFor(page-1)+`; rel="prev"`)
+	}
+	if page < lastPage {
+		links = append(links, linkFor(page+1)+`; rel="next"`)
+	}
+	links = append(links, linkFor(lastPage)+`; rel="last"`)
+
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+// toInterfaceSlice boxes a typed slice (e.g. []models.User) into []interface{}.
+func toInterfaceSlice(items interface{}) ([]interface{}, error) {
+	switch v := items.(type) {
+	case []models.User:
+		result := make([]interface{}, len(v))
+		for i, u := range v {
+			result[i] = u
+		}
+		return result, nil
+	case []models.UserGroup:
+		result := make([]interface{}, len(v))
+		for i, g := range v {
+			result[i] = g
+		}
+		return result, nil
+	case []models.UserRole:
+		result := make([]interface{}, len(v))
+		for i, r := range v {
+			result[i] = r
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported list item type")
+	}
 }
 
 // GetAllPrincipals returns all users, groups, and roles
@@ -73,10 +238,13 @@ func (h *Handler) ListUsers(c *gin.Context) {
 		users = h.storage.GetAllUsers()
 	}
 
-	c.JSON(http.StatusOK, models.UsersResponse{
-		Users: users,
-		Count: len(users),
-	})
+	result, err := applyListParams(c, users)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": result, "count": len(users)})
 }
 
 // GetUser returns a user by ID
@@ -105,20 +273,26 @@ func (h *Handler) SearchUsers(c *gin.Context) {
 	query := c.Query("q")
 	users := h.storage.SearchUsers(query)
 
-	c.JSON(http.StatusOK, models.UsersResponse{
-		Users: users,
-		Count: len(users),
-	})
+	result, err := applyListParams(c, users)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": result, "count": len(users)})
 }
 
 // ListGroups returns all groups
 func (h *Handler) ListGroups(c *gin.Context) {
 	groups := h.storage.GetAllGroups()
 
-	c.JSON(http.StatusOK, models.GroupsResponse{
-		Groups: groups,
-		Count:  len(groups),
-	})
+	result, err := applyListParams(c, groups)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groups": result, "count": len(groups)})
 }
 
 // GetGroup returns a group by ID
@@ -165,20 +339,26 @@ func (h *Handler) SearchGroups(c *gin.Context) {
 	query := c.Query("q")
 	groups := h.storage.SearchGroups(query)
 
-	c.JSON(http.StatusOK, models.GroupsResponse{
-		Groups: groups,
-		Count:  len(groups),
-	})
+	result, err := applyListParams(c, groups)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"groups": result, "count": len(groups)})
 }
 
 // ListRoles returns all roles
 func (h *Handler) ListRoles(c *gin.Context) {
 	roles := h.storage.GetAllRoles()
 
-	c.JSON(http.StatusOK, models.RolesResponse{
-		Roles: roles,
-		Count: len(roles),
-	})
+	result, err := applyListParams(c, roles)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"roles": result, "count": len(roles)})
 }
 
 // GetRole returns a role by ID
@@ -225,13 +405,395 @@ func (h *Handler) SearchRoles(c *gin.Context) {
 	query := c.Query("q")
 	roles := h.storage.SearchRoles(query)
 
-	c.JSON(http.StatusOK, models.RolesResponse{
-		Roles: roles,
-		Count: len(roles),
+	result, err := applyListParams(c, roles)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"roles": result, "count": len(roles)})
+}
+
+// CreateUser creates a new user
+func (h *Handler) CreateUser(c *gin.Context) {
+	var user models.User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.CreateUser(actor(c), &user); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// UpdateUser updates an existing user
+func (h *Handler) UpdateUser(c *gin.Context) {
+	id := c.Param("id")
+
+	var user models.User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.UpdateUser(actor(c), id, &user); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser deletes a user
+func (h *Handler) DeleteUser(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.storage.DeleteUser(actor(c), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted", "user_id": id})
+}
+
+// AssignUserRole assigns a role to a user
+func (h *Handler) AssignUserRole(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.RoleAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.AssignUserRole(actor(c), id, req.RoleID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role assigned", "user_id": id, "role_id": req.RoleID})
+}
+
+// RemoveUserRole removes a role from a user
+func (h *Handler) RemoveUserRole(c *gin.Context) {
+	id := c.Param("id")
+	roleID := c.Param("roleId")
+
+	if err := h.storage.RemoveUserRole(actor(c), id, roleID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role removed", "user_id": id, "role_id": roleID})
+}
+
+// resolveEffectivePermissions walks a user's direct role plus every role
+// inherited from their group memberships, deduplicating permissions while
+// keeping track of which role (and, if inherited, which group) granted each
+// one. The first source found for a given permission wins.
+func (h *Handler) resolveEffectivePermissions(user *models.User) []models.EffectivePermission {
+	seen := make(map[string]bool)
+	var permissions []models.EffectivePermission
+
+	addFromRole := func(roleID, viaGroupID string) {
+		role := h.storage.GetRoleByID(roleID)
+		if role == nil {
+			return
+		}
+		for _, perm := range role.Permissions {
+			if seen[perm] {
+				continue
+			}
+			seen[perm] = true
+			permissions = append(permissions, models.EffectivePermission{
+				Permission: perm,
+				Source:     models.PermissionSource{RoleID: roleID, ViaGroupID: viaGroupID},
+			})
+		}
+	}
+
+	if user.Role != "" {
+		addFromRole(user.Role, "")
+	}
+
+	for _, groupID := range user.Groups {
+		group := h.storage.GetGroupByID(groupID)
+		if group == nil {
+			continue
+		}
+		for _, roleID := range group.Roles {
+			addFromRole(roleID, groupID)
+		}
+	}
+
+	return permissions
+}
+
+// GetEffectivePermissions returns every permission a user holds, whether
+// directly assigned or inherited via a group's roles.
+func (h *Handler) GetEffectivePermissions(c *gin.Context) {
+	id := c.Param("id")
+
+	user := h.storage.GetUserByID(id)
+	if user == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "User not found",
+			"user_id": id,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.EffectivePermissionsResponse{
+		UserID:      user.ID,
+		Permissions: h.resolveEffectivePermissions(user),
 	})
 }
 
-// Reload reloads the users data from disk
+// CanUser reports whether a user holds a given permission, and why.
+func (h *Handler) CanUser(c *gin.Context) {
+	id := c.Param("id")
+	permission := c.Query("permission")
+	if permission == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "permission query parameter is required"})
+		return
+	}
+
+	user := h.storage.GetUserByID(id)
+	if user == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "User not found",
+			"user_id": id,
+		})
+		return
+	}
+
+	var reasons []string
+	allowed := false
+	for _, ep := range h.resolveEffectivePermissions(user) {
+		if ep.Permission != permission {
+			continue
+		}
+		allowed = true
+		if ep.Source.ViaGroupID != "" {
+			reasons = append(reasons, fmt.Sprintf("granted by role %q via group %q", ep.Source.RoleID, ep.Source.ViaGroupID))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("granted by role %q", ep.Source.RoleID))
+		}
+	}
+	if !allowed {
+		reasons = append(reasons, fmt.Sprintf("no role grants permission %q", permission))
+	}
+
+	c.JSON(http.StatusOK, models.CanResponse{Allowed: allowed, Reasons: reasons})
+}
+
+// CreateGroup creates a new group
+func (h *Handler) CreateGroup(c *gin.Context) {
+	var group models.UserGroup
+	if err := c.ShouldBindJSON(&group); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.CreateGroup(actor(c), &group); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+// UpdateGroup updates an existing group
+func (h *Handler) UpdateGroup(c *gin.Context) {
+	id := c.Param("id")
+
+	var group models.UserGroup
+	if err := c.ShouldBindJSON(&group); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.UpdateGroup(actor(c), id, &group); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// DeleteGroup deletes a group
+func (h *Handler) DeleteGroup(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.storage.DeleteGroup(actor(c), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Group deleted", "group_id": id})
+}
+
+// AddGroupMember adds a user to a group
+func (h *Handler) AddGroupMember(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.GroupMembershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.AddGroupMember(actor(c), id, req.UserID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member added", "group_id": id, "user_id": req.UserID})
+}
+
+// RemoveGroupMember removes a user from a group
+func (h *Handler) RemoveGroupMember(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Param("userId")
+
+	if err := h.storage.RemoveGroupMember(actor(c), id, userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed", "group_id": id, "user_id": userID})
+}
+
+// CreateRole creates a new role
+func (h *Handler) CreateRole(c *gin.Context) {
+	var role models.UserRole
+	if err := c.ShouldBindJSON(&role); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.CreateRole(actor(c), &role); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// UpdateRole updates an existing role
+func (h *Handler) UpdateRole(c *gin.Context) {
+	id := c.Param("id")
+
+	var role models.UserRole
+	if err := c.ShouldBindJSON(&role); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.UpdateRole(actor(c), id, &role); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// DeleteRole deletes a role
+func (h *Handler) DeleteRole(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.storage.DeleteRole(actor(c), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role deleted", "role_id": id})
+}
+
+// ListSources returns the configured IdP sync sources and their last status
+func (h *Handler) ListSources(c *gin.Context) {
+	if h.syncer == nil {
+		c.JSON(http.StatusOK, gin.H{"sources": []idp.SourceStatus{}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sources": h.syncer.Statuses()})
+}
+
+// SyncSource triggers an immediate sync of a single named IdP source
+func (h *Handler) SyncSource(c *gin.Context) {
+	if h.syncer == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no IdP sources configured"})
+		return
+	}
+
+	source := c.Param("source")
+	if err := h.syncer.SyncOne(context.Background(), source); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "sync triggered", "source": source})
+}
+
+// ImportPrincipals bulk-imports users from a YAML, JSON, or CSV payload.
+// ?mode= selects upsert (default; create/overwrite, never delete), merge
+// (create/overlay only the fields present in each row), or replace (also
+// deletes existing users absent from the batch). ?dry_run=true computes and
+// returns the diff without touching storage. The whole batch is rejected if
+// any row fails validation (missing email, duplicate email, or references
+// to an unknown group/role).
+func (h *Handler) ImportPrincipals(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	mode := c.DefaultQuery("mode", "upsert")
+	if mode != "upsert" && mode != "merge" && mode != "replace" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be one of upsert, merge, replace"})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	incoming, err := decodeUsersPayload(format, body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	diff, err := h.storage.ImportUsers(actor(c), mode, incoming, dryRun)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "diff": diff})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// ExportPrincipals streams the current user dataset as YAML, JSON, or CSV.
+func (h *Handler) ExportPrincipals(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+
+	body, contentType, err := encodeUsersPayload(format, h.storage.GetAllUsers())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// Reload reloads the users data from disk. Registered at both
+// /principals/reload and /admin/users/reload - a manual fallback for
+// environments where the hot-reload watcher started by NewHandler hasn't
+// picked up an edit.
 func (h *Handler) Reload(c *gin.Context) {
 	if err := h.storage.LoadAll(); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -249,3 +811,9 @@ func (h *Handler) Reload(c *gin.Context) {
 		"roles":   len(data.Roles),
 	})
 }
+
+// ReloadStatus reports the users data hot-reload watcher's current state -
+// whether it's running, and the last change it saw, applied, or rejected.
+func (h *Handler) ReloadStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.storage.WatchStatus())
+}
@@ -0,0 +1,151 @@
+package users
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// usersCSVColumns is the documented CSV column set for principal import/export.
+var usersCSVColumns = []string{
+	"id", "email", "name", "first_name", "last_name", "role", "department",
+	"title", "groups", "status", "mfa_enabled", "created_at", "last_login",
+}
+
+// principalsPayload is the JSON/YAML import/export document shape; a bare
+// array of users is also accepted on import for convenience.
+type principalsPayload struct {
+	Users []models.User `json:"users" yaml:"users"`
+}
+
+// decodeUsersPayload parses a YAML, JSON, or CSV import body into users.
+func decodeUsersPayload(format string, body []byte) ([]models.User, error) {
+	switch format {
+	case "", "json":
+		var payload principalsPayload
+		if err := json.Unmarshal(body, &payload); err == nil && len(payload.Users) > 0 {
+			return payload.Users, nil
+		}
+		var users []models.User
+		if err := json.Unmarshal(body, &users); err != nil {
+			return nil, fmt.Errorf("invalid JSON import payload: %w", err)
+		}
+		return users, nil
+	case "yaml":
+		var payload principalsPayload
+		if err := yaml.Unmarshal(body, &payload); err == nil && len(payload.Users) > 0 {
+			return payload.Users, nil
+		}
+		var users []models.User
+		if err := yaml.Unmarshal(body, &users); err != nil {
+			return nil, fmt.Errorf("invalid YAML import payload: %w", err)
+		}
+		return users, nil
+	case "csv":
+		return decodeUsersCSV(body)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s", format)
+	}
+}
+
+// decodeUsersCSV parses a CSV body using the usersCSVColumns column set,
+// matched by header name so columns may appear in any order. groups is a
+// ";"-separated list of group IDs.
+func decodeUsersCSV(body []byte) ([]models.User, error) {
+	reader := csv.NewReader(strings.NewReader(string(body)))
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV import payload: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	col := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		col[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	get := func(row []string, name string) string {
+		idx, ok := col[name]
+		if !ok || idx >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[idx])
+	}
+
+	users := make([]models.User, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		mfaEnabled, _ := strconv.ParseBool(get(row, "mfa_enabled"))
+
+		var groups []string
+		if g := get(row, "groups"); g != "" {
+			for _, part := range strings.Split(g, ";") {
+				groups = append(groups, strings.TrimSpace(part))
+			}
+		}
+
+		users = append(users, models.User{
+			ID:         get(row, "id"),
+			Email:      get(row, "email"),
+			Name:       get(row, "name"),
+			FirstName:  get(row, "first_name"),
+			LastName:   get(row, "last_name"),
+			Role:       get(row, "role"),
+			Department: get(row, "department"),
+			Title:      get(row, "title"),
+			Groups:     groups,
+			Status:     get(row, "status"),
+			MFAEnabled: mfaEnabled,
+			CreatedAt:  get(row, "created_at"),
+			LastLogin:  get(row, "last_login"),
+		})
+	}
+	return users, nil
+}
+
+// encodeUsersPayload renders users as YAML, JSON, or CSV, returning the
+// body and its Content-Type.
+func encodeUsersPayload(format string, users []models.User) ([]byte, string, error) {
+	switch format {
+	case "", "json":
+		data, err := json.MarshalIndent(principalsPayload{Users: users}, "", "  ")
+		return data, "application/json", err
+	case "yaml":
+		data, err := yaml.Marshal(principalsPayload{Users: users})
+		return data, "application/x-yaml", err
+	case "csv":
+		data, err := encodeUsersCSV(users)
+		return data, "text/csv", err
+	default:
+		return nil, "", fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func encodeUsersCSV(users []models.User) ([]byte, error) {
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(usersCSVColumns); err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		row := []string{
+			u.ID, u.Email, u.Name, u.FirstName, u.LastName, u.Role, u.Department,
+			u.Title, strings.Join(u.Groups, ";"), u.Status, strconv.FormatBool(u.MFAEnabled),
+			u.CreatedAt, u.LastLogin,
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return []byte(buf.String()), nil
+}
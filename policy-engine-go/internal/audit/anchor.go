@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Anchor publishes the audit chain's current head (Seq, Hash) to an
+// external system - a blockchain, transparency log, or trusted timestamping
+// service - establishing a point a later reviewer can use to prove the
+// chain wasn't rewritten after the fact. This package only calls Publish;
+// it has no anchoring backend of its own.
+type Anchor interface {
+	Publish(seq int64, hash string, timestamp time.Time) error
+}
+
+// AnchorLoop calls anchor.Publish with l's current head every interval,
+// until ctx is canceled. A failed publish is logged, not returned - a
+// missed anchor shouldn't interrupt decision logging.
+func (l *Logger) AnchorLoop(ctx context.Context, anchor Anchor, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				l.mu.Lock()
+				seq, hash := l.seq, l.prevHash
+				l.mu.Unlock()
+				if seq == 0 {
+					continue
+				}
+				if err := anchor.Publish(seq, hash, time.Now()); err != nil {
+					log.WithError(err).Warn("Failed to publish audit chain anchor")
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// FileSink appends AuditDecisionRecords as newline-delimited JSON to a
+// single file - the simplest Sink, and the default when no external store
+// is configured. Every record ever appended is also kept in memory so
+// Query/Range/Head don't re-read the file, the same tradeoff
+// unifiedhistory.Store's per-policy History() makes by trading a bit of
+// memory for not re-scanning disk on every read.
+type FileSink struct {
+	mu      sync.Mutex
+	file    *os.File
+	records []*models.AuditDecisionRecord
+}
+
+// NewFileSink opens (or creates) path and replays its existing records into
+// memory, so a restarted process resumes with Query/Range/Head already
+// populated.
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	records, err := readFileSinkRecords(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+
+	return &FileSink{file: file, records: records}, nil
+}
+
+func readFileSinkRecords(path string) ([]*models.AuditDecisionRecord, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	defer f.Close()
+
+	var records []*models.AuditDecisionRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec models.AuditDecisionRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse audit log line: %w", err)
+		}
+		records = append(records, &rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log file: %w", err)
+	}
+	return records, nil
+}
+
+func (s *FileSink) Append(rec *models.AuditDecisionRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log file: %w", err)
+	}
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func (s *FileSink) Head() (*models.AuditDecisionRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.records) == 0 {
+		return nil, nil
+	}
+	return s.records[len(s.records)-1], nil
+}
+
+func (s *FileSink) Range(fromSeq, toSeq int64) ([]*models.AuditDecisionRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*models.AuditDecisionRecord
+	for _, rec := range s.records {
+		if rec.Seq >= fromSeq && rec.Seq <= toSeq {
+			result = append(result, rec)
+		}
+	}
+	return result, nil
+}
+
+func (s *FileSink) Query(filter models.AuditQueryFilter) ([]*models.AuditDecisionRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*models.AuditDecisionRecord
+	for _, rec := range s.records {
+		if matchesFilter(rec, filter) {
+			result = append(result, rec)
+		}
+	}
+	return result, nil
+}
+
+func matchesFilter(rec *models.AuditDecisionRecord, filter models.AuditQueryFilter) bool {
+	if filter.UserID != "" && rec.UserID != filter.UserID {
+		return false
+	}
+	if filter.ResourceType != "" && rec.ResourceType != filter.ResourceType {
+		return false
+	}
+	if filter.ResourceID != "" && rec.ResourceID != filter.ResourceID {
+		return false
+	}
+	if filter.From != nil && rec.Timestamp.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && rec.Timestamp.After(*filter.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// Producer publishes a message to a topic; satisfied by a thin adapter over
+// whichever Kafka client library the embedding binary already depends on.
+// This package deliberately doesn't import one itself, so enabling
+// KafkaSink doesn't pull a new external dependency into every build that
+// only wants FileSink or PostgresSink.
+type Producer interface {
+	Publish(topic string, key, value []byte) error
+}
+
+// KafkaSink publishes each AuditDecisionRecord as a JSON message keyed by
+// its Seq, for downstream consumers (a SIEM, a warehouse, a streaming
+// anchor job) to pick up. It is write-only: Kafka isn't a random-access
+// store, so Range/Query report that querying must go through whatever
+// durable store the downstream consumer writes to, and Head reports an
+// empty sink. KafkaSink is meant to
+// be paired with a FileSink or PostgresSink for the canonical chain and
+// GET /audit/verify, with Kafka mirroring records for real-time
+// consumption.
+type KafkaSink struct {
+	producer Producer
+	topic    string
+}
+
+// NewKafkaSink wraps producer, publishing every Append to topic.
+func NewKafkaSink(producer Producer, topic string) *KafkaSink {
+	return &KafkaSink{producer: producer, topic: topic}
+}
+
+func (k *KafkaSink) Append(rec *models.AuditDecisionRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	key := fmt.Sprintf("%d", rec.Seq)
+	if err := k.producer.Publish(k.topic, []byte(key), data); err != nil {
+		return fmt.Errorf("failed to publish audit record to kafka: %w", err)
+	}
+	return nil
+}
+
+// Head always reports an empty sink: Kafka is not a random-access store,
+// so a KafkaSink-only Logger starts a fresh chain on every restart rather
+// than failing outright. Pair with a durable Sink (FileSink/PostgresSink)
+// when chain continuity across restarts matters.
+func (k *KafkaSink) Head() (*models.AuditDecisionRecord, error) {
+	return nil, nil
+}
+
+func (k *KafkaSink) Range(fromSeq, toSeq int64) ([]*models.AuditDecisionRecord, error) {
+	return nil, fmt.Errorf("KafkaSink is write-only: query decisions from the downstream consumer's store, not the Kafka topic directly")
+}
+
+func (k *KafkaSink) Query(filter models.AuditQueryFilter) ([]*models.AuditDecisionRecord, error) {
+	return nil, fmt.Errorf("KafkaSink is write-only: query decisions from the downstream consumer's store, not the Kafka topic directly")
+}
@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// postgresSchema mirrors enhancedhistory.Store's normalization approach: one row
+// per record, with the decision and policy versions stored as JSON text
+// rather than further normalized, since a record is read whole and never
+// queried by an individual decision field.
+//
+// Placeholders use "?", the database/sql convention MySQL and SQLite
+// drivers expect natively; a Postgres *sql.DB must go through a driver that
+// rebinds "?" to "$N", the same caveat storage.SQLStore and
+// enhancedhistory.Store document.
+const postgresSchema = `
+CREATE TABLE IF NOT EXISTS audit_decision_records (
+	seq                  BIGINT PRIMARY KEY,
+	input_hash           VARCHAR(64)  NOT NULL,
+	user_id              VARCHAR(255),
+	resource_type        VARCHAR(64),
+	resource_id          VARCHAR(255),
+	decision_json        TEXT         NOT NULL,
+	policy_versions_json TEXT,
+	timestamp            TIMESTAMP    NOT NULL,
+	prev_hash            VARCHAR(64)  NOT NULL,
+	hash                 VARCHAR(64)  NOT NULL
+);
+`
+
+// PostgresSink persists AuditDecisionRecords in a SQL database reachable via db,
+// the durable counterpart to FileSink - the same pairing
+// enhancedhistory.Store is to EnhancedStorage's policy_history JSONL log.
+type PostgresSink struct {
+	db *sql.DB
+}
+
+// NewPostgresSink creates a PostgresSink against db, applying postgresSchema with
+// CREATE TABLE IF NOT EXISTS so it's safe to call against an
+// already-migrated database.
+func NewPostgresSink(db *sql.DB) (*PostgresSink, error) {
+	if _, err := db.Exec(postgresSchema); err != nil {
+		return nil, fmt.Errorf("failed to apply audit decision record schema: %w", err)
+	}
+	return &PostgresSink{db: db}, nil
+}
+
+func (s *PostgresSink) Append(rec *models.AuditDecisionRecord) error {
+	decisionJSON, err := json.Marshal(rec.Decision)
+	if err != nil {
+		return fmt.Errorf("failed to marshal decision: %w", err)
+	}
+	versionsJSON, err := json.Marshal(rec.PolicyVersions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy versions: %w", err)
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO audit_decision_records
+		(seq, input_hash, user_id, resource_type, resource_id, decision_json, policy_versions_json, timestamp, prev_hash, hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, rec.Seq, rec.InputHash, rec.UserID, string(rec.ResourceType), rec.ResourceID,
+		string(decisionJSON), string(versionsJSON), rec.Timestamp, rec.PrevHash, rec.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit record: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresSink) Head() (*models.AuditDecisionRecord, error) {
+	row := s.db.QueryRow(`
+		SELECT seq, input_hash, user_id, resource_type, resource_id, decision_json, policy_versions_json, timestamp, prev_hash, hash
+		FROM audit_decision_records ORDER BY seq DESC LIMIT 1
+	`)
+	rec, err := scanAuditRecord(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit sink head: %w", err)
+	}
+	return rec, nil
+}
+
+func (s *PostgresSink) Range(fromSeq, toSeq int64) ([]*models.AuditDecisionRecord, error) {
+	rows, err := s.db.Query(`
+		SELECT seq, input_hash, user_id, resource_type, resource_id, decision_json, policy_versions_json, timestamp, prev_hash, hash
+		FROM audit_decision_records WHERE seq >= ? AND seq <= ? ORDER BY seq ASC
+	`, fromSeq, toSeq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to range audit records: %w", err)
+	}
+	defer rows.Close()
+	return scanAuditRecords(rows)
+}
+
+func (s *PostgresSink) Query(filter models.AuditQueryFilter) ([]*models.AuditDecisionRecord, error) {
+	query := `
+		SELECT seq, input_hash, user_id, resource_type, resource_id, decision_json, policy_versions_json, timestamp, prev_hash, hash
+		FROM audit_decision_records WHERE 1=1
+	`
+	var args []interface{}
+	if filter.UserID != "" {
+		query += " AND user_id = ?"
+		args = append(args, filter.UserID)
+	}
+	if filter.ResourceType != "" {
+		query += " AND resource_type = ?"
+		args = append(args, string(filter.ResourceType))
+	}
+	if filter.ResourceID != "" {
+		query += " AND resource_id = ?"
+		args = append(args, filter.ResourceID)
+	}
+	if filter.From != nil {
+		query += " AND timestamp >= ?"
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		query += " AND timestamp <= ?"
+		args = append(args, *filter.To)
+	}
+	query += " ORDER BY seq ASC"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit records: %w", err)
+	}
+	defer rows.Close()
+	return scanAuditRecords(rows)
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows, so
+// scanAuditRecord can back both Head (one row) and Range/Query (many
+// rows).
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAuditRecord(row rowScanner) (*models.AuditDecisionRecord, error) {
+	var rec models.AuditDecisionRecord
+	var resourceType, decisionJSON, versionsJSON string
+	var userID, resourceID sql.NullString
+	if err := row.Scan(&rec.Seq, &rec.InputHash, &userID, &resourceType, &resourceID, &decisionJSON, &versionsJSON, &rec.Timestamp, &rec.PrevHash, &rec.Hash); err != nil {
+		return nil, err
+	}
+	rec.UserID = userID.String
+	rec.ResourceType = models.ResourceType(resourceType)
+	rec.ResourceID = resourceID.String
+	if err := json.Unmarshal([]byte(decisionJSON), &rec.Decision); err != nil {
+		return nil, fmt.Errorf("failed to parse decision: %w", err)
+	}
+	if versionsJSON != "" {
+		if err := json.Unmarshal([]byte(versionsJSON), &rec.PolicyVersions); err != nil {
+			return nil, fmt.Errorf("failed to parse policy versions: %w", err)
+		}
+	}
+	return &rec, nil
+}
+
+func scanAuditRecords(rows *sql.Rows) ([]*models.AuditDecisionRecord, error) {
+	var records []*models.AuditDecisionRecord
+	for rows.Next() {
+		rec, err := scanAuditRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
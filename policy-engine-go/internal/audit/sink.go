@@ -0,0 +1,30 @@
+// Package audit maintains a hash-chained, tamper-evident log of
+// PolicyDecisions, pluggable across storage backends the way
+// internal/ratelimit's Limiter is pluggable across rate-limiting
+// algorithms.
+package audit
+
+import "github.com/datacline/policy-engine/internal/models"
+
+// Sink persists AuditDecisionRecords in insertion order and serves them
+// back for GET /audit/decisions and GET /audit/verify. A Sink does not
+// compute or validate the hash chain itself - Logger does that before
+// calling Append - it only has to store and return records faithfully.
+type Sink interface {
+	// Append persists rec, which Logger has already chained via
+	// PrevHash/Hash. Must not reorder or drop records.
+	Append(rec *models.AuditDecisionRecord) error
+
+	// Head returns the most recently appended record, or nil if the sink
+	// is empty, so Logger can resume Seq/PrevHash correctly after a
+	// restart. A Sink that cannot answer this (KafkaSink) returns
+	// (nil, nil) and Logger starts a fresh chain.
+	Head() (*models.AuditDecisionRecord, error)
+
+	// Range returns records with Seq in [fromSeq, toSeq], ordered by Seq,
+	// for GET /audit/verify to walk.
+	Range(fromSeq, toSeq int64) ([]*models.AuditDecisionRecord, error)
+
+	// Query returns records matching filter, for GET /audit/decisions.
+	Query(filter models.AuditQueryFilter) ([]*models.AuditDecisionRecord, error)
+}
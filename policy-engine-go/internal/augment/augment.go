@@ -0,0 +1,103 @@
+// Package augment enriches a PolicyEvaluationRequest with context pulled
+// from external services before it reaches the evaluation engine, similar to
+// Romana's augmentPolicy pattern of attaching datacenter/topology metadata to
+// a policy before enforcement. Built-in Augmenters fetch things like
+// subject.groups from LDAP or resource.tags from an HTTP/OPA data endpoint;
+// Pipeline runs the enabled ones, merges their fields into the request's
+// Context, caches each provider's result for its own TTL, and reports
+// per-provider failures instead of failing the whole request.
+package augment
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// Augmenter looks up additional context for req and returns fields to merge
+// into it, keyed the way the caller expects them to appear under Context
+// (e.g. "subject.groups", "resource.tags", "env.region").
+type Augmenter interface {
+	Name() string
+	Augment(req *models.PolicyEvaluationRequest) (map[string]interface{}, error)
+	TTL() time.Duration
+}
+
+// Pipeline runs a set of Augmenters against incoming requests, caching each
+// provider's result for its configured TTL.
+type Pipeline struct {
+	augmenters []Augmenter
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	fields    map[string]interface{}
+	expiresAt time.Time
+}
+
+// NewPipeline creates a Pipeline running augmenters in the given order.
+func NewPipeline(augmenters ...Augmenter) *Pipeline {
+	return &Pipeline{
+		augmenters: augmenters,
+		cache:      make(map[string]cacheEntry),
+	}
+}
+
+// Run executes every augmenter against req, merging their fields directly
+// into req.Context (initializing it if nil). It returns one error message
+// per augmenter that failed, in augmenter order, so the engine can still
+// evaluate defensively (e.g. deny when enrichment fails) rather than
+// aborting the request outright.
+func (p *Pipeline) Run(req *models.PolicyEvaluationRequest) []string {
+	if len(p.augmenters) == 0 {
+		return nil
+	}
+	if req.Context == nil {
+		req.Context = make(map[string]interface{})
+	}
+
+	var errs []string
+	for _, a := range p.augmenters {
+		fields, err := p.augment(a, req)
+		if err != nil {
+			log.WithError(err).WithField("augmenter", a.Name()).Warn("Augmenter failed; continuing without its fields")
+			errs = append(errs, fmt.Sprintf("%s: %v", a.Name(), err))
+			continue
+		}
+		for k, v := range fields {
+			req.Context[k] = v
+		}
+	}
+	return errs
+}
+
+func (p *Pipeline) augment(a Augmenter, req *models.PolicyEvaluationRequest) (map[string]interface{}, error) {
+	key := cacheKey(a.Name(), req)
+
+	p.mu.Lock()
+	entry, ok := p.cache[key]
+	p.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.fields, nil
+	}
+
+	fields, err := a.Augment(req)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.cache[key] = cacheEntry{fields: fields, expiresAt: time.Now().Add(a.TTL())}
+	p.mu.Unlock()
+
+	return fields, nil
+}
+
+func cacheKey(name string, req *models.PolicyEvaluationRequest) string {
+	return fmt.Sprintf("%s|%s|%s", name, req.User, req.Resource)
+}
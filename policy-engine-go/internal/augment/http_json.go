@@ -0,0 +1,67 @@
+package augment
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// HTTPJSONAugmenter fetches a JSON object from BaseURL+"/"+req.Resource (or
+// req.User when Resource is empty) and merges it into the request under
+// FieldPrefix, e.g. a response of {"tags": ["prod"]} with FieldPrefix
+// "resource." becomes Context["resource.tags"] = ["prod"].
+type HTTPJSONAugmenter struct {
+	NameTag     string
+	BaseURL     string
+	FieldPrefix string
+	CacheTTL    time.Duration
+	httpClient  *http.Client
+}
+
+// NewHTTPJSONAugmenter creates an HTTPJSONAugmenter with a 5s request timeout.
+func NewHTTPJSONAugmenter(name, baseURL, fieldPrefix string, cacheTTL time.Duration) *HTTPJSONAugmenter {
+	return &HTTPJSONAugmenter{
+		NameTag:     name,
+		BaseURL:     baseURL,
+		FieldPrefix: fieldPrefix,
+		CacheTTL:    cacheTTL,
+		httpClient:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (a *HTTPJSONAugmenter) Name() string { return a.NameTag }
+
+func (a *HTTPJSONAugmenter) TTL() time.Duration { return a.CacheTTL }
+
+func (a *HTTPJSONAugmenter) Augment(req *models.PolicyEvaluationRequest) (map[string]interface{}, error) {
+	subject := req.Resource
+	if subject == "" {
+		subject = req.User
+	}
+
+	url := strings.TrimRight(a.BaseURL, "/") + "/" + subject
+	resp, err := a.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch augmentation data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("augmentation endpoint returned status %d", resp.StatusCode)
+	}
+
+	var data map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode augmentation response: %w", err)
+	}
+
+	fields := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		fields[a.FieldPrefix+k] = v
+	}
+	return fields, nil
+}
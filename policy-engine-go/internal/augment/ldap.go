@@ -0,0 +1,76 @@
+package augment
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// LDAPGroupAugmenter looks up the groups req.User belongs to via an LDAP
+// memberOf-style search and merges them into Context["subject.groups"].
+type LDAPGroupAugmenter struct {
+	NameTag      string
+	Addr         string // e.g. ldap://dc1.example.com:389
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	// UserFilter is an LDAP filter template with "%s" substituted for the
+	// request's User, e.g. "(&(objectClass=user)(sAMAccountName=%s))".
+	UserFilter string
+	GroupAttr  string // defaults to "memberOf"
+	CacheTTL   time.Duration
+}
+
+// NewLDAPGroupAugmenter creates an LDAPGroupAugmenter, defaulting GroupAttr
+// to "memberOf" when unset.
+func NewLDAPGroupAugmenter(name, addr, bindDN, bindPassword, baseDN, userFilter string, cacheTTL time.Duration) *LDAPGroupAugmenter {
+	return &LDAPGroupAugmenter{
+		NameTag:      name,
+		Addr:         addr,
+		BindDN:       bindDN,
+		BindPassword: bindPassword,
+		BaseDN:       baseDN,
+		UserFilter:   userFilter,
+		GroupAttr:    "memberOf",
+		CacheTTL:     cacheTTL,
+	}
+}
+
+func (a *LDAPGroupAugmenter) Name() string { return a.NameTag }
+
+func (a *LDAPGroupAugmenter) TTL() time.Duration { return a.CacheTTL }
+
+func (a *LDAPGroupAugmenter) Augment(req *models.PolicyEvaluationRequest) (map[string]interface{}, error) {
+	conn, err := ldap.DialURL(a.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+	}
+	defer conn.Close()
+
+	if a.BindDN != "" {
+		if err := conn.Bind(a.BindDN, a.BindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind to LDAP server: %w", err)
+		}
+	}
+
+	searchReq := ldap.NewSearchRequest(
+		a.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf(a.UserFilter, ldap.EscapeFilter(req.User)),
+		[]string{a.GroupAttr},
+		nil,
+	)
+
+	result, err := conn.Search(searchReq)
+	if err != nil {
+		return nil, fmt.Errorf("LDAP group search failed: %w", err)
+	}
+	if len(result.Entries) == 0 {
+		return map[string]interface{}{"subject.groups": []string{}}, nil
+	}
+
+	groups := result.Entries[0].GetAttributeValues(a.GroupAttr)
+	return map[string]interface{}{"subject.groups": groups}, nil
+}
@@ -0,0 +1,73 @@
+package augment
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// OPADataAugmenter reads an OPA data document at BaseURL+"/v1/data/"+Path
+// (the standard OPA REST Data API) and merges its "result" into the request
+// under FieldPrefix, e.g. Path "env/region" with FieldPrefix "env." becomes
+// Context["env.region"].
+type OPADataAugmenter struct {
+	NameTag     string
+	BaseURL     string
+	Path        string
+	FieldPrefix string
+	CacheTTL    time.Duration
+	httpClient  *http.Client
+}
+
+// NewOPADataAugmenter creates an OPADataAugmenter with a 5s request timeout.
+func NewOPADataAugmenter(name, baseURL, path, fieldPrefix string, cacheTTL time.Duration) *OPADataAugmenter {
+	return &OPADataAugmenter{
+		NameTag:     name,
+		BaseURL:     baseURL,
+		Path:        path,
+		FieldPrefix: fieldPrefix,
+		CacheTTL:    cacheTTL,
+		httpClient:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (a *OPADataAugmenter) Name() string { return a.NameTag }
+
+func (a *OPADataAugmenter) TTL() time.Duration { return a.CacheTTL }
+
+type opaDataResponse struct {
+	Result interface{} `json:"result"`
+}
+
+func (a *OPADataAugmenter) Augment(req *models.PolicyEvaluationRequest) (map[string]interface{}, error) {
+	url := strings.TrimRight(a.BaseURL, "/") + "/v1/data/" + strings.TrimLeft(a.Path, "/")
+	resp, err := a.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OPA data document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OPA data endpoint returned status %d", resp.StatusCode)
+	}
+
+	var data opaDataResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode OPA data response: %w", err)
+	}
+
+	fieldName := a.FieldPrefix + lastSegment(a.Path)
+	return map[string]interface{}{fieldName: data.Result}, nil
+}
+
+func lastSegment(path string) string {
+	path = strings.TrimRight(path, "/")
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
@@ -0,0 +1,169 @@
+// Package chain compiles loaded policies into an IAM/FrostFS-APE-style
+// ordered Chain of Allow/AccessDenied entries, evaluated first-match-wins
+// with an explicit default-deny fallback. It is an alternative to the
+// engine package's default "highest scope/priority score wins" evaluation,
+// selected per-policy or globally via engine.EvaluationMode, for admins who
+// find an ordered chain easier to reason about than a scored one.
+package chain
+
+import (
+	"sort"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// Status is the outcome a matched chain Entry applies.
+type Status string
+
+const (
+	// Allow means the request is permitted.
+	Allow Status = "Allow"
+	// AccessDenied means the request is blocked.
+	AccessDenied Status = "AccessDenied"
+	// NoRuleFound is reported only for the chain's synthetic final trace
+	// entry when nothing matched - the default-deny fallback.
+	NoRuleFound Status = "NoRuleFound"
+)
+
+// Operator is the IAM-recognizable name recorded for a compiled Condition,
+// translated from the underlying models.Condition (and its Inverted flag)
+// by Compile so a /policies/simulate trace reads in terms an admin coming
+// from AWS IAM or FrostFS APE already knows.
+type Operator string
+
+const (
+	OpStringEquals    Operator = "StringEquals"
+	OpStringNotEquals Operator = "StringNotEquals"
+	OpStringLike      Operator = "StringLike"
+	OpStringNotLike   Operator = "StringNotLike"
+	OpNumericCompare  Operator = "NumericCompare"
+	OpOther           Operator = "Other"
+)
+
+// Condition is one compiled, display-friendly condition of a chain Entry.
+// Source retains the original condition so Evaluate applies its exact
+// matching semantics instead of re-deriving them from Operator.
+type Condition struct {
+	Field    string      `json:"field"`
+	Operator Operator    `json:"operator"`
+	Value    interface{} `json:"value"`
+	Source   models.Condition
+}
+
+// Entry is one compiled, ordered step of a Chain, corresponding to a single
+// Conditions-based rule of a policy. Rego rules have no fixed Conditions
+// list to compile ahead of time and are skipped by Compile; a policy that
+// needs chain-mode semantics should use plain Conditions/Actions rules.
+type Entry struct {
+	PolicyID   string      `json:"policy_id"`
+	PolicyName string      `json:"policy_name"`
+	RuleID     string      `json:"rule_id"`
+	Status     Status      `json:"status"`
+	Conditions []Condition `json:"conditions"`
+	// Resources lists the literal values of every ConditionTypeResource
+	// condition on this rule, IAM-Resource-style.
+	Resources []string `json:"resources,omitempty"`
+	// Actions lists this rule's action types, IAM-Action-style (note: this
+	// is the policy_rules Action, unrelated to ConditionTypeTool, which IAM
+	// calls "Action" for a different reason - the MCP tool being called).
+	Actions []string `json:"actions,omitempty"`
+}
+
+// Chain is an ordered, first-match-wins list of compiled Entries.
+type Chain []Entry
+
+// Compile builds a Chain from policies, ordered narrowest-scope-first (so a
+// principal policy's entries are checked before a tenant or global policy's,
+// mirroring the priority engine's scope precedence) and, within a policy, in
+// rule-priority-descending order. Disabled policies and Rego rules are
+// skipped.
+func Compile(policies []*models.Policy) Chain {
+	ordered := make([]*models.Policy, 0, len(policies))
+	for _, p := range policies {
+		if p.Enabled {
+			ordered = append(ordered, p)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return models.ScopePrecedence(ordered[i].EffectiveScope()) > models.ScopePrecedence(ordered[j].EffectiveScope())
+	})
+
+	var c Chain
+	for _, policy := range ordered {
+		rules := make([]models.PolicyRule, len(policy.Rules))
+		copy(rules, policy.Rules)
+		sort.SliceStable(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
+
+		for _, rule := range rules {
+			if rule.IsRego() {
+				continue
+			}
+			c = append(c, compileRule(policy, &rule))
+		}
+	}
+	return c
+}
+
+func compileRule(policy *models.Policy, rule *models.PolicyRule) Entry {
+	entry := Entry{
+		PolicyID:   policy.ID,
+		PolicyName: policy.Name,
+		RuleID:     rule.ID,
+		Status:     statusForRule(rule),
+	}
+
+	for _, cond := range rule.Conditions {
+		entry.Conditions = append(entry.Conditions, compileCondition(cond))
+		if cond.Type == models.ConditionTypeResource {
+			if s, ok := cond.Value.(string); ok {
+				entry.Resources = append(entry.Resources, s)
+			}
+		}
+	}
+	for _, action := range rule.Actions {
+		entry.Actions = append(entry.Actions, string(action.Type))
+	}
+	return entry
+}
+
+// compileCondition translates cond into its IAM-recognizable Operator,
+// applying NotPrincipal/NotAction semantics when cond.Inverted is set on a
+// ConditionTypeUser or ConditionTypeTool condition.
+func compileCondition(cond models.Condition) Condition {
+	op := OpOther
+	switch cond.Operator {
+	case models.OperatorEq:
+		op = OpStringEquals
+	case models.OperatorMatches, models.OperatorContains:
+		op = OpStringLike
+	case models.OperatorGt, models.OperatorLt, models.OperatorGte, models.OperatorLte:
+		op = OpNumericCompare
+	}
+
+	if cond.Inverted && (cond.Type == models.ConditionTypeUser || cond.Type == models.ConditionTypeTool) {
+		switch op {
+		case OpStringEquals:
+			op = OpStringNotEquals
+		case OpStringLike:
+			op = OpStringNotLike
+		}
+	}
+
+	return Condition{Field: cond.Field, Operator: op, Value: cond.Value, Source: cond}
+}
+
+// statusForRule reports the chain Status a rule's first action implies: a
+// Deny or RequireApproval action denies access, same as ShouldBlock
+// elsewhere in the engine; anything else (including no actions at all, or
+// an Allow/LogOnly/Modify/Redact/RateLimit action) allows it.
+func statusForRule(rule *models.PolicyRule) Status {
+	if len(rule.Actions) == 0 {
+		return Allow
+	}
+	switch rule.Actions[0].Type {
+	case models.ActionDeny, models.ActionRequireApproval:
+		return AccessDenied
+	default:
+		return Allow
+	}
+}
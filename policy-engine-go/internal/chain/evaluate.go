@@ -0,0 +1,306 @@
+package chain
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/labels"
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// TraceEntry records how one Entry was treated while evaluating a request,
+// for POST /policies/simulate's full ordered trace.
+type TraceEntry struct {
+	PolicyID   string `json:"policy_id"`
+	PolicyName string `json:"policy_name"`
+	RuleID     string `json:"rule_id"`
+	Status     Status `json:"status"`
+	// Matched reports whether every one of the entry's Conditions matched
+	// the simulated request.
+	Matched bool `json:"matched"`
+	// Outcome is "decisive" for the first matched entry (the one whose
+	// Status decided the request), "skipped" for every entry checked before
+	// it, and "default-deny" for the synthetic final entry appended when
+	// nothing in the Chain matched.
+	Outcome string `json:"outcome"`
+}
+
+// Result is a chain evaluation's outcome: the decisive Entry (nil when
+// nothing matched, meaning the default-deny fallback applied) plus the full
+// ordered Trace of every Entry considered.
+type Result struct {
+	Decision Status       `json:"decision"`
+	PolicyID string       `json:"policy_id,omitempty"`
+	RuleID   string       `json:"rule_id,omitempty"`
+	Trace    []TraceEntry `json:"trace"`
+}
+
+// Evaluate walks c in order and returns the first Entry whose Conditions
+// all match req (first-match-wins), alongside the full trace of every Entry
+// checked. An Entry with no Conditions always matches. If nothing matches,
+// Result.Decision is AccessDenied per the chain's explicit default-deny
+// fallback, and the trace's final entry reports NoRuleFound.
+func Evaluate(c Chain, req *models.PolicyEvaluationRequest) *Result {
+	trace := make([]TraceEntry, 0, len(c)+1)
+
+	for _, entry := range c {
+		matched := conditionsMatch(entry.Conditions, req)
+		te := TraceEntry{
+			PolicyID:   entry.PolicyID,
+			PolicyName: entry.PolicyName,
+			RuleID:     entry.RuleID,
+			Status:     entry.Status,
+			Matched:    matched,
+		}
+		if matched {
+			te.Outcome = "decisive"
+			trace = append(trace, te)
+			return &Result{Decision: entry.Status, PolicyID: entry.PolicyID, RuleID: entry.RuleID, Trace: trace}
+		}
+		te.Outcome = "skipped"
+		trace = append(trace, te)
+	}
+
+	trace = append(trace, TraceEntry{Status: NoRuleFound, Outcome: "default-deny"})
+	return &Result{Decision: AccessDenied, Trace: trace}
+}
+
+func conditionsMatch(conditions []Condition, req *models.PolicyEvaluationRequest) bool {
+	for _, cond := range conditions {
+		if !conditionMatches(cond, req) {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionMatches applies cond.Source's exact semantics (including
+// Inverted), covering every ConditionOperator the priority engine supports
+// except ConditionTypeRate (no meaning outside the priority engine's
+// stateful rate limiter), ConditionTypeJWT (no jwtauth.Verifier wiring in
+// chain mode), and OperatorNameConstraints (its Value is a structured
+// allow/deny object, not a scalar compareValues can compare); all three
+// always fail to match here. ConditionTypeLabels is stateless and fully
+// supported - see labelsConditionMatches.
+func conditionMatches(cond Condition, req *models.PolicyEvaluationRequest) bool {
+	src := cond.Source
+	if src.Type == models.ConditionTypeRate {
+		log.Warn("ConditionTypeRate is not supported in chain evaluation mode; treating as non-matching")
+		return false
+	}
+	if src.Type == models.ConditionTypeJWT {
+		log.Warn("ConditionTypeJWT is not supported in chain evaluation mode; treating as non-matching")
+		return false
+	}
+	if src.Type == models.ConditionTypeResource && src.Operator == models.OperatorNameConstraints {
+		log.Warn("OperatorNameConstraints is not supported in chain evaluation mode; treating as non-matching")
+		return false
+	}
+	if src.Type == models.ConditionTypeLabels {
+		return labelsConditionMatches(src, req)
+	}
+
+	actual := fieldValue(src, req)
+
+	var matched bool
+	if src.Type == models.ConditionTypeTime {
+		matched = compareTime(actual, src.Value, src.Operator)
+	} else {
+		matched = compareValues(actual, src.Value, src.Operator)
+	}
+
+	if src.Inverted && (src.Type == models.ConditionTypeUser || src.Type == models.ConditionTypeTool) {
+		return !matched
+	}
+	return matched
+}
+
+// labelsConditionMatches evaluates a ConditionTypeLabels condition the same
+// way engine.evaluateLabelsCondition does: cond.Field selects UserLabels or
+// ResourceLabels, and cond.Value (a label map or selector expression
+// string) is matched via labels.MatchValue.
+func labelsConditionMatches(cond models.Condition, req *models.PolicyEvaluationRequest) bool {
+	var target map[string]string
+	switch cond.Field {
+	case "user":
+		target = req.UserLabels
+	case "resource":
+		target = req.ResourceLabels
+	default:
+		log.WithField("field", cond.Field).Warn("ConditionTypeLabels field must be \"user\" or \"resource\"")
+		return false
+	}
+
+	matched, err := labels.MatchValue(cond.Value, target)
+	if err != nil {
+		log.WithError(err).Warn("Invalid labels condition value")
+		return false
+	}
+	return matched
+}
+
+func fieldValue(cond models.Condition, req *models.PolicyEvaluationRequest) interface{} {
+	var base interface{}
+	switch cond.Type {
+	case models.ConditionTypeUser:
+		base = req.User
+	case models.ConditionTypeTool:
+		base = req.Tool
+	case models.ConditionTypeResource:
+		base = req.Resource
+	case models.ConditionTypeData:
+		if req.Parameters != nil {
+			return req.Parameters[cond.Field]
+		}
+		return nil
+	case models.ConditionTypeTime:
+		if req.Timestamp != nil {
+			return *req.Timestamp
+		}
+		return time.Now()
+	default:
+		return nil
+	}
+
+	if cond.Field == "" {
+		return base
+	}
+	if req.Context != nil {
+		if val, ok := req.Context[cond.Field]; ok {
+			return val
+		}
+	}
+	return base
+}
+
+func compareValues(actual, expected interface{}, op models.ConditionOperator) bool {
+	switch op {
+	case models.OperatorEq:
+		return reflect.DeepEqual(actual, expected)
+	case models.OperatorNeq:
+		return !reflect.DeepEqual(actual, expected)
+	case models.OperatorIn:
+		if expectedSlice, ok := expected.([]interface{}); ok {
+			for _, v := range expectedSlice {
+				if reflect.DeepEqual(actual, v) {
+					return true
+				}
+			}
+		}
+		return false
+	case models.OperatorNotIn:
+		if expectedSlice, ok := expected.([]interface{}); ok {
+			for _, v := range expectedSlice {
+				if reflect.DeepEqual(actual, v) {
+					return false
+				}
+			}
+			return true
+		}
+		return false
+	case models.OperatorGt, models.OperatorLt, models.OperatorGte, models.OperatorLte:
+		return compareNumeric(actual, expected, op)
+	case models.OperatorMatches:
+		if actualStr, ok := actual.(string); ok {
+			if pattern, ok := expected.(string); ok {
+				matched, err := regexp.MatchString(pattern, actualStr)
+				return err == nil && matched
+			}
+		}
+		return false
+	case models.OperatorContains:
+		if actualStr, ok := actual.(string); ok {
+			if substr, ok := expected.(string); ok {
+				return strings.Contains(actualStr, substr)
+			}
+		}
+		return false
+	default:
+		log.WithField("operator", op).Warn("Unknown operator in chain evaluation")
+		return false
+	}
+}
+
+func compareNumeric(actual, expected interface{}, op models.ConditionOperator) bool {
+	actualFloat, actualOk := toFloat64(actual)
+	expectedFloat, expectedOk := toFloat64(expected)
+	if !actualOk || !expectedOk {
+		return false
+	}
+
+	switch op {
+	case models.OperatorGt:
+		return actualFloat > expectedFloat
+	case models.OperatorLt:
+		return actualFloat < expectedFloat
+	case models.OperatorGte:
+		return actualFloat >= expectedFloat
+	case models.OperatorLte:
+		return actualFloat <= expectedFloat
+	default:
+		return false
+	}
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case float32:
+		return float64(val), true
+	case int:
+		return float64(val), true
+	case int32:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	default:
+		return 0, false
+	}
+}
+
+func compareTime(actual, expected interface{}, op models.ConditionOperator) bool {
+	actualTime, ok := asTime(actual)
+	if !ok {
+		return false
+	}
+	expectedTime, ok := asTime(expected)
+	if !ok {
+		return false
+	}
+
+	switch op {
+	case models.OperatorEq:
+		return actualTime.Equal(expectedTime)
+	case models.OperatorNeq:
+		return !actualTime.Equal(expectedTime)
+	case models.OperatorGt:
+		return actualTime.After(expectedTime)
+	case models.OperatorLt:
+		return actualTime.Before(expectedTime)
+	case models.OperatorGte:
+		return !actualTime.Before(expectedTime)
+	case models.OperatorLte:
+		return !actualTime.After(expectedTime)
+	default:
+		return false
+	}
+}
+
+func asTime(v interface{}) (time.Time, bool) {
+	switch val := v.(type) {
+	case time.Time:
+		return val, true
+	case string:
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
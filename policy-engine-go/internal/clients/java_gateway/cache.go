@@ -0,0 +1,75 @@
+package java_gateway
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEntry is one cached response body plus the ETag the Java gateway
+// returned alongside it, so a refresh past ttl can send If-None-Match and
+// skip re-transferring (and re-decoding) an unchanged body.
+type cacheEntry struct {
+	body      []byte
+	etag      string
+	fetchedAt time.Time
+}
+
+// ttlCache is the short-lived in-memory cache ListServers/ListTools/
+// GetServerInfo share, keyed by request URL. A ttl of 0 disables caching
+// outright (get always reports a miss, set is a no-op) rather than making
+// every call site branch on whether caching is configured.
+type ttlCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{ttl: ttl, entries: make(map[string]cacheEntry)}
+}
+
+// get returns the cached entry for key and whether it's still within ttl.
+// An entry past ttl is still returned with fresh=false (rather than
+// dropped) so the caller can send its ETag with If-None-Match instead of
+// throwing away a perfectly good body just because it's due for
+// revalidation.
+func (c *ttlCache) get(key string) (entry cacheEntry, fresh bool) {
+	if c.ttl <= 0 {
+		return cacheEntry{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[key]
+	if !found {
+		return cacheEntry{}, false
+	}
+	return entry, time.Since(entry.fetchedAt) < c.ttl
+}
+
+// set stores entry for key, stamping fetchedAt to now.
+func (c *ttlCache) set(key string, entry cacheEntry) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.fetchedAt = time.Now()
+	c.entries[key] = entry
+}
+
+// touch refreshes key's fetchedAt without changing its body/etag, for a
+// 304 Not Modified response that confirms the cached body is still good.
+func (c *ttlCache) touch(key string) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, found := c.entries[key]; found {
+		entry.fetchedAt = time.Now()
+		c.entries[key] = entry
+	}
+}
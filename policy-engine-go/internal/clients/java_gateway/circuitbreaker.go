@@ -0,0 +1,108 @@
+package java_gateway
+
+import (
+	"sync"
+	"time"
+)
+
+// breakerState is the same gobreaker-style closed/open/half-open state
+// machine pkg/client's circuitBreaker uses, duplicated here rather than
+// exported and shared since that type is deliberately unexported there.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker trips to Open after failureThreshold consecutive failures
+// (5xx responses, timeouts, or connection errors), and stays there until
+// resetTimeout elapses, at which point a single call is let through to
+// probe recovery.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	state            breakerState
+	failures         int
+	failureThreshold int
+	resetTimeout     time.Duration
+	openedAt         time.Time
+}
+
+func newCircuitBreaker(failureThreshold int, resetTimeout time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+	}
+}
+
+// allow reports whether a call should be attempted, transitioning Open to
+// HalfOpen once resetTimeout has elapsed.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		// The single probe is already in flight.
+		return false
+	}
+	if b.state != breakerOpen {
+		return true
+	}
+	if time.Since(b.openedAt) < b.resetTimeout {
+		return false
+	}
+	b.state = breakerHalfOpen
+	return true
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.state = breakerClosed
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.failureThreshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// breakerRegistry hands out one circuitBreaker per endpoint name (e.g.
+// "list-servers", "list-tools"), so a Java gateway endpoint that's failing
+// doesn't trip the breaker for sibling endpoints that are still healthy.
+type breakerRegistry struct {
+	mu               sync.Mutex
+	breakers         map[string]*circuitBreaker
+	failureThreshold int
+	resetTimeout     time.Duration
+}
+
+func newBreakerRegistry(failureThreshold int, resetTimeout time.Duration) *breakerRegistry {
+	return &breakerRegistry{
+		breakers:         make(map[string]*circuitBreaker),
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+	}
+}
+
+// get returns endpoint's circuitBreaker, creating it on first use.
+func (r *breakerRegistry) get(endpoint string) *circuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.breakers[endpoint]
+	if !ok {
+		b = newCircuitBreaker(r.failureThreshold, r.resetTimeout)
+		r.breakers[endpoint] = b
+	}
+	return b
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"time"
 
@@ -38,44 +39,235 @@ type MCPToolsResponse struct {
 	Tools []MCPTool `json:"tools"`
 }
 
+// ClientOptions configures retry, circuit breaking, response caching and
+// transport behavior for a Client. NewClient uses DefaultClientOptions;
+// NewClientWithOptions lets a caller override any of it (tests stubbing the
+// Java gateway, or Prometheus middleware wanting to wrap Transport).
+type ClientOptions struct {
+	// Timeout is the per-request HTTP timeout.
+	Timeout time.Duration
+	// MaxRetries is the number of additional attempts after the first, on
+	// a 5xx response, a timeout, or a connection error, using exponential
+	// backoff with jitter between them.
+	MaxRetries  int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// FailureThreshold is the number of consecutive failures on a given
+	// endpoint (ListServers, ListTools, GetServerInfo, HealthCheck are
+	// each tracked independently) that trips its circuit breaker open.
+	FailureThreshold int
+	// BreakerResetTimeout is how long an open breaker stays open before
+	// letting a single probe request through.
+	BreakerResetTimeout time.Duration
+	// CacheTTL is how long a ListServers/ListTools/GetServerInfo response
+	// is served from memory before being revalidated (via ETag/
+	// If-None-Match, cheaply, if the gateway sends one) or refetched.
+	// Zero disables caching.
+	CacheTTL time.Duration
+	// Transport, if set, overrides the http.Client's RoundTripper - for
+	// tests stubbing the Java gateway or middleware instrumenting calls
+	// (e.g. a Prometheus-exporting RoundTripper).
+	Transport http.RoundTripper
+}
+
+// DefaultClientOptions returns the options NewClient uses.
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		Timeout:             30 * time.Second,
+		MaxRetries:          2,
+		BaseBackoff:         100 * time.Millisecond,
+		MaxBackoff:          2 * time.Second,
+		FailureThreshold:    5,
+		BreakerResetTimeout: 30 * time.Second,
+		CacheTTL:            10 * time.Second,
+	}
+}
+
 // Client is a client for the Java MCP Gateway
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	opts       ClientOptions
+	breakers   *breakerRegistry
+	cache      *ttlCache
+	group      *singleflightGroup
 }
 
-// NewClient creates a new Java gateway client
+// NewClient creates a new Java gateway client using DefaultClientOptions.
 func NewClient(baseURL string) *Client {
+	return NewClientWithOptions(baseURL, DefaultClientOptions())
+}
+
+// NewClientWithOptions creates a Java gateway client with custom retry,
+// circuit breaking, caching and transport behavior.
+func NewClientWithOptions(baseURL string, opts ClientOptions) *Client {
 	return &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   opts.Timeout,
+			Transport: opts.Transport,
 		},
+		opts:     opts,
+		breakers: newBreakerRegistry(opts.FailureThreshold, opts.BreakerResetTimeout),
+		cache:    newTTLCache(opts.CacheTTL),
+		group:    newSingleflightGroup(),
 	}
 }
 
-// ListServers fetches all MCP servers from the Java gateway
-func (c *Client) ListServers() (*MCPServersResponse, error) {
-	url := fmt.Sprintf("%s/mcp/servers", c.baseURL)
-	
-	log.WithField("url", url).Debug("Fetching MCP servers from Java gateway")
-	
-	resp, err := c.httpClient.Get(url)
+// jitter returns d plus up to d/2 of random jitter, to keep retrying
+// clients from synchronizing on the same backoff schedule.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
+
+// withRetry runs fn (a single HTTP attempt returning the response status
+// code, or an error if the request itself failed) through up to
+// opts.MaxRetries additional attempts with exponential backoff and jitter,
+// consulting and updating endpoint's circuit breaker around the whole
+// sequence. A status >= 500 is retried exactly like a transport error; any
+// other status (including 4xx) is treated as a final answer and returned
+// to the caller without retrying.
+func (c *Client) withRetry(endpoint string, fn func() (status int, err error)) error {
+	breaker := c.breakers.get(endpoint)
+	if !breaker.allow() {
+		return fmt.Errorf("circuit breaker open for endpoint %q", endpoint)
+	}
+
+	var lastErr error
+	backoff := c.opts.BaseBackoff
+	for attempt := 0; attempt <= c.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(jitter(backoff))
+			backoff *= 2
+			if backoff > c.opts.MaxBackoff {
+				backoff = c.opts.MaxBackoff
+			}
+		}
+
+		status, err := fn()
+		if err == nil && status < http.StatusInternalServerError {
+			breaker.recordSuccess()
+			return nil
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("unexpected status %d", status)
+		}
+	}
+
+	breaker.recordFailure()
+	return lastErr
+}
+
+// doGet issues a single GET to url, setting If-None-Match when etag is
+// non-empty, and returns the raw body, the response's ETag header, and the
+// status code. A 304 Not Modified response has no body, so body is nil.
+func (c *Client) doGet(url, etag string) (body []byte, respEtag string, status int, err error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch servers: %w", err)
+		return nil, "", 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", 0, err
 	}
 	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, resp.Header.Get("ETag"), resp.StatusCode, nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", resp.StatusCode, fmt.Errorf("failed to read response: %w", err)
 	}
-	
+	return data, resp.Header.Get("ETag"), resp.StatusCode, nil
+}
+
+// fetchWithResilience fetches url under endpoint's circuit breaker and
+// retry policy, sending If-None-Match: etag when the caller already has a
+// cached copy. A 5xx response or a 304 confirming the cached body is still
+// good are both folded into the normal retry/breaker bookkeeping.
+func (c *Client) fetchWithResilience(endpoint, url, etag string) (body []byte, respEtag string, notModified bool, err error) {
+	var status int
+	err = c.withRetry(endpoint, func() (int, error) {
+		data, respondedEtag, s, err := c.doGet(url, etag)
+		if err != nil {
+			return 0, err
+		}
+		if s >= http.StatusInternalServerError {
+			return s, nil
+		}
+		body, respEtag, status = data, respondedEtag, s
+		return s, nil
+	})
+	if err != nil {
+		return nil, "", false, err
+	}
+	if status != http.StatusOK && status != http.StatusNotModified {
+		return nil, "", false, fmt.Errorf("unexpected status %d: %s", status, string(body))
+	}
+	return body, respEtag, status == http.StatusNotModified, nil
+}
+
+// fetchCached returns url's response body, serving it straight from the
+// TTL cache while fresh, and otherwise revalidating (or refetching)
+// through fetchWithResilience with concurrent misses for the same url
+// collapsed by the singleflight group.
+func (c *Client) fetchCached(endpoint, url string) ([]byte, error) {
+	if entry, fresh := c.cache.get(url); fresh {
+		return entry.body, nil
+	}
+	cached, _ := c.cache.get(url)
+
+	body, _, err := c.group.do(url, func() ([]byte, string, error) {
+		data, respEtag, notModified, err := c.fetchWithResilience(endpoint, url, cached.etag)
+		if err != nil {
+			return nil, "", err
+		}
+		if notModified {
+			c.cache.touch(url)
+			refreshed, _ := c.cache.get(url)
+			return refreshed.body, refreshed.etag, nil
+		}
+		c.cache.set(url, cacheEntry{body: data, etag: respEtag})
+		return data, respEtag, nil
+	})
+	return body, err
+}
+
+// fetchJSON fetches url via fetchCached and decodes the resulting body
+// into out.
+func (c *Client) fetchJSON(endpoint, url string, out interface{}) error {
+	body, err := c.fetchCached(endpoint, url)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// ListServers fetches all MCP servers from the Java gateway
+func (c *Client) ListServers() (*MCPServersResponse, error) {
+	url := fmt.Sprintf("%s/mcp/servers", c.baseURL)
+
+	log.WithField("url", url).Debug("Fetching MCP servers from Java gateway")
+
 	var result MCPServersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.fetchJSON("list-servers", url, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch servers: %w", err)
 	}
-	
+
 	log.WithField("count", result.Count).Info("MCP servers fetched from Java gateway")
 	return &result, nil
 }
@@ -83,77 +275,58 @@ func (c *Client) ListServers() (*MCPServersResponse, error) {
 // ListTools fetches tools for a specific MCP server
 func (c *Client) ListTools(serverName string) (*MCPToolsResponse, error) {
 	url := fmt.Sprintf("%s/mcp/list-tools?mcp_server=%s", c.baseURL, serverName)
-	
+
 	log.WithFields(log.Fields{
 		"url":    url,
 		"server": serverName,
 	}).Debug("Fetching tools from Java gateway")
-	
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch tools: %w", err)
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
-	}
-	
+
 	var result MCPToolsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.fetchJSON("list-tools", url, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch tools: %w", err)
 	}
-	
+
 	log.WithFields(log.Fields{
 		"server": serverName,
 		"tools":  len(result.Tools),
 	}).Info("Tools fetched from Java gateway")
-	
+
 	return &result, nil
 }
 
 // GetServerInfo fetches detailed info for a specific MCP server
 func (c *Client) GetServerInfo(serverName string) (map[string]interface{}, error) {
 	url := fmt.Sprintf("%s/mcp/server/%s/info", c.baseURL, serverName)
-	
+
 	log.WithFields(log.Fields{
 		"url":    url,
 		"server": serverName,
 	}).Debug("Fetching server info from Java gateway")
-	
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch server info: %w", err)
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
-	}
-	
+
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.fetchJSON("server-info", url, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch server info: %w", err)
 	}
-	
+
 	return result, nil
 }
 
-// HealthCheck checks if the Java gateway is reachable
+// HealthCheck checks if the Java gateway is reachable. Unlike
+// ListServers/ListTools/GetServerInfo it's never served from cache - a
+// health probe that returns stale good news isn't worth having.
 func (c *Client) HealthCheck() error {
 	url := fmt.Sprintf("%s/actuator/health", c.baseURL)
-	
-	resp, err := c.httpClient.Get(url)
+
+	err := c.withRetry("health", func() (int, error) {
+		resp, err := c.httpClient.Get(url)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode, nil
+	})
 	if err != nil {
 		return fmt.Errorf("health check failed: %w", err)
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("health check returned status %d", resp.StatusCode)
-	}
-	
 	return nil
 }
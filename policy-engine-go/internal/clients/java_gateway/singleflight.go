@@ -0,0 +1,55 @@
+package java_gateway
+
+import "sync"
+
+// singleflightCall is one in-flight (or just-completed) fetch that other
+// callers for the same key are waiting on.
+type singleflightCall struct {
+	wg   sync.WaitGroup
+	val  []byte
+	etag string
+	err  error
+}
+
+// singleflightGroup collapses concurrent calls for the same key into a
+// single execution of fn, so a cache-miss storm (many goroutines hitting a
+// stale or missing URL at once) results in one outbound HTTP request
+// instead of one per caller. This is a small hand-rolled stand-in for
+// golang.org/x/sync/singleflight.Group - this repo vendors no external
+// dependencies (see search_index.go's tokenize and taxonomy.go's
+// foldForMatch for the same stdlib-only tradeoff elsewhere in this repo),
+// and the fuller semantics that package offers (Forget, distinguishing a
+// shared result from a fresh one) aren't needed here.
+type singleflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*singleflightCall
+}
+
+func newSingleflightGroup() *singleflightGroup {
+	return &singleflightGroup{calls: make(map[string]*singleflightCall)}
+}
+
+// do executes fn for key, or waits for and returns the result of an
+// identical call already in flight.
+func (g *singleflightGroup) do(key string, fn func() ([]byte, string, error)) ([]byte, string, error) {
+	g.mu.Lock()
+	if call, inFlight := g.calls[key]; inFlight {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.val, call.etag, call.err
+	}
+
+	call := &singleflightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.val, call.etag, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return call.val, call.etag, call.err
+}
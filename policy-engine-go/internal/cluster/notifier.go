@@ -0,0 +1,158 @@
+// Package cluster keeps a cluster of policy-management nodes in sync: each
+// node persists to its own local YAML directory, so PeerNotifier pushes a
+// small change event to the other nodes after every successful write,
+// similar in spirit to MinIO's globalNotificationSys.
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// PolicyEvent is the payload pushed to peers when a policy mutates.
+type PolicyEvent struct {
+	PolicyID string `json:"policy_id"`
+	Version  int    `json:"version"`
+	Op       string `json:"op"`
+}
+
+// PeerNotifier pushes PolicyEvents to a configured set of peer nodes, each of
+// which exposes POST /internal/v1/policies/reload to refresh its in-memory
+// cache for the affected policy, and POST /internal/v1/policies/reload-all
+// to reload everything from disk.
+type PeerNotifier struct {
+	peers      []string
+	httpClient *http.Client
+	quorum     bool
+	maxRetries int
+}
+
+// NewPeerNotifier creates a notifier for the given peer base URLs (e.g.
+// "http://node-2:9000"). When quorum is true, Notify returns an error unless
+// a strict majority of peers acknowledged the event.
+func NewPeerNotifier(peers []string, quorum bool) *PeerNotifier {
+	return &PeerNotifier{
+		peers:      peers,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		quorum:     quorum,
+		maxRetries: 3,
+	}
+}
+
+// Peers returns the configured peer URLs.
+func (n *PeerNotifier) Peers() []string {
+	return n.peers
+}
+
+// Notify pushes a policy change event to every peer concurrently, retrying
+// each with exponential backoff. In quorum mode it blocks until all peers
+// have been attempted and returns an error unless more than half
+// acknowledged; otherwise it always returns nil and failures are left to the
+// caller to log.
+func (n *PeerNotifier) Notify(event PolicyEvent) error {
+	if len(n.peers) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy event: %w", err)
+	}
+
+	acked := make([]bool, len(n.peers))
+	var wg sync.WaitGroup
+	for i, peer := range n.peers {
+		wg.Add(1)
+		go func(i int, peer string) {
+			defer wg.Done()
+			if err := n.sendWithRetry(peer, "/internal/v1/policies/reload", body); err != nil {
+				log.WithFields(log.Fields{"peer": peer, "policy_id": event.PolicyID, "op": event.Op}).WithError(err).Warn("Peer policy notification failed")
+				return
+			}
+			acked[i] = true
+		}(i, peer)
+	}
+	wg.Wait()
+
+	if !n.quorum {
+		return nil
+	}
+
+	ackCount := 0
+	for _, ok := range acked {
+		if ok {
+			ackCount++
+		}
+	}
+	if ackCount <= len(n.peers)/2 {
+		return fmt.Errorf("quorum not reached for policy %s: %d/%d peers acknowledged", event.PolicyID, ackCount, len(n.peers))
+	}
+	return nil
+}
+
+// TriggerReloadAll asks every peer to reload its entire policy set from
+// disk. All peers are contacted regardless of earlier failures; the first
+// error encountered (if any) is returned.
+func (n *PeerNotifier) TriggerReloadAll() error {
+	var firstErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, peer := range n.peers {
+		wg.Add(1)
+		go func(peer string) {
+			defer wg.Done()
+			if err := n.sendWithRetry(peer, "/internal/v1/policies/reload-all", nil); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("peer %s: %w", peer, err)
+				}
+				mu.Unlock()
+			}
+		}(peer)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// sendWithRetry POSTs body to peer+path, retrying with exponential backoff
+// up to maxRetries times.
+func (n *PeerNotifier) sendWithRetry(peer, path string, body []byte) error {
+	url := strings.TrimRight(peer, "/") + path
+
+	var lastErr error
+	backoff := 200 * time.Millisecond
+	for attempt := 0; attempt <= n.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		var reader *bytes.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		} else {
+			reader = bytes.NewReader([]byte{})
+		}
+
+		resp, err := n.httpClient.Post(url, "application/json", reader)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("peer %s returned status %d", url, resp.StatusCode)
+	}
+	return lastErr
+}
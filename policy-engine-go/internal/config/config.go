@@ -1,8 +1,18 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/enhancedhistory"
+	"github.com/datacline/policy-engine/internal/jwtauth"
+	"github.com/datacline/policy-engine/internal/ratelimit"
+	"github.com/redis/go-redis/v9"
 )
 
 // Config holds application configuration
@@ -10,14 +20,85 @@ type Config struct {
 	// Server configuration
 	Port        string
 	Environment string
-	
+
 	// Service enablement
 	EnableEvaluation bool
 	EnableManagement bool
-	
+
 	// Policy configuration
 	PolicyDir string
-	
+
+	// Cluster sync: peer management nodes notified after a policy write.
+	// Populated from the PEER_URLS env var and/or the --peers CLI flag.
+	PeerURLs   []string
+	PeerQuorum bool
+
+	// SigningKeyHex is a hex-encoded Ed25519 seed (SIGNING_KEY) used to sign
+	// saved policy bundles and their revision history. Empty disables
+	// signing entirely.
+	SigningKeyHex string
+	// EnforceSignatures refuses to load a policy file whose signature is
+	// missing or doesn't verify, when a signing key is configured.
+	EnforceSignatures bool
+
+	// Rate limiting backend for ConditionTypeRate conditions: "memory" (the
+	// default, one counter per process) or "redis" (shared across replicas).
+	RateLimiterBackend string
+	// RateLimiterAlgorithm selects the in-memory implementation when
+	// RateLimiterBackend is "memory": "sliding_window" (default, exact) or
+	// "token_bucket" (approximate, cheaper per-call). Ignored for "redis",
+	// which is always a sliding window.
+	RateLimiterAlgorithm string
+	// RedisAddr is the host:port of the Redis instance backing a "redis"
+	// RateLimiterBackend.
+	RedisAddr string
+
+	// TLSCertFile and TLSKeyFile, when both set, serve the HTTP API
+	// (including the admission webhook, which Kubernetes requires TLS for)
+	// over HTTPS instead of plain HTTP.
+	TLSCertFile string
+	TLSKeyFile  string
+	// AdmissionAuditOnly puts the admission webhook in dry-run mode: every
+	// AdmissionReview is allowed, with the decision it would have made
+	// logged instead of enforced.
+	AdmissionAuditOnly bool
+
+	// EvaluationMode selects the engine's global default evaluation
+	// strategy: "priority" (the default, highest scope/priority score wins)
+	// or "chain" (ordered, first-match-wins with a default-deny fallback).
+	// A policy's own Policy.EvaluationMode overrides this for any request
+	// it's in scope for.
+	EvaluationMode string
+
+	// JWTProvidersFile, if set, is a YAML file declaring the jwt_provider
+	// config block(s) backing ConditionTypeJWT verification (issuer,
+	// audiences, JWKS URL, refresh interval, forwarding header, optional
+	// local keyset) - see jwtauth.ProviderConfig. Empty disables JWT
+	// verification entirely, the same "disabled, not broken" treatment an
+	// unset EnhancedHistoryDSN gets.
+	JWTProvidersFile string
+
+	// EnhancedHistoryDriver and EnhancedHistoryDSN select the database/sql
+	// driver (e.g. "sqlite3", "postgres" - the binary's main package must
+	// blank-import the matching driver) and data source backing the
+	// EnhancedPolicy versioned revision/audit-history store. Empty DSN
+	// disables it: the enhanced policy API then has no history/revisions/
+	// rollback routes, just the best-effort policy_history/<id>.jsonl log.
+	EnhancedHistoryDriver string
+	EnhancedHistoryDSN    string
+
+	// StreamWorkers bounds the concurrent worker pool POST /evaluate/stream
+	// evaluates NDJSON lines with. 0 (the default) means "use
+	// runtime.GOMAXPROCS(0)" - see evaluation.Handler.SetStreamWorkers.
+	StreamWorkers int
+
+	// WebhookAllowedHosts exempts these hostnames, from the
+	// WEBHOOK_ALLOWED_HOSTS env var, from notify.Dispatcher's
+	// private-destination check - for a subscriber that legitimately lives
+	// on a private address. Every other subscription URL that resolves to
+	// a loopback, link-local, or RFC1918/ULA private address is refused.
+	WebhookAllowedHosts []string
+
 	// Logging
 	LogLevel string
 }
@@ -25,13 +106,112 @@ type Config struct {
 // LoadConfig loads configuration from environment variables
 func LoadConfig() *Config {
 	return &Config{
-		Port:             getEnv("PORT", "9000"),
-		Environment:      getEnv("ENVIRONMENT", "production"),
-		EnableEvaluation: getBoolEnv("ENABLE_EVALUATION", true),
-		EnableManagement: getBoolEnv("ENABLE_MANAGEMENT", true),
-		PolicyDir:        getEnv("POLICY_DIR", "./policies"),
-		LogLevel:         getEnv("LOG_LEVEL", "info"),
+		Port:              getEnv("PORT", "9000"),
+		Environment:       getEnv("ENVIRONMENT", "production"),
+		EnableEvaluation:  getBoolEnv("ENABLE_EVALUATION", true),
+		EnableManagement:  getBoolEnv("ENABLE_MANAGEMENT", true),
+		PolicyDir:         getEnv("POLICY_DIR", "./policies"),
+		PeerURLs:          getListEnv("PEER_URLS"),
+		PeerQuorum:        getBoolEnv("PEER_QUORUM", false),
+		SigningKeyHex:     getEnv("SIGNING_KEY", ""),
+		EnforceSignatures: getBoolEnv("ENFORCE_SIGNATURES", true),
+
+		RateLimiterBackend:   getEnv("RATE_LIMIT_BACKEND", "memory"),
+		RateLimiterAlgorithm: getEnv("RATE_LIMIT_ALGORITHM", "sliding_window"),
+		RedisAddr:            getEnv("REDIS_ADDR", "localhost:6379"),
+
+		TLSCertFile:        getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:         getEnv("TLS_KEY_FILE", ""),
+		AdmissionAuditOnly: getBoolEnv("ADMISSION_AUDIT_ONLY", false),
+		EvaluationMode:     getEnv("EVALUATION_MODE", "priority"),
+
+		JWTProvidersFile: getEnv("JWT_PROVIDERS_FILE", ""),
+
+		EnhancedHistoryDriver: getEnv("ENHANCED_HISTORY_DRIVER", "sqlite3"),
+		EnhancedHistoryDSN:    getEnv("ENHANCED_HISTORY_DSN", ""),
+
+		StreamWorkers: getIntEnv("EVALUATE_STREAM_WORKERS", 0),
+
+		WebhookAllowedHosts: getListEnv("WEBHOOK_ALLOWED_HOSTS"),
+
+		LogLevel: getEnv("LOG_LEVEL", "info"),
+	}
+}
+
+// SigningKey derives the Ed25519 signing key from SigningKeyHex, or returns
+// nil if signing is not configured.
+func (c *Config) SigningKey() (ed25519.PrivateKey, error) {
+	if c.SigningKeyHex == "" {
+		return nil, nil
+	}
+
+	seed, err := hex.DecodeString(c.SigningKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SIGNING_KEY: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("SIGNING_KEY must be a %d-byte hex-encoded seed", ed25519.SeedSize)
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// NewRateLimiter builds the ratelimit.Limiter selected by RateLimiterBackend
+// ("memory" or "redis"), or returns an error for an unrecognized backend.
+func (c *Config) NewRateLimiter() (ratelimit.Limiter, error) {
+	switch c.RateLimiterBackend {
+	case "redis":
+		return ratelimit.NewRedisLimiter(redis.NewClient(&redis.Options{Addr: c.RedisAddr})), nil
+	case "memory", "":
+		switch c.RateLimiterAlgorithm {
+		case "token_bucket":
+			return ratelimit.NewTokenBucketLimiter(), nil
+		case "sliding_window", "":
+			return ratelimit.NewSlidingWindowLimiter(), nil
+		default:
+			return nil, fmt.Errorf("unknown RATE_LIMIT_ALGORITHM %q", c.RateLimiterAlgorithm)
+		}
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_BACKEND %q", c.RateLimiterBackend)
+	}
+}
+
+// NewJWTVerifier loads JWTProvidersFile (if set) and builds the
+// jwtauth.ProviderSet backing ConditionTypeJWT conditions, or returns (nil,
+// nil) if JWTProvidersFile is unset - the caller should then skip wiring
+// EngineOptions.JWTVerifier and any provider-refresh watcher, the same
+// "disabled" path an unset EnhancedHistoryDSN takes.
+func (c *Config) NewJWTVerifier() (*jwtauth.ProviderSet, error) {
+	if c.JWTProvidersFile == "" {
+		return nil, nil
 	}
+
+	providers, err := LoadJWTProviders(c.JWTProvidersFile)
+	if err != nil {
+		return nil, err
+	}
+	return jwtauth.NewProviderSet(providers), nil
+}
+
+// NewEnhancedHistoryStore opens EnhancedHistoryDSN with the
+// EnhancedHistoryDriver database/sql driver and wraps it in an
+// enhancedhistory.Store, or returns (nil, nil) if EnhancedHistoryDSN is
+// unset - the caller should then skip enhanced.Handler.SetHistory and run
+// without revision history/rollback. The binary's main package must
+// blank-import whichever driver package (e.g.
+// github.com/mattn/go-sqlite3, github.com/lib/pq) matches
+// EnhancedHistoryDriver; this package only opens the DSN against it.
+func (c *Config) NewEnhancedHistoryStore() (*enhancedhistory.Store, error) {
+	if c.EnhancedHistoryDSN == "" {
+		return nil, nil
+	}
+
+	db, err := sql.Open(c.EnhancedHistoryDriver, c.EnhancedHistoryDSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open enhanced history database: %w", err)
+	}
+
+	return enhancedhistory.NewStore(db)
 }
 
 // IsEvaluationOnly returns true if only evaluation service is enabled
@@ -77,3 +257,31 @@ func getBoolEnv(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// getIntEnv parses key as an integer, falling back to defaultValue if unset
+// or unparseable.
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+// getListEnv parses a comma-separated env var into a trimmed, non-empty
+// slice of values.
+func getListEnv(key string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
@@ -1,18 +1,23 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/datacline/policy-engine/internal/jwtauth"
 	"github.com/datacline/policy-engine/internal/models"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
 
-// LoadPolicies loads all policies from the specified directory
-func LoadPolicies(policyDir string) ([]*models.Policy, error) {
+// LoadPolicies loads all policies from the specified directory. cfg, if
+// non-nil, is used to verify each policy's signature; pass nil for an
+// unverified load (e.g. a context where no Config is available).
+func LoadPolicies(policyDir string, cfg *Config) ([]*models.Policy, error) {
 	log.WithField("dir", policyDir).Info("Loading policies")
 
 	var policies []*models.Policy
@@ -34,7 +39,7 @@ func LoadPolicies(policyDir string) ([]*models.Policy, error) {
 		}
 
 		policyPath := filepath.Join(policyDir, name)
-		policy, err := LoadPolicy(policyPath)
+		policy, err := LoadPolicy(policyPath, cfg)
 		if err != nil {
 			log.WithError(err).WithField("file", name).Warn("Failed to load policy")
 			continue
@@ -51,8 +56,9 @@ func LoadPolicies(policyDir string) ([]*models.Policy, error) {
 	return policies, nil
 }
 
-// LoadPolicy loads a single policy from a YAML file
-func LoadPolicy(path string) (*models.Policy, error) {
+// LoadPolicy loads a single policy from a YAML file, verifying its
+// signature against cfg's signing key when cfg is non-nil and configured.
+func LoadPolicy(path string, cfg *Config) (*models.Policy, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read policy file: %w", err)
@@ -63,6 +69,10 @@ func LoadPolicy(path string) (*models.Policy, error) {
 		return nil, fmt.Errorf("failed to parse policy YAML: %w", err)
 	}
 
+	if err := verifyPolicySignature(&policy, cfg); err != nil {
+		return nil, err
+	}
+
 	// Set defaults
 	if policy.Version == 0 {
 		policy.Version = 1
@@ -79,8 +89,109 @@ func LoadPolicy(path string) (*models.Policy, error) {
 	return &policy, nil
 }
 
+// verifyPolicySignature checks policy's bundle Signature against cfg's
+// signing key, mirroring storage.Storage's on-disk check so evaluation-only
+// deployments (which read PolicyDir directly via LoadPolicies, bypassing
+// management's Storage) get the same tamper protection. A nil cfg or an
+// unconfigured signing key skips verification entirely.
+func verifyPolicySignature(policy *models.Policy, cfg *Config) error {
+	if cfg == nil {
+		return nil
+	}
+	key, err := cfg.SigningKey()
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return nil
+	}
+
+	sig := policy.Signature
+	policy.Signature = ""
+	unsigned, err := yaml.Marshal(policy)
+	policy.Signature = sig
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy for verification: %w", err)
+	}
+
+	valid := false
+	if sig != "" {
+		if sigBytes, err := hex.DecodeString(sig); err == nil {
+			valid = ed25519.Verify(key.Public().(ed25519.PublicKey), unsigned, sigBytes)
+		}
+	}
+
+	if !valid {
+		if cfg.EnforceSignatures {
+			return fmt.Errorf("policy %s failed signature verification", policy.ID)
+		}
+		log.WithField("policy", policy.ID).Warn("Policy signature missing or invalid; loading anyway (ENFORCE_SIGNATURES=false)")
+	}
+	return nil
+}
+
 // ReloadPolicies reloads policies from directory
-func ReloadPolicies(policyDir string) ([]*models.Policy, error) {
+func ReloadPolicies(policyDir string, cfg *Config) ([]*models.Policy, error) {
 	log.Info("Reloading policies")
-	return LoadPolicies(policyDir)
+	return LoadPolicies(policyDir, cfg)
+}
+
+// LoadPolicyTypes loads every registered PolicyType from the specified
+// directory (policyschema.Registry and policytype.Registry's own disk
+// format), for the evaluation-only binary's fail-fast startup check - it
+// has no need for policytype.Registry's CRUD/persistence machinery, only
+// the same set of types the management API's Registry would load.
+// A missing directory is not an error; it returns no policy types.
+func LoadPolicyTypes(dir string) ([]*models.PolicyTypeDef, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy type directory: %w", err)
+	}
+
+	var types []*models.PolicyTypeDef
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || (!strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml")) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy type file: %w", err)
+		}
+		var pt models.PolicyTypeDef
+		if err := yaml.Unmarshal(data, &pt); err != nil {
+			return nil, fmt.Errorf("failed to parse policy type YAML %q: %w", name, err)
+		}
+		types = append(types, &pt)
+	}
+	return types, nil
+}
+
+// LoadJWTProviders reads a YAML file declaring the jwt_provider config
+// block(s) backing ConditionTypeJWT verification:
+//
+//	providers:
+//	  - name: okta
+//	    issuer: https://example.okta.com
+//	    audiences: ["mcp-gateway"]
+//	    jwks_url: https://example.okta.com/oauth2/v1/keys
+//
+// Used by Config.NewJWTVerifier.
+func LoadJWTProviders(path string) ([]jwtauth.ProviderConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwt providers file: %w", err)
+	}
+
+	var doc struct {
+		Providers []jwtauth.ProviderConfig `yaml:"providers"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse jwt providers YAML %q: %w", path, err)
+	}
+	return doc.Providers, nil
 }
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultDebounce coalesces bursts of fs events (e.g. an editor's
+// write-temp-then-rename) into a single reload.
+const defaultDebounce = 500 * time.Millisecond
+
+// ReloadStatus reports the outcome of the most recent hot-reload attempt,
+// surfaced via GET /api/v1/reload/status so operators can detect a broken
+// drop without restarting the process.
+type ReloadStatus struct {
+	WatchedDir      string `json:"watched_dir"`
+	LastReloadAt    string `json:"last_reload_at,omitempty"`
+	LastTriggerFile string `json:"last_trigger_file,omitempty"`
+	LastError       string `json:"last_error,omitempty"`
+}
+
+// Watcher watches a policy directory for .yaml/.yml changes and invokes
+// OnReload with the freshly loaded policy set, debouncing bursts of fs
+// events into a single reload.
+type Watcher struct {
+	policyDir string
+	cfg       *Config
+	debounce  time.Duration
+	onReload  func(policies []*models.Policy)
+
+	mu              sync.RWMutex
+	lastReloadAt    time.Time
+	lastTriggerFile string
+	lastError       string
+
+	fsWatcher *fsnotify.Watcher
+	cancel    context.CancelFunc
+}
+
+// NewWatcher creates a Watcher for policyDir. onReload is called with the
+// newly loaded policy set every time a watched file changes and the new set
+// loads successfully. cfg may be nil, in which case reloaded policies are
+// not signature-verified.
+func NewWatcher(policyDir string, cfg *Config, onReload func(policies []*models.Policy)) *Watcher {
+	return &Watcher{
+		policyDir: policyDir,
+		cfg:       cfg,
+		debounce:  defaultDebounce,
+		onReload:  onReload,
+	}
+}
+
+// Start begins watching policyDir in the background. It returns once the
+// watch is established; reloads happen asynchronously until Stop is called
+// or ctx is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create policy file watcher: %w", err)
+	}
+
+	if err := fsWatcher.Add(w.policyDir); err != nil {
+		fsWatcher.Close()
+		return fmt.Errorf("failed to watch policy directory %s: %w", w.policyDir, err)
+	}
+	w.fsWatcher = fsWatcher
+
+	ctx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+
+	go w.run(ctx)
+
+	log.WithField("dir", w.policyDir).Info("Policy hot-reload watcher started")
+	return nil
+}
+
+// Stop stops the watcher. It is safe to call multiple times.
+func (w *Watcher) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer w.fsWatcher.Close()
+
+	var debounceTimer *time.Timer
+
+	for {
+		select {
+		case <-ctx.Done():
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
+			return
+
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if !isPolicyFile(event.Name) {
+				continue
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename) == 0 {
+				continue
+			}
+
+			triggerFile := filepath.Base(event.Name)
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
+			debounceTimer = time.AfterFunc(w.debounce, func() {
+				w.reload(triggerFile)
+			})
+
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			log.WithError(err).Warn("Policy file watcher error")
+		}
+	}
+}
+
+func (w *Watcher) reload(triggerFile string) {
+	policies, err := LoadPolicies(w.policyDir, w.cfg)
+
+	w.mu.Lock()
+	w.lastReloadAt = time.Now()
+	w.lastTriggerFile = triggerFile
+	if err != nil {
+		w.lastError = err.Error()
+	} else {
+		w.lastError = ""
+	}
+	w.mu.Unlock()
+
+	if err != nil {
+		log.WithError(err).WithField("file", triggerFile).Warn("Policy hot-reload failed, keeping previous engine")
+		return
+	}
+
+	w.onReload(policies)
+	log.WithFields(log.Fields{
+		"file":  triggerFile,
+		"count": len(policies),
+	}).Info("Policies hot-reloaded from disk")
+}
+
+// Status returns the outcome of the most recent reload attempt.
+func (w *Watcher) Status() ReloadStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	status := ReloadStatus{
+		WatchedDir:      w.policyDir,
+		LastTriggerFile: w.lastTriggerFile,
+		LastError:       w.lastError,
+	}
+	if !w.lastReloadAt.IsZero() {
+		status.LastReloadAt = w.lastReloadAt.Format(time.RFC3339)
+	}
+	return status
+}
+
+func isPolicyFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
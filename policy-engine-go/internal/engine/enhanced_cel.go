@@ -0,0 +1,378 @@
+package engine
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/google/cel-go/cel"
+	log "github.com/sirupsen/logrus"
+)
+
+// expressionDeadline bounds the wall-clock time a single ConditionOpExpression
+// evaluation may take, on top of expressionCostLimit, since a pathological
+// expression could still run long within its step budget.
+const expressionDeadline = 50 * time.Millisecond
+
+// expressionCostLimit caps the number of CEL "steps" a single expression may
+// execute, so a runaway or adversarial expression can't stall policy
+// evaluation.
+const expressionCostLimit = 1_000_000
+
+// expressionEnv is the shared CEL environment every ConditionOpExpression
+// program is compiled against. Its variables mirror
+// models.EnhancedEvaluationRequest's Context fields - subject, oauth, server,
+// request carry the matching PolicyEvaluationContext struct as a map, tool
+// carries Context.Tool (including its Arguments), and payload carries
+// EnhancedEvaluationRequest.Payload directly.
+var expressionEnv, expressionEnvErr = cel.NewEnv(
+	cel.Variable("subject", cel.DynType),
+	cel.Variable("oauth", cel.DynType),
+	cel.Variable("server", cel.DynType),
+	cel.Variable("tool", cel.DynType),
+	cel.Variable("request", cel.DynType),
+	cel.Variable("payload", cel.DynType),
+)
+
+// PrepareExpression compiles and plans a CEL expression against
+// expressionEnv, returning a cel.Program ready to evaluate. Both
+// enhanced.Storage.Validate (at save time) and compileExpressionConditions
+// (at load time) call this, so a bad expression is rejected in exactly one
+// place rather than two diverging checks.
+func PrepareExpression(expr string) (cel.Program, error) {
+	if expressionEnvErr != nil {
+		return nil, fmt.Errorf("expression environment unavailable: %w", expressionEnvErr)
+	}
+	ast, issues := expressionEnv.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile expression: %w", issues.Err())
+	}
+	program, err := expressionEnv.Program(ast, cel.CostLimit(expressionCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan expression program: %w", err)
+	}
+	return program, nil
+}
+
+// expressionKey identifies a single ConditionOpExpression condition for
+// caching its compiled cel.Program. Unlike PolicyRule.ID in the priority
+// engine, models.PolicyConditionEnhanced has no per-condition ID, so the key
+// is the owning policy's ID plus the condition's index in Conditions.
+func expressionKey(policyID string, index int) string {
+	return fmt.Sprintf("%s:%d", policyID, index)
+}
+
+// compileExpressionConditions prepares a cel.Program for every
+// ConditionOpExpression condition across policies, keyed by expressionKey. A
+// compile failure is logged and the condition is skipped rather than failing
+// engine construction - enhanced.Storage.Validate should have already caught
+// it before the policy was saved, so this is a last line of defense against
+// a bad policy loaded straight off disk.
+func compileExpressionConditions(policies []*models.EnhancedPolicy) map[string]cel.Program {
+	programs := make(map[string]cel.Program)
+	for _, policy := range policies {
+		for i, condition := range policy.Conditions {
+			if condition.Operator != models.ConditionOpExpression {
+				continue
+			}
+			expr, ok := condition.Value.(string)
+			if !ok {
+				log.WithField("policy", policy.ID).Warn("Expression condition value is not a string, skipping")
+				continue
+			}
+			program, err := PrepareExpression(expr)
+			if err != nil {
+				log.WithError(err).WithField("policy", policy.ID).Warn("Failed to compile expression condition, skipping")
+				continue
+			}
+			programs[expressionKey(policy.ID, i)] = program
+		}
+	}
+	return programs
+}
+
+// expressionStats holds the error/timeout counters exposed by
+// EnhancedEngine.ExpressionStats for observability. Plain counters, not
+// histograms, since ConditionOpExpression evaluation sits on the request hot
+// path.
+type expressionStats struct {
+	errors   int64
+	timeouts int64
+}
+
+// ExpressionStats is a snapshot of an EnhancedEngine's ConditionOpExpression
+// error and timeout counts since construction or the last Reload.
+type ExpressionStats struct {
+	Errors   int64
+	Timeouts int64
+}
+
+// ExpressionStats returns a snapshot of e's expression evaluation counters.
+func (e *EnhancedEngine) ExpressionStats() ExpressionStats {
+	return ExpressionStats{
+		Errors:   atomic.LoadInt64(&e.expressionStats.errors),
+		Timeouts: atomic.LoadInt64(&e.expressionStats.timeouts),
+	}
+}
+
+// evaluateExpressionCondition evaluates the cached cel.Program for the
+// ConditionOpExpression condition at (policyID, index) against req, binding
+// subject/oauth/server/tool/request/payload as CEL variables. A missing
+// program (compile failure at load time), an eval error, or a deadline
+// overrun all count as a non-match rather than blocking the request, the
+// same fail-closed-but-non-fatal treatment evaluateCondition's default case
+// gives an unknown operator.
+func (e *EnhancedEngine) evaluateExpressionCondition(snap *enhancedEngineSnapshot, policyID string, index int, req *models.EnhancedEvaluationRequest) bool {
+	program, ok := snap.expressionPrograms[expressionKey(policyID, index)]
+	if !ok {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), expressionDeadline)
+	defer cancel()
+
+	vars := map[string]interface{}{
+		"subject": map[string]interface{}{
+			"email":  req.Context.Subject.Email,
+			"type":   req.Context.Subject.Type,
+			"roles":  req.Context.Subject.Roles,
+			"groups": req.Context.Subject.Groups,
+		},
+		"oauth": map[string]interface{}{
+			"provider": req.Context.OAuth.Provider,
+			"scopes":   req.Context.OAuth.Scopes,
+			"verified": req.Context.OAuth.Verified,
+		},
+		"server": map[string]interface{}{
+			"name":      req.Context.Server.Name,
+			"auth_type": req.Context.Server.AuthType,
+			"mode":      req.Context.Server.Mode,
+		},
+		"tool": map[string]interface{}{
+			"name":      req.Context.Tool.Name,
+			"arguments": req.Context.Tool.Arguments,
+		},
+		"request": map[string]interface{}{
+			"ip":         req.Context.Request.IP,
+			"user_agent": req.Context.Request.UserAgent,
+			"timestamp":  req.Context.Request.Timestamp,
+		},
+		"payload": req.Payload,
+	}
+
+	out, _, err := program.ContextEval(ctx, vars)
+	if err != nil {
+		if ctx.Err() != nil {
+			atomic.AddInt64(&e.expressionStats.timeouts, 1)
+			log.WithField("policy", policyID).Warn("Expression condition timed out")
+		} else {
+			atomic.AddInt64(&e.expressionStats.errors, 1)
+			log.WithError(err).WithField("policy", policyID).Warn("Expression condition evaluation failed")
+		}
+		return false
+	}
+
+	matched, ok := out.Value().(bool)
+	if !ok {
+		atomic.AddInt64(&e.expressionStats.errors, 1)
+		log.WithField("policy", policyID).Warn("Expression condition did not evaluate to a bool")
+		return false
+	}
+	return matched
+}
+
+// celEnv is the CEL environment every ConditionOpCEL program compiles
+// against. Unlike expressionEnv's flat subject/oauth/server/tool/request
+// variables, it groups everything but payload under a single "meta" DynType
+// variable so an expression reads meta.request.ip, meta.subject.roles, etc. -
+// matching MetadataConditionField's own "meta.*" dotted field names used by
+// every other operator.
+var celEnv, celEnvErr = cel.NewEnv(
+	cel.Variable("payload", cel.DynType),
+	cel.Variable("meta", cel.DynType),
+)
+
+// celProgramCacheCapacity bounds celProgramCache: distinct CEL expressions
+// across a large policy set are few in practice (the same business-hours or
+// role check tends to be reused verbatim on several policies), so this is
+// generous headroom rather than a tight limit.
+const celProgramCacheCapacity = 512
+
+// celCache is the process-wide compiled-program cache for ConditionOpCEL,
+// shared across every EnhancedEngine instance and surviving Reload - unlike
+// expressionPrograms, which compileExpressionConditions rebuilds from
+// scratch on every Reload, a ConditionOpCEL program is compiled once per
+// distinct expression text and then reused for the life of the process.
+var celCache = newCELProgramCache(celProgramCacheCapacity)
+
+// celProgramCache is a small LRU cache from a CEL expression's sha256 hash
+// to its compiled cel.Program.
+type celProgramCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type celCacheEntry struct {
+	key     string
+	program cel.Program
+}
+
+func newCELProgramCache(capacity int) *celProgramCache {
+	return &celProgramCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *celProgramCache) get(key string) (cel.Program, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*celCacheEntry).program, true
+}
+
+func (c *celProgramCache) put(key string, program cel.Program) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*celCacheEntry).program = program
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&celCacheEntry{key: key, program: program})
+	c.entries[key] = elem
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*celCacheEntry).key)
+		}
+	}
+}
+
+// hashExpression keys celCache by an expression's sha256 hash rather than
+// its raw text, so a pathologically long expression doesn't bloat the cache's
+// key storage.
+func hashExpression(expr string) string {
+	sum := sha256.Sum256([]byte(expr))
+	return hex.EncodeToString(sum[:])
+}
+
+// PrepareCELExpression compiles and plans a ConditionOpCEL expression
+// against celEnv, consulting celCache first so a repeated expression across
+// policies (or repeated evaluations of the same condition) is compiled once.
+// Both enhanced.Storage.Validate (at save time) and evaluateCELCondition (at
+// evaluation time) call this, so a bad expression is rejected in exactly one
+// place.
+func PrepareCELExpression(expr string) (cel.Program, error) {
+	key := hashExpression(expr)
+	if program, ok := celCache.get(key); ok {
+		return program, nil
+	}
+
+	if celEnvErr != nil {
+		return nil, fmt.Errorf("CEL environment unavailable: %w", celEnvErr)
+	}
+	ast, issues := celEnv.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile CEL expression: %w", issues.Err())
+	}
+	program, err := celEnv.Program(ast, cel.CostLimit(expressionCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan CEL expression program: %w", err)
+	}
+
+	celCache.put(key, program)
+	return program, nil
+}
+
+// evaluateCELCondition evaluates condition's ConditionOpCEL expression
+// against req, binding payload and meta.{request,subject,oauth,server,tool}.
+// As with evaluateExpressionCondition, a compile failure, eval error,
+// deadline overrun, or non-bool result are all treated as a non-match rather
+// than blocking the request.
+func (e *EnhancedEngine) evaluateCELCondition(policyID string, condition models.PolicyConditionEnhanced, req *models.EnhancedEvaluationRequest) bool {
+	expr, ok := condition.Value.(string)
+	if !ok {
+		atomic.AddInt64(&e.expressionStats.errors, 1)
+		log.WithField("policy", policyID).Warn("CEL condition value is not a string")
+		return false
+	}
+
+	program, err := PrepareCELExpression(expr)
+	if err != nil {
+		atomic.AddInt64(&e.expressionStats.errors, 1)
+		log.WithError(err).WithField("policy", policyID).Warn("Failed to compile CEL condition")
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), expressionDeadline)
+	defer cancel()
+
+	vars := map[string]interface{}{
+		"payload": req.Payload,
+		"meta": map[string]interface{}{
+			"request": map[string]interface{}{
+				"ip":         req.Context.Request.IP,
+				"user_agent": req.Context.Request.UserAgent,
+				"timestamp":  req.Context.Request.Timestamp,
+			},
+			"subject": map[string]interface{}{
+				"email":  req.Context.Subject.Email,
+				"type":   req.Context.Subject.Type,
+				"roles":  req.Context.Subject.Roles,
+				"groups": req.Context.Subject.Groups,
+			},
+			"oauth": map[string]interface{}{
+				"provider": req.Context.OAuth.Provider,
+				"scopes":   req.Context.OAuth.Scopes,
+				"verified": req.Context.OAuth.Verified,
+			},
+			"server": map[string]interface{}{
+				"name":      req.Context.Server.Name,
+				"auth_type": req.Context.Server.AuthType,
+				"mode":      req.Context.Server.Mode,
+			},
+			"tool": map[string]interface{}{
+				"name":      req.Context.Tool.Name,
+				"arguments": req.Context.Tool.Arguments,
+			},
+		},
+	}
+
+	out, _, err := program.ContextEval(ctx, vars)
+	if err != nil {
+		if ctx.Err() != nil {
+			atomic.AddInt64(&e.expressionStats.timeouts, 1)
+			log.WithField("policy", policyID).Warn("CEL condition timed out")
+		} else {
+			atomic.AddInt64(&e.expressionStats.errors, 1)
+			log.WithError(err).WithField("policy", policyID).Warn("CEL condition evaluation failed")
+		}
+		return false
+	}
+
+	matched, ok := out.Value().(bool)
+	if !ok {
+		atomic.AddInt64(&e.expressionStats.errors, 1)
+		log.WithField("policy", policyID).Warn("CEL condition did not evaluate to a bool")
+		return false
+	}
+	return matched
+}
@@ -5,25 +5,99 @@ import (
 	"net"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/datacline/policy-engine/internal/enhancedchain"
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/ratelimit"
+	"github.com/google/cel-go/cel"
 	log "github.com/sirupsen/logrus"
+	"github.com/xeipuuv/gojsonschema"
 )
 
+// enhancedEngineSnapshot bundles every piece of state Reload replaces, so a
+// single atomic.Pointer swap applies a reload consistently - Evaluate either
+// sees the old snapshot in full or the new one, never a mix, and never
+// blocks behind a Reload in progress.
+type enhancedEngineSnapshot struct {
+	policies []*models.EnhancedPolicy
+	// chain is policies compiled into an enhancedchain.Chain, ordered by
+	// priority and indexed by (server, tool) so Evaluate can narrow to the
+	// policies a request could possibly match in O(1) - see Candidates.
+	chain enhancedchain.Chain
+	// expressionPrograms caches a compiled cel.Program per
+	// ConditionOpExpression condition, keyed by expressionKey - see
+	// compileExpressionConditions.
+	expressionPrograms map[string]cel.Program
+	// payloadSchemas caches a compiled *gojsonschema.Schema per policy ID,
+	// for policies that declare a PayloadSchema. See compilePayloadSchemas.
+	payloadSchemas map[string]*gojsonschema.Schema
+}
+
+func buildEnhancedEngineSnapshot(policies []*models.EnhancedPolicy) *enhancedEngineSnapshot {
+	return &enhancedEngineSnapshot{
+		policies:           policies,
+		chain:              enhancedchain.Compile(policies),
+		expressionPrograms: compileExpressionConditions(policies),
+		payloadSchemas:     compilePayloadSchemas(policies),
+	}
+}
+
 // EnhancedEngine evaluates Runlayer-style policies
 type EnhancedEngine struct {
-	policies []*models.EnhancedPolicy
+	// snapshot is swapped atomically by Reload, so Evaluate never blocks on
+	// a reload in progress - e.g. a hot-reload triggered by
+	// enhanced.EnhancedStorage.Watch picking up an edited policy file.
+	snapshot atomic.Pointer[enhancedEngineSnapshot]
+	// rateLimiter backs a policy's "rate_limit" Obligation with real
+	// counters; nil means rate_limit obligations are returned to the caller
+	// but never enforced. See checkRateLimitObligation.
+	rateLimiter     ratelimit.Limiter
+	expressionStats expressionStats
 }
 
-// NewEnhancedEngine creates a new enhanced policy engine
+// EnhancedEngineOptions bundles EnhancedEngine's optional collaborators so
+// adding one doesn't require another NewEnhancedEngineWithX constructor.
+type EnhancedEngineOptions struct {
+	RateLimiter ratelimit.Limiter
+}
+
+// NewEnhancedEngine creates a new enhanced policy engine with rate-limit
+// enforcement of "rate_limit" obligations disabled.
 func NewEnhancedEngine(policies []*models.EnhancedPolicy) *EnhancedEngine {
-	return &EnhancedEngine{
-		policies: policies,
-	}
+	return NewEnhancedEngineWithOptions(policies, EnhancedEngineOptions{})
+}
+
+// NewEnhancedEngineWithOptions creates an enhanced policy engine with the
+// given optional collaborators. A nil RateLimiter makes "rate_limit"
+// obligations never enforced, though they are still returned on
+// EnhancedEvaluationResult.
+func NewEnhancedEngineWithOptions(policies []*models.EnhancedPolicy, opts EnhancedEngineOptions) *EnhancedEngine {
+	e := &EnhancedEngine{rateLimiter: opts.RateLimiter}
+	e.snapshot.Store(buildEnhancedEngineSnapshot(policies))
+	return e
 }
 
-// Evaluate evaluates a request against all loaded policies
+// policyOutcome is one candidate's evaluatePolicy result, computed once per
+// Evaluate call and reused by both the Allow/Deny decision phases and
+// collectObligations, so a policy with a rate_limit Obligation is only
+// charged once per request regardless of how many phases look at it.
+type policyOutcome struct {
+	policy  *models.EnhancedPolicy
+	matched bool
+	status  enhancedchain.Status
+	err     error
+}
+
+// Evaluate evaluates a request against the policies in e's current
+// snapshot whose scope could possibly apply to req's (server, tool) pair - see
+// enhancedchain.Chain.Candidates - in two phases: global deny policies
+// first (enhancedchain.DenyPriority: the first match wins immediately),
+// then server-level policies (enhancedchain.FirstMatch: the first match, in
+// priority order, decides the request). Obligations are collected
+// separately, enhancedchain.AllMatch style, from every matching policy
+// regardless of which one decides the Allow/Deny outcome.
 func (e *EnhancedEngine) Evaluate(req *models.EnhancedEvaluationRequest) *models.EnhancedEvaluationResult {
 	log.WithFields(log.Fields{
 		"user":   req.Context.Subject.Email,
@@ -31,72 +105,97 @@ func (e *EnhancedEngine) Evaluate(req *models.EnhancedEvaluationRequest) *models
 		"server": req.Context.Server.Name,
 	}).Debug("Evaluating enhanced policies")
 
-	// Sort policies by priority (highest first)
-	sortedPolicies := e.sortByPriority(e.policies)
+	snap := e.snapshot.Load()
+	candidates := snap.chain.Candidates(req.Context.Server.Name, req.Context.Tool.Name)
+	outcomes := make([]policyOutcome, len(candidates))
+	for i, entry := range candidates {
+		matched, status, err := e.evaluatePolicy(snap, entry.Source, req)
+		outcomes[i] = policyOutcome{policy: entry.Source, matched: matched, status: status, err: err}
+	}
+
+	return decideEnhanced(outcomes)
+}
 
+// decideEnhanced applies Evaluate's two decision phases (global deny
+// policies first, then the first matching server-level policy) to an
+// already-computed outcomes slice. Split out of Evaluate so
+// EvaluateWithTrace can reach the same decision from outcomes it computed
+// itself (once, alongside a Considered trace) instead of calling
+// evaluatePolicy a second time and double-charging a matched policy's
+// rate_limit obligation.
+func decideEnhanced(outcomes []policyOutcome) *models.EnhancedEvaluationResult {
 	// Default decision: deny (fail-closed)
 	result := &models.EnhancedEvaluationResult{
-		Decision:  models.PolicyActionDeny,
-		Reason:    "No matching policy found - default deny",
-		Timestamp: time.Now(),
+		Decision:    models.PolicyActionDeny,
+		Reason:      "No matching policy found - default deny",
+		Obligations: collectObligations(outcomes),
+		Timestamp:   time.Now(),
 	}
 
-	// Evaluate global deny policies first
-	for _, policy := range sortedPolicies {
-		if !policy.Enabled {
+	// Phase 1 (DenyPriority): any matching global deny policy wins
+	// immediately, before any server-level policy is considered.
+	for _, o := range outcomes {
+		if o.policy.Type != models.PolicyTypeGlobal || o.policy.Action != models.PolicyActionDeny {
 			continue
 		}
-
-		if policy.Type == models.PolicyTypeGlobal && policy.Action == models.PolicyActionDeny {
-			if e.evaluatePolicy(policy, req) {
-				result.Decision = models.PolicyActionDeny
-				result.MatchedPolicy = policy
-				result.Reason = fmt.Sprintf("Denied by global policy: %s", policy.Name)
-				
-				// Update policy match statistics
-				policy.LastMatchedAt = &result.Timestamp
-				policy.MatchCount++
-				
-				log.WithFields(log.Fields{
-					"policy": policy.Name,
-					"reason": result.Reason,
-				}).Info("Global deny policy matched")
-				
-				return result
-			}
+		if o.err != nil {
+			return schemaViolationResult(o.policy, o.err)
 		}
+		if !o.matched {
+			continue
+		}
+
+		result.Decision = models.PolicyActionDeny
+		result.MatchedPolicy = o.policy
+		result.Reason = fmt.Sprintf("Denied by global policy: %s", o.policy.Name)
+
+		o.policy.LastMatchedAt = &result.Timestamp
+		o.policy.MatchCount++
+
+		log.WithFields(log.Fields{
+			"policy": o.policy.Name,
+			"reason": result.Reason,
+		}).Info("Global deny policy matched")
+
+		return result
 	}
 
-	// Evaluate server-level policies
-	for _, policy := range sortedPolicies {
-		if !policy.Enabled {
+	// Phase 2 (FirstMatch): the first matching server-level policy, in
+	// priority order, decides the request.
+	for _, o := range outcomes {
+		if o.policy.Type != models.PolicyTypeServerLevel {
+			continue
+		}
+		if o.err != nil {
+			return schemaViolationResult(o.policy, o.err)
+		}
+		if !o.matched {
 			continue
 		}
 
-		if policy.Type == models.PolicyTypeServerLevel {
-			if e.evaluatePolicy(policy, req) {
-				result.Decision = policy.Action
-				result.MatchedPolicy = policy
-				
-				if policy.Action == models.PolicyActionAllow {
-					result.Reason = fmt.Sprintf("Allowed by policy: %s", policy.Name)
-				} else {
-					result.Reason = fmt.Sprintf("Denied by policy: %s", policy.Name)
-				}
-				
-				// Update policy match statistics
-				policy.LastMatchedAt = &result.Timestamp
-				policy.MatchCount++
-				
-				log.WithFields(log.Fields{
-					"policy":   policy.Name,
-					"decision": result.Decision,
-					"reason":   result.Reason,
-				}).Info("Server-level policy matched")
-				
-				return result
-			}
+		result.MatchedPolicy = o.policy
+		switch o.status {
+		case enhancedchain.QuotaLimitReached:
+			result.Decision = models.PolicyActionDeny
+			result.Reason = fmt.Sprintf("Denied by policy %s: rate_limit obligation exceeded", o.policy.Name)
+		case enhancedchain.AccessDenied:
+			result.Decision = models.PolicyActionDeny
+			result.Reason = fmt.Sprintf("Denied by policy: %s", o.policy.Name)
+		default:
+			result.Decision = models.PolicyActionAllow
+			result.Reason = fmt.Sprintf("Allowed by policy: %s", o.policy.Name)
 		}
+
+		o.policy.LastMatchedAt = &result.Timestamp
+		o.policy.MatchCount++
+
+		log.WithFields(log.Fields{
+			"policy":   o.policy.Name,
+			"decision": result.Decision,
+			"reason":   result.Reason,
+		}).Info("Server-level policy matched")
+
+		return result
 	}
 
 	// No matching policy - deny by default
@@ -104,26 +203,77 @@ func (e *EnhancedEngine) Evaluate(req *models.EnhancedEvaluationRequest) *models
 	return result
 }
 
-// evaluatePolicy checks if a policy matches the request
-func (e *EnhancedEngine) evaluatePolicy(policy *models.EnhancedPolicy, req *models.EnhancedEvaluationRequest) bool {
+// schemaViolationResult is Evaluate's response when a candidate's payload
+// fails its PayloadSchema: a deny distinct from an ordinary policy mismatch,
+// named after the policy that declared the schema.
+func schemaViolationResult(policy *models.EnhancedPolicy, err error) *models.EnhancedEvaluationResult {
+	reason := fmt.Sprintf("schema violation: %s", err)
+	log.WithFields(log.Fields{
+		"policy": policy.Name,
+		"reason": reason,
+	}).Warn("Payload failed schema validation")
+	return &models.EnhancedEvaluationResult{
+		Decision:      models.PolicyActionDeny,
+		MatchedPolicy: policy,
+		Reason:        reason,
+		Timestamp:     time.Now(),
+	}
+}
+
+// collectObligations runs enhancedchain.AllMatch semantics over outcomes:
+// every policy that matched req (regardless of its Action, or whether it's
+// the policy that ultimately decides Evaluate's Decision) contributes its
+// Obligations to the result.
+func collectObligations(outcomes []policyOutcome) []models.Obligation {
+	var obligations []models.Obligation
+	for _, o := range outcomes {
+		if o.err != nil || !o.matched || len(o.policy.Obligations) == 0 {
+			continue
+		}
+		obligations = append(obligations, o.policy.Obligations...)
+	}
+	return obligations
+}
+
+// evaluatePolicy checks if a policy matches the request. A non-nil error
+// means the request's payload failed policy.PayloadSchema - a distinct
+// outcome from an ordinary false, which Evaluate surfaces as its own
+// PolicyActionDeny decision rather than falling through to the next policy.
+// The returned Status is only meaningful when matched is true: Allow or
+// AccessDenied per policy.Action, or QuotaLimitReached if an Allow policy's
+// rate_limit Obligation has been exceeded.
+func (e *EnhancedEngine) evaluatePolicy(snap *enhancedEngineSnapshot, policy *models.EnhancedPolicy, req *models.EnhancedEvaluationRequest) (bool, enhancedchain.Status, error) {
 	// Check if subject matches
 	if !e.evaluateSubject(policy.AppliesTo, req) {
-		return false
+		return false, "", nil
 	}
 
 	// Check if scope matches
 	if !e.evaluateScope(policy.Scope, req) {
-		return false
+		return false, "", nil
+	}
+
+	// Check payload against the declared schema, if any, before evaluating
+	// conditions - a malformed payload shouldn't silently fail a condition
+	// closed just because getFieldValue returned nil for a mistyped key.
+	if err := e.validatePayload(snap, policy, req); err != nil {
+		return false, "", err
 	}
 
 	// Check all conditions
-	for _, condition := range policy.Conditions {
-		if !e.evaluateCondition(condition, req) {
-			return false
+	for i, condition := range policy.Conditions {
+		if !e.evaluateCondition(snap, policy.ID, i, condition, req) {
+			return false, "", nil
 		}
 	}
 
-	return true
+	status := enhancedchain.Allow
+	if policy.Action == models.PolicyActionDeny {
+		status = enhancedchain.AccessDenied
+	} else if !e.checkRateLimitObligation(policy, req) {
+		status = enhancedchain.QuotaLimitReached
+	}
+	return true, status, nil
 }
 
 // evaluateSubject checks if the subject matches
@@ -211,10 +361,17 @@ func (e *EnhancedEngine) evaluateScope(scope models.AccessScope, req *models.Enh
 }
 
 // evaluateCondition checks if a condition matches
-func (e *EnhancedEngine) evaluateCondition(condition models.PolicyConditionEnhanced, req *models.EnhancedEvaluationRequest) bool {
+func (e *EnhancedEngine) evaluateCondition(snap *enhancedEngineSnapshot, policyID string, index int, condition models.PolicyConditionEnhanced, req *models.EnhancedEvaluationRequest) bool {
+	if condition.Operator == models.ConditionOpExpression {
+		return e.evaluateExpressionCondition(snap, policyID, index, req)
+	}
+	if condition.Operator == models.ConditionOpCEL {
+		return e.evaluateCELCondition(policyID, condition, req)
+	}
+
 	// Get the field value from context
 	fieldValue := e.getFieldValue(condition.Field, req)
-	
+
 	// Evaluate based on operator
 	switch condition.Operator {
 	case models.ConditionOpEquals:
@@ -446,25 +603,9 @@ func (e *EnhancedEngine) compareLessOrEqual(fieldValue, conditionValue interface
 	return false
 }
 
-// sortByPriority sorts policies by priority (highest first)
-func (e *EnhancedEngine) sortByPriority(policies []*models.EnhancedPolicy) []*models.EnhancedPolicy {
-	sorted := make([]*models.EnhancedPolicy, len(policies))
-	copy(sorted, policies)
-	
-	// Simple bubble sort by priority
-	for i := 0; i < len(sorted)-1; i++ {
-		for j := 0; j < len(sorted)-i-1; j++ {
-			if sorted[j].Priority < sorted[j+1].Priority {
-				sorted[j], sorted[j+1] = sorted[j+1], sorted[j]
-			}
-		}
-	}
-	
-	return sorted
-}
-
-// Reload reloads the engine with new policies
+// Reload reloads the engine with new policies. rateLimiter is left as-is -
+// it's a long-lived collaborator, not policy data.
 func (e *EnhancedEngine) Reload(policies []*models.EnhancedPolicy) {
-	e.policies = policies
+	e.snapshot.Store(buildEnhancedEngineSnapshot(policies))
 	log.WithField("count", len(policies)).Info("Enhanced engine reloaded")
 }
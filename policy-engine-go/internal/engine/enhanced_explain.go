@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/enhancedchain"
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// PolicyConsiderationTrace is one candidate policy's outcome, in the order
+// EvaluateWithTrace considered it (see enhancedchain.Chain.Candidates).
+// Populated only by EvaluateWithTrace(explain=true) - Evaluate and
+// EvaluateWithTrace(explain=false) skip computing Reason to stay cheap on
+// the request hot path.
+type PolicyConsiderationTrace struct {
+	PolicyID string              `json:"policy_id"`
+	Name     string              `json:"name"`
+	Type     models.PolicyType   `json:"type"`
+	Action   models.PolicyAction `json:"action"`
+	Matched  bool                `json:"matched"`
+	// Reason names the specific condition (or subject/scope mismatch, schema
+	// violation, or exhausted rate_limit obligation) that decided Matched.
+	Reason string `json:"reason,omitempty"`
+}
+
+// EnhancedExplainResult is EvaluateWithTrace's return value: the same
+// decision Evaluate would reach for req, plus (when explain is requested)
+// the ordered list of policies considered and how long evaluation took.
+type EnhancedExplainResult struct {
+	*models.EnhancedEvaluationResult
+	Considered []PolicyConsiderationTrace `json:"considered,omitempty"`
+	DurationMs float64                    `json:"duration_ms"`
+}
+
+// EvaluateWithTrace evaluates req exactly like Evaluate - same candidates,
+// same two-phase decision, same Obligations - but computes outcomes once so
+// it can also report, when explain is true, the full ordered Considered
+// trace backing the decision. Used by the enhanced policy batch/simulation
+// endpoint; Evaluate itself stays on its own simpler path since most callers
+// never need a trace.
+func (e *EnhancedEngine) EvaluateWithTrace(req *models.EnhancedEvaluationRequest, explain bool) *EnhancedExplainResult {
+	start := time.Now()
+
+	snap := e.snapshot.Load()
+	candidates := snap.chain.Candidates(req.Context.Server.Name, req.Context.Tool.Name)
+	outcomes := make([]policyOutcome, len(candidates))
+
+	var considered []PolicyConsiderationTrace
+	if explain {
+		considered = make([]PolicyConsiderationTrace, 0, len(candidates))
+	}
+
+	for i, entry := range candidates {
+		var matched bool
+		var status enhancedchain.Status
+		var reason string
+		var err error
+		if explain {
+			matched, status, reason, err = e.evaluatePolicyTraced(snap, entry.Source, req)
+		} else {
+			matched, status, err = e.evaluatePolicy(snap, entry.Source, req)
+		}
+		outcomes[i] = policyOutcome{policy: entry.Source, matched: matched, status: status, err: err}
+
+		if explain {
+			if err != nil {
+				reason = fmt.Sprintf("schema violation: %s", err)
+			}
+			considered = append(considered, PolicyConsiderationTrace{
+				PolicyID: entry.Source.ID,
+				Name:     entry.Source.Name,
+				Type:     entry.Source.Type,
+				Action:   entry.Source.Action,
+				Matched:  matched,
+				Reason:   reason,
+			})
+		}
+	}
+
+	return &EnhancedExplainResult{
+		EnhancedEvaluationResult: decideEnhanced(outcomes),
+		Considered:               considered,
+		DurationMs:               float64(time.Since(start)) / float64(time.Millisecond),
+	}
+}
+
+// evaluatePolicyTraced is evaluatePolicy's explain=true counterpart: the same
+// subject/scope/schema/condition checks, in the same order, but returning a
+// human-readable reason for whichever check decided the outcome instead of
+// just a bool. Kept as its own function rather than adding a reason
+// out-parameter to evaluatePolicy, so the hot path Evaluate/evaluatePolicy
+// pays nothing for a trace almost no caller asks for.
+func (e *EnhancedEngine) evaluatePolicyTraced(snap *enhancedEngineSnapshot, policy *models.EnhancedPolicy, req *models.EnhancedEvaluationRequest) (matched bool, status enhancedchain.Status, reason string, err error) {
+	if !e.evaluateSubject(policy.AppliesTo, req) {
+		return false, "", "subject does not match applies_to", nil
+	}
+
+	if !e.evaluateScope(policy.Scope, req) {
+		return false, "", "request scope does not match policy scope", nil
+	}
+
+	if err := e.validatePayload(snap, policy, req); err != nil {
+		return false, "", "", err
+	}
+
+	for i, condition := range policy.Conditions {
+		if !e.evaluateCondition(snap, policy.ID, i, condition, req) {
+			return false, "", fmt.Sprintf("condition %d (%s %s) did not match", i, condition.Field, condition.Operator), nil
+		}
+	}
+
+	status = enhancedchain.Allow
+	reason = "all conditions matched"
+	if policy.Action == models.PolicyActionDeny {
+		status = enhancedchain.AccessDenied
+	} else if !e.checkRateLimitObligation(policy, req) {
+		status = enhancedchain.QuotaLimitReached
+		reason = "rate_limit obligation exceeded"
+	}
+	return true, status, reason, nil
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// rateLimitObligationKey is the Obligation.Key a policy uses to declare a
+// quota the engine itself enforces, e.g. {Key: "rate_limit", Value: "10/min"}.
+// Any other Obligation.Key is returned to the caller on
+// EnhancedEvaluationResult without the engine acting on it.
+const rateLimitObligationKey = "rate_limit"
+
+// parseRateLimitObligation parses a "rate_limit" Obligation's Value, the
+// shape "<limit>/<window>" (e.g. "10/min", "100/hour", "5/sec"), into a
+// limit and window ratelimit.Limiter.Increment can use directly.
+func parseRateLimitObligation(value interface{}) (limit int, window time.Duration, err error) {
+	spec, ok := value.(string)
+	if !ok {
+		return 0, 0, fmt.Errorf("rate_limit obligation value must be a string like \"10/min\", got %T", value)
+	}
+	parts := strings.SplitN(spec, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("rate_limit obligation value %q must be \"<limit>/<window>\"", spec)
+	}
+	limit, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("rate_limit obligation value %q has a non-numeric limit: %w", spec, err)
+	}
+	window, err = parseRateWindow(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("rate_limit obligation value %q: %w", spec, err)
+	}
+	return limit, window, nil
+}
+
+// parseRateWindow accepts a handful of common shorthands in addition to
+// whatever time.ParseDuration itself accepts, since "10/min" reads more
+// naturally in a policy than "10/1m".
+func parseRateWindow(unit string) (time.Duration, error) {
+	switch unit {
+	case "sec", "second", "s":
+		return time.Second, nil
+	case "min", "minute", "m":
+		return time.Minute, nil
+	case "hour", "h":
+		return time.Hour, nil
+	case "day", "d":
+		return 24 * time.Hour, nil
+	default:
+		return time.ParseDuration(unit)
+	}
+}
+
+// checkRateLimitObligation enforces policy's "rate_limit" Obligation, if it
+// has one and e has a RateLimiter configured. It returns false only when the
+// request is over the limit, so Evaluate can override the decision to
+// enhancedchain.QuotaLimitReached instead of allowing it through. A missing
+// RateLimiter, a policy with no rate_limit obligation, or a malformed one
+// (logged; enhanced.Storage.Validate does not check obligations, so a
+// malformed one is first noticed here) all return true: quota enforcement degrades to "not enforced", never to
+// "request blocked by a config error".
+func (e *EnhancedEngine) checkRateLimitObligation(policy *models.EnhancedPolicy, req *models.EnhancedEvaluationRequest) bool {
+	if e.rateLimiter == nil {
+		return true
+	}
+	for _, ob := range policy.Obligations {
+		if ob.Key != rateLimitObligationKey {
+			continue
+		}
+		limit, window, err := parseRateLimitObligation(ob.Value)
+		if err != nil {
+			log.WithError(err).WithField("policy", policy.ID).Warn("Invalid rate_limit obligation, not enforcing")
+			return true
+		}
+		key := policy.ID + ":" + req.Context.Subject.Email
+		count, err := e.rateLimiter.Increment(key, window, limit)
+		if err != nil {
+			log.WithError(err).WithField("policy", policy.ID).Warn("Rate limiter error, not enforcing rate_limit obligation")
+			return true
+		}
+		if count > limit {
+			return false
+		}
+	}
+	return true
+}
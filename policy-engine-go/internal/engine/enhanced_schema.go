@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// PreparePayloadSchema compiles an EnhancedPolicy.PayloadSchema document into
+// a *gojsonschema.Schema, so a malformed schema is rejected by
+// enhanced.Storage.Validate at save time instead of silently never matching
+// at evaluation time. An empty schema is not an error - PayloadSchema is
+// optional - and returns (nil, nil).
+func PreparePayloadSchema(schema map[string]interface{}) (*gojsonschema.Schema, error) {
+	if len(schema) == 0 {
+		return nil, nil
+	}
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile payload schema: %w", err)
+	}
+	return compiled, nil
+}
+
+// compilePayloadSchemas prepares PreparePayloadSchema for every policy that
+// declares one, keyed by policy ID. A compile failure is logged and that
+// policy's payload goes unchecked rather than failing engine construction -
+// enhanced.Storage.Validate should have already caught it before the policy
+// was saved, so this is a last line of defense against a bad policy loaded
+// straight off disk.
+func compilePayloadSchemas(policies []*models.EnhancedPolicy) map[string]*gojsonschema.Schema {
+	schemas := make(map[string]*gojsonschema.Schema)
+	for _, policy := range policies {
+		compiled, err := PreparePayloadSchema(policy.PayloadSchema)
+		if err != nil {
+			log.WithError(err).WithField("policy", policy.ID).Warn("Failed to compile payload schema, skipping")
+			continue
+		}
+		if compiled != nil {
+			schemas[policy.ID] = compiled
+		}
+	}
+	return schemas
+}
+
+// validatePayload runs req's tool arguments through policy's compiled
+// payload schema, if it declared one. The returned error names the first
+// failing field so a caller can tell a malformed request apart from an
+// ordinary policy denial.
+func (e *EnhancedEngine) validatePayload(snap *enhancedEngineSnapshot, policy *models.EnhancedPolicy, req *models.EnhancedEvaluationRequest) error {
+	schema, ok := snap.payloadSchemas[policy.ID]
+	if !ok {
+		return nil
+	}
+
+	result, err := schema.Validate(gojsonschema.NewGoLoader(req.Context.Tool.Arguments))
+	if err != nil {
+		return fmt.Errorf("payload validation error: %w", err)
+	}
+	if !result.Valid() {
+		if errs := result.Errors(); len(errs) > 0 {
+			return fmt.Errorf("%s: %s", errs[0].Field(), errs[0].Description())
+		}
+		return fmt.Errorf("payload does not match schema")
+	}
+	return nil
+}
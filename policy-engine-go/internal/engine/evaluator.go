@@ -7,29 +7,201 @@ import (
 	"strings"
 	"time"
 
+	"github.com/datacline/policy-engine/internal/chain"
+	"github.com/datacline/policy-engine/internal/jwtauth"
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/notify"
+	"github.com/datacline/policy-engine/internal/policyschema"
+	"github.com/datacline/policy-engine/internal/ratelimit"
+	"github.com/open-policy-agent/opa/rego"
 	log "github.com/sirupsen/logrus"
 )
 
+// EvaluationMode selects how Evaluate picks a winner among a request's
+// matched rules.
+type EvaluationMode string
+
+const (
+	// EvaluationModePriority (the default) picks the matched rule with the
+	// highest scope/rule-priority score, across every matched policy.
+	EvaluationModePriority EvaluationMode = "priority"
+	// EvaluationModeChain evaluates an internal/chain.Chain compiled from
+	// the same policies instead: an ordered, first-match-wins list with an
+	// explicit default-deny fallback, IAM/FrostFS-APE style. A policy's own
+	// Policy.EvaluationMode == "chain" forces this mode for any request it's
+	// in scope for, regardless of the engine's default.
+	EvaluationModeChain EvaluationMode = "chain"
+)
+
+// RegoConditionEvaluator evaluates a single ConditionTypeRego condition.
+// Implemented by *evaluation.RegoEvaluator; declared as an interface here,
+// rather than the Engine importing internal/services/evaluation directly,
+// since that package already imports engine and a Go import cycle would
+// otherwise result. A nil RegoConditionEvaluator makes ConditionTypeRego
+// conditions never match, the same way a nil ratelimit.Limiter does for
+// ConditionTypeRate.
+type RegoConditionEvaluator interface {
+	EvaluateCondition(cond *models.Condition, req *models.PolicyEvaluationRequest) (bool, error)
+}
+
 // Engine is the policy evaluation engine
 type Engine struct {
-	policies []*models.Policy
+	policies               []*models.Policy
+	dispatcher             *notify.Dispatcher                 // nil disables webhook notifications
+	rateLimiter            ratelimit.Limiter                  // nil makes ConditionTypeRate conditions never match
+	regoQueries            map[string]*rego.PreparedEvalQuery // compiled once per rule ID, see rego.go
+	regoConditionEvaluator RegoConditionEvaluator             // nil makes ConditionTypeRego conditions never match
+	schema                 *policyschema.Registry             // nil leaves every field at its Go runtime type
+	mode                   EvaluationMode                     // "" is treated as EvaluationModePriority
+	jwtVerifier            jwtauth.Verifier                   // nil makes ConditionTypeJWT conditions never match
+}
+
+// EngineOptions bundles the Engine's optional collaborators so adding one
+// doesn't require another NewEngineWithX constructor.
+type EngineOptions struct {
+	Dispatcher    *notify.Dispatcher
+	RateLimiter   ratelimit.Limiter
+	RegoEvaluator RegoConditionEvaluator
+	Schema        *policyschema.Registry
+	Mode          EvaluationMode
+	JWTVerifier   jwtauth.Verifier
 }
 
-// NewEngine creates a new policy engine
+// NewEngine creates a new policy engine with webhook notifications and rate
+// limiting disabled.
 func NewEngine(policies []*models.Policy) *Engine {
+	return NewEngineWithOptions(policies, EngineOptions{})
+}
+
+// NewEngineWithDispatcher creates a policy engine that POSTs a
+// PolicyNotification via dispatcher to every NotificationSubscription on a
+// policy each time that policy is evaluated. A nil dispatcher disables
+// notifications.
+func NewEngineWithDispatcher(policies []*models.Policy, dispatcher *notify.Dispatcher) *Engine {
+	return NewEngineWithOptions(policies, EngineOptions{Dispatcher: dispatcher})
+}
+
+// NewEngineWithOptions creates a policy engine with the given optional
+// collaborators. A nil Dispatcher disables webhook notifications; a nil
+// RateLimiter makes ConditionTypeRate conditions never match.
+func NewEngineWithOptions(policies []*models.Policy, opts EngineOptions) *Engine {
 	return &Engine{
-		policies: policies,
+		policies:               policies,
+		dispatcher:             opts.Dispatcher,
+		rateLimiter:            opts.RateLimiter,
+		regoQueries:            compileRegoRules(policies),
+		regoConditionEvaluator: opts.RegoEvaluator,
+		schema:                 opts.Schema,
+		mode:                   opts.Mode,
+		jwtVerifier:            opts.JWTVerifier,
 	}
 }
 
-// Evaluate evaluates a request against all loaded policies
+// Evaluate evaluates a request against all loaded policies, in whichever
+// EvaluationMode is effective for req - see effectiveMode.
 func (e *Engine) Evaluate(req *models.PolicyEvaluationRequest) *models.PolicyEvaluationResult {
+	if e.effectiveMode(req) == EvaluationModeChain {
+		return e.evaluateChain(req)
+	}
+	return e.evaluatePriority(req)
+}
+
+// effectiveMode resolves the EvaluationMode to use for req: the engine's
+// global default (EngineOptions.Mode), unless a policy in scope for req
+// explicitly requests EvaluationModeChain, which always wins so a single
+// chain-mode policy isn't silently outvoted by an otherwise priority-mode
+// fleet.
+func (e *Engine) effectiveMode(req *models.PolicyEvaluationRequest) EvaluationMode {
+	mode := e.mode
+	if mode == "" {
+		mode = EvaluationModePriority
+	}
+	for _, policy := range e.scopedPolicies(req) {
+		if EvaluationMode(policy.EvaluationMode) == EvaluationModeChain {
+			return EvaluationModeChain
+		}
+	}
+	return mode
+}
+
+// scopedPolicies returns the enabled policies whose scope applies to req,
+// shared by effectiveMode, evaluateChain, and Simulate.
+func (e *Engine) scopedPolicies(req *models.PolicyEvaluationRequest) []*models.Policy {
+	var scoped []*models.Policy
+	for _, policy := range e.policies {
+		if policy.Enabled && scopeMatches(policy, req) {
+			scoped = append(scoped, policy)
+		}
+	}
+	return scoped
+}
+
+// evaluateChain evaluates req against an internal/chain.Chain compiled from
+// the policies in scope for it, first-match-wins with a default-deny
+// fallback. Unlike evaluatePriority, it does not notify webhook subscribers;
+// chain mode is meant for admins who opt a policy into simpler, ordered
+// semantics, not a drop-in replacement for every priority-mode behavior.
+func (e *Engine) evaluateChain(req *models.PolicyEvaluationRequest) *models.PolicyEvaluationResult {
+	result := &models.PolicyEvaluationResult{
+		Matched:      false,
+		MatchedRules: []string{},
+		Action:       models.ActionAllow,
+		ShouldBlock:  false,
+		Timestamp:    time.Now(),
+	}
+
+	res := e.Simulate(req)
+	if res.PolicyID != "" {
+		result.Matched = true
+		result.PolicyID = res.PolicyID
+		result.MatchedRules = []string{res.RuleID}
+	}
+
+	if res.Decision == chain.Allow {
+		result.Action = models.ActionAllow
+		result.ShouldBlock = false
+	} else {
+		result.Action = models.ActionDeny
+		result.ShouldBlock = true
+		if res.PolicyID != "" {
+			result.Message = fmt.Sprintf("Request blocked by chain entry %s/%s", res.PolicyID, res.RuleID)
+		} else {
+			result.Message = "Request blocked by chain default-deny fallback"
+		}
+	}
+	return result
+}
+
+// Simulate compiles the policies in scope for req into a Chain and evaluates
+// it, returning the full ordered trace of entries checked (matched, skipped,
+// decisive) regardless of which EvaluationMode is actually deciding req.
+// POST /policies/simulate uses this to let admins debug a decision without
+// switching the engine (or any policy) into chain mode.
+func (e *Engine) Simulate(req *models.PolicyEvaluationRequest) *chain.Result {
+	compiled := chain.Compile(e.scopedPolicies(req))
+	return chain.Evaluate(compiled, req)
+}
+
+// evaluatePriority evaluates a request against all loaded policies using the
+// default scoped-priority scoring: the matched rule with the highest
+// scope/priority score wins, across every matched policy.
+func (e *Engine) evaluatePriority(req *models.PolicyEvaluationRequest) *models.PolicyEvaluationResult {
 	log.WithFields(log.Fields{
 		"user": req.User,
 		"tool": req.Tool,
 	}).Debug("Evaluating policies")
 
+	// Verify req.JWT (if present) once, up front - a bad token should never
+	// get a chance to match a ConditionTypeJWT condition it would otherwise
+	// satisfy, so a failure short-circuits the whole evaluation instead of
+	// just skipping the conditions that would have consulted it.
+	jwtRes, err := e.verifyRequestJWT(req)
+	if err != nil {
+		result := jwtDenyResult(err)
+		result.Timestamp = time.Now()
+		return result
+	}
+
 	// Default result - allow if no policies match
 	result := &models.PolicyEvaluationResult{
 		Matched:      false,
@@ -41,48 +213,93 @@ func (e *Engine) Evaluate(req *models.PolicyEvaluationRequest) *models.PolicyEva
 
 	var highestPriorityPolicy *models.Policy
 	var matchedRules []string
-	highestPriority := -1
+	var scopeTrace []models.ScopeTraceEntry
+	highestScore := -1
+	regoDecisions := make(map[string]*regoDecision)
 
-	// Evaluate each policy
+	// Evaluate each policy bound to a scope the request falls under
 	for _, policy := range e.policies {
 		if !policy.Enabled {
+			e.notifySubscribers(policy, req, false, nil, models.EnforceReasonOther)
 			continue
 		}
+		if !scopeMatches(policy, req) {
+			e.notifySubscribers(policy, req, false, nil, models.EnforceReasonScopeNotApplicable)
+			continue
+		}
+
+		policyMatched, rules, decisions := e.evaluatePolicy(policy, req, jwtRes)
+		for ruleID, decision := range decisions {
+			regoDecisions[ruleID] = decision
+		}
+		if !policyMatched {
+			e.notifySubscribers(policy, req, false, nil, models.EnforceReasonStatementNotApplicable)
+		} else if policy.Enforcement == "audit_only" {
+			e.notifySubscribers(policy, req, false, rules, models.EnforceReasonAuditOnly)
+		} else {
+			e.notifySubscribers(policy, req, true, rules, models.EnforceReasonOther)
+		}
 
-		policyMatched, rules := e.evaluatePolicy(policy, req)
 		if policyMatched {
 			log.WithFields(log.Fields{
 				"policy": policy.Name,
+				"scope":  policy.EffectiveScope(),
 				"rules":  rules,
 			}).Info("Policy matched")
 
 			// Track matched rules
 			matchedRules = append(matchedRules, rules...)
 
-			// Get highest priority action
+			// Winning rule is the one from the narrowest scope; ties within
+			// the same scope are broken by rule priority, so a principal
+			// policy always overrides a tenant or global one.
 			for _, rule := range policy.Rules {
-				if rule.Priority > highestPriority && containsRule(rules, rule.ID) {
-					highestPriority = rule.Priority
+				if !containsRule(rules, rule.ID) {
+					continue
+				}
+
+				scopeTrace = append(scopeTrace, models.ScopeTraceEntry{
+					Scope:    policy.EffectiveScope(),
+					ScopeID:  policy.ScopeID,
+					PolicyID: policy.ID,
+					RuleID:   rule.ID,
+				})
+
+				score := models.ScopePrecedence(policy.EffectiveScope())*1000 + rule.Priority
+				if score > highestScore {
+					highestScore = score
 					highestPriorityPolicy = policy
 				}
 			}
 		}
 	}
 
+	result.ScopeTrace = scopeTrace
+
 	// Apply action from highest priority policy
 	if highestPriorityPolicy != nil {
 		result.PolicyID = highestPriorityPolicy.ID
 		result.Matched = true
 		result.MatchedRules = matchedRules
-		
+
 		// Get the action from the highest priority rule
-		action, modifications := e.getActionFromPolicy(highestPriorityPolicy, matchedRules)
+		action, modifications, regoMessage := e.getActionFromPolicy(highestPriorityPolicy, matchedRules, regoDecisions)
 		result.Action = action
 		result.Modifications = modifications
 
+		// Surface the verified claims so a downstream ActionModify rule (or
+		// a caller inspecting the result) can route on them without
+		// re-verifying the token itself.
+		if jwtRes != nil {
+			if result.Modifications == nil {
+				result.Modifications = make(map[string]interface{})
+			}
+			result.Modifications["jwt_claims"] = jwtRes.claims
+		}
+
 		// Determine if should block
 		result.ShouldBlock = (action == models.ActionDeny || action == models.ActionRequireApproval)
-		
+
 		// Set message
 		if result.ShouldBlock {
 			result.Message = fmt.Sprintf("Request blocked by policy: %s", highestPriorityPolicy.Name)
@@ -91,6 +308,9 @@ func (e *Engine) Evaluate(req *models.PolicyEvaluationRequest) *models.PolicyEva
 		} else if action == models.ActionRedact {
 			result.Message = "Response will be redacted"
 		}
+		if regoMessage != "" {
+			result.Message = regoMessage
+		}
 
 		// Handle enforcement mode
 		if highestPriorityPolicy.Enforcement == "audit_only" {
@@ -102,32 +322,100 @@ func (e *Engine) Evaluate(req *models.PolicyEvaluationRequest) *models.PolicyEva
 	return result
 }
 
-// evaluatePolicy evaluates a single policy against the request
-func (e *Engine) evaluatePolicy(policy *models.Policy, req *models.PolicyEvaluationRequest) (bool, []string) {
+// notifySubscribers dispatches a PolicyNotification to every one of policy's
+// NotificationSubscriptions, if any, describing how this evaluation treated
+// it. It is a no-op when the engine has no dispatcher or the policy has no
+// subscriptions.
+func (e *Engine) notifySubscribers(policy *models.Policy, req *models.PolicyEvaluationRequest, enforced bool, matchedRules []string, reason models.EnforceReason) {
+	if e.dispatcher == nil || len(policy.Subscriptions) == 0 {
+		return
+	}
+
+	status := models.EnforceStatusNotEnforced
+	if enforced {
+		status = models.EnforceStatusEnforced
+	}
+
+	notification := models.PolicyNotification{
+		PolicyID:      policy.ID,
+		EnforceStatus: status,
+		EnforceReason: reason,
+		MatchedRules:  matchedRules,
+		Timestamp:     time.Now(),
+	}
+	for _, sub := range policy.Subscriptions {
+		e.dispatcher.Dispatch(sub, notification)
+	}
+}
+
+// evaluatePolicy evaluates a single policy against the request. The returned
+// map holds the decoded decision for every matched Rego rule, keyed by rule
+// ID, so getActionFromPolicy can pull its action from the decision document
+// instead of rule.Actions. jwtRes is the request's verified JWT, if any -
+// see verifyRequestJWT.
+func (e *Engine) evaluatePolicy(policy *models.Policy, req *models.PolicyEvaluationRequest, jwtRes *jwtResult) (bool, []string, map[string]*regoDecision) {
 	matchedRules := []string{}
+	decisions := make(map[string]*regoDecision)
 
 	for _, rule := range policy.Rules {
-		if e.evaluateRule(&rule, req) {
+		if rule.IsRego() {
+			matched, decision := e.evaluateRegoRule(&rule, req)
+			if matched {
+				matchedRules = append(matchedRules, rule.ID)
+				decisions[rule.ID] = decision
+			}
+			continue
+		}
+		if e.evaluateRule(&rule, req, jwtRes) {
 			matchedRules = append(matchedRules, rule.ID)
 		}
 	}
 
-	return len(matchedRules) > 0, matchedRules
+	return len(matchedRules) > 0, matchedRules, decisions
 }
 
 // evaluateRule evaluates a single rule against the request
-func (e *Engine) evaluateRule(rule *models.PolicyRule, req *models.PolicyEvaluationRequest) bool {
+func (e *Engine) evaluateRule(rule *models.PolicyRule, req *models.PolicyEvaluationRequest, jwtRes *jwtResult) bool {
 	// All conditions must match for the rule to match
 	for _, condition := range rule.Conditions {
-		if !e.evaluateCondition(&condition, req) {
+		if !e.evaluateCondition(rule, &condition, req, jwtRes) {
 			return false
 		}
 	}
 	return true
 }
 
-// evaluateCondition evaluates a single condition
-func (e *Engine) evaluateCondition(cond *models.Condition, req *models.PolicyEvaluationRequest) bool {
+// evaluateCondition evaluates a single condition. rule scopes rate-limit
+// buckets (by ID) and a ConditionTypeJWT condition's provider allowlist, so
+// neither rate keys nor accepted providers leak across rules. jwtRes is the
+// request's verified JWT, if any - see verifyRequestJWT. A
+// ConditionTypeResource condition using OperatorNameConstraints matches when
+// the resource identifier is denied by its allow/deny name constraints; see
+// evaluateResourceNameConstraints.
+func (e *Engine) evaluateCondition(rule *models.PolicyRule, cond *models.Condition, req *models.PolicyEvaluationRequest, jwtRes *jwtResult) bool {
+	if cond.Type == models.ConditionTypeRate {
+		return e.evaluateRateCondition(rule.ID, cond, req)
+	}
+	if cond.Type == models.ConditionTypeTime {
+		// A time condition's actual value is always a timestamp, whether or
+		// not a schema field backs it, so it's compared as time.Time rather
+		// than going through compareValues/compareNumeric (which can't
+		// parse an RFC3339 string or a time.Time into a float64).
+		return e.evaluateTimeCondition(cond, req)
+	}
+	if cond.Type == models.ConditionTypeRego {
+		return e.evaluateRegoCondition(cond, req)
+	}
+	if cond.Type == models.ConditionTypeJWT {
+		return e.evaluateJWTCondition(rule, cond, jwtRes)
+	}
+	if cond.Type == models.ConditionTypeResource && cond.Operator == models.OperatorNameConstraints {
+		return e.evaluateResourceNameConstraints(cond, req)
+	}
+	if cond.Type == models.ConditionTypeLabels {
+		return e.evaluateLabelsCondition(cond, req)
+	}
+
 	var actualValue interface{}
 
 	// Extract actual value based on condition type
@@ -138,28 +426,131 @@ func (e *Engine) evaluateCondition(cond *models.Condition, req *models.PolicyEva
 		actualValue = e.getFieldValue(req.Tool, cond.Field, req.Context)
 	case models.ConditionTypeResource:
 		actualValue = e.getFieldValue(req.Resource, cond.Field, req.Context)
-	case models.ConditionTypeTime:
-		if req.Timestamp != nil {
-			actualValue = *req.Timestamp
-		} else {
-			actualValue = time.Now()
-		}
 	case models.ConditionTypeData:
 		if req.Parameters != nil {
 			actualValue = req.Parameters[cond.Field]
 		}
-	case models.ConditionTypeRate:
-		// Rate limiting would need external state - placeholder
-		actualValue = 0
 	default:
 		log.WithField("type", cond.Type).Warn("Unknown condition type")
 		return false
 	}
 
+	if e.schema != nil {
+		if entity, ok := policyschema.EntityForConditionType(cond.Type); ok {
+			if fieldType, ok := e.schema.FieldType(entity, cond.Field); ok && fieldType == policyschema.TypeTime {
+				return e.compareTime(actualValue, cond.Value, cond.Operator)
+			}
+		}
+	}
+
 	// Compare using operator
 	return e.compareValues(actualValue, cond.Value, cond.Operator)
 }
 
+// evaluateTimeCondition evaluates a ConditionTypeTime condition.
+func (e *Engine) evaluateTimeCondition(cond *models.Condition, req *models.PolicyEvaluationRequest) bool {
+	var actualValue interface{}
+	if req.Timestamp != nil {
+		actualValue = *req.Timestamp
+	} else {
+		actualValue = time.Now()
+	}
+	return e.compareTime(actualValue, cond.Value, cond.Operator)
+}
+
+// compareTime coerces actual and expected to time.Time (parsing an RFC3339
+// string when either isn't already one) and compares them.
+func (e *Engine) compareTime(actual, expected interface{}, op models.ConditionOperator) bool {
+	actualTime, ok := asTime(actual)
+	if !ok {
+		return false
+	}
+	expectedTime, ok := asTime(expected)
+	if !ok {
+		return false
+	}
+
+	switch op {
+	case models.OperatorEq:
+		return actualTime.Equal(expectedTime)
+	case models.OperatorNeq:
+		return !actualTime.Equal(expectedTime)
+	case models.OperatorGt:
+		return actualTime.After(expectedTime)
+	case models.OperatorLt:
+		return actualTime.Before(expectedTime)
+	case models.OperatorGte:
+		return !actualTime.Before(expectedTime)
+	case models.OperatorLte:
+		return !actualTime.After(expectedTime)
+	default:
+		log.WithField("operator", op).Warn("Unsupported operator for time comparison")
+		return false
+	}
+}
+
+// asTime coerces v to a time.Time, parsing a string as RFC3339.
+func asTime(v interface{}) (time.Time, bool) {
+	switch val := v.(type) {
+	case time.Time:
+		return val, true
+	case string:
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// evaluateRateCondition increments the rate bucket for cond's key value and
+// reports whether the resulting count violates cond's limit. Unlike other
+// condition types, cond.Value here is the {window, limit, key} object itself
+// rather than a directly-comparable scalar, so it bypasses compareValues.
+func (e *Engine) evaluateRateCondition(ruleID string, cond *models.Condition, req *models.PolicyEvaluationRequest) bool {
+	if e.rateLimiter == nil {
+		return false
+	}
+
+	parsed, err := ratelimit.ParseCondition(cond.Value)
+	if err != nil {
+		log.WithError(err).Warn("Invalid rate condition")
+		return false
+	}
+
+	keyValue := lookupField(requestFields(req), parsed.Key)
+	bucketKey := fmt.Sprintf("%s:%v", ruleID, keyValue)
+
+	count, err := e.rateLimiter.Increment(bucketKey, parsed.Window, parsed.Limit)
+	if err != nil {
+		log.WithError(err).Warn("Rate limiter increment failed")
+		return false
+	}
+
+	return e.compareNumeric(float64(count), float64(parsed.Limit), cond.Operator)
+}
+
+// evaluateRegoCondition evaluates a ConditionTypeRego condition via the
+// injected RegoConditionEvaluator (see EngineOptions.RegoEvaluator). It
+// never matches if none is configured, or if evaluation itself errors -
+// the same fail-closed behavior evaluateRateCondition has for a missing
+// rate limiter.
+func (e *Engine) evaluateRegoCondition(cond *models.Condition, req *models.PolicyEvaluationRequest) bool {
+	if e.regoConditionEvaluator == nil {
+		log.Warn("ConditionTypeRego condition evaluated with no Rego evaluator configured")
+		return false
+	}
+
+	matched, err := e.regoConditionEvaluator.EvaluateCondition(cond, req)
+	if err != nil {
+		log.WithError(err).Warn("Rego condition evaluation failed")
+		return false
+	}
+	return matched
+}
+
 // getFieldValue extracts a field value (supports dot notation)
 func (e *Engine) getFieldValue(base interface{}, field string, context map[string]interface{}) interface{} {
 	// If field is empty, return base
@@ -191,15 +582,48 @@ func (e *Engine) getFieldValue(base interface{}, field string, context map[strin
 	return base
 }
 
+// requestFields flattens req's top-level fields into a map so a rate
+// condition's dotted key expression (e.g. "context.tenant") can be resolved
+// the same way regardless of whether it points at a scalar or nested field.
+func requestFields(req *models.PolicyEvaluationRequest) map[string]interface{} {
+	fields := map[string]interface{}{
+		"user":       req.User,
+		"tool":       req.Tool,
+		"resource":   req.Resource,
+		"action":     req.Action,
+		"org_id":     req.OrgID,
+		"session_id": req.SessionID,
+		"ip_address": req.IPAddress,
+		"parameters": req.Parameters,
+		"context":    req.Context,
+	}
+	return fields
+}
+
+// lookupField resolves a dot-notation path (e.g. "context.tenant") against
+// nested map[string]interface{} data, returning nil if any segment is
+// missing or not itself a map.
+func lookupField(data map[string]interface{}, path string) interface{} {
+	var current interface{} = data
+	for _, part := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+	return current
+}
+
 // compareValues compares two values using the specified operator
 func (e *Engine) compareValues(actual, expected interface{}, op models.ConditionOperator) bool {
 	switch op {
 	case models.OperatorEq:
 		return reflect.DeepEqual(actual, expected)
-		
+
 	case models.OperatorNeq:
 		return !reflect.DeepEqual(actual, expected)
-		
+
 	case models.OperatorIn:
 		if expectedSlice, ok := expected.([]interface{}); ok {
 			for _, v := range expectedSlice {
@@ -209,7 +633,7 @@ func (e *Engine) compareValues(actual, expected interface{}, op models.Condition
 			}
 		}
 		return false
-		
+
 	case models.OperatorNotIn:
 		if expectedSlice, ok := expected.([]interface{}); ok {
 			for _, v := range expectedSlice {
@@ -220,10 +644,10 @@ func (e *Engine) compareValues(actual, expected interface{}, op models.Condition
 			return true
 		}
 		return false
-		
+
 	case models.OperatorGt, models.OperatorLt, models.OperatorGte, models.OperatorLte:
 		return e.compareNumeric(actual, expected, op)
-		
+
 	case models.OperatorMatches:
 		if actualStr, ok := actual.(string); ok {
 			if pattern, ok := expected.(string); ok {
@@ -232,7 +656,7 @@ func (e *Engine) compareValues(actual, expected interface{}, op models.Condition
 			}
 		}
 		return false
-		
+
 	case models.OperatorContains:
 		if actualStr, ok := actual.(string); ok {
 			if substr, ok := expected.(string); ok {
@@ -240,7 +664,7 @@ func (e *Engine) compareValues(actual, expected interface{}, op models.Condition
 			}
 		}
 		return false
-		
+
 	default:
 		log.WithField("operator", op).Warn("Unknown operator")
 		return false
@@ -251,11 +675,11 @@ func (e *Engine) compareValues(actual, expected interface{}, op models.Condition
 func (e *Engine) compareNumeric(actual, expected interface{}, op models.ConditionOperator) bool {
 	actualFloat, actualOk := toFloat64(actual)
 	expectedFloat, expectedOk := toFloat64(expected)
-	
+
 	if !actualOk || !expectedOk {
 		return false
 	}
-	
+
 	switch op {
 	case models.OperatorGt:
 		return actualFloat > expectedFloat
@@ -270,28 +694,57 @@ func (e *Engine) compareNumeric(actual, expected interface{}, op models.Conditio
 	}
 }
 
-// getActionFromPolicy gets the action from matched rules
-func (e *Engine) getActionFromPolicy(policy *models.Policy, matchedRules []string) (models.ActionType, map[string]interface{}) {
+// getActionFromPolicy gets the action from matched rules. regoDecisions
+// supplies the action/message for a matched Rego rule, which has no
+// rule.Actions of its own; the returned message is empty unless the winning
+// rule was a Rego rule whose decision set one.
+func (e *Engine) getActionFromPolicy(policy *models.Policy, matchedRules []string, regoDecisions map[string]*regoDecision) (models.ActionType, map[string]interface{}, string) {
 	var highestPriorityRule *models.PolicyRule
 	highestPriority := -1
-	
+
 	for _, rule := range policy.Rules {
 		if containsRule(matchedRules, rule.ID) && rule.Priority > highestPriority {
 			highestPriority = rule.Priority
 			highestPriorityRule = &rule
 		}
 	}
-	
-	if highestPriorityRule != nil && len(highestPriorityRule.Actions) > 0 {
+
+	if highestPriorityRule == nil {
+		return models.ActionAllow, nil, ""
+	}
+
+	if highestPriorityRule.IsRego() {
+		if decision, ok := regoDecisions[highestPriorityRule.ID]; ok && decision.Action != "" {
+			return models.ActionType(decision.Action), decision.Modifications, decision.Message
+		}
+		return models.ActionAllow, nil, ""
+	}
+
+	if len(highestPriorityRule.Actions) > 0 {
 		action := highestPriorityRule.Actions[0]
-		return action.Type, action.Params
+		return action.Type, action.Params, ""
 	}
-	
-	return models.ActionAllow, nil
+
+	return models.ActionAllow, nil, ""
 }
 
 // Helper functions
 
+// scopeMatches reports whether policy's scope applies to req: a global
+// policy always applies, a tenant policy applies when its ScopeID matches
+// req.OrgID, and a principal policy applies when its ScopeID matches
+// req.User.
+func scopeMatches(policy *models.Policy, req *models.PolicyEvaluationRequest) bool {
+	switch policy.EffectiveScope() {
+	case models.ScopeTenant:
+		return policy.ScopeID != "" && policy.ScopeID == req.OrgID
+	case models.ScopePrincipal:
+		return policy.ScopeID != "" && policy.ScopeID == req.User
+	default:
+		return true
+	}
+}
+
 func containsRule(rules []string, ruleID string) bool {
 	for _, r := range rules {
 		if r == ruleID {
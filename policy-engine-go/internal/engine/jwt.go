@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// jwtResult is the outcome of verifying PolicyEvaluationRequest.JWT,
+// computed once per evaluatePriority call and threaded through to
+// evaluateCondition so a ConditionTypeJWT condition doesn't re-verify the
+// token for every comparison.
+type jwtResult struct {
+	claims   map[string]interface{}
+	provider string
+}
+
+// verifyRequestJWT verifies req.JWT against e.jwtVerifier, if both are set.
+// It returns (nil, nil) when there's no token to verify or no verifier
+// configured - the same fail-open-to-"never matches" behavior a nil
+// rateLimiter or regoConditionEvaluator gets, since an engine without JWT
+// support configured simply can't evaluate ConditionTypeJWT. A present token
+// that fails verification returns a non-nil error instead; evaluatePriority
+// turns that into an immediate ActionDeny naming the failure, before any
+// rule - and so before any ConditionTypeJWT condition - is evaluated.
+func (e *Engine) verifyRequestJWT(req *models.PolicyEvaluationRequest) (*jwtResult, error) {
+	if req.JWT == "" || e.jwtVerifier == nil {
+		return nil, nil
+	}
+
+	claims, provider, err := e.jwtVerifier.Verify(req.JWT)
+	if err != nil {
+		return nil, err
+	}
+	return &jwtResult{claims: claims, provider: provider}, nil
+}
+
+// evaluateJWTCondition matches cond against jwtRes's verified claims. It
+// never matches if the request's token wasn't verified (jwtRes is nil), or
+// if rule declares a JWTProviders allowlist that doesn't include the
+// provider that actually verified the token. cond.Field addresses the claim
+// with a "claims." prefix (e.g. "claims.groups"), resolved the same
+// dot-notation way getFieldValue resolves any other nested field.
+func (e *Engine) evaluateJWTCondition(rule *models.PolicyRule, cond *models.Condition, jwtRes *jwtResult) bool {
+	if jwtRes == nil {
+		return false
+	}
+	if len(rule.JWTProviders) > 0 && !containsRule(rule.JWTProviders, jwtRes.provider) {
+		return false
+	}
+
+	base := map[string]interface{}{"claims": jwtRes.claims}
+	actualValue := e.getFieldValue(base, cond.Field, nil)
+	return e.compareValues(actualValue, cond.Value, cond.Operator)
+}
+
+// jwtDenyResult builds the short-circuit ActionDeny result evaluatePriority
+// returns when req.JWT failed verification, naming the failure in Message.
+func jwtDenyResult(err error) *models.PolicyEvaluationResult {
+	return &models.PolicyEvaluationResult{
+		Matched:      false,
+		MatchedRules: []string{},
+		Action:       models.ActionDeny,
+		ShouldBlock:  true,
+		Message:      fmt.Sprintf("JWT verification failed: %v", err),
+	}
+}
@@ -0,0 +1,31 @@
+package engine
+
+import (
+	"github.com/datacline/policy-engine/internal/labels"
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// evaluateLabelsCondition evaluates a ConditionTypeLabels condition.
+// cond.Field selects which side of req carries the labels to match
+// ("user" for req.UserLabels, "resource" for req.ResourceLabels); any other
+// Field never matches. cond.Value is parsed by labels.MatchValue.
+func (e *Engine) evaluateLabelsCondition(cond *models.Condition, req *models.PolicyEvaluationRequest) bool {
+	var target map[string]string
+	switch cond.Field {
+	case "user":
+		target = req.UserLabels
+	case "resource":
+		target = req.ResourceLabels
+	default:
+		log.WithField("field", cond.Field).Warn("ConditionTypeLabels field must be \"user\" or \"resource\"")
+		return false
+	}
+
+	matched, err := labels.MatchValue(cond.Value, target)
+	if err != nil {
+		log.WithError(err).Warn("Invalid labels condition value")
+		return false
+	}
+	return matched
+}
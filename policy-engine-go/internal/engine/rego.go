@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/open-policy-agent/opa/rego"
+	log "github.com/sirupsen/logrus"
+)
+
+// regoDecision is the document a Rego rule's query must return:
+// `{allow: bool, action: "...", modifications: {...}, message: "..."}`.
+// Action/Modifications/Message are only consulted when Allow is true.
+type regoDecision struct {
+	Allow         bool                   `json:"allow"`
+	Action        string                 `json:"action"`
+	Modifications map[string]interface{} `json:"modifications"`
+	Message       string                 `json:"message"`
+}
+
+// unsafeRegoRuleBuiltins blocks the OPA built-ins that would let an
+// attacker-supplied PolicyRule.Rego reach outside the evaluation sandbox:
+// http.send (arbitrary outbound requests - SSRF against internal services,
+// and a channel to exfiltrate data via the response), net.lookup_ip_addr
+// (DNS-based network probing), and opa.runtime (leaks this process's
+// environment variables and OPA config). rule.Rego is plain attacker-
+// reachable input on the policy API, not a trusted administrator's script.
+var unsafeRegoRuleBuiltins = map[string]struct{}{
+	"http.send":          {},
+	"net.lookup_ip_addr": {},
+	"opa.runtime":        {},
+}
+
+// PrepareRegoQuery compiles module and returns a query prepared against
+// `data.policy.decision`, so callers (storage.Validate at save time,
+// compileRegoRules at load time) can surface a compile error without
+// duplicating the rego.New(...) options.
+func PrepareRegoQuery(module string) (*rego.PreparedEvalQuery, error) {
+	query, err := rego.New(
+		rego.Query("data.policy.decision"),
+		rego.Module("policy.rego", module),
+		rego.UnsafeBuiltins(unsafeRegoRuleBuiltins),
+	).PrepareForEval(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &query, nil
+}
+
+// compileRegoRules prepares the query for every Rego-backed rule across
+// policies, keyed by rule ID. A compile failure is logged and the rule is
+// skipped rather than failing engine construction - the corresponding
+// storage.Validate call should have already caught it before the policy was
+// saved, so this is a last line of defense against a bad policy file loaded
+// straight off disk.
+func compileRegoRules(policies []*models.Policy) map[string]*rego.PreparedEvalQuery {
+	queries := make(map[string]*rego.PreparedEvalQuery)
+	for _, policy := range policies {
+		for _, rule := range policy.Rules {
+			if !rule.IsRego() {
+				continue
+			}
+			query, err := PrepareRegoQuery(rule.Rego)
+			if err != nil {
+				log.WithError(err).WithField("rule", rule.ID).Warn("Failed to compile rego rule, skipping")
+				continue
+			}
+			queries[rule.ID] = query
+		}
+	}
+	return queries
+}
+
+// evaluateRegoRule runs rule's prepared query against req and reports
+// whether it matched, along with the decoded decision document so
+// getActionFromPolicy can pull action/modifications/message from it instead
+// of rule.Actions.
+func (e *Engine) evaluateRegoRule(rule *models.PolicyRule, req *models.PolicyEvaluationRequest) (bool, *regoDecision) {
+	query, ok := e.regoQueries[rule.ID]
+	if !ok {
+		log.WithField("rule", rule.ID).Warn("Rego rule has no compiled query")
+		return false, nil
+	}
+
+	input := map[string]interface{}{
+		"user":       req.User,
+		"tool":       req.Tool,
+		"resource":   req.Resource,
+		"parameters": req.Parameters,
+		"context":    req.Context,
+		"timestamp":  req.Timestamp,
+	}
+
+	results, err := query.Eval(context.Background(), rego.EvalInput(input))
+	if err != nil {
+		log.WithError(err).WithField("rule", rule.ID).Warn("Rego evaluation failed")
+		return false, nil
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return false, nil
+	}
+
+	decision, err := decodeRegoDecision(results[0].Expressions[0].Value)
+	if err != nil {
+		log.WithError(err).WithField("rule", rule.ID).Warn("Rego rule returned an unexpected decision shape")
+		return false, nil
+	}
+
+	return decision.Allow, decision
+}
+
+func decodeRegoDecision(value interface{}) (*regoDecision, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("decision document must be an object")
+	}
+
+	decision := &regoDecision{}
+	if allow, ok := m["allow"].(bool); ok {
+		decision.Allow = allow
+	}
+	if action, ok := m["action"].(string); ok {
+		decision.Action = action
+	}
+	if message, ok := m["message"].(string); ok {
+		decision.Message = message
+	}
+	if mods, ok := m["modifications"].(map[string]interface{}); ok {
+		decision.Modifications = mods
+	}
+	return decision, nil
+}
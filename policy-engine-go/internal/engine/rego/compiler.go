@@ -0,0 +1,124 @@
+// Package rego compiles and evaluates UnifiedPolicy.RegoSource modules for
+// policies whose PolicyLanguage is models.PolicyLanguageRego, as an
+// alternative to the PolicyRules DSL walked by engine.Simulate. It is a
+// distinct package from the top-level engine.rego.go file, which prepares
+// Rego queries for the older models.Policy/PolicyRule model - the two share
+// the same github.com/open-policy-agent/opa/rego idiom but have no other
+// coupling.
+package rego
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// Module is a compiled Rego policy, ready for repeated evaluation via
+// Evaluate.
+type Module struct {
+	PolicyID string
+	Version  int
+
+	query *rego.PreparedEvalQuery
+
+	// Wasm would hold a WASM-compiled form of query for callers that want to
+	// run evaluation outside this process (e.g. in a sidecar). It is always
+	// nil today: producing it requires the `opa build -t wasm` toolchain,
+	// which isn't available as a library call, only a separate CLI this
+	// process doesn't depend on. Evaluate always runs query through OPA's
+	// tree-walking interpreter instead, which doubles as the fallback
+	// interpreter a WASM-capable caller would need anyway.
+	Wasm []byte
+}
+
+var (
+	cacheMu sync.RWMutex
+	cache   = make(map[string]*Module)
+)
+
+// unsafeBuiltins blocks the OPA built-ins that would otherwise let an
+// attacker-supplied RegoSource reach outside the evaluation sandbox:
+// http.send (arbitrary outbound requests - SSRF against internal services,
+// and a channel to exfiltrate data via the response), net.lookup_ip_addr
+// (DNS-based network probing), and opa.runtime (leaks this process's
+// environment variables and OPA config). RegoSource arrives as a plain
+// field on the unauthenticated POST/PUT /unified/policies body, so it must
+// be treated as untrusted input, not a trusted administrator's script.
+var unsafeBuiltins = map[string]struct{}{
+	"http.send":          {},
+	"net.lookup_ip_addr": {},
+	"opa.runtime":        {},
+}
+
+func cacheKey(policyID string, version int) string {
+	return fmt.Sprintf("%s@%d", policyID, version)
+}
+
+// Compile prepares source (expected to define `package policy` and a
+// `decision` rule, mirroring the regoDecision document shape used elsewhere
+// in this codebase) for evaluation, and caches the result keyed by
+// (policyID, version) so a hot path that evaluates the same policy version
+// repeatedly - e.g. one request per call to the unified policy evaluate
+// endpoint - doesn't recompile it every time. Callers that edit a policy's
+// RegoSource are expected to bump Version, which changes the cache key and
+// so naturally invalidates the old entry rather than requiring an explicit
+// purge.
+func Compile(policyID string, version int, source string) (*Module, error) {
+	key := cacheKey(policyID, version)
+
+	cacheMu.RLock()
+	if m, ok := cache[key]; ok {
+		cacheMu.RUnlock()
+		return m, nil
+	}
+	cacheMu.RUnlock()
+
+	query, err := prepare(policyID, source)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Module{PolicyID: policyID, Version: version, query: query}
+
+	cacheMu.Lock()
+	cache[key] = m
+	cacheMu.Unlock()
+
+	return m, nil
+}
+
+// Validate compiles source without caching the result, so a handler can
+// reject an invalid RegoSource at create/update time without polluting
+// Compile's cache with a module no policy actually references yet.
+func Validate(policyID, source string) error {
+	_, err := prepare(policyID, source)
+	return err
+}
+
+func prepare(policyID, source string) (*rego.PreparedEvalQuery, error) {
+	query, err := rego.New(
+		rego.Query("data.policy.decision"),
+		rego.Module(policyID+".rego", source),
+		rego.UnsafeBuiltins(unsafeBuiltins),
+	).PrepareForEval(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("compile rego policy %s: %w", policyID, err)
+	}
+	return &query, nil
+}
+
+// Purge drops policyID's cached modules for every version, so a stale
+// compiled query can never outlive the policy it was compiled from (e.g.
+// once the policy is deleted or its language is switched away from rego).
+func Purge(policyID string) {
+	prefix := policyID + "@"
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	for key := range cache {
+		if len(key) >= len(prefix) && key[:len(prefix)] == prefix {
+			delete(cache, key)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package rego
+
+import "testing"
+
+func TestCompileRejectsHTTPSend(t *testing.T) {
+	source := `package policy
+
+decision := {"allow": true} {
+	resp := http.send({"method": "GET", "url": "http://127.0.0.1:1/nope"})
+	resp.status_code == 200
+}`
+
+	if _, err := Compile("ssrf-test", 1, source); err == nil {
+		t.Fatal("expected Compile to reject a module calling http.send, the SSRF/exfiltration primitive UnsafeBuiltins blocks")
+	}
+}
+
+func TestCompileAcceptsSafeModule(t *testing.T) {
+	source := `package policy
+
+decision := {"allow": true}`
+
+	if _, err := Compile("safe-test", 1, source); err != nil {
+		t.Fatalf("expected a module with no unsafe built-ins to compile, got: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package rego
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// decision is the document module's `data.policy.decision` rule must
+// evaluate to, mirroring the {allow, action, reason} shape the DSL path
+// reaches via engine.Simulate/models.PolicyDecision.
+type decision struct {
+	Allow  bool   `json:"allow"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+// Evaluate runs module against in, the same PolicyEvaluationInput the DSL
+// evaluation path consumes, and returns a PolicyDecision shaped like the one
+// engine.Simulate would produce for a matching DSL rule - so callers (the
+// unified policy evaluate handler) don't need to branch on PolicyLanguage
+// once a decision comes back.
+func Evaluate(module *Module, in models.PolicyEvaluationInput) (*models.PolicyDecision, error) {
+	input := map[string]interface{}{
+		"user": map[string]interface{}{
+			"id":      in.UserID,
+			"roles":   in.Roles,
+			"org_ids": in.OrgIDs,
+		},
+		"resource": map[string]interface{}{
+			"type": string(in.ResourceType),
+			"id":   in.ResourceID,
+		},
+		"context": in.Context,
+	}
+
+	results, err := module.query.Eval(context.Background(), rego.EvalInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("evaluate rego policy %s: %w", module.PolicyID, err)
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return nil, fmt.Errorf("rego policy %s produced no decision", module.PolicyID)
+	}
+
+	d, err := decodeDecision(results[0].Expressions[0].Value)
+	if err != nil {
+		return nil, fmt.Errorf("rego policy %s: %w", module.PolicyID, err)
+	}
+
+	action := models.RuleActionDeny
+	if d.Allow {
+		action = models.RuleActionAllow
+	}
+
+	return &models.PolicyDecision{
+		Decision:  action,
+		PolicyIDs: []string{module.PolicyID},
+		Reason:    d.Reason,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+func decodeDecision(value interface{}) (*decision, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("decision document must be an object")
+	}
+
+	d := &decision{}
+	if allow, ok := m["allow"].(bool); ok {
+		d.Allow = allow
+	}
+	if action, ok := m["action"].(string); ok {
+		d.Action = action
+	}
+	if reason, ok := m["reason"].(string); ok {
+		d.Reason = reason
+	}
+	return d, nil
+}
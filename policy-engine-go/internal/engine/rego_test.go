@@ -0,0 +1,26 @@
+package engine
+
+import "testing"
+
+func TestPrepareRegoQueryRejectsHTTPSend(t *testing.T) {
+	module := `package policy
+
+decision := {"allow": true} {
+	resp := http.send({"method": "GET", "url": "http://127.0.0.1:1/nope"})
+	resp.status_code == 200
+}`
+
+	if _, err := PrepareRegoQuery(module); err == nil {
+		t.Fatal("expected PrepareRegoQuery to reject a module calling http.send, the SSRF/exfiltration primitive unsafeRegoRuleBuiltins blocks")
+	}
+}
+
+func TestPrepareRegoQueryAcceptsSafeModule(t *testing.T) {
+	module := `package policy
+
+decision := {"allow": true}`
+
+	if _, err := PrepareRegoQuery(module); err != nil {
+		t.Fatalf("expected a module with no unsafe built-ins to compile, got: %v", err)
+	}
+}
@@ -0,0 +1,284 @@
+package engine
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// nameConstraints is the parsed form of a name-constraints Condition.Value:
+// deny is checked first (any match denies outright), then the incoming
+// identifier's category must satisfy its allow list, if that category
+// declares one - a category with no allow entries is implicitly allow-all.
+type nameConstraints struct {
+	Allow constraintSet
+	Deny  constraintSet
+}
+
+// constraintSet lists the patterns checked for each identifier category
+// this package classifies an incoming resource value into.
+type constraintSet struct {
+	DNS         []string
+	CIDR        []string
+	Email       []string
+	URIPatterns []string
+	Principal   []string
+}
+
+// patternsFor returns set's pattern list for category, or nil for a
+// category this package doesn't understand.
+func (set constraintSet) patternsFor(category string) []string {
+	switch category {
+	case "dns":
+		return set.DNS
+	case "cidr":
+		return set.CIDR
+	case "email":
+		return set.Email
+	case "uri":
+		return set.URIPatterns
+	case "principal":
+		return set.Principal
+	default:
+		return nil
+	}
+}
+
+// parseNameConstraints parses a name-constraints Condition.Value, which
+// arrives as map[string]interface{} after JSON/YAML unmarshaling - the same
+// hand-parsed approach ratelimit.ParseCondition takes for its own
+// object-shaped Value.
+func parseNameConstraints(value interface{}) (*nameConstraints, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("name constraints value must be an object with allow/deny")
+	}
+
+	nc := &nameConstraints{}
+	if allow, ok := m["allow"]; ok {
+		set, err := parseConstraintSet(allow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allow constraints: %w", err)
+		}
+		nc.Allow = set
+	}
+	if deny, ok := m["deny"]; ok {
+		set, err := parseConstraintSet(deny)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deny constraints: %w", err)
+		}
+		nc.Deny = set
+	}
+	return nc, nil
+}
+
+func parseConstraintSet(value interface{}) (constraintSet, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return constraintSet{}, fmt.Errorf("must be an object")
+	}
+
+	var set constraintSet
+	var err error
+	if set.DNS, err = stringList(m["dns"]); err != nil {
+		return constraintSet{}, fmt.Errorf("dns: %w", err)
+	}
+	if set.CIDR, err = stringList(m["cidr"]); err != nil {
+		return constraintSet{}, fmt.Errorf("cidr: %w", err)
+	}
+	if set.Email, err = stringList(m["email"]); err != nil {
+		return constraintSet{}, fmt.Errorf("email: %w", err)
+	}
+	if set.URIPatterns, err = stringList(m["uri_patterns"]); err != nil {
+		return constraintSet{}, fmt.Errorf("uri_patterns: %w", err)
+	}
+	if set.Principal, err = stringList(m["principal"]); err != nil {
+		return constraintSet{}, fmt.Errorf("principal: %w", err)
+	}
+	return set, nil
+}
+
+func stringList(v interface{}) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be an array of strings")
+	}
+	out := make([]string, 0, len(arr))
+	for _, e := range arr {
+		s, ok := e.(string)
+		if !ok {
+			return nil, fmt.Errorf("entries must be strings")
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// identifierCategory classifies value into the name-constraint category its
+// patterns are checked against: "cidr" for an IP literal, "uri" for
+// anything with a scheme, "email" for anything with an "@", "dns" for a
+// bare hostname, and "principal" as the fallback for everything else (e.g.
+// an ARN or a "user:alice"-shaped identifier with no other structure).
+func identifierCategory(value string) string {
+	if _, err := netip.ParseAddr(value); err == nil {
+		return "cidr"
+	}
+	if strings.Contains(value, "://") {
+		return "uri"
+	}
+	if strings.Contains(value, "@") {
+		return "email"
+	}
+	if isDNSName(value) {
+		return "dns"
+	}
+	return "principal"
+}
+
+// isDNSName reports whether value looks like a dot-separated hostname: at
+// least one non-empty, alphanumeric-or-hyphen label.
+func isDNSName(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, label := range strings.Split(value, ".") {
+		if label == "" {
+			return false
+		}
+		for _, r := range label {
+			if !(r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// categoryMatches reports whether value matches any of patterns under
+// category's rules.
+func categoryMatches(category, value string, patterns []string) bool {
+	for _, pattern := range patterns {
+		var ok bool
+		switch category {
+		case "dns":
+			ok = matchesDNSPattern(value, pattern)
+		case "cidr":
+			ok = matchesCIDRPattern(value, pattern)
+		case "email":
+			ok = matchesEmailPattern(value, pattern)
+		case "uri":
+			ok = matchesURIPattern(value, pattern)
+		default: // "principal"
+			ok = value == pattern
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesDNSPattern matches value against pattern, which may be a
+// left-most-label wildcard ("*.corp.example.com") or an exact hostname.
+// Unlike a TLS SAN wildcard, the wildcard label replaces exactly one label
+// - "*.corp.example.com" matches "foo.corp.example.com" but not
+// "a.b.corp.example.com" or "corp.example.com" itself.
+func matchesDNSPattern(value, pattern string) bool {
+	if !strings.HasPrefix(pattern, "*.") {
+		return value == pattern
+	}
+
+	valueLabels := strings.Split(value, ".")
+	patternLabels := strings.Split(pattern, ".")
+	if len(valueLabels) != len(patternLabels) {
+		return false
+	}
+	for i := 1; i < len(patternLabels); i++ {
+		if valueLabels[i] != patternLabels[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesCIDRPattern matches an IP literal value against pattern, which may
+// itself be a CIDR range or a single IP.
+func matchesCIDRPattern(value, pattern string) bool {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return false
+	}
+	if prefix, err := netip.ParsePrefix(pattern); err == nil {
+		return prefix.Contains(addr)
+	}
+	patternAddr, err := netip.ParseAddr(pattern)
+	if err != nil {
+		return false
+	}
+	return addr == patternAddr
+}
+
+// matchesEmailPattern matches value against an exact address or an
+// "@domain" pattern matching any local part at that domain.
+func matchesEmailPattern(value, pattern string) bool {
+	if strings.HasPrefix(pattern, "@") {
+		_, domain, found := strings.Cut(value, "@")
+		return found && "@"+domain == pattern
+	}
+	return value == pattern
+}
+
+// matchesURIPattern matches value's scheme, host, and path prefix against
+// pattern's.
+func matchesURIPattern(value, pattern string) bool {
+	valueURL, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	patternURL, err := url.Parse(pattern)
+	if err != nil {
+		return false
+	}
+	return valueURL.Scheme == patternURL.Scheme &&
+		valueURL.Host == patternURL.Host &&
+		strings.HasPrefix(valueURL.Path, patternURL.Path)
+}
+
+// evaluateResourceNameConstraints classifies the resource identifier cond
+// addresses and checks it against cond.Value's name constraints, returning
+// whether the condition matches - which, following ConditionTypeRate's
+// "matched means act" convention, means the identifier is DENIED: it hit a
+// deny pattern, or its category declares an allow list the identifier isn't
+// on. A malformed Value never matches, the same fail-closed-to-no-match
+// behavior an invalid rate Condition.Value gets.
+func (e *Engine) evaluateResourceNameConstraints(cond *models.Condition, req *models.PolicyEvaluationRequest) bool {
+	nc, err := parseNameConstraints(cond.Value)
+	if err != nil {
+		log.WithError(err).Warn("Invalid name constraints condition")
+		return false
+	}
+
+	value := e.getFieldValue(req.Resource, cond.Field, req.Context)
+	valueStr, ok := value.(string)
+	if !ok {
+		return false
+	}
+
+	category := identifierCategory(valueStr)
+	if categoryMatches(category, valueStr, nc.Deny.patternsFor(category)) {
+		return true
+	}
+
+	allowed := nc.Allow.patternsFor(category)
+	if len(allowed) == 0 {
+		return false
+	}
+	return !categoryMatches(category, valueStr, allowed)
+}
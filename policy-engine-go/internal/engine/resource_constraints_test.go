@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+func nameConstraintsValue(allow, deny map[string]interface{}) map[string]interface{} {
+	v := map[string]interface{}{}
+	if allow != nil {
+		v["allow"] = allow
+	}
+	if deny != nil {
+		v["deny"] = deny
+	}
+	return v
+}
+
+func stringArray(values ...string) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+func TestIdentifierCategory(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"10.0.0.1", "cidr"},
+		{"https://api.example.com/v1", "uri"},
+		{"alice@example.com", "email"},
+		{"corp.example.com", "dns"},
+		{"arn:aws:iam::123456789012:role/admin", "principal"},
+	}
+	for _, tt := range tests {
+		if got := identifierCategory(tt.value); got != tt.want {
+			t.Errorf("identifierCategory(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesDNSPattern(t *testing.T) {
+	if !matchesDNSPattern("foo.corp.example.com", "*.corp.example.com") {
+		t.Error("expected a single left-most label to match the wildcard")
+	}
+	if matchesDNSPattern("a.b.corp.example.com", "*.corp.example.com") {
+		t.Error("expected the wildcard to replace exactly one label, not match an extra one")
+	}
+	if matchesDNSPattern("corp.example.com", "*.corp.example.com") {
+		t.Error("expected the wildcard pattern itself (no extra label) not to match")
+	}
+	if !matchesDNSPattern("corp.example.com", "corp.example.com") {
+		t.Error("expected an exact hostname match with no wildcard")
+	}
+}
+
+func TestMatchesCIDRPattern(t *testing.T) {
+	if !matchesCIDRPattern("10.0.0.5", "10.0.0.0/24") {
+		t.Error("expected an address inside the CIDR range to match")
+	}
+	if matchesCIDRPattern("10.0.1.5", "10.0.0.0/24") {
+		t.Error("expected an address outside the CIDR range not to match")
+	}
+	if !matchesCIDRPattern("10.0.0.5", "10.0.0.5") {
+		t.Error("expected an exact single-IP pattern to match")
+	}
+}
+
+func TestEvaluateResourceNameConstraintsDenyTakesPrecedenceOverAllow(t *testing.T) {
+	e := NewEngine(nil)
+	cond := &models.Condition{
+		Field: "resource",
+		Value: nameConstraintsValue(
+			map[string]interface{}{"dns": stringArray("*.corp.example.com")},
+			map[string]interface{}{"dns": stringArray("blocked.corp.example.com")},
+		),
+	}
+	req := &models.PolicyEvaluationRequest{Resource: "blocked.corp.example.com"}
+
+	if !e.evaluateResourceNameConstraints(cond, req) {
+		t.Error("expected a deny-listed identifier to match (deny) even though it also satisfies the allow list")
+	}
+}
+
+func TestEvaluateResourceNameConstraintsAllowListRejectsUnlistedIdentifier(t *testing.T) {
+	e := NewEngine(nil)
+	cond := &models.Condition{
+		Field: "resource",
+		Value: nameConstraintsValue(
+			map[string]interface{}{"dns": stringArray("*.corp.example.com")},
+			nil,
+		),
+	}
+	req := &models.PolicyEvaluationRequest{Resource: "evil.external.com"}
+
+	if !e.evaluateResourceNameConstraints(cond, req) {
+		t.Error("expected an identifier outside the category's allow list to match (deny)")
+	}
+}
+
+func TestEvaluateResourceNameConstraintsAllowListAdmitsListedIdentifier(t *testing.T) {
+	e := NewEngine(nil)
+	cond := &models.Condition{
+		Field: "resource",
+		Value: nameConstraintsValue(
+			map[string]interface{}{"dns": stringArray("*.corp.example.com")},
+			nil,
+		),
+	}
+	req := &models.PolicyEvaluationRequest{Resource: "foo.corp.example.com"}
+
+	if e.evaluateResourceNameConstraints(cond, req) {
+		t.Error("expected an identifier covered by the allow list not to match (allow)")
+	}
+}
+
+func TestEvaluateResourceNameConstraintsNoAllowListIsAllowAll(t *testing.T) {
+	e := NewEngine(nil)
+	cond := &models.Condition{
+		Field: "resource",
+		Value: nameConstraintsValue(nil, map[string]interface{}{"email": stringArray("@blocked.example.com")}),
+	}
+	req := &models.PolicyEvaluationRequest{Resource: "alice@anywhere.example.com"}
+
+	if e.evaluateResourceNameConstraints(cond, req) {
+		t.Error("expected a category with no allow entries to implicitly allow everything not denied")
+	}
+}
+
+func TestEvaluateResourceNameConstraintsMalformedValueFailsClosed(t *testing.T) {
+	e := NewEngine(nil)
+	cond := &models.Condition{Field: "resource", Value: "not-an-object"}
+	req := &models.PolicyEvaluationRequest{Resource: "foo.corp.example.com"}
+
+	if e.evaluateResourceNameConstraints(cond, req) {
+		t.Error("expected a malformed name-constraints Value never to match, the same fail-closed-to-no-match treatment an invalid rate condition gets")
+	}
+}
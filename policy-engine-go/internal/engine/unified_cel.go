@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/google/cel-go/cel"
+	log "github.com/sirupsen/logrus"
+)
+
+// unifiedExpressionEnv is the CEL environment RuleOpCEL conditions compile
+// against. Its variables mirror SimulationContext.attributes(): every
+// variable is DynType since the tool/principal trees are caller-supplied and
+// have no fixed shape.
+var unifiedExpressionEnv, unifiedExpressionEnvErr = cel.NewEnv(
+	cel.Variable("resource_type", cel.DynType),
+	cel.Variable("resource_id", cel.DynType),
+	cel.Variable("tool", cel.DynType),
+	cel.Variable("principal", cel.DynType),
+)
+
+// prepareUnifiedExpression compiles and plans a RuleOpCEL expression against
+// unifiedExpressionEnv. Both CompileRuleExpression (at rule-save time) and
+// evaluateCELCondition (at simulation time) call this, so a bad expression
+// is rejected in exactly one place rather than two diverging checks -
+// mirroring PrepareExpression's role for the Enhanced subsystem.
+func prepareUnifiedExpression(expr string) (cel.Program, error) {
+	if unifiedExpressionEnvErr != nil {
+		return nil, fmt.Errorf("expression environment unavailable: %w", unifiedExpressionEnvErr)
+	}
+	ast, issues := unifiedExpressionEnv.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile CEL expression: %w", issues.Err())
+	}
+	program, err := unifiedExpressionEnv.Program(ast, cel.CostLimit(expressionCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan CEL expression program: %w", err)
+	}
+	return program, nil
+}
+
+// evaluateCELCondition evaluates cond's RuleOpCEL expression against attrs,
+// compiling and caching the program on cond.CompiledCache the first time
+// it's evaluated so a policy held in memory across many Simulate calls only
+// pays the compile cost once. A compile failure, eval error, deadline
+// overrun, or non-bool result are all treated as a non-match rather than a
+// fatal error, the same fail-closed-but-non-fatal treatment
+// matchesRuleOperator's default case gives an unrecognized operator.
+func evaluateCELCondition(cond *models.RuleCondition, attrs map[string]interface{}) bool {
+	program, ok := cond.CompiledCache.(cel.Program)
+	if !ok {
+		expr, ok := cond.Value.(string)
+		if !ok {
+			log.Warn("CEL rule condition value is not a string, treating as non-match")
+			return false
+		}
+		compiled, err := prepareUnifiedExpression(expr)
+		if err != nil {
+			log.WithError(err).Warn("Failed to compile CEL rule condition, treating as non-match")
+			return false
+		}
+		program = compiled
+		cond.CompiledCache = program
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), expressionDeadline)
+	defer cancel()
+
+	out, _, err := program.ContextEval(ctx, attrs)
+	if err != nil {
+		log.WithError(err).Warn("CEL rule condition evaluation failed")
+		return false
+	}
+	matched, ok := out.Value().(bool)
+	return ok && matched
+}
+
+// CompileRuleExpression validates the Field/Value of a leaf condition whose
+// Operator has an up-front expression to parse (RuleOpCEL, RuleOpJSONPath,
+// RuleOpCIDR, RuleOpSemverRange), called by unified.validateCondition so a
+// malformed expression is rejected by the API rather than silently
+// non-matching the first time the rule is evaluated. Operators with no such
+// expression return nil.
+func CompileRuleExpression(cond *models.RuleCondition) error {
+	switch cond.Operator {
+	case models.RuleOpCEL:
+		expr, ok := cond.Value.(string)
+		if !ok {
+			return fmt.Errorf("cel operator requires a string expression in value")
+		}
+		_, err := prepareUnifiedExpression(expr)
+		return err
+	case models.RuleOpJSONPath:
+		_, err := parseJSONPath(cond.Field)
+		return err
+	case models.RuleOpCIDR:
+		return validateCIDRValue(cond.Value)
+	case models.RuleOpSemverRange:
+		expr, ok := cond.Value.(string)
+		if !ok {
+			return fmt.Errorf("semver_range operator requires a string range in value")
+		}
+		_, err := parseSemverRange(expr)
+		return err
+	}
+	return nil
+}
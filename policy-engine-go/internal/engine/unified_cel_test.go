@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+func TestEvaluateCELConditionMatchesAndCachesProgram(t *testing.T) {
+	cond := &models.RuleCondition{
+		Operator: models.RuleOpCEL,
+		Value:    `resource_type == "database"`,
+	}
+
+	if !evaluateCELCondition(cond, map[string]interface{}{"resource_type": "database"}) {
+		t.Fatal("expected a matching CEL expression to evaluate true")
+	}
+	if evaluateCELCondition(cond, map[string]interface{}{"resource_type": "queue"}) {
+		t.Fatal("expected a non-matching CEL expression to evaluate false")
+	}
+	if cond.CompiledCache == nil {
+		t.Fatal("expected evaluateCELCondition to populate CompiledCache after the first evaluation")
+	}
+}
+
+func TestEvaluateCELConditionTreatsCompileFailureAsNonMatch(t *testing.T) {
+	cond := &models.RuleCondition{
+		Operator: models.RuleOpCEL,
+		Value:    `this is not valid CEL (((`,
+	}
+
+	if evaluateCELCondition(cond, map[string]interface{}{}) {
+		t.Fatal("expected an uncompilable CEL expression to evaluate as a non-match, not panic or error out")
+	}
+}
+
+func TestEvaluateCELConditionTreatsNonStringValueAsNonMatch(t *testing.T) {
+	cond := &models.RuleCondition{
+		Operator: models.RuleOpCEL,
+		Value:    42,
+	}
+
+	if evaluateCELCondition(cond, map[string]interface{}{}) {
+		t.Fatal("expected a non-string condition value to evaluate as a non-match")
+	}
+}
+
+func TestEvaluateCELConditionTreatsNonBoolResultAsNonMatch(t *testing.T) {
+	cond := &models.RuleCondition{
+		Operator: models.RuleOpCEL,
+		Value:    `resource_type`,
+	}
+
+	if evaluateCELCondition(cond, map[string]interface{}{"resource_type": "database"}) {
+		t.Fatal("expected a non-bool CEL result to evaluate as a non-match")
+	}
+}
+
+func TestCompileRuleExpressionValidatesCELOperator(t *testing.T) {
+	valid := &models.RuleCondition{Operator: models.RuleOpCEL, Value: `resource_type == "database"`}
+	if err := CompileRuleExpression(valid); err != nil {
+		t.Fatalf("expected a valid CEL expression to compile, got: %v", err)
+	}
+
+	invalid := &models.RuleCondition{Operator: models.RuleOpCEL, Value: `not valid CEL (((`}
+	if err := CompileRuleExpression(invalid); err == nil {
+		t.Fatal("expected an invalid CEL expression to fail CompileRuleExpression")
+	}
+
+	wrongType := &models.RuleCondition{Operator: models.RuleOpCEL, Value: 42}
+	if err := CompileRuleExpression(wrongType); err == nil {
+		t.Fatal("expected a non-string cel value to fail CompileRuleExpression")
+	}
+}
@@ -0,0 +1,347 @@
+package engine
+
+import (
+	"reflect"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// RuleConflict describes a detected contradiction between two rules bound to
+// the same resource but belonging to different policies, for
+// GET /unified/resources/:type/:id/conflicts and
+// GET /unified/policies/conflicts.
+type RuleConflict struct {
+	ResourceType models.ResourceType `json:"resource_type,omitempty"`
+	ResourceID   string              `json:"resource_id,omitempty"`
+
+	PolicyAID   string                `json:"policy_a_id"`
+	PolicyACode string                `json:"policy_a_code"`
+	RuleAID     string                `json:"rule_a_id"`
+	ActionA     models.RuleActionType `json:"action_a"`
+
+	PolicyBID   string                `json:"policy_b_id"`
+	PolicyBCode string                `json:"policy_b_code"`
+	RuleBID     string                `json:"rule_b_id"`
+	ActionB     models.RuleActionType `json:"action_b"`
+
+	// Resolved is true when priority or deny_override deterministically
+	// picks a winner; false means the two rules are tied, and Suggestions
+	// offers ways to break the tie.
+	Resolved       bool     `json:"resolved"`
+	WinnerPolicyID string   `json:"winner_policy_id,omitempty"`
+	WinnerRuleID   string   `json:"winner_rule_id,omitempty"`
+	Suggestions    []string `json:"suggestions,omitempty"`
+}
+
+// maxConflictClauses bounds the DNF expansion conjunctiveClauses performs on
+// a single RuleCondition tree, so a pathologically nested All/Any tree can't
+// make DetectConflicts run away. Exceeding it falls back to treating the
+// condition as unconditionally true (i.e. a possible overlap with anything)
+// rather than silently under-reporting.
+const maxConflictClauses = 64
+
+// DetectConflicts compares every pair of rules from different policies in
+// policies - all expected to be bound to (resourceType, resourceID) - and
+// reports one RuleConflict per pair whose actions contradict (one allows,
+// one denies) and whose conditions can't be proven disjoint by the
+// symbolic evaluator below. Rules within the same policy are never
+// compared - intra-policy precedence is PolicyRuleDSL.Priority, already
+// handled by Simulate's winner selection.
+func DetectConflicts(resourceType models.ResourceType, resourceID string, policies []*models.UnifiedPolicy) []RuleConflict {
+	var conflicts []RuleConflict
+	for i := 0; i < len(policies); i++ {
+		for j := i + 1; j < len(policies); j++ {
+			polA, polB := policies[i], policies[j]
+			if polA.PolicyID == polB.PolicyID {
+				continue
+			}
+			for ri := range polA.PolicyRules {
+				for rj := range polB.PolicyRules {
+					ruleA, ruleB := &polA.PolicyRules[ri], &polB.PolicyRules[rj]
+					actionA, actionB, ok := contradictingActions(ruleA.Actions, ruleB.Actions)
+					if !ok {
+						continue
+					}
+					if !conditionsMayOverlap(ruleA.Conditions, ruleB.Conditions) {
+						continue
+					}
+					conflict := buildConflict(polA, ruleA, actionA, polB, ruleB, actionB)
+					conflict.ResourceType = resourceType
+					conflict.ResourceID = resourceID
+					conflicts = append(conflicts, conflict)
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// contradictingActions reports whether a and b contain opposite Allow/Deny
+// actions, returning which side holds which so buildConflict can label them.
+// Other action type combinations (redact, transform, audit) aren't treated
+// as contradictions - they compose rather than override each other.
+func contradictingActions(a, b []models.RuleAction) (models.RuleActionType, models.RuleActionType, bool) {
+	aAllow, aDeny := hasAction(a, models.RuleActionAllow), hasAction(a, models.RuleActionDeny)
+	bAllow, bDeny := hasAction(b, models.RuleActionAllow), hasAction(b, models.RuleActionDeny)
+	if aAllow && bDeny {
+		return models.RuleActionAllow, models.RuleActionDeny, true
+	}
+	if aDeny && bAllow {
+		return models.RuleActionDeny, models.RuleActionAllow, true
+	}
+	return "", "", false
+}
+
+func hasAction(actions []models.RuleAction, t models.RuleActionType) bool {
+	for _, a := range actions {
+		if a.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// buildConflict assembles the RuleConflict report for one contradicting rule
+// pair, resolving a winner when priority or deny_override breaks the tie.
+func buildConflict(polA *models.UnifiedPolicy, ruleA *models.PolicyRuleDSL, actionA models.RuleActionType,
+	polB *models.UnifiedPolicy, ruleB *models.PolicyRuleDSL, actionB models.RuleActionType) RuleConflict {
+
+	conflict := RuleConflict{
+		PolicyAID: polA.PolicyID, PolicyACode: polA.PolicyCode, RuleAID: ruleA.RuleID, ActionA: actionA,
+		PolicyBID: polB.PolicyID, PolicyBCode: polB.PolicyCode, RuleBID: ruleB.RuleID, ActionB: actionB,
+	}
+
+	if winnerPolicy, winnerRule, viaDenyOverride := resolveConflictWinner(polA, ruleA, polB, ruleB); winnerPolicy != nil {
+		conflict.Resolved = true
+		conflict.WinnerPolicyID = winnerPolicy.PolicyID
+		conflict.WinnerRuleID = winnerRule.RuleID
+		if viaDenyOverride {
+			conflict.Suggestions = []string{"resolved: winner's policy is marked deny_override"}
+		} else {
+			conflict.Suggestions = []string{"resolved by rule/policy priority ordering"}
+		}
+		return conflict
+	}
+
+	conflict.Resolved = false
+	conflict.Suggestions = []string{
+		"raise the priority of the intended-winner rule or policy so the conflict resolves deterministically",
+		"add an exclusion condition to one rule so their conditions no longer overlap",
+		"mark the deny-side policy deny_override so it wins regardless of priority",
+	}
+	return conflict
+}
+
+// resolveConflictWinner picks which of two contradicting rules takes effect,
+// first honoring DenyOverride (a deny rule on a deny_override policy beats a
+// non-deny_override opponent outright), then PolicyRuleDSL.Priority - the
+// tie-break Simulate already uses within a single policy - then
+// UnifiedPolicy.Priority, the cross-policy ordering ListPolicies/
+// GetPoliciesByResource sort by. Returns (nil, nil, false) when nothing
+// breaks the tie.
+func resolveConflictWinner(polA *models.UnifiedPolicy, ruleA *models.PolicyRuleDSL, polB *models.UnifiedPolicy, ruleB *models.PolicyRuleDSL) (*models.UnifiedPolicy, *models.PolicyRuleDSL, bool) {
+	aDenyOverride := polA.DenyOverride && hasAction(ruleA.Actions, models.RuleActionDeny)
+	bDenyOverride := polB.DenyOverride && hasAction(ruleB.Actions, models.RuleActionDeny)
+	if aDenyOverride && !bDenyOverride {
+		return polA, ruleA, true
+	}
+	if bDenyOverride && !aDenyOverride {
+		return polB, ruleB, true
+	}
+
+	if ruleA.Priority != ruleB.Priority {
+		if ruleA.Priority > ruleB.Priority {
+			return polA, ruleA, false
+		}
+		return polB, ruleB, false
+	}
+	if polA.Priority != polB.Priority {
+		if polA.Priority > polB.Priority {
+			return polA, ruleA, false
+		}
+		return polB, ruleB, false
+	}
+	return nil, nil, false
+}
+
+// conditionsMayOverlap is the symbolic evaluator: it expands both condition
+// trees into disjunctive-normal-form clause sets (conjunctiveClauses) and
+// reports true unless every pair of clauses between the two sides can be
+// proven disjoint.
+func conditionsMayOverlap(a, b *models.RuleCondition) bool {
+	clausesA := conjunctiveClauses(a)
+	clausesB := conjunctiveClauses(b)
+	for _, ca := range clausesA {
+		for _, cb := range clausesB {
+			if clauseMayOverlap(ca, cb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// conjunctiveClauses expands cond into DNF: a list of AND-clauses (each a
+// flat list of leaf conditions) such that cond is satisfied iff at least one
+// clause's leaves are all satisfied. A nil cond (no conditions on the rule)
+// is unconditionally true, represented as a single empty clause.
+func conjunctiveClauses(cond *models.RuleCondition) [][]*models.RuleCondition {
+	if cond == nil {
+		return [][]*models.RuleCondition{{}}
+	}
+	if len(cond.All) == 0 && len(cond.Any) == 0 {
+		return [][]*models.RuleCondition{{cond}}
+	}
+
+	allClauses := [][]*models.RuleCondition{{}}
+	for i := range cond.All {
+		allClauses = crossProductClauses(allClauses, conjunctiveClauses(&cond.All[i]))
+		if len(allClauses) > maxConflictClauses {
+			return [][]*models.RuleCondition{{}}
+		}
+	}
+
+	if len(cond.Any) == 0 {
+		return allClauses
+	}
+
+	var anyClauses [][]*models.RuleCondition
+	for i := range cond.Any {
+		anyClauses = append(anyClauses, conjunctiveClauses(&cond.Any[i])...)
+		if len(anyClauses) > maxConflictClauses {
+			return [][]*models.RuleCondition{{}}
+		}
+	}
+
+	result := crossProductClauses(allClauses, anyClauses)
+	if len(result) > maxConflictClauses {
+		return [][]*models.RuleCondition{{}}
+	}
+	return result
+}
+
+func crossProductClauses(a, b [][]*models.RuleCondition) [][]*models.RuleCondition {
+	result := make([][]*models.RuleCondition, 0, len(a)*len(b))
+	for _, ca := range a {
+		for _, cb := range b {
+			merged := make([]*models.RuleCondition, 0, len(ca)+len(cb))
+			merged = append(merged, ca...)
+			merged = append(merged, cb...)
+			result = append(result, merged)
+		}
+	}
+	return result
+}
+
+// clauseMayOverlap reports whether two AND-clauses can be simultaneously
+// satisfied: true unless some pair of leaves sharing a Field is provably
+// incompatible.
+func clauseMayOverlap(a, b []*models.RuleCondition) bool {
+	for _, la := range a {
+		for _, lb := range b {
+			if la.Field == "" || la.Field != lb.Field {
+				continue
+			}
+			if !leafCompatible(la, lb) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// leavesRequiringValue are operators whose actual value must exist and
+// satisfy some constraint - incompatible with RuleOpNotExists on the same
+// field.
+var leavesRequiringValue = map[models.RuleOperator]bool{
+	models.RuleOpEquals: true, models.RuleOpGt: true, models.RuleOpLt: true,
+	models.RuleOpGte: true, models.RuleOpLte: true, models.RuleOpIn: true,
+	models.RuleOpContains: true, models.RuleOpCIDR: true, models.RuleOpSemverRange: true,
+}
+
+// leafCompatible decides whether two leaf conditions on the same Field can
+// both hold for some value. It only proves disjointness for the operator
+// combinations below (equals/not_equals/in/not_in/exists/not_exists and
+// numeric comparisons); any other combination - regex, contains, cel,
+// json_path, cidr, semver_range, or an unrecognized pairing - can't be
+// proven disjoint by this evaluator, so it conservatively reports a
+// possible overlap rather than hiding a real conflict.
+func leafCompatible(a, b *models.RuleCondition) bool {
+	if (a.Operator == models.RuleOpExists && b.Operator == models.RuleOpNotExists) ||
+		(a.Operator == models.RuleOpNotExists && b.Operator == models.RuleOpExists) {
+		return false
+	}
+	if a.Operator == models.RuleOpNotExists && leavesRequiringValue[b.Operator] {
+		return false
+	}
+	if b.Operator == models.RuleOpNotExists && leavesRequiringValue[a.Operator] {
+		return false
+	}
+
+	switch {
+	case a.Operator == models.RuleOpEquals && b.Operator == models.RuleOpEquals:
+		return reflect.DeepEqual(a.Value, b.Value)
+	case a.Operator == models.RuleOpEquals && b.Operator == models.RuleOpNotEquals,
+		a.Operator == models.RuleOpNotEquals && b.Operator == models.RuleOpEquals:
+		return !reflect.DeepEqual(a.Value, b.Value)
+	case a.Operator == models.RuleOpEquals && b.Operator == models.RuleOpIn:
+		return inSlice(a.Value, b.Value)
+	case a.Operator == models.RuleOpIn && b.Operator == models.RuleOpEquals:
+		return inSlice(b.Value, a.Value)
+	case a.Operator == models.RuleOpEquals && b.Operator == models.RuleOpNotIn:
+		return !inSlice(a.Value, b.Value)
+	case a.Operator == models.RuleOpNotIn && b.Operator == models.RuleOpEquals:
+		return !inSlice(b.Value, a.Value)
+	}
+
+	if ba, ok := numericBound(a.Operator, a.Value); ok {
+		if bb, ok := numericBound(b.Operator, b.Value); ok {
+			return boundsOverlap(ba, bb)
+		}
+	}
+
+	return true
+}
+
+// numericBound converts a scalar comparison operator/value into the half-
+// open or closed interval it constrains the field to, for overlap-checking
+// two numeric comparisons on the same field (e.g. "gt 10" vs "lte 5").
+type numericRange struct {
+	lo, hi         float64
+	loSet, hiSet   bool
+	loIncl, hiIncl bool
+}
+
+func numericBound(op models.RuleOperator, value interface{}) (numericRange, bool) {
+	v, ok := toFloat64(value)
+	if !ok {
+		return numericRange{}, false
+	}
+	switch op {
+	case models.RuleOpEquals:
+		return numericRange{lo: v, hi: v, loSet: true, hiSet: true, loIncl: true, hiIncl: true}, true
+	case models.RuleOpGt:
+		return numericRange{lo: v, loSet: true, loIncl: false}, true
+	case models.RuleOpGte:
+		return numericRange{lo: v, loSet: true, loIncl: true}, true
+	case models.RuleOpLt:
+		return numericRange{hi: v, hiSet: true, hiIncl: false}, true
+	case models.RuleOpLte:
+		return numericRange{hi: v, hiSet: true, hiIncl: true}, true
+	}
+	return numericRange{}, false
+}
+
+func boundsOverlap(a, b numericRange) bool {
+	if a.loSet && b.hiSet {
+		if a.lo > b.hi || (a.lo == b.hi && !(a.loIncl && b.hiIncl)) {
+			return false
+		}
+	}
+	if b.loSet && a.hiSet {
+		if b.lo > a.hi || (b.lo == a.hi && !(b.loIncl && a.hiIncl)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/google/cel-go/cel"
+	log "github.com/sirupsen/logrus"
+)
+
+// unifiedConditionExprEnv is the CEL environment RuleCondition.Expression
+// compiles against. Its bindings are richer than unifiedExpressionEnv's
+// (RuleOpCEL's flat resource_type/resource_id/tool/principal set): input,
+// user, resource, context, and now - predicates like
+// `user.roles.exists(r, r.startsWith("admin:")) && resource.type == "tool"`.
+// resource carries only type and id; it has no labels, so an expression
+// reading resource.labels fails at evaluation and its condition does not
+// match.
+var unifiedConditionExprEnv, unifiedConditionExprEnvErr = cel.NewEnv(
+	cel.Variable("input", cel.DynType),
+	cel.Variable("user", cel.DynType),
+	cel.Variable("resource", cel.DynType),
+	cel.Variable("context", cel.DynType),
+	cel.Variable("now", cel.TimestampType),
+)
+
+var (
+	conditionExprCacheMu sync.RWMutex
+	conditionExprCache   = make(map[string]cel.Program)
+)
+
+func conditionExprCacheKey(policyID, ruleID string, version int) string {
+	return fmt.Sprintf("%s/%s@%d", policyID, ruleID, version)
+}
+
+// compileConditionExpression compiles expr against unifiedConditionExprEnv,
+// caching the program keyed by (policyID, ruleID, version) when policyID is
+// non-empty. An inline simulation (no saved policy - see
+// unified.SimulatePolicyInline) has no stable identity to key a cache entry
+// against, so it always compiles fresh rather than risking a stale cache
+// hit across two unrelated ad hoc rule bodies that happen to reuse the same
+// RuleID.
+func compileConditionExpression(policyID, ruleID string, version int, expr string) (cel.Program, error) {
+	if unifiedConditionExprEnvErr != nil {
+		return nil, fmt.Errorf("expression environment unavailable: %w", unifiedConditionExprEnvErr)
+	}
+
+	var key string
+	if policyID != "" {
+		key = conditionExprCacheKey(policyID, ruleID, version)
+		conditionExprCacheMu.RLock()
+		if program, ok := conditionExprCache[key]; ok {
+			conditionExprCacheMu.RUnlock()
+			return program, nil
+		}
+		conditionExprCacheMu.RUnlock()
+	}
+
+	ast, issues := unifiedConditionExprEnv.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile condition expression: %w", issues.Err())
+	}
+	program, err := unifiedConditionExprEnv.Program(ast, cel.CostLimit(expressionCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan condition expression program: %w", err)
+	}
+
+	if key != "" {
+		conditionExprCacheMu.Lock()
+		conditionExprCache[key] = program
+		conditionExprCacheMu.Unlock()
+	}
+
+	return program, nil
+}
+
+// ValidateConditionExpression compiles expr against unifiedConditionExprEnv
+// without caching the result, called by unified.validateCondition so a
+// malformed RuleCondition.Expression is rejected by the create/update API
+// rather than silently non-matching the first time the rule is evaluated.
+func ValidateConditionExpression(expr string) error {
+	_, err := compileConditionExpression("", "", 0, expr)
+	return err
+}
+
+// PurgeConditionExpressions drops policyID's cached expression programs for
+// every (rule, version), so a policy edit or delete can never leave a stale
+// compiled program reachable under a RuleID the policy later reuses.
+func PurgeConditionExpressions(policyID string) {
+	prefix := policyID + "/"
+	conditionExprCacheMu.Lock()
+	defer conditionExprCacheMu.Unlock()
+	for key := range conditionExprCache {
+		if len(key) >= len(prefix) && key[:len(prefix)] == prefix {
+			delete(conditionExprCache, key)
+		}
+	}
+}
+
+// evaluateConditionExpression evaluates cond.Expression (the caller has
+// already confirmed it's non-empty) against attrs - the same attribute tree
+// matchesRuleOperator reads via lookupAttribute - exposing it under richer
+// input/user/resource/context/now bindings. Compile/eval errors, a CEL cost
+// limit overrun, and a non-bool result are all treated as a non-match
+// rather than a fatal error, the same fail-closed-but-non-fatal treatment
+// evaluateCELCondition gives RuleOpCEL.
+func evaluateConditionExpression(policyID string, version int, ruleID string, cond *models.RuleCondition, attrs map[string]interface{}) bool {
+	program, err := compileConditionExpression(policyID, ruleID, version, cond.Expression)
+	if err != nil {
+		log.WithError(err).WithField("rule", ruleID).Warn("Failed to compile rule condition expression, treating as non-match")
+		return false
+	}
+
+	evalCtx, cancel := context.WithTimeout(context.Background(), expressionDeadline)
+	defer cancel()
+
+	var resourceContext interface{}
+	if tool, ok := attrs["tool"].(map[string]interface{}); ok {
+		resourceContext = tool["arguments"]
+	}
+
+	vars := map[string]interface{}{
+		"input": attrs,
+		"user":  attrs["principal"],
+		"resource": map[string]interface{}{
+			"type": attrs["resource_type"],
+			"id":   attrs["resource_id"],
+		},
+		"context": resourceContext,
+		"now":     time.Now(),
+	}
+
+	out, _, err := program.ContextEval(evalCtx, vars)
+	if err != nil {
+		log.WithError(err).WithField("rule", ruleID).Warn("Rule condition expression evaluation failed")
+		return false
+	}
+	matched, ok := out.Value().(bool)
+	return ok && matched
+}
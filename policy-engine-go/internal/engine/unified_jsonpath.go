@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// jsonPathSegment is one step of a parsed RuleOpJSONPath field, a dot-
+// separated key optionally followed by a "[N]" index or "[*]" wildcard, e.g.
+// "tool.arguments.items[*].id" parses into segments
+// {key:"tool"} {key:"arguments"} {key:"items"} {wildcard:true} {key:"id"}.
+type jsonPathSegment struct {
+	key      string
+	index    int
+	hasIndex bool
+	wildcard bool
+}
+
+// parseJSONPath validates and parses a RuleOpJSONPath field at rule-save
+// time (via CompileRuleExpression) and again the first time the condition is
+// evaluated.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("json_path field must not be empty")
+	}
+
+	var segments []jsonPathSegment
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		var suffix string
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, fmt.Errorf("invalid json_path %q: unterminated '[' in %q", path, part)
+			}
+			key = part[:i]
+			suffix = part[i+1 : len(part)-1]
+		}
+		if key == "" {
+			return nil, fmt.Errorf("invalid json_path %q: empty segment", path)
+		}
+		segments = append(segments, jsonPathSegment{key: key})
+
+		if suffix == "" {
+			continue
+		}
+		if suffix == "*" {
+			segments = append(segments, jsonPathSegment{wildcard: true})
+			continue
+		}
+		idx, err := strconv.Atoi(suffix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid json_path %q: index %q is not an integer or '*'", path, suffix)
+		}
+		segments = append(segments, jsonPathSegment{index: idx, hasIndex: true})
+	}
+	return segments, nil
+}
+
+// evalJSONPath walks segments against root, returning the resolved value and
+// whether the full path resolved. A wildcard segment fans out into a
+// []interface{} holding the remaining path's result for every element of
+// the array at that point, skipping elements where the remaining path
+// doesn't resolve.
+func evalJSONPath(segments []jsonPathSegment, root interface{}) (interface{}, bool) {
+	current := root
+	for i, seg := range segments {
+		switch {
+		case seg.wildcard:
+			slice, ok := current.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			rest := segments[i+1:]
+			results := make([]interface{}, 0, len(slice))
+			for _, item := range slice {
+				if len(rest) == 0 {
+					results = append(results, item)
+					continue
+				}
+				if v, ok := evalJSONPath(rest, item); ok {
+					results = append(results, v)
+				}
+			}
+			return results, true
+		case seg.hasIndex:
+			slice, ok := current.([]interface{})
+			if !ok || seg.index < 0 || seg.index >= len(slice) {
+				return nil, false
+			}
+			current = slice[seg.index]
+		default:
+			m, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			v, ok := m[seg.key]
+			if !ok {
+				return nil, false
+			}
+			current = v
+		}
+	}
+	return current, true
+}
+
+// evaluateJSONPathCondition evaluates cond's RuleOpJSONPath field against
+// attrs, caching the parsed path on cond.CompiledCache the first time it's
+// evaluated. A wildcard path matches if any element's resolved value equals
+// Value; otherwise the single resolved value is compared directly.
+func evaluateJSONPathCondition(cond *models.RuleCondition, attrs map[string]interface{}) bool {
+	segments, ok := cond.CompiledCache.([]jsonPathSegment)
+	if !ok {
+		parsed, err := parseJSONPath(cond.Field)
+		if err != nil {
+			log.WithError(err).Warn("Failed to parse json_path rule condition, treating as non-match")
+			return false
+		}
+		segments = parsed
+		cond.CompiledCache = segments
+	}
+
+	resolved, ok := evalJSONPath(segments, map[string]interface{}(attrs))
+	if !ok {
+		return false
+	}
+	if results, isSlice := resolved.([]interface{}); isSlice {
+		for _, v := range results {
+			if reflect.DeepEqual(v, cond.Value) {
+				return true
+			}
+		}
+		return false
+	}
+	return reflect.DeepEqual(resolved, cond.Value)
+}
@@ -0,0 +1,212 @@
+package engine
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// validateCIDRValue checks that value is a string CIDR or a list of string
+// CIDRs, each of which parses, the RuleOpCIDR analog of RuleOpIn's expected
+// shape for Value.
+func validateCIDRValue(value interface{}) error {
+	ranges, err := cidrRanges(value)
+	if err != nil {
+		return err
+	}
+	if len(ranges) == 0 {
+		return fmt.Errorf("cidr operator requires at least one CIDR range in value")
+	}
+	for _, cidr := range ranges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+	}
+	return nil
+}
+
+func cidrRanges(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		ranges := make([]string, 0, len(v))
+		for _, r := range v {
+			s, ok := r.(string)
+			if !ok {
+				return nil, fmt.Errorf("cidr operator requires string values")
+			}
+			ranges = append(ranges, s)
+		}
+		return ranges, nil
+	default:
+		return nil, fmt.Errorf("cidr operator requires a string or list of strings")
+	}
+}
+
+// matchesCIDR reports whether actual (an IP address string) falls inside any
+// of the CIDR ranges in expected.
+func matchesCIDR(actual, expected interface{}) bool {
+	ipStr, ok := actual.(string)
+	if !ok {
+		return false
+	}
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return false
+	}
+
+	ranges, err := cidrRanges(expected)
+	if err != nil {
+		return false
+	}
+	for _, cidr := range ranges {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// semverVersion is a parsed major.minor.patch triple. Pre-release/build
+// metadata (anything from the first '-' or '+' onward) is stripped rather
+// than compared, which is sufficient for the gateway/client-version gating
+// RuleOpSemverRange exists for.
+type semverVersion struct {
+	major, minor, patch int
+}
+
+func parseSemverVersion(v string) (semverVersion, error) {
+	trimmed := strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(trimmed, "-+"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	parts := strings.Split(trimmed, ".")
+	if len(parts) != 3 {
+		return semverVersion{}, fmt.Errorf("invalid semver %q: expected major.minor.patch", v)
+	}
+	var ints [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return semverVersion{}, fmt.Errorf("invalid semver %q: %w", v, err)
+		}
+		ints[i] = n
+	}
+	return semverVersion{major: ints[0], minor: ints[1], patch: ints[2]}, nil
+}
+
+func (v semverVersion) compare(other semverVersion) int {
+	switch {
+	case v.major != other.major:
+		return v.major - other.major
+	case v.minor != other.minor:
+		return v.minor - other.minor
+	default:
+		return v.patch - other.patch
+	}
+}
+
+// semverComparator is one "<op><version>" term of a RuleOpSemverRange
+// expression, e.g. ">=1.2.0". A range is an implicit AND of its comparators.
+type semverComparator struct {
+	op      string
+	version semverVersion
+}
+
+// semverOps lists valid comparators, longest-prefix-first so ">=" isn't
+// mistaken for ">".
+var semverOps = []string{">=", "<=", "!=", ">", "<", "="}
+
+// parseSemverRange validates and parses a RuleOpSemverRange expression at
+// rule-save time (via CompileRuleExpression) and again the first time the
+// condition is evaluated.
+func parseSemverRange(expr string) ([]semverComparator, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("semver_range expression must not be empty")
+	}
+
+	comparators := make([]semverComparator, 0, len(fields))
+	for _, term := range fields {
+		var op string
+		for _, candidate := range semverOps {
+			if strings.HasPrefix(term, candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return nil, fmt.Errorf("invalid semver_range term %q: missing comparator (expected one of %v)", term, semverOps)
+		}
+		version, err := parseSemverVersion(strings.TrimPrefix(term, op))
+		if err != nil {
+			return nil, fmt.Errorf("invalid semver_range term %q: %w", term, err)
+		}
+		comparators = append(comparators, semverComparator{op: op, version: version})
+	}
+	return comparators, nil
+}
+
+func matchesSemverRangeComparators(actual interface{}, comparators []semverComparator) bool {
+	verStr, ok := actual.(string)
+	if !ok {
+		return false
+	}
+	version, err := parseSemverVersion(verStr)
+	if err != nil {
+		return false
+	}
+	for _, c := range comparators {
+		cmp := version.compare(c.version)
+		var satisfied bool
+		switch c.op {
+		case ">=":
+			satisfied = cmp >= 0
+		case "<=":
+			satisfied = cmp <= 0
+		case "!=":
+			satisfied = cmp != 0
+		case ">":
+			satisfied = cmp > 0
+		case "<":
+			satisfied = cmp < 0
+		case "=":
+			satisfied = cmp == 0
+		}
+		if !satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluateSemverRangeCondition evaluates cond's RuleOpSemverRange range
+// against the version string at cond.Field, caching the parsed comparators
+// on cond.CompiledCache the first time it's evaluated.
+func evaluateSemverRangeCondition(cond *models.RuleCondition, attrs map[string]interface{}) bool {
+	comparators, ok := cond.CompiledCache.([]semverComparator)
+	if !ok {
+		expr, ok := cond.Value.(string)
+		if !ok {
+			log.Warn("semver_range rule condition value is not a string, treating as non-match")
+			return false
+		}
+		parsed, err := parseSemverRange(expr)
+		if err != nil {
+			log.WithError(err).Warn("Failed to parse semver_range rule condition, treating as non-match")
+			return false
+		}
+		comparators = parsed
+		cond.CompiledCache = comparators
+	}
+	return matchesSemverRangeComparators(lookupAttribute(attrs, cond.Field), comparators)
+}
@@ -0,0 +1,321 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// SimulationContext is the request tuple a PolicyRuleDSL is evaluated
+// against by Simulate, standing in for a live gateway request the way
+// models.PolicyEvaluationInput does for GetActiveByResource. A
+// RuleCondition.Field resolves by dot-separated path (e.g.
+// "tool.arguments.path", "principal.role") against the attribute tree built
+// from these fields.
+type SimulationContext struct {
+	ResourceType models.ResourceType    `json:"resource_type,omitempty"`
+	ResourceID   string                 `json:"resource_id,omitempty"`
+	ToolName     string                 `json:"tool_name,omitempty"`
+	Arguments    map[string]interface{} `json:"arguments,omitempty"`
+	Principal    map[string]interface{} `json:"principal,omitempty"`
+}
+
+// attributes flattens ctx into the map RuleCondition.Field paths resolve
+// against.
+func (ctx *SimulationContext) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"resource_type": string(ctx.ResourceType),
+		"resource_id":   ctx.ResourceID,
+		"tool": map[string]interface{}{
+			"name":      ctx.ToolName,
+			"arguments": ctx.Arguments,
+		},
+		"principal": ctx.Principal,
+	}
+}
+
+// ConditionTrace explains how a single RuleCondition - leaf or All/Any
+// composition - evaluated. Reason is only populated when Simulate is called
+// with explain=true.
+type ConditionTrace struct {
+	Field    string              `json:"field,omitempty"`
+	Operator models.RuleOperator `json:"operator,omitempty"`
+	Matched  bool                `json:"matched"`
+	Reason   string              `json:"reason,omitempty"`
+	All      []ConditionTrace    `json:"all,omitempty"`
+	Any      []ConditionTrace    `json:"any,omitempty"`
+}
+
+// RuleTrace is Simulate's per-rule decision trace.
+type RuleTrace struct {
+	RuleID    string              `json:"rule_id"`
+	Matched   bool                `json:"matched"`
+	Actions   []models.RuleAction `json:"actions,omitempty"`
+	Condition *ConditionTrace     `json:"condition,omitempty"`
+}
+
+// SimulationResult is Simulate's overall verdict: the winning rule's
+// composed actions (highest Priority among matched rules, same tie-break as
+// Engine.getActionFromPolicy) plus the full per-rule trace.
+type SimulationResult struct {
+	Matched bool                `json:"matched"`
+	Actions []models.RuleAction `json:"actions,omitempty"`
+	Rules   []RuleTrace         `json:"rules"`
+}
+
+// Simulate evaluates every rule in rules against ctx with no side effects -
+// no rate-limit buckets, no subscriber notifications, nothing persisted -
+// for the POST /unified/policies/:id/simulate dry-run endpoint. explain
+// additionally populates each RuleTrace's Condition tree with a
+// step-by-step reason per leaf condition. policyID and version identify the
+// policy rules came from, so a RuleCondition.Expression can cache its
+// compiled CEL program keyed by (policyID, RuleID, version); pass "", 0 for
+// rules with no durable identity (e.g. SimulatePolicyInline's ad hoc body),
+// which disables that cache rather than risking a stale hit.
+func Simulate(policyID string, version int, rules []models.PolicyRuleDSL, ctx *SimulationContext, explain bool) SimulationResult {
+	attrs := ctx.attributes()
+
+	result := SimulationResult{Rules: make([]RuleTrace, 0, len(rules))}
+	var winningRule *models.PolicyRuleDSL
+	winningPriority := -1
+
+	for i := range rules {
+		rule := &rules[i]
+		trace := RuleTrace{RuleID: rule.RuleID, Matched: true}
+
+		var condTrace *ConditionTrace
+		if rule.Conditions != nil {
+			condTrace = evaluateConditionTrace(policyID, version, rule.RuleID, rule.Conditions, attrs, explain)
+			trace.Matched = condTrace.Matched
+		}
+		if explain {
+			trace.Condition = condTrace
+		}
+
+		if trace.Matched {
+			trace.Actions = rule.Actions
+			result.Matched = true
+			if rule.Priority > winningPriority {
+				winningPriority = rule.Priority
+				winningRule = rule
+			}
+		}
+
+		result.Rules = append(result.Rules, trace)
+	}
+
+	if winningRule != nil {
+		result.Actions = winningRule.Actions
+	}
+
+	return result
+}
+
+// evaluateConditionTrace recursively evaluates cond. A condition with both
+// All and Any set requires every All entry to match AND at least one Any
+// entry to match, mirroring how a rule with both top-level fields would read
+// as an implicit AND between the two groups.
+func evaluateConditionTrace(policyID string, version int, ruleID string, cond *models.RuleCondition, attrs map[string]interface{}, explain bool) *ConditionTrace {
+	if len(cond.All) > 0 || len(cond.Any) > 0 {
+		trace := &ConditionTrace{Matched: true}
+
+		if len(cond.All) > 0 {
+			trace.All = make([]ConditionTrace, 0, len(cond.All))
+			for i := range cond.All {
+				sub := evaluateConditionTrace(policyID, version, ruleID, &cond.All[i], attrs, explain)
+				trace.All = append(trace.All, *sub)
+				if !sub.Matched {
+					trace.Matched = false
+				}
+			}
+		}
+
+		if len(cond.Any) > 0 {
+			trace.Any = make([]ConditionTrace, 0, len(cond.Any))
+			anyMatched := false
+			for i := range cond.Any {
+				sub := evaluateConditionTrace(policyID, version, ruleID, &cond.Any[i], attrs, explain)
+				trace.Any = append(trace.Any, *sub)
+				if sub.Matched {
+					anyMatched = true
+				}
+			}
+			trace.Matched = trace.Matched && anyMatched
+		}
+
+		return trace
+	}
+
+	// Leaf condition. An Expression takes over matching entirely, bypassing
+	// Field/Operator/Value - it's an alternative way to author a leaf, not
+	// an additional constraint layered on top of one.
+	if cond.Expression != "" {
+		matched := evaluateConditionExpression(policyID, version, ruleID, cond, attrs)
+		trace := &ConditionTrace{Matched: matched}
+		if explain {
+			trace.Reason = fmt.Sprintf("expression %q evaluated to %t", cond.Expression, matched)
+		}
+		return trace
+	}
+
+	actual := lookupAttribute(attrs, cond.Field)
+	matched := matchesRuleOperator(cond, attrs)
+	trace := &ConditionTrace{
+		Field:    cond.Field,
+		Operator: cond.Operator,
+		Matched:  matched,
+	}
+	if explain {
+		trace.Reason = explainCondition(cond.Field, cond.Operator, actual, cond.Value, matched)
+	}
+	return trace
+}
+
+// OperatorPlugin evaluates a RuleOperator outside the built-in set,
+// matchesRuleOperator's fallback for any operator it doesn't itself
+// recognize. Registered via RegisterOperatorPlugin, normally from
+// unified.Handler.SetOperatorPlugin at startup, so a deployment can add
+// organization-specific comparison logic (e.g. an internal entitlement
+// lookup) without forking the DSL.
+type OperatorPlugin func(cond *models.RuleCondition, attrs map[string]interface{}) bool
+
+// operatorPlugins holds every operator registered via RegisterOperatorPlugin.
+var operatorPlugins = map[models.RuleOperator]OperatorPlugin{}
+
+// RegisterOperatorPlugin installs fn as the evaluator for a custom
+// RuleOperator not in the built-in set. Registering the same operator twice
+// overwrites the previous plugin - last writer wins, since this is expected
+// to be called once at startup, not concurrently with evaluation.
+func RegisterOperatorPlugin(op models.RuleOperator, fn OperatorPlugin) {
+	operatorPlugins[op] = fn
+}
+
+// IsOperatorPluginRegistered reports whether op has a plugin registered via
+// RegisterOperatorPlugin, so unified.validateCondition can accept it
+// alongside the built-in operator set.
+func IsOperatorPluginRegistered(op models.RuleOperator) bool {
+	_, ok := operatorPlugins[op]
+	return ok
+}
+
+// lookupAttribute resolves a dot-notation path (e.g. "tool.arguments.path")
+// against nested map[string]interface{} data, returning nil if any segment
+// is missing or not itself a map.
+func lookupAttribute(attrs map[string]interface{}, path string) interface{} {
+	if path == "" {
+		return nil
+	}
+	var current interface{} = attrs
+	for _, part := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+	return current
+}
+
+// matchesRuleOperator dispatches cond's Operator against attrs, the
+// PolicyRuleDSL analog of Engine.compareValues. RuleOpCEL/RuleOpJSONPath/
+// RuleOpSemverRange resolve their own field/value handling (and cache a
+// compiled form on cond.CompiledCache), so they're dispatched before the
+// plain actual/expected comparisons below.
+func matchesRuleOperator(cond *models.RuleCondition, attrs map[string]interface{}) bool {
+	switch cond.Operator {
+	case models.RuleOpCEL:
+		return evaluateCELCondition(cond, attrs)
+	case models.RuleOpJSONPath:
+		return evaluateJSONPathCondition(cond, attrs)
+	case models.RuleOpSemverRange:
+		return evaluateSemverRangeCondition(cond, attrs)
+	}
+
+	actual := lookupAttribute(attrs, cond.Field)
+	expected := cond.Value
+	switch cond.Operator {
+	case models.RuleOpExists:
+		return actual != nil
+	case models.RuleOpNotExists:
+		return actual == nil
+	case models.RuleOpEquals:
+		return reflect.DeepEqual(actual, expected)
+	case models.RuleOpNotEquals:
+		return !reflect.DeepEqual(actual, expected)
+	case models.RuleOpIn:
+		return inSlice(actual, expected)
+	case models.RuleOpNotIn:
+		return !inSlice(actual, expected)
+	case models.RuleOpContains:
+		actualStr, aok := actual.(string)
+		substr, eok := expected.(string)
+		return aok && eok && strings.Contains(actualStr, substr)
+	case models.RuleOpNotContains:
+		actualStr, aok := actual.(string)
+		substr, eok := expected.(string)
+		return !(aok && eok && strings.Contains(actualStr, substr))
+	case models.RuleOpMatches:
+		actualStr, aok := actual.(string)
+		pattern, eok := expected.(string)
+		if !aok || !eok {
+			return false
+		}
+		matched, err := regexp.MatchString(pattern, actualStr)
+		return err == nil && matched
+	case models.RuleOpGt, models.RuleOpLt, models.RuleOpGte, models.RuleOpLte:
+		return compareRuleNumeric(actual, expected, cond.Operator)
+	case models.RuleOpCIDR:
+		return matchesCIDR(actual, expected)
+	default:
+		if plugin, ok := operatorPlugins[cond.Operator]; ok {
+			return plugin(cond, attrs)
+		}
+		return false
+	}
+}
+
+func inSlice(actual, expected interface{}) bool {
+	slice, ok := expected.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, v := range slice {
+		if reflect.DeepEqual(actual, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareRuleNumeric(actual, expected interface{}, op models.RuleOperator) bool {
+	actualFloat, aok := toFloat64(actual)
+	expectedFloat, eok := toFloat64(expected)
+	if !aok || !eok {
+		return false
+	}
+	switch op {
+	case models.RuleOpGt:
+		return actualFloat > expectedFloat
+	case models.RuleOpLt:
+		return actualFloat < expectedFloat
+	case models.RuleOpGte:
+		return actualFloat >= expectedFloat
+	case models.RuleOpLte:
+		return actualFloat <= expectedFloat
+	default:
+		return false
+	}
+}
+
+// explainCondition renders a step-by-step reason string for a single leaf
+// condition, for SimulationRequest's explain=true mode.
+func explainCondition(field string, op models.RuleOperator, actual, expected interface{}, matched bool) string {
+	verdict := "did not match"
+	if matched {
+		verdict = "matched"
+	}
+	return fmt.Sprintf("field %q %s operator %q against expected %v (actual: %v)", field, verdict, op, expected, actual)
+}
@@ -0,0 +1,158 @@
+// Package enhancedchain compiles EnhancedPolicy objects into an ordered,
+// indexed Chain, FrostFS-APE style, so EnhancedEngine.Evaluate can discard
+// policies that can't possibly apply to a request's (server, tool) pair in
+// O(1) before running the expensive per-condition loop, instead of scanning
+// every loaded policy on every request.
+package enhancedchain
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// Status is the outcome a matched chain Entry applies.
+type Status string
+
+const (
+	// Allow means the request is permitted.
+	Allow Status = "Allow"
+	// AccessDenied means the request is blocked.
+	AccessDenied Status = "AccessDenied"
+	// QuotaLimitReached means the request matched an otherwise-allowing
+	// policy whose "rate_limit" Obligation has been exceeded - see
+	// engine.checkRateLimitObligation.
+	QuotaLimitReached Status = "QuotaLimitReached"
+	// NoRuleFound is the default-deny outcome when nothing in the chain
+	// matches.
+	NoRuleFound Status = "NoRuleFound"
+)
+
+// MatchType selects how a caller walks a Chain's candidate Entries.
+type MatchType string
+
+const (
+	// FirstMatch stops at, and is decided by, the first candidate Entry
+	// whose subject/scope/conditions all match - the server-level phase's
+	// semantics in EnhancedEngine.Evaluate.
+	FirstMatch MatchType = "FirstMatch"
+	// AllMatch walks every candidate Entry and collects a result from each
+	// match instead of stopping at the first - used to gather Obligations
+	// from every matching policy, regardless of which one decides the
+	// request's Allow/Deny outcome.
+	AllMatch MatchType = "AllMatch"
+	// DenyPriority walks every candidate Entry looking for a match; the
+	// first match decides the chain immediately - the global-deny phase's
+	// semantics in EnhancedEngine.Evaluate.
+	DenyPriority MatchType = "DenyPriority"
+)
+
+// Entry is one compiled, ordered step of a Chain.
+type Entry struct {
+	PolicyID   string
+	PolicyName string
+	// Status is the outcome Source's own Action implies (Allow or
+	// AccessDenied); it does not reflect Obligation-driven overrides like
+	// QuotaLimitReached, which only a live evaluation against a request can
+	// determine.
+	Status Status
+	Source *models.EnhancedPolicy
+}
+
+// Index narrows a Chain's Entries to the ones whose AccessScope could match
+// a given (server, tool) pair in O(1), instead of running evaluateScope's
+// string-compare loops against every policy. Keys are lower-cased, matching
+// evaluateScope's case-insensitive comparisons.
+type Index struct {
+	specificTool map[string][]int // "server\x00tool" -> entry indices, PolicyScopeSpecificTools
+	entireServer map[string][]int // server -> entry indices, PolicyScopeEntireServer
+	allServers   []int            // entry indices, PolicyScopeAllServers
+}
+
+// Chain is a priority-ordered list of compiled Entries plus the Index used
+// to narrow them to a request's candidates.
+type Chain struct {
+	Entries []Entry
+	index   Index
+}
+
+// Compile builds a Chain from policies, ordered priority-descending.
+// Disabled policies are skipped entirely - they can never be a candidate.
+func Compile(policies []*models.EnhancedPolicy) Chain {
+	enabled := make([]*models.EnhancedPolicy, 0, len(policies))
+	for _, p := range policies {
+		if p.Enabled {
+			enabled = append(enabled, p)
+		}
+	}
+	sort.SliceStable(enabled, func(i, j int) bool { return enabled[i].Priority > enabled[j].Priority })
+
+	entries := make([]Entry, len(enabled))
+	index := Index{
+		specificTool: make(map[string][]int),
+		entireServer: make(map[string][]int),
+	}
+	for i, policy := range enabled {
+		entries[i] = Entry{
+			PolicyID:   policy.ID,
+			PolicyName: policy.Name,
+			Status:     statusForPolicy(policy),
+			Source:     policy,
+		}
+
+		switch policy.Scope.Type {
+		case models.PolicyScopeAllServers:
+			index.allServers = append(index.allServers, i)
+		case models.PolicyScopeEntireServer:
+			for _, server := range policy.Scope.ServerIDs {
+				key := strings.ToLower(server)
+				index.entireServer[key] = append(index.entireServer[key], i)
+			}
+		case models.PolicyScopeSpecificTools:
+			for _, server := range policy.Scope.ServerIDs {
+				for _, tool := range policy.Scope.ToolNames {
+					key := indexKey(server, tool)
+					index.specificTool[key] = append(index.specificTool[key], i)
+				}
+			}
+		}
+	}
+
+	return Chain{Entries: entries, index: index}
+}
+
+func statusForPolicy(policy *models.EnhancedPolicy) Status {
+	if policy.Action == models.PolicyActionDeny {
+		return AccessDenied
+	}
+	return Allow
+}
+
+// indexKey joins the lower-cased server and tool with a separator that
+// can't appear in either (NUL), so a name containing other punctuation
+// can't collide with a different (server, tool) pair.
+func indexKey(server, tool string) string {
+	return strings.ToLower(server) + "\x00" + strings.ToLower(tool)
+}
+
+// Candidates returns the subset of c's Entries whose AccessScope could match
+// a request naming server and tool - every PolicyScopeAllServers entry,
+// every PolicyScopeEntireServer entry scoped to server, and every
+// PolicyScopeSpecificTools entry scoped to exactly (server, tool) - in the
+// same priority order as Entries. A caller still runs the full
+// subject/scope/condition check against each candidate; Candidates only
+// rules out policies that cannot possibly match, it never asserts a match.
+func (c Chain) Candidates(server, tool string) []Entry {
+	var idxs []int
+	idxs = append(idxs, c.index.allServers...)
+	idxs = append(idxs, c.index.entireServer[strings.ToLower(server)]...)
+	idxs = append(idxs, c.index.specificTool[indexKey(server, tool)]...)
+	sort.Ints(idxs)
+
+	out := make([]Entry, len(idxs))
+	for i, idx := range idxs {
+		out[i] = c.Entries[idx]
+	}
+	return out
+}
@@ -0,0 +1,97 @@
+package enhancedhistory
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// diffEnhancedPolicy compares two EnhancedPolicy snapshots field by field,
+// reporting Conditions/Obligations as add/remove/replace ops keyed by their
+// position-independent identity so a reorder alone doesn't show up as
+// spurious churn, matching storage.diffUnifiedPolicy for the UnifiedPolicy
+// subsystem.
+func diffEnhancedPolicy(a, b *models.EnhancedPolicy) []models.EnhancedPolicyDiffOp {
+	var ops []models.EnhancedPolicyDiffOp
+
+	diffScalar(&ops, "/name", a.Name, b.Name)
+	diffScalar(&ops, "/description", a.Description, b.Description)
+	diffScalar(&ops, "/type", string(a.Type), string(b.Type))
+	diffScalar(&ops, "/action", string(a.Action), string(b.Action))
+	diffScalar(&ops, "/priority", a.Priority, b.Priority)
+	diffScalar(&ops, "/enabled", a.Enabled, b.Enabled)
+	diffScalar(&ops, "/org_id", a.OrgID, b.OrgID)
+	if !reflect.DeepEqual(a.AppliesTo, b.AppliesTo) {
+		ops = append(ops, models.EnhancedPolicyDiffOp{Op: "replace", Path: "/applies_to", Value: b.AppliesTo})
+	}
+	if !reflect.DeepEqual(a.Scope, b.Scope) {
+		ops = append(ops, models.EnhancedPolicyDiffOp{Op: "replace", Path: "/scope", Value: b.Scope})
+	}
+	if !reflect.DeepEqual(a.PayloadSchema, b.PayloadSchema) {
+		ops = append(ops, models.EnhancedPolicyDiffOp{Op: "replace", Path: "/payload_schema", Value: b.PayloadSchema})
+	}
+
+	diffConditions(&ops, a.Conditions, b.Conditions)
+	diffObligations(&ops, a.Obligations, b.Obligations)
+
+	return ops
+}
+
+func diffScalar(ops *[]models.EnhancedPolicyDiffOp, path string, a, b interface{}) {
+	if a != b {
+		*ops = append(*ops, models.EnhancedPolicyDiffOp{Op: "replace", Path: path, Value: b})
+	}
+}
+
+func diffConditions(ops *[]models.EnhancedPolicyDiffOp, a, b []models.PolicyConditionEnhanced) {
+	before := make(map[string]models.PolicyConditionEnhanced, len(a))
+	for _, cond := range a {
+		before[conditionKey(cond)] = cond
+	}
+	after := make(map[string]models.PolicyConditionEnhanced, len(b))
+	for _, cond := range b {
+		after[conditionKey(cond)] = cond
+	}
+
+	for key, cond := range after {
+		if old, ok := before[key]; !ok {
+			*ops = append(*ops, models.EnhancedPolicyDiffOp{Op: "add", Path: "/conditions/" + key, Value: cond})
+		} else if !reflect.DeepEqual(old, cond) {
+			*ops = append(*ops, models.EnhancedPolicyDiffOp{Op: "replace", Path: "/conditions/" + key, Value: cond})
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			*ops = append(*ops, models.EnhancedPolicyDiffOp{Op: "remove", Path: "/conditions/" + key})
+		}
+	}
+}
+
+func conditionKey(cond models.PolicyConditionEnhanced) string {
+	return fmt.Sprintf("%s:%s:%s", cond.Field, cond.Operator, cond.Value)
+}
+
+func diffObligations(ops *[]models.EnhancedPolicyDiffOp, a, b []models.Obligation) {
+	before := make(map[string]models.Obligation, len(a))
+	for _, ob := range a {
+		before[ob.Key] = ob
+	}
+	after := make(map[string]models.Obligation, len(b))
+	for _, ob := range b {
+		after[ob.Key] = ob
+	}
+
+	for key, ob := range after {
+		if old, ok := before[key]; !ok {
+			*ops = append(*ops, models.EnhancedPolicyDiffOp{Op: "add", Path: "/obligations/" + key, Value: ob})
+		} else if !reflect.DeepEqual(old, ob) {
+			*ops = append(*ops, models.EnhancedPolicyDiffOp{Op: "replace", Path: "/obligations/" + key, Value: ob})
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			*ops = append(*ops, models.EnhancedPolicyDiffOp{Op: "remove", Path: "/obligations/" + key})
+		}
+	}
+}
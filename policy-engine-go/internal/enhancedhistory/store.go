@@ -0,0 +1,209 @@
+// Package enhancedhistory persists a versioned, queryable audit trail for
+// EnhancedPolicy writes in a SQL table - the durable counterpart to
+// EnhancedStorage's append-only policy_history/<id>.jsonl log, which has no
+// revision IDs, can't be queried by time, and can't be rolled back to.
+// Store appends one immutable revision row per CreatePolicy/UpdatePolicy/
+// DeletePolicy/RollbackPolicy call, each holding the full policy JSON, the
+// acting user, and a diff against the immediately prior revision;
+// enhanced.Handler surfaces it via the /enhanced/policies/:id/history,
+// /enhanced/policies/:id/revisions/:rev, and
+// /enhanced/policies/:id/rollback/:rev routes, and via ?as_of= on
+// GET /enhanced/policies/:id. Modeled on storage.SQLStore: the caller opens
+// db with whichever driver matches their DSN (sqlite3, postgres, ...) and
+// registers it the usual database/sql way - Store only issues portable SQL
+// against it, selected at the process's config layer
+// (config.NewEnhancedHistoryStore) rather than hardcoded here.
+package enhancedhistory
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// schema mirrors sqlSchema's normalization approach in storage.SQLStore:
+// one row per revision, with the policy and its diff stored as JSON text
+// rather than further normalized, since revisions are read whole (by ID or
+// by policy) and never queried by an individual policy field.
+//
+// Placeholders use "?", the database/sql convention MySQL and SQLite
+// drivers expect natively; a Postgres *sql.DB must go through a driver that
+// rebinds "?" to "$N", same caveat as storage.SQLStore.
+const schema = `
+CREATE TABLE IF NOT EXISTS enhanced_policy_revisions (
+	policy_id     VARCHAR(255) NOT NULL,
+	id            VARCHAR(64)  NOT NULL,
+	op            VARCHAR(32)  NOT NULL,
+	author        VARCHAR(255),
+	timestamp     TIMESTAMP    NOT NULL,
+	snapshot_json TEXT,
+	diff_json     TEXT,
+	PRIMARY KEY (policy_id, id)
+);
+`
+
+// Store records EnhancedPolicy revisions in a SQL database reachable via db.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates a Store against db, applying schema with
+// CREATE TABLE IF NOT EXISTS so it's safe to call against an
+// already-migrated database.
+func NewStore(db *sql.DB) (*Store, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("failed to apply enhanced policy revision schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Record appends a new immutable revision for policy and returns it, with
+// Diff computed against the revision immediately prior (nil for a policy's
+// first recorded revision).
+func (s *Store) Record(policy *models.EnhancedPolicy, op, author string) (*models.EnhancedPolicyRevision, error) {
+	prior, err := s.latest(policy.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff []models.EnhancedPolicyDiffOp
+	var snapshot *models.EnhancedPolicy
+	if op != "delete" {
+		snap := *policy
+		snapshot = &snap
+	}
+	if prior != nil && prior.Snapshot != nil && snapshot != nil {
+		diff = diffEnhancedPolicy(prior.Snapshot, snapshot)
+	}
+
+	rev := &models.EnhancedPolicyRevision{
+		ID:        fmt.Sprintf("%d-%s", policy.Version, op),
+		PolicyID:  policy.ID,
+		Op:        op,
+		Author:    author,
+		Timestamp: time.Now(),
+		Snapshot:  snapshot,
+		Diff:      diff,
+	}
+
+	snapshotJSON, err := json.Marshal(rev.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy snapshot: %w", err)
+	}
+	diffJSON, err := json.Marshal(rev.Diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy diff: %w", err)
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO enhanced_policy_revisions (policy_id, id, op, author, timestamp, snapshot_json, diff_json)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, rev.PolicyID, rev.ID, rev.Op, rev.Author, rev.Timestamp, string(snapshotJSON), string(diffJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert enhanced policy revision: %w", err)
+	}
+
+	return rev, nil
+}
+
+// History returns every revision recorded for policyID, oldest first. A
+// policy with no recorded revisions returns an empty slice, not an error.
+func (s *Store) History(policyID string) ([]*models.EnhancedPolicyRevision, error) {
+	rows, err := s.db.Query(`
+		SELECT policy_id, id, op, author, timestamp, snapshot_json, diff_json
+		FROM enhanced_policy_revisions WHERE policy_id = ? ORDER BY timestamp ASC
+	`, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query enhanced policy revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*models.EnhancedPolicyRevision
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, rows.Err()
+}
+
+// GetRevision returns a single recorded revision for policyID.
+func (s *Store) GetRevision(policyID, revisionID string) (*models.EnhancedPolicyRevision, error) {
+	row := s.db.QueryRow(`
+		SELECT policy_id, id, op, author, timestamp, snapshot_json, diff_json
+		FROM enhanced_policy_revisions WHERE policy_id = ? AND id = ?
+	`, policyID, revisionID)
+
+	rev, err := scanRevision(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("revision not found: %s/%s", policyID, revisionID)
+		}
+		return nil, err
+	}
+	return rev, nil
+}
+
+// AsOf returns the revision in effect for policyID at instant at: the most
+// recent revision recorded at or before at. It returns an error if the
+// policy had no recorded revision yet at that instant.
+func (s *Store) AsOf(policyID string, at time.Time) (*models.EnhancedPolicyRevision, error) {
+	row := s.db.QueryRow(`
+		SELECT policy_id, id, op, author, timestamp, snapshot_json, diff_json
+		FROM enhanced_policy_revisions WHERE policy_id = ? AND timestamp <= ?
+		ORDER BY timestamp DESC LIMIT 1
+	`, policyID, at)
+
+	rev, err := scanRevision(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no revision of %s recorded as of %s", policyID, at.Format(time.RFC3339))
+		}
+		return nil, err
+	}
+	return rev, nil
+}
+
+func (s *Store) latest(policyID string) (*models.EnhancedPolicyRevision, error) {
+	revisions, err := s.History(policyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+	return revisions[len(revisions)-1], nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows, so scanRevision
+// can back both GetRevision/AsOf and History.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRevision(row rowScanner) (*models.EnhancedPolicyRevision, error) {
+	var rev models.EnhancedPolicyRevision
+	var author, snapshotJSON, diffJSON sql.NullString
+
+	if err := row.Scan(&rev.PolicyID, &rev.ID, &rev.Op, &author, &rev.Timestamp, &snapshotJSON, &diffJSON); err != nil {
+		return nil, err
+	}
+	rev.Author = author.String
+
+	if snapshotJSON.String != "" && snapshotJSON.String != "null" {
+		if err := json.Unmarshal([]byte(snapshotJSON.String), &rev.Snapshot); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal revision snapshot: %w", err)
+		}
+	}
+	if diffJSON.String != "" && diffJSON.String != "null" {
+		if err := json.Unmarshal([]byte(diffJSON.String), &rev.Diff); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal revision diff: %w", err)
+		}
+	}
+	return &rev, nil
+}
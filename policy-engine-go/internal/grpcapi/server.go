@@ -0,0 +1,131 @@
+// Package grpcapi implements the business logic behind the PolicyEvaluator
+// gRPC service defined in api/proto/evaluator.proto, for deployments that
+// want bidi-streaming evaluation instead of (or alongside) the NDJSON
+// POST /evaluate/stream HTTP endpoint.
+//
+// This tree has no protoc/protoc-gen-go toolchain and does not vendor
+// google.golang.org/grpc, so evaluator.pb.go / evaluator_grpc.pb.go cannot be
+// generated here. Server implements the RPC bodies against plain Go structs
+// that mirror the proto messages field-for-field; wiring it up once the
+// generated stubs exist is a matter of satisfying the generated
+// PolicyEvaluatorServer interface with these same methods and registering
+// Server on a *grpc.Server. Until then it is not referenced by cmd/server.
+package grpcapi
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/services/evaluation"
+)
+
+// EvaluationRequest mirrors the proto EvaluationRequest message.
+type EvaluationRequest struct {
+	User    string
+	Tool    string
+	Action  string
+	Context map[string]string
+	// CorrelationID is echoed back on the matching EvaluationResult -
+	// EvaluateStream's worker pool can complete requests out of order, so
+	// this is how a caller matches a result back to its request.
+	CorrelationID string
+}
+
+// EvaluationResult mirrors the proto EvaluationResult message.
+type EvaluationResult struct {
+	Action        string
+	ShouldBlock   bool
+	Matched       bool
+	Message       string
+	MatchedRules  []string
+	CorrelationID string
+	// Error is set instead of the fields above when evaluating the
+	// matching EvaluationRequest failed.
+	Error string
+}
+
+// Server implements the PolicyEvaluator RPCs against an evaluation.Service.
+type Server struct {
+	service *evaluation.Service
+	workers int // <= 0 means runtime.GOMAXPROCS(0); see SetWorkers
+}
+
+// NewServer creates a gRPC-facing evaluator server backed by service.
+func NewServer(service *evaluation.Service) *Server {
+	return &Server{service: service}
+}
+
+// SetWorkers bounds EvaluateStream's concurrent worker pool. n <= 0 (the
+// default) falls back to runtime.GOMAXPROCS(0) at call time.
+func (s *Server) SetWorkers(n int) {
+	s.workers = n
+}
+
+// Evaluate handles a single unary evaluation request.
+func (s *Server) Evaluate(req *EvaluationRequest) (*EvaluationResult, error) {
+	result, err := s.service.Evaluate(toModelsRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return fromModelsResult(req.CorrelationID, result), nil
+}
+
+// EvaluateStream evaluates requests arriving on in across a bounded worker
+// pool, sending one result per request on out as each completes - out may
+// therefore not be in the same order as in, which is what
+// EvaluationRequest/Result.CorrelationID is for. A per-request evaluation
+// error is surfaced inline as that result's Error field rather than ending
+// the stream; EvaluateStream always returns nil, once in closes and every
+// result has been sent on out.
+func (s *Server) EvaluateStream(in <-chan *EvaluationRequest, out chan<- *EvaluationResult) error {
+	defer close(out)
+
+	workers := s.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for req := range in {
+				result, err := s.service.Evaluate(toModelsRequest(req))
+				if err != nil {
+					out <- &EvaluationResult{CorrelationID: req.CorrelationID, Error: err.Error()}
+					continue
+				}
+				out <- fromModelsResult(req.CorrelationID, result)
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func toModelsRequest(req *EvaluationRequest) *models.PolicyEvaluationRequest {
+	context := make(map[string]interface{}, len(req.Context))
+	for k, v := range req.Context {
+		context[k] = v
+	}
+	return &models.PolicyEvaluationRequest{
+		User:          req.User,
+		Tool:          req.Tool,
+		Action:        req.Action,
+		Context:       context,
+		CorrelationID: req.CorrelationID,
+	}
+}
+
+func fromModelsResult(correlationID string, result *models.PolicyEvaluationResult) *EvaluationResult {
+	return &EvaluationResult{
+		Action:        string(result.Action),
+		ShouldBlock:   result.ShouldBlock,
+		Matched:       result.Matched,
+		Message:       result.Message,
+		MatchedRules:  result.MatchedRules,
+		CorrelationID: correlationID,
+	}
+}
@@ -22,7 +22,7 @@ type Handler struct {
 func NewHandler(policyDir string) (*Handler, error) {
 	// Initialize storage
 	store := storage.NewStorage(policyDir)
-	
+
 	// Load all policies
 	policies, err := store.LoadAll()
 	if err != nil {
@@ -59,7 +59,7 @@ func (h *Handler) BatchEvaluate(c *gin.Context) {
 	}
 
 	results := make([]models.PolicyEvaluationResult, len(req.Requests))
-	
+
 	h.mu.RLock()
 	for i, r := range req.Requests {
 		results[i] = *h.engine.Evaluate(&r)
@@ -105,7 +105,7 @@ func (h *Handler) Reload(c *gin.Context) {
 // ListPolicies returns all policies
 func (h *Handler) ListPolicies(c *gin.Context) {
 	policies := h.storage.GetAll()
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"policies": policies,
 		"count":    len(policies),
@@ -115,13 +115,13 @@ func (h *Handler) ListPolicies(c *gin.Context) {
 // GetPolicy returns a specific policy by ID
 func (h *Handler) GetPolicy(c *gin.Context) {
 	id := c.Param("id")
-	
+
 	policy, err := h.storage.Get(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, policy)
 }
 
@@ -140,7 +140,7 @@ func (h *Handler) CreatePolicy(c *gin.Context) {
 	}
 
 	// Create policy
-	if err := h.storage.Create(&policy); err != nil {
+	if err := h.storage.Create(&policy, "unknown"); err != nil {
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		return
 	}
@@ -154,7 +154,7 @@ func (h *Handler) CreatePolicy(c *gin.Context) {
 // UpdatePolicy updates an existing policy
 func (h *Handler) UpdatePolicy(c *gin.Context) {
 	id := c.Param("id")
-	
+
 	var policy models.Policy
 	if err := c.ShouldBindJSON(&policy); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -168,7 +168,7 @@ func (h *Handler) UpdatePolicy(c *gin.Context) {
 	}
 
 	// Update policy
-	if err := h.storage.Update(id, &policy); err != nil {
+	if err := h.storage.Update(id, &policy, "unknown"); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
@@ -182,8 +182,8 @@ func (h *Handler) UpdatePolicy(c *gin.Context) {
 // DeletePolicy deletes a policy
 func (h *Handler) DeletePolicy(c *gin.Context) {
 	id := c.Param("id")
-	
-	if err := h.storage.Delete(id); err != nil {
+
+	if err := h.storage.Delete(id, "unknown"); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
@@ -192,7 +192,7 @@ func (h *Handler) DeletePolicy(c *gin.Context) {
 	h.reloadEngine()
 
 	c.JSON(http.StatusOK, gin.H{
-		"status":  "deleted",
+		"status":    "deleted",
 		"policy_id": id,
 	})
 }
@@ -200,7 +200,7 @@ func (h *Handler) DeletePolicy(c *gin.Context) {
 // EnablePolicy enables a policy
 func (h *Handler) EnablePolicy(c *gin.Context) {
 	id := c.Param("id")
-	
+
 	if err := h.storage.Enable(id); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -218,7 +218,7 @@ func (h *Handler) EnablePolicy(c *gin.Context) {
 // DisablePolicy disables a policy
 func (h *Handler) DisablePolicy(c *gin.Context) {
 	id := c.Param("id")
-	
+
 	if err := h.storage.Disable(id); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -258,10 +258,10 @@ func (h *Handler) ValidatePolicy(c *gin.Context) {
 // Helper to reload engine
 func (h *Handler) reloadEngine() {
 	policies := h.storage.GetAll()
-	
+
 	h.mu.Lock()
 	h.engine = engine.NewEngine(policies)
 	h.mu.Unlock()
-	
+
 	log.WithField("count", len(policies)).Debug("Engine reloaded after policy change")
 }
@@ -0,0 +1,123 @@
+package idp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// LDAPConfig configures an LDAP/AD PrincipalSource.
+type LDAPConfig struct {
+	Name            string
+	Addr            string // host:port
+	BindDN          string
+	BindPassword    string
+	UserBaseDN      string
+	UserFilter      string // e.g. "(objectClass=person)"
+	GroupBaseDN     string
+	GroupFilter     string // e.g. "(objectClass=groupOfNames)"
+	MemberAttribute string // attribute on the group entry holding member DNs, e.g. "member"
+}
+
+// LDAPSource syncs users and groups by running paged searches against an
+// LDAP directory.
+type LDAPSource struct {
+	cfg LDAPConfig
+}
+
+// NewLDAPSource creates an LDAP-backed PrincipalSource.
+func NewLDAPSource(cfg LDAPConfig) *LDAPSource {
+	return &LDAPSource{cfg: cfg}
+}
+
+// Name returns the configured source name.
+func (l *LDAPSource) Name() string {
+	return l.cfg.Name
+}
+
+// FetchPrincipals binds, searches the user and group base DNs, and maps
+// entries into the internal principal model.
+func (l *LDAPSource) FetchPrincipals(ctx context.Context) ([]models.User, []models.UserGroup, error) {
+	conn, err := ldap.DialURL(fmt.Sprintf("ldap://%s", l.cfg.Addr))
+	if err != nil {
+		return nil, nil, fmt.Errorf("ldap: dial failed: %w", err)
+	}
+	defer conn.Close()
+
+	if l.cfg.BindDN != "" {
+		if err := conn.Bind(l.cfg.BindDN, l.cfg.BindPassword); err != nil {
+			return nil, nil, fmt.Errorf("ldap: bind failed: %w", err)
+		}
+	}
+
+	users, err := l.searchUsers(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groups, err := l.searchGroups(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return users, groups, nil
+}
+
+func (l *LDAPSource) searchUsers(conn *ldap.Conn) ([]models.User, error) {
+	req := ldap.NewSearchRequest(
+		l.cfg.UserBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		l.cfg.UserFilter,
+		[]string{"uid", "mail", "givenName", "sn", "cn"},
+		nil,
+	)
+
+	result, err := conn.SearchWithPaging(req, 500)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: user search failed: %w", err)
+	}
+
+	users := make([]models.User, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		users = append(users, models.User{
+			ID:        entry.GetAttributeValue("uid"),
+			Email:     entry.GetAttributeValue("mail"),
+			Name:      entry.GetAttributeValue("cn"),
+			FirstName: entry.GetAttributeValue("givenName"),
+			LastName:  entry.GetAttributeValue("sn"),
+			Status:    "active",
+			Source:    l.cfg.Name,
+		})
+	}
+
+	return users, nil
+}
+
+func (l *LDAPSource) searchGroups(conn *ldap.Conn) ([]models.UserGroup, error) {
+	req := ldap.NewSearchRequest(
+		l.cfg.GroupBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		l.cfg.GroupFilter,
+		[]string{"cn", l.cfg.MemberAttribute},
+		nil,
+	)
+
+	result, err := conn.SearchWithPaging(req, 500)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: group search failed: %w", err)
+	}
+
+	groups := make([]models.UserGroup, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		groups = append(groups, models.UserGroup{
+			ID:          entry.GetAttributeValue("cn"),
+			Name:        entry.GetAttributeValue("cn"),
+			MemberCount: len(entry.GetAttributeValues(l.cfg.MemberAttribute)),
+			Source:      l.cfg.Name,
+		})
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,103 @@
+package idp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// OIDCConfig configures a PrincipalSource that derives a single principal
+// per configured bearer token from an OIDC provider's UserInfo endpoint.
+type OIDCConfig struct {
+	Name        string
+	UserInfoURL string   // e.g. https://idp.example.com/oauth2/userinfo
+	Tokens      []string // one bearer token per known subject to refresh
+	GroupsClaim string   // claim name carrying group membership, defaults to "groups"
+}
+
+// OIDCSource syncs principals by calling the UserInfo endpoint once per
+// configured token and reading the subject plus its group claim.
+type OIDCSource struct {
+	cfg        OIDCConfig
+	httpClient *http.Client
+}
+
+// NewOIDCSource creates an OIDC-backed PrincipalSource.
+func NewOIDCSource(cfg OIDCConfig) *OIDCSource {
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	return &OIDCSource{cfg: cfg, httpClient: &http.Client{}}
+}
+
+// Name returns the configured source name.
+func (o *OIDCSource) Name() string {
+	return o.cfg.Name
+}
+
+// FetchPrincipals calls UserInfo for each configured token and maps the
+// claims (plus groups claim) into the internal principal model. Roles are
+// not derived from OIDC claims here; group membership alone is synced.
+func (o *OIDCSource) FetchPrincipals(ctx context.Context) ([]models.User, []models.UserGroup, error) {
+	var users []models.User
+	seenGroups := make(map[string]*models.UserGroup)
+
+	for _, token := range o.cfg.Tokens {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.cfg.UserInfoURL, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := o.httpClient.Do(req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("oidc: userinfo request failed: %w", err)
+		}
+
+		var raw map[string]interface{}
+		decodeErr := json.NewDecoder(resp.Body).Decode(&raw)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, nil, fmt.Errorf("oidc: unexpected status %d from userinfo", resp.StatusCode)
+		}
+		if decodeErr != nil {
+			return nil, nil, fmt.Errorf("oidc: failed to decode userinfo: %w", decodeErr)
+		}
+
+		sub, _ := raw["sub"].(string)
+		email, _ := raw["email"].(string)
+		name, _ := raw["name"].(string)
+
+		var groupNames []string
+		if rawGroups, ok := raw[o.cfg.GroupsClaim].([]interface{}); ok {
+			for _, g := range rawGroups {
+				if gs, ok := g.(string); ok {
+					groupNames = append(groupNames, gs)
+					if _, exists := seenGroups[gs]; !exists {
+						seenGroups[gs] = &models.UserGroup{ID: gs, Name: gs, Source: o.cfg.Name}
+					}
+					seenGroups[gs].MemberCount++
+				}
+			}
+		}
+
+		users = append(users, models.User{
+			ID:     sub,
+			Email:  email,
+			Name:   name,
+			Groups: groupNames,
+			Status: "active",
+			Source: o.cfg.Name,
+		})
+	}
+
+	groups := make([]models.UserGroup, 0, len(seenGroups))
+	for _, g := range seenGroups {
+		groups = append(groups, *g)
+	}
+
+	return users, groups, nil
+}
@@ -0,0 +1,169 @@
+package idp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// SCIMConfig configures a SCIM 2.0 PrincipalSource.
+type SCIMConfig struct {
+	Name     string // source name used to tag synced principals
+	BaseURL  string // e.g. https://idp.example.com/scim/v2
+	Token    string // bearer token
+	PageSize int    // SCIM "count" query parameter, defaults to 100
+}
+
+// scimListResponse is the subset of a SCIM ListResponse this source reads.
+type scimListResponse struct {
+	TotalResults int               `json:"totalResults"`
+	StartIndex   int               `json:"startIndex"`
+	ItemsPerPage int               `json:"itemsPerPage"`
+	Resources    []json.RawMessage `json:"Resources"`
+}
+
+type scimUser struct {
+	ID       string `json:"id"`
+	UserName string `json:"userName"`
+	Name     struct {
+		GivenName  string `json:"givenName"`
+		FamilyName string `json:"familyName"`
+	} `json:"name"`
+	Emails []struct {
+		Value   string `json:"value"`
+		Primary bool   `json:"primary"`
+	} `json:"emails"`
+	Active bool `json:"active"`
+}
+
+type scimGroup struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Members     []struct {
+		Value string `json:"value"`
+	} `json:"members"`
+}
+
+// SCIMSource syncs users and groups from a SCIM 2.0 provider's /Users and
+// /Groups endpoints, paging with startIndex/count until exhausted.
+type SCIMSource struct {
+	cfg        SCIMConfig
+	httpClient *http.Client
+}
+
+// NewSCIMSource creates a SCIM-backed PrincipalSource.
+func NewSCIMSource(cfg SCIMConfig) *SCIMSource {
+	if cfg.PageSize == 0 {
+		cfg.PageSize = 100
+	}
+	return &SCIMSource{cfg: cfg, httpClient: &http.Client{}}
+}
+
+// Name returns the configured source name.
+func (s *SCIMSource) Name() string {
+	return s.cfg.Name
+}
+
+// FetchPrincipals pages through /Users and /Groups and converts them to the
+// internal principal model.
+func (s *SCIMSource) FetchPrincipals(ctx context.Context) ([]models.User, []models.UserGroup, error) {
+	rawUsers, err := s.listResource(ctx, "/Users")
+	if err != nil {
+		return nil, nil, fmt.Errorf("scim: failed to list users: %w", err)
+	}
+	rawGroups, err := s.listResource(ctx, "/Groups")
+	if err != nil {
+		return nil, nil, fmt.Errorf("scim: failed to list groups: %w", err)
+	}
+
+	users := make([]models.User, 0, len(rawUsers))
+	for _, raw := range rawUsers {
+		var su scimUser
+		if err := json.Unmarshal(raw, &su); err != nil {
+			continue
+		}
+		email := su.UserName
+		for _, e := range su.Emails {
+			if e.Primary && e.Value != "" {
+				email = e.Value
+			}
+		}
+		status := "inactive"
+		if su.Active {
+			status = "active"
+		}
+		users = append(users, models.User{
+			ID:        su.ID,
+			Email:     email,
+			Name:      su.Name.GivenName + " " + su.Name.FamilyName,
+			FirstName: su.Name.GivenName,
+			LastName:  su.Name.FamilyName,
+			Status:    status,
+			Source:    s.cfg.Name,
+		})
+	}
+
+	groups := make([]models.UserGroup, 0, len(rawGroups))
+	for _, raw := range rawGroups {
+		var sg scimGroup
+		if err := json.Unmarshal(raw, &sg); err != nil {
+			continue
+		}
+		groups = append(groups, models.UserGroup{
+			ID:          sg.ID,
+			Name:        sg.DisplayName,
+			MemberCount: len(sg.Members),
+			Source:      s.cfg.Name,
+		})
+	}
+
+	return users, groups, nil
+}
+
+// listResource pages through a SCIM list endpoint until all resources are read.
+func (s *SCIMSource) listResource(ctx context.Context, path string) ([]json.RawMessage, error) {
+	var all []json.RawMessage
+	startIndex := 1
+
+	for {
+		url := fmt.Sprintf("%s%s?startIndex=%d&count=%d", s.cfg.BaseURL, path, startIndex, s.cfg.PageSize)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if s.cfg.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+s.cfg.Token)
+		}
+		req.Header.Set("Accept", "application/scim+json")
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, path)
+		}
+
+		var page scimListResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+
+		all = append(all, page.Resources...)
+
+		fetched := page.StartIndex + len(page.Resources) - 1
+		if len(page.Resources) == 0 || fetched >= page.TotalResults {
+			break
+		}
+		startIndex = fetched + 1
+	}
+
+	return all, nil
+}
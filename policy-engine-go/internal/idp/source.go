@@ -0,0 +1,32 @@
+// Package idp provides pluggable identity-provider sources that sync
+// external users and groups into storage.UsersStorage.
+package idp
+
+import (
+	"context"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// PrincipalSource fetches the current set of users and groups from an
+// external identity provider so they can be merged into UsersStorage.
+type PrincipalSource interface {
+	// Name identifies the source, used to tag synced principals and to
+	// address the source via the /principals/sources API.
+	Name() string
+
+	// FetchPrincipals returns the full current set of users and groups
+	// known to the provider. Implementations that support delta sync may
+	// use an internal cursor/token to avoid refetching unchanged records.
+	FetchPrincipals(ctx context.Context) ([]models.User, []models.UserGroup, error)
+}
+
+// SourceStatus reports the health of a configured PrincipalSource.
+type SourceStatus struct {
+	Name         string `json:"name"`
+	Kind         string `json:"kind"`
+	LastSyncedAt string `json:"last_synced_at,omitempty"`
+	LastError    string `json:"last_error,omitempty"`
+	UserCount    int    `json:"user_count"`
+	GroupCount   int    `json:"group_count"`
+}
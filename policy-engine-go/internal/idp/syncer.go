@@ -0,0 +1,123 @@
+package idp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+// Syncer periodically pulls principals from a set of PrincipalSources and
+// merges them into a storage.UsersStorage, tagged by source so file-defined
+// and IdP-defined principals coexist.
+type Syncer struct {
+	storage  *storage.UsersStorage
+	sources  map[string]PrincipalSource
+	interval time.Duration
+
+	mu       sync.RWMutex
+	statuses map[string]*SourceStatus
+
+	cancel context.CancelFunc
+}
+
+// NewSyncer creates a Syncer over the given sources.
+func NewSyncer(us *storage.UsersStorage, interval time.Duration, sources ...PrincipalSource) *Syncer {
+	s := &Syncer{
+		storage:  us,
+		sources:  make(map[string]PrincipalSource),
+		interval: interval,
+		statuses: make(map[string]*SourceStatus),
+	}
+	for _, src := range sources {
+		s.sources[src.Name()] = src
+		s.statuses[src.Name()] = &SourceStatus{Name: src.Name()}
+	}
+	return s
+}
+
+// Start launches the background sync loop. Call Stop to terminate it.
+func (s *Syncer) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.SyncAll(ctx)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.SyncAll(ctx)
+			}
+		}
+	}()
+}
+
+// Stop terminates the background sync loop.
+func (s *Syncer) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Statuses returns the current sync status of every registered source.
+func (s *Syncer) Statuses() []SourceStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]SourceStatus, 0, len(s.statuses))
+	for _, st := range s.statuses {
+		result = append(result, *st)
+	}
+	return result
+}
+
+// SyncAll refreshes every registered source.
+func (s *Syncer) SyncAll(ctx context.Context) {
+	for name := range s.sources {
+		s.SyncOne(ctx, name)
+	}
+}
+
+// SyncOne refreshes a single named source, merging its result into storage.
+func (s *Syncer) SyncOne(ctx context.Context, name string) error {
+	src, ok := s.sources[name]
+	if !ok {
+		return storage.ErrSourceNotFound
+	}
+
+	users, groups, err := src.FetchPrincipals(ctx)
+
+	s.mu.Lock()
+	st := s.statuses[name]
+	if err != nil {
+		st.LastError = err.Error()
+		s.mu.Unlock()
+		log.WithError(err).WithField("source", name).Warn("Principal sync failed")
+		return err
+	}
+	st.LastError = ""
+	st.LastSyncedAt = time.Now().Format(time.RFC3339)
+	st.UserCount = len(users)
+	st.GroupCount = len(groups)
+	s.mu.Unlock()
+
+	if err := s.storage.MergeFromSource(name, users, groups); err != nil {
+		log.WithError(err).WithField("source", name).Warn("Failed to merge synced principals")
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"source": name,
+		"users":  len(users),
+		"groups": len(groups),
+	}).Info("Principals synced from IdP source")
+	return nil
+}
@@ -0,0 +1,296 @@
+// Package jwtauth verifies bearer JWTs against one or more configured
+// jwt_providers so models.ConditionTypeJWT conditions can match on a
+// request's verified claims. A Provider tracks a single issuer's JWKS
+// (refreshed on an interval) or a fixed local keyset; a ProviderSet tries
+// each configured Provider in turn and returns the claims from the first one
+// to accept the token.
+package jwtauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultRefreshInterval is how often a Provider re-fetches its JWKS when
+// ProviderConfig.RefreshInterval isn't set.
+const defaultRefreshInterval = 5 * time.Minute
+
+// ProviderConfig configures a single jwt_provider: the issuer and
+// audience(s) a verified token must carry, where to fetch its JWKS, and how
+// often to refresh it.
+type ProviderConfig struct {
+	// Name identifies this provider in PolicyRule.JWTProviders and the
+	// "provider" reported by ProviderSet.Verify.
+	Name string `yaml:"name"`
+	// Issuer must exactly match the token's iss claim. Empty skips issuer
+	// verification.
+	Issuer string `yaml:"issuer"`
+	// Audiences, if non-empty, requires the token's aud claim to contain at
+	// least one of these values.
+	Audiences []string `yaml:"audiences"`
+	// JWKSURL is fetched and refreshed every RefreshInterval to resolve a
+	// token's kid to a public key. Empty relies on Keys alone.
+	JWKSURL string `yaml:"jwks_url"`
+	// RefreshInterval overrides defaultRefreshInterval.
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	// ForwardHeader names the HTTP header the gateway forwards the bearer
+	// token in, e.g. "Authorization" - purely documentary here, since
+	// PolicyEvaluationRequest.JWT arrives already extracted; it tells a
+	// caller building that field from an inbound request which header to
+	// read.
+	ForwardHeader string `yaml:"forward_header"`
+	// Keys, if set, seeds the provider's key set alongside whatever JWKSURL
+	// resolves - a fixed local keyset for an issuer with no JWKS endpoint
+	// (e.g. a test or air-gapped deployment).
+	Keys []JWK `yaml:"keys"`
+}
+
+// JWK is the subset of RFC 7517 fields this package understands: RSA public
+// keys identified by kid. A non-RSA key is logged and skipped rather than
+// rejected outright, so one EC key in an otherwise-RSA JWKS doesn't take the
+// whole provider down.
+type JWK struct {
+	Kid string `yaml:"kid" json:"kid"`
+	Kty string `yaml:"kty" json:"kty"`
+	N   string `yaml:"n" json:"n"`
+	E   string `yaml:"e" json:"e"`
+}
+
+// jwks is the JWKS document shape returned by a JWKSURL.
+type jwks struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Verifier verifies a bearer token against one or more configured
+// jwt_providers and reports which one accepted it. Implemented by
+// *ProviderSet; declared as an interface, the same way ratelimit.Limiter is,
+// since jwtauth has no dependency on engine and no cycle requires the
+// interface to live there instead.
+type Verifier interface {
+	Verify(tokenString string) (claims map[string]interface{}, provider string, err error)
+}
+
+// Provider verifies tokens against a single issuer's key set.
+type Provider struct {
+	cfg        ProviderConfig
+	httpClient *http.Client
+
+	mu   sync.RWMutex
+	keys map[string]*rsa.PublicKey // kid -> public key
+}
+
+// NewProvider creates a Provider for cfg, seeded with cfg.Keys. Call Refresh
+// or Watch to populate the rest of its key set from cfg.JWKSURL.
+func NewProvider(cfg ProviderConfig) *Provider {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = defaultRefreshInterval
+	}
+	p := &Provider{
+		cfg: cfg,
+		// Transport is explicit, rather than relying on
+		// http.DefaultTransport's implicit default, so it's clear at a
+		// glance that JWKS fetches honor HTTP_PROXY/HTTPS_PROXY/NO_PROXY -
+		// evaluators commonly run behind egress proxies.
+		httpClient: &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment}},
+		keys:       make(map[string]*rsa.PublicKey),
+	}
+	p.addKeys(cfg.Keys)
+	return p
+}
+
+// addKeys merges jwkKeys into p's key set, skipping (and logging) any
+// non-RSA or malformed key rather than failing the whole refresh.
+func (p *Provider) addKeys(jwkKeys []JWK) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, k := range jwkKeys {
+		if k.Kty != "RSA" {
+			log.WithFields(log.Fields{"kid": k.Kid, "kty": k.Kty}).Warn("jwtauth: skipping non-RSA JWK")
+			continue
+		}
+		pub, err := rsaPublicKey(k)
+		if err != nil {
+			log.WithError(err).WithField("kid", k.Kid).Warn("jwtauth: skipping malformed JWK")
+			continue
+		}
+		p.keys[k.Kid] = pub
+	}
+}
+
+// rsaPublicKey reconstructs an RSA public key from a JWK's base64url-encoded
+// modulus and exponent.
+func rsaPublicKey(k JWK) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK exponent: %w", err)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
+
+// Refresh fetches cfg.JWKSURL and merges any RSA keys found into p's key
+// set, leaving existing keys in place on a fetch or parse failure so a
+// transient JWKS outage doesn't invalidate tokens signed with an
+// already-cached key. A no-op when JWKSURL isn't configured.
+func (p *Provider) Refresh() error {
+	if p.cfg.JWKSURL == "" {
+		return nil
+	}
+
+	resp, err := p.httpClient.Get(p.cfg.JWKSURL)
+	if err != nil {
+		return fmt.Errorf("jwtauth: fetching JWKS from %s: %w", p.cfg.JWKSURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwtauth: unexpected status %d fetching JWKS from %s", resp.StatusCode, p.cfg.JWKSURL)
+	}
+
+	var set jwks
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("jwtauth: decoding JWKS from %s: %w", p.cfg.JWKSURL, err)
+	}
+
+	p.addKeys(set.Keys)
+	return nil
+}
+
+// Watch refreshes p's JWKS every cfg.RefreshInterval until ctx is canceled,
+// logging (not failing) a refresh error - the same best-effort behavior
+// evaluation.Service.WatchRegoConfig has for a rejected Rego reload. A no-op
+// when JWKSURL isn't configured, since there's then nothing to refresh.
+func (p *Provider) Watch(ctx context.Context) {
+	if p.cfg.JWKSURL == "" {
+		return
+	}
+
+	ticker := time.NewTicker(p.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Refresh(); err != nil {
+				log.WithError(err).Warn("jwtauth: JWKS refresh failed, keeping previous key set")
+			}
+		}
+	}
+}
+
+// Verify parses and validates tokenString against p's issuer, audiences,
+// and key set, returning its claims on success. Failures are worded to name
+// the specific problem (unknown kid, bad issuer, wrong audience, or the
+// underlying parse/signature/expiry error from jwt.ParseWithClaims) so a
+// caller can surface it directly in a PolicyEvaluationResult.Message.
+func (p *Provider) Verify(tokenString string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	var opts []jwt.ParserOption
+	if p.cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(p.cfg.Issuer))
+	}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		p.mu.RLock()
+		key, ok := p.keys[kid]
+		p.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("jwtauth: %w", err)
+	}
+
+	if len(p.cfg.Audiences) > 0 && !audienceMatches(claims, p.cfg.Audiences) {
+		return nil, fmt.Errorf("jwtauth: token audience does not match any configured audience")
+	}
+
+	return claims, nil
+}
+
+// audienceMatches reports whether claims' aud claim contains at least one of
+// audiences.
+func audienceMatches(claims jwt.MapClaims, audiences []string) bool {
+	got, err := claims.GetAudience()
+	if err != nil {
+		return false
+	}
+	for _, want := range audiences {
+		for _, have := range got {
+			if want == have {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ProviderSet tries each configured Provider in order and returns the claims
+// from the first one to verify a token - the engine's single injected
+// collaborator for every configured jwt_provider, the same way a single
+// ratelimit.Limiter backs every rate condition regardless of backend.
+type ProviderSet struct {
+	providers []*Provider
+}
+
+var _ Verifier = (*ProviderSet)(nil)
+
+// NewProviderSet builds a ProviderSet from configs.
+func NewProviderSet(configs []ProviderConfig) *ProviderSet {
+	providers := make([]*Provider, 0, len(configs))
+	for _, cfg := range configs {
+		providers = append(providers, NewProvider(cfg))
+	}
+	return &ProviderSet{providers: providers}
+}
+
+// Watch starts every provider's JWKS-refresh loop, each running until ctx is
+// canceled.
+func (s *ProviderSet) Watch(ctx context.Context) {
+	for _, p := range s.providers {
+		go p.Watch(ctx)
+	}
+}
+
+// Verify tries each configured provider in turn and returns the claims and
+// name of the first one to accept tokenString. Returns the last provider's
+// error if every provider rejects the token, or an error immediately if none
+// are configured.
+func (s *ProviderSet) Verify(tokenString string) (map[string]interface{}, string, error) {
+	if len(s.providers) == 0 {
+		return nil, "", fmt.Errorf("jwtauth: no jwt providers configured")
+	}
+
+	var lastErr error
+	for _, p := range s.providers {
+		claims, err := p.Verify(tokenString)
+		if err == nil {
+			return claims, p.cfg.Name, nil
+		}
+		lastErr = err
+	}
+	return nil, "", lastErr
+}
@@ -0,0 +1,160 @@
+package jwtauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testKid = "test-key-1"
+
+func newTestProvider(t *testing.T, cfg ProviderConfig) (*Provider, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	cfg.Keys = []JWK{{
+		Kid: testKid,
+		Kty: "RSA",
+		N:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+	}}
+	return NewProvider(cfg), key
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return signed
+}
+
+func TestProviderVerifyAcceptsValidToken(t *testing.T) {
+	p, key := newTestProvider(t, ProviderConfig{Issuer: "https://issuer.example", Audiences: []string{"gateway"}})
+	token := signRS256(t, key, testKid, jwt.MapClaims{
+		"iss": "https://issuer.example",
+		"aud": "gateway",
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	claims, err := p.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims["sub"] != "alice" {
+		t.Fatalf("expected sub claim to survive verification, got %v", claims["sub"])
+	}
+}
+
+func TestProviderVerifyRejectsAlgNone(t *testing.T) {
+	p, _ := newTestProvider(t, ProviderConfig{})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"sub": "alice"})
+	token.Header["kid"] = testKid
+	unsigned, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+
+	if _, err := p.Verify(unsigned); err == nil {
+		t.Fatal("expected Verify to reject an alg=none token, an attacker's most direct way to bypass signature verification")
+	}
+}
+
+func TestProviderVerifyRejectsHMACAlgConfusion(t *testing.T) {
+	p, key := newTestProvider(t, ProviderConfig{})
+
+	// Sign with HS256 using the RSA public modulus as the HMAC secret - the
+	// classic RS256/HS256 key-confusion attack, since an RSA public key is
+	// not secret.
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "alice"})
+	token.Header["kid"] = testKid
+	signed, err := token.SignedString(key.PublicKey.N.Bytes())
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+
+	if _, err := p.Verify(signed); err == nil {
+		t.Fatal("expected Verify to reject an HS256 token even though it is kid-addressed to an RSA key")
+	}
+}
+
+func TestProviderVerifyRejectsUnknownKid(t *testing.T) {
+	p, key := newTestProvider(t, ProviderConfig{})
+	token := signRS256(t, key, "some-other-kid", jwt.MapClaims{"sub": "alice"})
+
+	if _, err := p.Verify(token); err == nil {
+		t.Fatal("expected Verify to reject a token signed with a kid not in the provider's key set")
+	}
+}
+
+func TestProviderVerifyRejectsWrongIssuer(t *testing.T) {
+	p, key := newTestProvider(t, ProviderConfig{Issuer: "https://issuer.example"})
+	token := signRS256(t, key, testKid, jwt.MapClaims{
+		"iss": "https://attacker.example",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	if _, err := p.Verify(token); err == nil {
+		t.Fatal("expected Verify to reject a token whose iss claim doesn't match the configured issuer")
+	}
+}
+
+func TestProviderVerifyRejectsWrongAudience(t *testing.T) {
+	p, key := newTestProvider(t, ProviderConfig{Audiences: []string{"gateway"}})
+	token := signRS256(t, key, testKid, jwt.MapClaims{
+		"aud": "other-service",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	if _, err := p.Verify(token); err == nil {
+		t.Fatal("expected Verify to reject a token whose aud claim doesn't contain any configured audience")
+	}
+}
+
+func TestProviderVerifyRejectsExpiredToken(t *testing.T) {
+	p, key := newTestProvider(t, ProviderConfig{})
+	token := signRS256(t, key, testKid, jwt.MapClaims{
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	})
+
+	if _, err := p.Verify(token); err == nil {
+		t.Fatal("expected Verify to reject an expired token")
+	}
+}
+
+func TestProviderSetVerifyTriesEachProviderInTurn(t *testing.T) {
+	_, keyA := newTestProvider(t, ProviderConfig{})
+	pOther, _ := newTestProvider(t, ProviderConfig{Issuer: "https://other.example"})
+	pB, keyB := newTestProvider(t, ProviderConfig{Issuer: "https://b.example"})
+
+	// pOther comes first and knows neither keyA nor keyB, so every token
+	// has to fall through it before pB gets a turn.
+	set := &ProviderSet{providers: []*Provider{pOther, pB}}
+	pB.cfg.Name = "provider-b"
+
+	tokenFromA := signRS256(t, keyA, testKid, jwt.MapClaims{"iss": "https://a.example"})
+	if _, _, err := set.Verify(tokenFromA); err == nil {
+		t.Fatal("expected ProviderSet.Verify to reject a token none of its providers can verify")
+	}
+
+	tokenFromB := signRS256(t, keyB, testKid, jwt.MapClaims{"iss": "https://b.example"})
+	_, provider, err := set.Verify(tokenFromB)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if provider != "provider-b" {
+		t.Fatalf("expected provider-b to report verifying the token, got %q", provider)
+	}
+}
@@ -0,0 +1,269 @@
+// Package labels provides Kubernetes-style label map and selector helpers
+// shared across subsystems that tag entities (policies, resources,
+// principals) with free-form key/value metadata. It deliberately has no
+// internal dependencies, including on internal/models: models.UnifiedPolicy
+// needs to reference LabelSelector below, and models importing labels while
+// labels imported models back would be a cycle. IsLabelMapSubset and
+// LabelMapToString therefore duplicate models.IsLabelMapSubset and
+// models.LabelMapToString rather than wrapping them - the logic is a
+// handful of lines each, and the duplication is the price of both packages
+// being able to use label maps without one having to depend on the other.
+package labels
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IsLabelMapSubset reports whether every key/value pair in subset is also
+// present in full. An empty or nil subset is trivially a subset of
+// anything. Mirrors models.IsLabelMapSubset; see the package doc comment
+// for why this isn't just a call to that function.
+func IsLabelMapSubset(subset, full map[string]string) bool {
+	for k, v := range subset {
+		if full[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// LabelMapToString renders a label map in canonical "k=v,k2=v2" form with
+// keys sorted, so it round-trips stably through YAML and query strings.
+// Mirrors models.LabelMapToString; see the package doc comment for why this
+// isn't just a call to that function.
+func LabelMapToString(m map[string]string) string {
+	if len(m) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+m[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// LabelMapFromArray parses a ["key=value", "key2=value2"] array - the shape
+// a YAML/JSON label list naturally takes - into the map form
+// LabelMapToString renders. Unlike models.LabelMapFromString (a single
+// comma-joined string), each element here is exactly one key=value pair and
+// is never itself split on commas.
+func LabelMapFromArray(arr []string) (map[string]string, error) {
+	if len(arr) == 0 {
+		return nil, nil
+	}
+
+	m := make(map[string]string, len(arr))
+	for _, pair := range arr {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid label %q, expected \"key=value\"", pair)
+		}
+		m[kv[0]] = kv[1]
+	}
+	return m, nil
+}
+
+// LabelSelectorOperator is a LabelRequirement's comparison, mirroring
+// Kubernetes' metav1.LabelSelectorOperator.
+type LabelSelectorOperator string
+
+const (
+	LabelSelectorOpIn           LabelSelectorOperator = "In"
+	LabelSelectorOpNotIn        LabelSelectorOperator = "NotIn"
+	LabelSelectorOpExists       LabelSelectorOperator = "Exists"
+	LabelSelectorOpDoesNotExist LabelSelectorOperator = "DoesNotExist"
+)
+
+// LabelRequirement is one LabelSelector.MatchExpressions entry: the
+// labels value at Key (if any) must satisfy Operator against Values.
+type LabelRequirement struct {
+	Key      string                `json:"key" yaml:"key"`
+	Operator LabelSelectorOperator `json:"operator" yaml:"operator"`
+	Values   []string              `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// Matches reports whether labelMap satisfies r. In/NotIn compare Key's
+// value against Values; Exists/DoesNotExist ignore Values and only check
+// whether Key is present. An unrecognized Operator never matches.
+func (r LabelRequirement) Matches(labelMap map[string]string) bool {
+	value, exists := labelMap[r.Key]
+	switch r.Operator {
+	case LabelSelectorOpExists:
+		return exists
+	case LabelSelectorOpDoesNotExist:
+		return !exists
+	case LabelSelectorOpIn:
+		if !exists {
+			return false
+		}
+		for _, v := range r.Values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	case LabelSelectorOpNotIn:
+		if !exists {
+			return true
+		}
+		for _, v := range r.Values {
+			if v == value {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// LabelSelector is a Kubernetes-style label selector: MatchLabels is an
+// implicit AND of exact key=value pairs (the same semantics as
+// IsLabelMapSubset), and MatchExpressions adds richer In/NotIn/Exists/
+// DoesNotExist requirements. A candidate's labels must satisfy every
+// MatchLabels pair AND every MatchExpressions requirement to match.
+type LabelSelector struct {
+	MatchLabels      map[string]string  `json:"match_labels,omitempty" yaml:"match_labels,omitempty"`
+	MatchExpressions []LabelRequirement `json:"match_expressions,omitempty" yaml:"match_expressions,omitempty"`
+}
+
+// ParseSelector parses a Kubernetes-style selector expression into the
+// LabelSelector form Matches evaluates. expr is a comma-separated list of
+// terms: "key=value" (added to MatchLabels), "key in (v1,v2)" / "key notin
+// (v1,v2)" (a MatchExpressions In/NotIn requirement), "!key" (DoesNotExist),
+// or a bare "key" (Exists). An empty expr parses to a zero LabelSelector
+// (matches nothing, per Matches' empty-selector rule).
+func ParseSelector(expr string) (*LabelSelector, error) {
+	sel := &LabelSelector{}
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return sel, nil
+	}
+
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		if err := parseSelectorTerm(term, sel); err != nil {
+			return nil, err
+		}
+	}
+	return sel, nil
+}
+
+func parseSelectorTerm(term string, sel *LabelSelector) error {
+	if strings.HasPrefix(term, "!") {
+		key := strings.TrimSpace(strings.TrimPrefix(term, "!"))
+		if key == "" {
+			return fmt.Errorf("invalid selector term %q", term)
+		}
+		sel.MatchExpressions = append(sel.MatchExpressions, LabelRequirement{Key: key, Operator: LabelSelectorOpDoesNotExist})
+		return nil
+	}
+	if key, values, ok := parseSetTerm(term, " in "); ok {
+		sel.MatchExpressions = append(sel.MatchExpressions, LabelRequirement{Key: key, Operator: LabelSelectorOpIn, Values: values})
+		return nil
+	}
+	if key, values, ok := parseSetTerm(term, " notin "); ok {
+		sel.MatchExpressions = append(sel.MatchExpressions, LabelRequirement{Key: key, Operator: LabelSelectorOpNotIn, Values: values})
+		return nil
+	}
+	if key, value, found := strings.Cut(term, "="); found {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return fmt.Errorf("invalid selector term %q", term)
+		}
+		if sel.MatchLabels == nil {
+			sel.MatchLabels = make(map[string]string)
+		}
+		sel.MatchLabels[key] = strings.TrimSpace(value)
+		return nil
+	}
+
+	sel.MatchExpressions = append(sel.MatchExpressions, LabelRequirement{Key: term, Operator: LabelSelectorOpExists})
+	return nil
+}
+
+// parseSetTerm splits a "key<sep>(v1,v2)" term, returning ok=false if term
+// doesn't have that shape.
+func parseSetTerm(term, sep string) (key string, values []string, ok bool) {
+	before, rest, found := strings.Cut(term, sep)
+	if !found {
+		return "", nil, false
+	}
+	key = strings.TrimSpace(before)
+	rest = strings.TrimSpace(rest)
+	if key == "" || !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+		return "", nil, false
+	}
+
+	for _, v := range strings.Split(rest[1:len(rest)-1], ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return key, values, true
+}
+
+// MatchValue evaluates a ConditionTypeLabels condition's Value against
+// target's labels. Value is either a label map (an implicit-AND subset
+// match, the IsLabelMapSubset semantics) or a selector expression string
+// parsed via ParseSelector; any other shape is an error.
+func MatchValue(value interface{}, target map[string]string) (bool, error) {
+	switch v := value.(type) {
+	case map[string]string:
+		return IsLabelMapSubset(v, target), nil
+	case map[string]interface{}:
+		subset := make(map[string]string, len(v))
+		for k, raw := range v {
+			s, ok := raw.(string)
+			if !ok {
+				return false, fmt.Errorf("label value for %q must be a string", k)
+			}
+			subset[k] = s
+		}
+		return IsLabelMapSubset(subset, target), nil
+	case string:
+		sel, err := ParseSelector(v)
+		if err != nil {
+			return false, err
+		}
+		return sel.Matches(target), nil
+	default:
+		return false, fmt.Errorf("labels condition value must be a label map or a selector expression string")
+	}
+}
+
+// Matches reports whether labelMap satisfies s. A nil selector, or one with
+// both MatchLabels and MatchExpressions empty, matches nothing - callers
+// that want "applies to everything" should leave the enclosing
+// *LabelSelector nil rather than construct an empty one, the same
+// empty-means-not-a-selector convention PolicyResource.ResourceLabels and
+// PolicyPrincipalScope.PrincipalLabels already use.
+func (s *LabelSelector) Matches(labelMap map[string]string) bool {
+	if s == nil || (len(s.MatchLabels) == 0 && len(s.MatchExpressions) == 0) {
+		return false
+	}
+	if !IsLabelMapSubset(s.MatchLabels, labelMap) {
+		return false
+	}
+	for _, req := range s.MatchExpressions {
+		if !req.Matches(labelMap) {
+			return false
+		}
+	}
+	return true
+}
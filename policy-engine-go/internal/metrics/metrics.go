@@ -0,0 +1,94 @@
+// Package metrics exposes Prometheus instrumentation for the policy-engine
+// HTTP server: request counters/latency via Gin middleware, plus gauges
+// reporting principal, policy, and reload freshness.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total HTTP requests processed, labeled by route, method and status",
+		},
+		[]string{"route", "method", "status"},
+	)
+
+	httpRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds, labeled by route and method",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"route", "method"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(httpRequestsTotal, httpRequestDuration)
+}
+
+// Middleware records request counts and latency for every handled route.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		httpRequestsTotal.WithLabelValues(route, c.Request.Method, strconv.Itoa(c.Writer.Status())).Inc()
+		httpRequestDuration.WithLabelValues(route, c.Request.Method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler serves the /metrics endpoint.
+func Handler() gin.HandlerFunc {
+	h := promhttp.Handler()
+	return gin.WrapH(h)
+}
+
+// RegisterGaugeFunc registers an unlabeled gauge whose value is computed on
+// every scrape, e.g. policies_total or seconds_since_last_reload.
+func RegisterGaugeFunc(name, help string, fn func() float64) {
+	prometheus.MustRegister(prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{Name: name, Help: help},
+		fn,
+	))
+}
+
+// RegisterLabeledGaugeFunc registers a gauge with a single label (e.g.
+// principals_total{kind}) whose values are computed on every scrape via fn,
+// which returns a map of label value to gauge value.
+func RegisterLabeledGaugeFunc(name, help, labelName string, fn func() map[string]float64) {
+	prometheus.MustRegister(&labeledGaugeCollector{
+		desc: prometheus.NewDesc(name, help, []string{labelName}, nil),
+		fn:   fn,
+	})
+}
+
+// labeledGaugeCollector is a minimal prometheus.Collector that reports
+// freshly-computed label/value pairs on every scrape.
+type labeledGaugeCollector struct {
+	desc *prometheus.Desc
+	fn   func() map[string]float64
+}
+
+func (c *labeledGaugeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *labeledGaugeCollector) Collect(ch chan<- prometheus.Metric) {
+	for label, value := range c.fn() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, value, label)
+	}
+}
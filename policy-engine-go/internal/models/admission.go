@@ -0,0 +1,60 @@
+package models
+
+import "encoding/json"
+
+// AdmissionGroupVersionKind mirrors k8s.io/apimachinery's GroupVersionKind,
+// trimmed to the fields an AdmissionReview actually carries.
+type AdmissionGroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// AdmissionUserInfo mirrors k8s.io/api/authentication/v1.UserInfo, the
+// subset AdmissionRequest.UserInfo uses.
+type AdmissionUserInfo struct {
+	Username string   `json:"username,omitempty"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// AdmissionRequest is the subset of k8s.io/api/admission/v1.AdmissionRequest
+// this gateway understands, accepted as the `request` field of an
+// AdmissionReview POSTed by a Kubernetes (or Kubernetes-compatible)
+// validating webhook caller.
+type AdmissionRequest struct {
+	UID       string                    `json:"uid"`
+	Kind      AdmissionGroupVersionKind `json:"kind"`
+	Resource  AdmissionGroupVersionKind `json:"resource,omitempty"`
+	Namespace string                    `json:"namespace,omitempty"`
+	Operation string                    `json:"operation"` // "CREATE", "UPDATE", "DELETE", "CONNECT"
+	UserInfo  AdmissionUserInfo         `json:"userInfo"`
+	Object    json.RawMessage           `json:"object,omitempty"`
+	OldObject json.RawMessage           `json:"oldObject,omitempty"`
+	DryRun    *bool                     `json:"dryRun,omitempty"`
+}
+
+// AdmissionStatus carries the human-readable reason for an admission
+// decision, mirroring k8s.io/apimachinery's metav1.Status as trimmed by
+// admission/v1.AdmissionResponse.Result.
+type AdmissionStatus struct {
+	Message string `json:"message,omitempty"`
+}
+
+// AdmissionResponse mirrors k8s.io/api/admission/v1.AdmissionResponse.
+type AdmissionResponse struct {
+	UID     string           `json:"uid"`
+	Allowed bool             `json:"allowed"`
+	Result  *AdmissionStatus `json:"status,omitempty"`
+}
+
+// AdmissionReview mirrors k8s.io/api/admission/v1.AdmissionReview, the
+// envelope a validating webhook exchanges with the API server: the caller
+// sets Request, the webhook responds with the same envelope with Response
+// populated instead.
+type AdmissionReview struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Request    *AdmissionRequest  `json:"request,omitempty"`
+	Response   *AdmissionResponse `json:"response,omitempty"`
+}
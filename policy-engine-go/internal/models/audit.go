@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// AuditDecisionRecord is one immutable entry in the tamper-evident policy
+// decision audit log (see internal/audit). Unlike PolicyDecision, which is
+// explicitly ephemeral and never persisted on its own, a recorded
+// AuditDecisionRecord is never mutated or deleted: Hash binds it to every
+// record before it via PrevHash, so an append-only Sink plus this chain
+// together make a later edit or deletion of a record detectable by
+// GET /audit/verify.
+type AuditDecisionRecord struct {
+	// Seq is the record's 1-based position in the chain, monotonically
+	// increasing and gapless within a single audit.Logger's lifetime.
+	Seq int64 `json:"seq"`
+
+	// InputHash is SHA-256(JSON(PolicyEvaluationInput)), hex-encoded, so
+	// the evaluated input can be correlated with a decision without the
+	// audit log itself becoming a second copy of potentially sensitive
+	// request context.
+	InputHash    string       `json:"input_hash"`
+	UserID       string       `json:"user_id,omitempty"`
+	ResourceType ResourceType `json:"resource_type,omitempty"`
+	ResourceID   string       `json:"resource_id,omitempty"`
+
+	Decision PolicyDecision `json:"decision"`
+
+	// PolicyVersions records every policy consulted to reach Decision, as
+	// PolicyID -> Version, so a later edit to PolicyRules can't
+	// retroactively change what an old audit record attests to.
+	PolicyVersions map[string]int `json:"policy_versions,omitempty"`
+
+	Timestamp time.Time `json:"timestamp"`
+
+	// PrevHash is the chain's prior record's Hash ("" for the first
+	// record), and Hash = SHA-256(PrevHash || JSON(record with Hash
+	// cleared)), hex-encoded. See audit.Logger.Record and audit.Logger.Verify.
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// AuditQueryFilter restricts GET /audit/decisions to records matching every
+// non-zero field.
+type AuditQueryFilter struct {
+	UserID       string
+	ResourceType ResourceType
+	ResourceID   string
+	From         *time.Time
+	To           *time.Time
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// ConstraintTemplate is a parameterized policy_rules body rendered into a
+// concrete Policy when a Constraint instantiates it, modeled after
+// Gatekeeper's ConstraintTemplate/Constraint CRD split. Unlike PolicyTemplate
+// (which renders into a UnifiedPolicy for the unified storage system), a
+// ConstraintTemplate renders into a plain Policy for the admission webhook
+// and the rest of the engine/management stack.
+type ConstraintTemplate struct {
+	ID          string `json:"id" yaml:"id"` // UUID, immutable
+	Name        string `json:"name" yaml:"name" binding:"required"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     int    `json:"version" yaml:"version"` // Bumped on every edit
+
+	Parameters []TemplateParameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	// RulesTemplate is a Go text/template body that, once parameters are
+	// substituted, renders to YAML for []PolicyRule.
+	RulesTemplate string `json:"rules_template" yaml:"rules_template" binding:"required"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// Constraint instantiates a ConstraintTemplate with concrete parameter
+// values, compiling to a Policy the admission webhook (and any other
+// evaluation caller) enforces. PolicyID records the compiled Policy so
+// re-rendering after a template edit can find and update it in place.
+type Constraint struct {
+	ID         string                 `json:"id" yaml:"id"` // UUID, immutable
+	TemplateID string                 `json:"template_id" yaml:"template_id" binding:"required"`
+	Name       string                 `json:"name" yaml:"name" binding:"required"`
+	Params     map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"`
+	// Enforcement mirrors Policy.Enforcement ("blocking" or "audit_only"),
+	// letting a constraint be rolled out in dry-run before it can block
+	// admission.
+	Enforcement string `json:"enforcement,omitempty" yaml:"enforcement,omitempty"`
+	PolicyID    string `json:"policy_id,omitempty" yaml:"policy_id,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// ConstraintTemplateCreateRequest registers a new ConstraintTemplate.
+type ConstraintTemplateCreateRequest struct {
+	Name          string              `json:"name" binding:"required"`
+	Description   string              `json:"description,omitempty"`
+	Parameters    []TemplateParameter `json:"parameters,omitempty"`
+	RulesTemplate string              `json:"rules_template" binding:"required"`
+}
+
+// ConstraintCreateRequest instantiates a ConstraintTemplate into a
+// Constraint and its compiled Policy.
+type ConstraintCreateRequest struct {
+	TemplateID  string                 `json:"template_id" binding:"required"`
+	Name        string                 `json:"name" binding:"required"`
+	Params      map[string]interface{} `json:"params"`
+	Enforcement string                 `json:"enforcement,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// EnhancedPolicyRevision is an immutable record of a single CreatePolicy/
+// UpdatePolicy/DeletePolicy write on an EnhancedPolicy, persisted by
+// enhancedhistory.Store to a versioned SQL table - the durable, queryable
+// counterpart to EnhancedStorage's append-only policy_history/<id>.jsonl
+// log, which has no revision IDs and can't be rolled back to. Mirrors
+// PolicyRevision/UnifiedPolicyRevision for the EnhancedPolicy subsystem,
+// with Diff computed against the immediately prior revision and stored
+// alongside it rather than via a separate diff endpoint.
+type EnhancedPolicyRevision struct {
+	ID        string    `json:"id"`
+	PolicyID  string    `json:"policy_id"`
+	Op        string    `json:"op"` // "create", "update", "delete", "rollback", "assign_subjects", "unassign_subjects", "assign_scope_servers", "unassign_scope_servers", "assign_scope_tools", or "unassign_scope_tools"
+	Author    string    `json:"author,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	// Snapshot is the full policy content at this revision, nil for a
+	// "delete" revision since there is nothing left to capture.
+	Snapshot *EnhancedPolicy        `json:"snapshot,omitempty"`
+	Diff     []EnhancedPolicyDiffOp `json:"diff,omitempty"`
+}
+
+// EnhancedPolicyDiffOp is one JSON-patch-style change against the revision
+// immediately before it, computed by enhancedhistory.diffEnhancedPolicy.
+type EnhancedPolicyDiffOp struct {
+	Op    string      `json:"op"` // "add", "remove", or "replace"
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
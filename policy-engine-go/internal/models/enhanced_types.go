@@ -85,6 +85,20 @@ const (
 	ConditionOpLessThan        ConditionOperatorEnhanced = "lt"
 	ConditionOpGreaterOrEqual  ConditionOperatorEnhanced = "gte"
 	ConditionOpLessOrEqual     ConditionOperatorEnhanced = "lte"
+	// ConditionOpExpression evaluates a CEL expression (Value) against the
+	// request instead of comparing Field to Value; Field is ignored. See
+	// engine.evaluateExpressionCondition.
+	ConditionOpExpression ConditionOperatorEnhanced = "expression"
+	// ConditionOpCEL is ConditionOpExpression's successor: the same
+	// CEL-expression-as-Value mechanism, but binding the evaluation context
+	// under "meta" (meta.request, meta.subject, meta.oauth, meta.server,
+	// meta.tool) to match MetadataConditionField's own "meta.*" dotted
+	// naming, plus a top-level "payload", rather than ConditionOpExpression's
+	// flat subject/oauth/server/tool/request/payload variables. New policies
+	// should prefer this operator; ConditionOpExpression is kept working
+	// unchanged for policies already written against it. Field is ignored.
+	// See engine.evaluateCELCondition.
+	ConditionOpCEL ConditionOperatorEnhanced = "cel"
 )
 
 // Subject represents who the policy applies to
@@ -101,6 +115,18 @@ type AccessScope struct {
 	Resources []string    `json:"resources,omitempty" yaml:"resources,omitempty"`   // Specific resources
 }
 
+// Obligation is a structured instruction returned alongside a matching
+// policy's decision for the caller to enforce post-decision, e.g.
+// {Key: "redact", Value: []string{"payload.email"}} or
+// {Key: "rate_limit", Value: "10/min"} - distinct from the Allow/Deny
+// decision itself. See engine.EnhancedEngine.checkRateLimitObligation for
+// the one Key the engine enforces itself; any other Key is surfaced on
+// EnhancedEvaluationResult for the gateway to act on.
+type Obligation struct {
+	Key   string      `json:"key" yaml:"key"`
+	Value interface{} `json:"value" yaml:"value"`
+}
+
 // PolicyConditionEnhanced represents runtime conditions
 type PolicyConditionEnhanced struct {
 	Field    MetadataConditionField    `json:"field" yaml:"field" binding:"required"`
@@ -122,7 +148,17 @@ type EnhancedPolicy struct {
 	AppliesTo  Subject                   `json:"applies_to" yaml:"applies_to" binding:"required"`
 	Scope      AccessScope               `json:"scope" yaml:"scope" binding:"required"`
 	Conditions []PolicyConditionEnhanced `json:"conditions,omitempty" yaml:"conditions,omitempty"`
-	
+	// PayloadSchema, if set, is a JSON Schema document describing the shape
+	// of Context.Tool.Arguments - required fields, types, enum constraints.
+	// evaluatePolicy validates the payload against it before evaluating
+	// Conditions; a violation yields a PolicyActionDeny decision naming the
+	// failing field, distinct from an ordinary condition mismatch.
+	PayloadSchema map[string]interface{} `json:"payload_schema,omitempty" yaml:"payload_schema,omitempty"`
+	// Obligations are returned on EnhancedEvaluationResult whenever this
+	// policy matches a request, regardless of whether it's the policy that
+	// decides the request - see enhancedchain.AllMatch.
+	Obligations []Obligation `json:"obligations,omitempty" yaml:"obligations,omitempty"`
+
 	// Metadata
 	Enabled   bool       `json:"enabled" yaml:"enabled"`
 	OrgID     string     `json:"org_id,omitempty" yaml:"org_id,omitempty"`
@@ -195,7 +231,10 @@ type EnhancedEvaluationResult struct {
 	MatchedPolicy *EnhancedPolicy        `json:"matched_policy"` // The policy that made the decision
 	Reason        string                 `json:"reason"`
 	Metadata      map[string]interface{} `json:"metadata,omitempty"`
-	Timestamp     time.Time              `json:"timestamp"`
+	// Obligations collects every matching policy's Obligations, not just
+	// MatchedPolicy's - see enhancedchain.AllMatch.
+	Obligations []Obligation `json:"obligations,omitempty"`
+	Timestamp   time.Time    `json:"timestamp"`
 }
 
 // PolicyListFilter for filtering policies
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IsLabelMapSubset reports whether every key/value pair in subset is also
+// present in full. An empty or nil subset is trivially a subset of anything.
+func IsLabelMapSubset(subset, full map[string]string) bool {
+	for k, v := range subset {
+		if full[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// LabelMapToString renders a label map in canonical "k=v,k2=v2" form with
+// keys sorted, so it round-trips stably through YAML and query strings.
+func LabelMapToString(m map[string]string) string {
+	if len(m) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+m[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// LabelMapFromString parses the canonical "k=v,k2=v2" form produced by
+// LabelMapToString, also accepted from the ?selector= query parameter.
+func LabelMapFromString(s string) (map[string]string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	m := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid label selector segment %q, expected \"key=value\"", pair)
+		}
+		m[kv[0]] = kv[1]
+	}
+	return m, nil
+}
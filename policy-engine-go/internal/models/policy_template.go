@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// TemplateParamType constrains the type of a PolicyTemplate parameter so
+// rendering can validate caller-supplied values before substitution.
+type TemplateParamType string
+
+const (
+	TemplateParamString TemplateParamType = "string"
+	TemplateParamInt    TemplateParamType = "int"
+	TemplateParamBool   TemplateParamType = "bool"
+	TemplateParamFloat  TemplateParamType = "float"
+)
+
+// TemplateParameter declares one parameter a PolicyTemplate's RulesTemplate
+// may reference (as {{.Name}}) when rendered.
+type TemplateParameter struct {
+	Name     string            `json:"name" yaml:"name" binding:"required"`
+	Type     TemplateParamType `json:"type" yaml:"type" binding:"required"`
+	Default  interface{}       `json:"default,omitempty" yaml:"default,omitempty"`
+	Required bool              `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// PolicyTemplate is a parameterized policy_rules body that can be rendered
+// into one or more concrete UnifiedPolicy rows, modeled after KubeSphere's
+// admission PolicyTemplate/Policy/Rule split.
+type PolicyTemplate struct {
+	TemplateID   string `json:"template_id" yaml:"template_id"` // UUID, immutable
+	TemplateCode string `json:"template_code" yaml:"template_code" binding:"required"`
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version      int    `json:"version" yaml:"version"` // Bumped on every edit; rollout re-renders bound policies to it
+
+	Parameters []TemplateParameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	// RulesTemplate is a Go text/template body that, once parameters are
+	// substituted, renders to YAML for []PolicyRuleDSL.
+	RulesTemplate string `json:"rules_template" yaml:"rules_template" binding:"required"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// PolicyTemplateBinding is a policy's back-reference to the template it was
+// rendered from, kept so a template Rollout can find and re-render every
+// policy instantiated from it.
+type PolicyTemplateBinding struct {
+	TemplateID      string                 `json:"template_id" yaml:"template_id"`
+	TemplateVersion int                    `json:"template_version" yaml:"template_version"`
+	Params          map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// PolicyTemplateCreateRequest registers a new PolicyTemplate.
+type PolicyTemplateCreateRequest struct {
+	TemplateCode  string              `json:"template_code" binding:"required"`
+	Name          string              `json:"name,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	Parameters    []TemplateParameter `json:"parameters,omitempty"`
+	RulesTemplate string              `json:"rules_template" binding:"required"`
+}
+
+// PolicyTemplateInstantiateRequest renders a template into a new concrete
+// UnifiedPolicy.
+type PolicyTemplateInstantiateRequest struct {
+	PolicyCode string                 `json:"policy_code" binding:"required"`
+	Name       string                 `json:"name,omitempty"`
+	Params     map[string]interface{} `json:"params"`
+	OwnerID    string                 `json:"owner_id,omitempty"`
+	OrgID      string                 `json:"org_id,omitempty"`
+	Priority   int                    `json:"priority,omitempty"`
+	Status     PolicyStatus           `json:"status,omitempty"`
+
+	Resources []PolicyResource       `json:"resources,omitempty"`
+	Scopes    []PolicyPrincipalScope `json:"scopes,omitempty"`
+}
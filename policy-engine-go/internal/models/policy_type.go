@@ -0,0 +1,22 @@
+package models
+
+// PolicyTypeDef registers a JSON Schema draft-07 pair governing what a
+// Policy of this type's Rules[].Conditions[].Value and Actions[].Params may
+// contain. A Policy references one via TypeID; registration is opt-in - a
+// Policy with no TypeID skips type validation entirely (see
+// policytype.Registry.Validate and policytype.ValidateAll). Named
+// PolicyTypeDef, not PolicyType, to avoid colliding with the pre-existing
+// PolicyType enum (server_level/global) in enhanced_types.go.
+type PolicyTypeDef struct {
+	ID   string `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name" binding:"required"`
+	// CreateSchema is the JSON Schema draft-07 document every rule
+	// condition Value and action Params of a policy of this type must
+	// satisfy when the policy is created.
+	CreateSchema map[string]interface{} `json:"create_schema,omitempty" yaml:"create_schema,omitempty"`
+	// ModifySchema, if set, replaces CreateSchema when validating an
+	// UpdatePolicy call instead - e.g. to let a field settable only at
+	// creation become read-only afterward. Unset falls back to
+	// CreateSchema.
+	ModifySchema map[string]interface{} `json:"modify_schema,omitempty" yaml:"modify_schema,omitempty"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// ReplicationFilterType selects what field a ReplicationFilter's Pattern is
+// matched against, mirroring Harbor's replication rule filters.
+type ReplicationFilterType string
+
+const (
+	ReplicationFilterName    ReplicationFilterType = "name"
+	ReplicationFilterTag     ReplicationFilterType = "tag"
+	ReplicationFilterProject ReplicationFilterType = "project"
+)
+
+// ReplicationFilter includes (or, with a leading "!", excludes) policies
+// whose field matches Pattern. Pattern supports "*" and "?" glob wildcards.
+// A ReplicationTarget with no filters replicates every policy.
+type ReplicationFilter struct {
+	Type    ReplicationFilterType `json:"type" yaml:"type" binding:"required"`
+	Pattern string                `json:"pattern" yaml:"pattern" binding:"required"`
+}
+
+// ReplicationTriggerMode selects when a ReplicationTarget's matching
+// policies are pushed.
+type ReplicationTriggerMode string
+
+const (
+	// ReplicationTriggerManual only replicates in response to
+	// POST /replication/targets/:id/trigger.
+	ReplicationTriggerManual ReplicationTriggerMode = "manual"
+	// ReplicationTriggerImmediate replicates a policy as soon as its
+	// create/update/delete CRUD event is observed.
+	ReplicationTriggerImmediate ReplicationTriggerMode = "immediate"
+	// ReplicationTriggerCron replicates every matching policy on the
+	// schedule in CronStr.
+	ReplicationTriggerCron ReplicationTriggerMode = "cron"
+)
+
+// ReplicationTarget is a peer gateway that a replication.Manager pushes
+// matching policies to, borrowing the replication-policy model from Harbor.
+type ReplicationTarget struct {
+	ID      string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name    string `json:"name" yaml:"name" binding:"required"`
+	URL     string `json:"url" yaml:"url" binding:"required"`
+	Token   string `json:"token,omitempty" yaml:"token,omitempty"`
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+
+	Filters []ReplicationFilter `json:"filters,omitempty" yaml:"filters,omitempty"`
+	// ReplicateDeletion controls whether a local policy delete propagates
+	// as a delete on this target. False by default so an operator must
+	// opt in to destructive replication.
+	ReplicateDeletion bool `json:"replicate_deletion" yaml:"replicate_deletion"`
+
+	Trigger ReplicationTriggerMode `json:"trigger" yaml:"trigger"`
+	// CronStr is a standard 5-field cron expression (see internal/schedule),
+	// required when Trigger is ReplicationTriggerCron.
+	CronStr string `json:"cron_str,omitempty" yaml:"cron_str,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// ReplicationExecutionStatus is the outcome of one replication run.
+type ReplicationExecutionStatus string
+
+const (
+	ReplicationStatusRunning   ReplicationExecutionStatus = "running"
+	ReplicationStatusSucceeded ReplicationExecutionStatus = "succeeded"
+	ReplicationStatusFailed    ReplicationExecutionStatus = "failed"
+)
+
+// ReplicationExecution records one run of replaying changes to a target,
+// whether triggered by a CRUD event, a cron firing, or a manual trigger.
+type ReplicationExecution struct {
+	ID         string                     `json:"id"`
+	TargetID   string                     `json:"target_id"`
+	Trigger    ReplicationTriggerMode     `json:"trigger"`
+	Status     ReplicationExecutionStatus `json:"status"`
+	StartedAt  time.Time                  `json:"started_at"`
+	FinishedAt *time.Time                 `json:"finished_at,omitempty"`
+	Total      int                        `json:"total"`
+	Succeeded  int                        `json:"succeeded"`
+	Failed     int                        `json:"failed"`
+	Errors     []string                   `json:"errors,omitempty"`
+}
@@ -12,6 +12,32 @@ const (
 	ConditionTypeRate     ConditionType = "rate"
 	ConditionTypeData     ConditionType = "data"
 	ConditionTypeTool     ConditionType = "tool"
+	// ConditionTypeRego evaluates an embedded OPA module instead of
+	// comparing a field to Value with Operator - for conditions the
+	// six-operator comparator can't express (graph traversal, set
+	// relations, time-windowed aggregates). Value holds the module
+	// source: an inline Rego module, a "file://" path, or a bundle URL.
+	// Field, if set, overrides the query entrypoint (default
+	// "data.policy.allow"); Operator is ignored, the same simplification
+	// ConditionTypeRate and ConditionTypeTime already make. Requires a
+	// services/evaluation.RegoEvaluator to be configured - see
+	// evaluation.NewWithConfig - or the condition never matches.
+	ConditionTypeRego ConditionType = "rego"
+	// ConditionTypeJWT matches a claim from PolicyEvaluationRequest.JWT once
+	// it's been verified against a configured jwt_provider - Field addresses
+	// the claim with a "claims." prefix (e.g. "claims.groups",
+	// "claims.scope"), Value/Operator compare it the same way any other
+	// condition does. Never matches if JWT is empty, verification fails, or
+	// no jwtauth.Verifier is configured; see engine.evaluateJWTCondition.
+	ConditionTypeJWT ConditionType = "jwt"
+	// ConditionTypeLabels matches PolicyEvaluationRequest.UserLabels or
+	// ResourceLabels (Field selects which: "user" or "resource") against
+	// Value, which is either a map[string]string implicit-AND subset match
+	// or a Kubernetes-style selector expression string ("env=prod,team in
+	// (sre,secops),!legacy") - see labels.MatchValue. Operator is ignored,
+	// the same simplification ConditionTypeRate and ConditionTypeTime
+	// already make.
+	ConditionTypeLabels ConditionType = "labels"
 )
 
 // ConditionOperator represents the comparison operator
@@ -28,6 +54,14 @@ const (
 	OperatorLte      ConditionOperator = "lte"
 	OperatorMatches  ConditionOperator = "matches"
 	OperatorContains ConditionOperator = "contains"
+	// OperatorNameConstraints marks a ConditionTypeResource condition whose
+	// Value is a {allow: {...}, deny: {...}} object classifying the resource
+	// identifier into dns/cidr/email/uri/principal categories and checking
+	// it against per-category allow/deny pattern lists - the x509/SSH
+	// name-constraints model applied to a policy resource. Deny is checked
+	// first; an allow list, if the category declares one, must also match.
+	// See engine.evaluateResourceNameConstraints.
+	OperatorNameConstraints ConditionOperator = "name_constraints"
 )
 
 // ActionType represents the action to take when a policy matches
@@ -41,6 +75,10 @@ const (
 	ActionRateLimit       ActionType = "rate_limit"
 	ActionLogOnly         ActionType = "log_only"
 	ActionModify          ActionType = "modify"
+	// ActionRegoModify marks a modification decided by a ConditionTypeRego
+	// condition's `actions` document rather than a fixed Action.Params
+	// value - the Rego analogue of ActionModify.
+	ActionRegoModify ActionType = "rego_modify"
 )
 
 // Condition represents a single policy condition
@@ -49,6 +87,12 @@ type Condition struct {
 	Operator ConditionOperator `json:"operator" yaml:"operator" binding:"required"`
 	Field    string            `json:"field" yaml:"field" binding:"required"`
 	Value    interface{}       `json:"value" yaml:"value" binding:"required"`
+	// Inverted negates the match, giving a ConditionTypeUser or
+	// ConditionTypeTool condition IAM's NotPrincipal/NotAction semantics
+	// (matches everything except Value) instead of Principal/Action's
+	// (matches only Value). Only consulted by chain.Compile; the priority
+	// engine's own evaluateCondition ignores it.
+	Inverted bool `json:"inverted,omitempty" yaml:"inverted,omitempty"`
 }
 
 // Action represents an action to take
@@ -57,13 +101,58 @@ type Action struct {
 	Params map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"`
 }
 
-// PolicyRule represents a single rule within a policy
+// PolicyRule represents a single rule within a policy. A rule is either a
+// Conditions tree evaluated by the Go engine, or a Rego module evaluated by
+// OPA - exactly one of the two is set. Actions is required for a Conditions
+// rule; a Rego rule instead derives its action from the decision document
+// returned by the query (see engine.evaluateRegoRule).
 type PolicyRule struct {
 	ID          string      `json:"id" yaml:"id" binding:"required"`
-	Conditions  []Condition `json:"conditions" yaml:"conditions" binding:"required"`
-	Actions     []Action    `json:"actions" yaml:"actions" binding:"required"`
+	Conditions  []Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Actions     []Action    `json:"actions,omitempty" yaml:"actions,omitempty"`
 	Priority    int         `json:"priority" yaml:"priority"`
 	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	// Rego is a module body defining a `decision` document shaped like
+	// `{allow: bool, action: "deny|modify|redact|require_approval", modifications: {...}, message: "..."}`.
+	// When set, it replaces Conditions/Actions for this rule.
+	Rego string `json:"rego,omitempty" yaml:"rego,omitempty"`
+	// JWTProviders, if non-empty, restricts this rule's ConditionTypeJWT
+	// conditions to only match when the jwt_provider that actually verified
+	// PolicyEvaluationRequest.JWT is named here - mirroring the Consul
+	// gateway-policy pattern of a rule declaring which provider(s) it
+	// trusts. Empty accepts claims verified by any configured provider.
+	JWTProviders []string `json:"jwt_providers,omitempty" yaml:"jwt_providers,omitempty"`
+}
+
+// IsRego reports whether r is evaluated by the embedded OPA engine rather
+// than the Conditions tree.
+func (r *PolicyRule) IsRego() bool {
+	return r.Rego != ""
+}
+
+// PolicyScopeType identifies the level of a three-level authority →
+// provisioner → account-style policy hierarchy a Policy is bound to.
+type PolicyScopeType string
+
+const (
+	ScopeGlobal    PolicyScopeType = "global"
+	ScopeTenant    PolicyScopeType = "tenant"
+	ScopePrincipal PolicyScopeType = "principal"
+)
+
+// scopePrecedence ranks scopes narrowest-wins, so a more specific policy
+// overrides a broader one when both match the same request: principal
+// overrides tenant overrides global.
+var scopePrecedence = map[PolicyScopeType]int{
+	ScopeGlobal:    0,
+	ScopeTenant:    1,
+	ScopePrincipal: 2,
+}
+
+// ScopePrecedence returns scope's rank in the global < tenant < principal
+// override order. An unrecognized or empty scope ranks as ScopeGlobal.
+func ScopePrecedence(scope PolicyScopeType) int {
+	return scopePrecedence[scope]
 }
 
 // Policy represents a complete policy definition
@@ -79,21 +168,143 @@ type Policy struct {
 	CreatedBy   string       `json:"created_by,omitempty" yaml:"created_by,omitempty"`
 	CreatedAt   *time.Time   `json:"created_at,omitempty" yaml:"created_at,omitempty"`
 	UpdatedAt   *time.Time   `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+	// ScopeType places the policy in the hierarchy; empty is treated as
+	// ScopeGlobal. ScopeID is the tenant or principal ID the policy is
+	// bound to and is ignored for ScopeGlobal.
+	ScopeType PolicyScopeType `json:"scope_type,omitempty" yaml:"scope_type,omitempty"`
+	ScopeID   string          `json:"scope_id,omitempty" yaml:"scope_id,omitempty"`
+	// Signature is an Ed25519 signature (hex-encoded) over the policy with
+	// Signature itself cleared, set by storage.Storage on save when a
+	// signing key is configured. LoadPolicies/Storage.LoadAll verify it on
+	// read and refuse to load a mismatch unless ENFORCE_SIGNATURES=false.
+	Signature string `json:"signature,omitempty" yaml:"signature,omitempty"`
+	// Subscriptions are webhook destinations notified by a
+	// notify.Dispatcher whenever this policy is evaluated or transitions
+	// enabled/disabled or audit_only/enforcing. Managed via
+	// POST/DELETE /policies/:id/subscriptions rather than the general
+	// policy update path.
+	Subscriptions []NotificationSubscription `json:"subscriptions,omitempty" yaml:"subscriptions,omitempty"`
+	// Tags classify a policy for filtering, e.g. by a replication.Manager
+	// deciding which policies to push to a given ReplicationTarget.
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// EvaluationMode selects how the engine decides a winner among this
+	// policy's matched rules: "" or "priority" (the default - highest
+	// scope/rule-priority score wins) or "chain" (engine/chain: ordered,
+	// first-match-wins, IAM-style). Set globally via engine.EngineOptions.Mode;
+	// a policy requesting "chain" overrides the global default for any
+	// request it's in scope for.
+	EvaluationMode string `json:"evaluation_mode,omitempty" yaml:"evaluation_mode,omitempty"`
+	// LastMatchedAt and MatchCount are maintained by storage.SQLStore's
+	// policy_matches table, not by the YAML-backed storage.Storage (which has
+	// no counter to persist them into). Both are zero-value for policies
+	// loaded from a backend that doesn't track them.
+	LastMatchedAt *time.Time `json:"last_matched_at,omitempty" yaml:"last_matched_at,omitempty"`
+	MatchCount    int64      `json:"match_count,omitempty" yaml:"match_count,omitempty"`
+	// TypeID, if set, references a registered PolicyType whose CreateSchema
+	// (or ModifySchema, on update) every rule condition Value and action
+	// Params in Rules must satisfy. Empty skips type validation entirely;
+	// see policytype.Registry.Validate.
+	TypeID string `json:"type_id,omitempty" yaml:"type_id,omitempty"`
+}
+
+// NotificationSubscription registers a webhook destination that receives a
+// PolicyNotification from a notify.Dispatcher.
+type NotificationSubscription struct {
+	ID     string `json:"id" yaml:"id"`
+	URL    string `json:"url" yaml:"url" binding:"required"`
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"` // HMAC-SHA256 signing key, optional
+}
+
+// EnforceStatus is the coarse enforcement outcome reported to a
+// NotificationSubscription: whether the policy actually blocked/modified the
+// request it was evaluated against.
+type EnforceStatus string
+
+const (
+	EnforceStatusEnforced    EnforceStatus = "ENFORCED"
+	EnforceStatusNotEnforced EnforceStatus = "NOT_ENFORCED"
+)
+
+// EnforceReason explains why EnforceStatus came out the way it did, from a
+// fixed enum so subscribers can branch on it without parsing free text.
+type EnforceReason string
+
+const (
+	// EnforceReasonScopeNotApplicable means the policy's scope (tenant or
+	// principal) didn't match the request.
+	EnforceReasonScopeNotApplicable EnforceReason = "SCOPE_NOT_APPLICABLE"
+	// EnforceReasonStatementNotApplicable means the policy was in scope but
+	// none of its rules matched the request.
+	EnforceReasonStatementNotApplicable EnforceReason = "STATEMENT_NOT_APPLICABLE"
+	// EnforceReasonAuditOnly means a rule matched but the policy's
+	// Enforcement is "audit_only", so the action was logged, not applied.
+	EnforceReasonAuditOnly EnforceReason = "AUDIT_ONLY"
+	// EnforceReasonOther covers everything else, including the
+	// disabled-policy and normal-enforcement cases.
+	EnforceReasonOther EnforceReason = "OTHER_REASON"
+)
+
+// PolicyNotification is the JSON body a notify.Dispatcher POSTs to a
+// NotificationSubscription's URL.
+type PolicyNotification struct {
+	PolicyID      string        `json:"policy_id"`
+	EnforceStatus EnforceStatus `json:"enforceStatus"`
+	EnforceReason EnforceReason `json:"enforceReason"`
+	MatchedRules  []string      `json:"matched_rules,omitempty"`
+	Timestamp     time.Time     `json:"timestamp"`
+}
+
+// EffectiveScope returns the policy's ScopeType, defaulting to ScopeGlobal
+// for policies predating the scope hierarchy.
+func (p *Policy) EffectiveScope() PolicyScopeType {
+	if p.ScopeType == "" {
+		return ScopeGlobal
+	}
+	return p.ScopeType
 }
 
 // PolicyEvaluationRequest represents a request to evaluate policies
 type PolicyEvaluationRequest struct {
-	User         string                 `json:"user" binding:"required"`
-	Tool         string                 `json:"tool" binding:"required"`
-	Resource     string                 `json:"resource,omitempty"`
-	Action       string                 `json:"action,omitempty"`
-	Parameters   map[string]interface{} `json:"parameters,omitempty"`
-	Context      map[string]interface{} `json:"context,omitempty"`
-	Timestamp    *time.Time             `json:"timestamp,omitempty"`
-	OrgID        string                 `json:"org_id,omitempty"`
-	SessionID    string                 `json:"session_id,omitempty"`
-	IPAddress    string                 `json:"ip_address,omitempty"`
-	UserAgent    string                 `json:"user_agent,omitempty"`
+	User       string                 `json:"user" binding:"required"`
+	Tool       string                 `json:"tool" binding:"required"`
+	Resource   string                 `json:"resource,omitempty"`
+	Action     string                 `json:"action,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Context    map[string]interface{} `json:"context,omitempty"`
+	Timestamp  *time.Time             `json:"timestamp,omitempty"`
+	OrgID      string                 `json:"org_id,omitempty"`
+	SessionID  string                 `json:"session_id,omitempty"`
+	IPAddress  string                 `json:"ip_address,omitempty"`
+	UserAgent  string                 `json:"user_agent,omitempty"`
+	// JWT is a bearer token to verify against the engine's configured
+	// jwtauth.Verifier before any ConditionTypeJWT condition runs. Empty
+	// skips JWT verification entirely - rules with ConditionTypeJWT
+	// conditions then simply never match. A present token that fails
+	// verification short-circuits evaluation to ActionDeny, naming the
+	// failure (expired, bad issuer, unknown kid) in the result Message.
+	JWT string `json:"jwt,omitempty"`
+	// UserLabels and ResourceLabels are the labels ConditionTypeLabels
+	// matches against (Condition.Field selects which). Both are optional -
+	// a missing map behaves like an empty one, so a ConditionTypeLabels
+	// condition simply never matches a request that carries no labels.
+	UserLabels     map[string]string `json:"user_labels,omitempty"`
+	ResourceLabels map[string]string `json:"resource_labels,omitempty"`
+	// CorrelationID is an opaque, client-supplied identifier echoed back on
+	// the matching response. Unused by Evaluate/BatchEvaluate (which
+	// already return results in request order); EvaluateStream's
+	// concurrent worker pool relies on it, since results there can
+	// complete out of order.
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// ScopeTraceEntry records which scope in the policy hierarchy contributed a
+// matched rule, so admins can debug precedence between a global, tenant and
+// principal policy that all matched the same request.
+type ScopeTraceEntry struct {
+	Scope    PolicyScopeType `json:"scope"`
+	ScopeID  string          `json:"scope_id,omitempty"`
+	PolicyID string          `json:"policy_id"`
+	RuleID   string          `json:"rule_id"`
 }
 
 // PolicyEvaluationResult represents the result of policy evaluation
@@ -106,6 +317,13 @@ type PolicyEvaluationResult struct {
 	Message       string                 `json:"message,omitempty"`
 	ShouldBlock   bool                   `json:"should_block"`
 	Timestamp     time.Time              `json:"timestamp"`
+	// ScopeTrace lists every matched rule across the global/tenant/principal
+	// scopes considered for this request, in no particular order.
+	ScopeTrace []ScopeTraceEntry `json:"scope_trace,omitempty"`
+	// AugmentationErrors lists one message per augment.Augmenter that failed
+	// to enrich the request, in augmenter order, so a defensive rule can
+	// still deny on missing context instead of the request failing outright.
+	AugmentationErrors []string `json:"augmentation_errors,omitempty"`
 }
 
 // BatchEvaluationRequest represents a batch evaluation request
@@ -117,3 +335,56 @@ type BatchEvaluationRequest struct {
 type BatchEvaluationResponse struct {
 	Results []PolicyEvaluationResult `json:"results"`
 }
+
+// PolicyTestCase is a single fixture for POST /policies/test: a sample
+// evaluation request paired with the decision it's expected to produce.
+type PolicyTestCase struct {
+	Name                string                  `json:"name,omitempty"`
+	Request             PolicyEvaluationRequest `json:"request" binding:"required"`
+	ExpectedAction      ActionType              `json:"expected_action" binding:"required"`
+	ExpectedShouldBlock *bool                   `json:"expected_should_block,omitempty"`
+}
+
+// PolicyTestCaseResult reports the outcome of running a single PolicyTestCase
+// against a candidate policy.
+type PolicyTestCaseResult struct {
+	Name           string     `json:"name,omitempty"`
+	Passed         bool       `json:"passed"`
+	MatchedRules   []string   `json:"matched_rules"`
+	ExpectedAction ActionType `json:"expected_action"`
+	ActualAction   ActionType `json:"actual_action"`
+	Diff           string     `json:"diff,omitempty"`
+}
+
+// PolicyTestRequest is the payload for POST /policies/test.
+type PolicyTestRequest struct {
+	Policy    Policy           `json:"policy" binding:"required"`
+	TestCases []PolicyTestCase `json:"test_cases" binding:"required"`
+}
+
+// PolicyTestReport is the response for POST /policies/test.
+type PolicyTestReport struct {
+	Passed  bool                   `json:"passed"`
+	Results []PolicyTestCaseResult `json:"results"`
+}
+
+// PolicyRevision is an immutable record of a single CreatePolicy/
+// UpdatePolicy/DeletePolicy write, appended by storage.Storage to
+// PolicyDir/.history/<policy-id>/. Revisions chain via PriorHash so a gap or
+// a tampered file in the history directory can be detected.
+type PolicyRevision struct {
+	ID        string    `json:"id"`
+	PolicyID  string    `json:"policy_id"`
+	Op        string    `json:"op"` // "create", "update", or "delete"
+	Hash      string    `json:"hash"`
+	PriorHash string    `json:"prior_hash,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	// Signature is an Ed25519 signature (hex-encoded) over the revision's
+	// identifying fields, set when a signing key is configured.
+	Signature string `json:"signature,omitempty"`
+	// Snapshot is the full policy content at this revision (nil for a
+	// "delete" of a policy that predates history tracking), used by
+	// RollbackPolicy to restore it.
+	Snapshot *Policy `json:"snapshot,omitempty"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// UnifiedPolicyRevision is an immutable record of a single Create/Update/
+// Activate/Suspend/Retire/AddResource/RemoveResource/Rollout/Rollback write,
+// appended by storage.UnifiedStorage to PolicyDir/.unified_history/
+// <policy-id>/. Revisions chain via PriorHash so a gap or a tampered file in
+// the history directory can be detected. Mirrors PolicyRevision for the
+// UnifiedPolicy subsystem.
+type UnifiedPolicyRevision struct {
+	ID        string    `json:"id"`
+	PolicyID  string    `json:"policy_id"`
+	Op        string    `json:"op"` // "create", "update", "activate", "suspend", "retire", "add_resource", "remove_resource", "rollout", or "rollback"
+	Hash      string    `json:"hash"`
+	PriorHash string    `json:"prior_hash,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	// Signature is an Ed25519 signature (hex-encoded) over the revision's
+	// identifying fields, set when a signing key is configured.
+	Signature string `json:"signature,omitempty"`
+	// Snapshot is the full policy content at this revision, used by
+	// UnifiedStorage.Rollback to restore it and by the diff endpoint to
+	// compare two revisions.
+	Snapshot *UnifiedPolicy `json:"snapshot,omitempty"`
+}
+
+// UnifiedPolicyDiffOp is one JSON-patch-style change between two
+// UnifiedPolicyRevision snapshots, returned by
+// GET /unified/policies/:id/revisions/:a/diff/:b.
+type UnifiedPolicyDiffOp struct {
+	Op    string      `json:"op"`   // "add", "remove", or "replace"
+	Path  string      `json:"path"` // e.g. "/policy_rules/rule-1", "/resources/2", "/name"
+	Value interface{} `json:"value,omitempty"`
+}
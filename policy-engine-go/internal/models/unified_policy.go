@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/datacline/policy-engine/internal/labels"
+)
 
 // Unified Policy Model based on authoritative context
 // This is the canonical policy system implementation
@@ -50,6 +54,23 @@ const (
 	RuleOpLte         RuleOperator = "lte"
 	RuleOpExists      RuleOperator = "exists"
 	RuleOpNotExists   RuleOperator = "not_exists"
+
+	// RuleOpCEL evaluates Value as a CEL expression (see internal/engine's
+	// prepareUnifiedExpression) against the full SimulationContext
+	// attribute tree; Field is typically left empty since the expression
+	// does its own field access.
+	RuleOpCEL RuleOperator = "cel"
+	// RuleOpJSONPath resolves Field as a JSONPath-style path (supporting
+	// "[N]" indices and "[*]" wildcards, e.g. "tool.arguments.items[*].id")
+	// and compares the result to Value.
+	RuleOpJSONPath RuleOperator = "json_path"
+	// RuleOpCIDR checks whether the IP address at Field falls inside the
+	// CIDR range, or any range in the list of CIDR ranges, given in Value.
+	RuleOpCIDR RuleOperator = "cidr"
+	// RuleOpSemverRange checks whether the version string at Field
+	// satisfies every comparator in the space-separated semver range given
+	// in Value (e.g. ">=1.2.0 <2.0.0").
+	RuleOpSemverRange RuleOperator = "semver_range"
 )
 
 // RuleActionType represents action types in policy rules DSL
@@ -74,6 +95,22 @@ type RuleCondition struct {
 	// For boolean composition
 	All []RuleCondition `json:"all,omitempty" yaml:"all,omitempty"` // AND
 	Any []RuleCondition `json:"any,omitempty" yaml:"any,omitempty"` // OR
+
+	// Expression, when set, replaces Field/Operator/Value entirely: a CEL
+	// predicate evaluated with input/user/resource/context/now bindings
+	// (see engine.evaluateConditionExpression), for rule authors who'd
+	// rather write one expression than compose nested All/Any trees. Unlike
+	// RuleOpCEL (an Operator value whose Value holds the expression
+	// string), this is a leaf-level alternative to the whole
+	// Field/Operator/Value/All/Any shape.
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+
+	// CompiledCache holds a lazily-computed, operator-specific parsed form
+	// of Value/Field (a cel.Program for RuleOpCEL, parsed segments for
+	// RuleOpJSONPath, a parsed comparator list for RuleOpSemverRange),
+	// populated on first evaluation by internal/engine. Never serialized -
+	// a policy reloaded from disk always recompiles on its first use.
+	CompiledCache interface{} `json:"-" yaml:"-"`
 }
 
 // RuleAction represents an action in the policy rules DSL
@@ -92,15 +129,78 @@ type PolicyRuleDSL struct {
 	Actions     []RuleAction   `json:"actions" yaml:"actions" binding:"required"`
 }
 
+// PolicyRulesFormat identifies which authoring format PolicyRules was
+// originally expressed in. PolicyFormatYAMLNative (the default/zero value)
+// is the internal DSL; PolicyFormatIAMJSON means the policy was imported
+// from, and can be re-exported as, an IAM/S3-bucket-policy-style JSON
+// document (see internal/policyformat).
+type PolicyRulesFormat string
+
+const (
+	PolicyFormatYAMLNative PolicyRulesFormat = "yaml-native"
+	PolicyFormatIAMJSON    PolicyRulesFormat = "iam-json"
+)
+
+// PolicySchedule expresses a recurring activation window for a policy, on
+// top of (not instead of) EffectiveFrom/EffectiveTo. Modeled after Harbor's
+// replication-policy scheduling (`cron_str` + `start_time` driving scheduled
+// and periodic execution): CronExpression fires the window open, and it
+// stays open for WindowDuration before the next firing is awaited.
+type PolicySchedule struct {
+	CronExpression string `json:"cron_expression" yaml:"cron_expression" binding:"required"`
+	// IANA zone name the cron expression is evaluated in; empty means UTC.
+	TimeZone string `json:"time_zone,omitempty" yaml:"time_zone,omitempty"`
+	// Parsed with time.ParseDuration, e.g. "30m", "2h".
+	WindowDuration string `json:"window_duration" yaml:"window_duration" binding:"required"`
+
+	// Maintained by the ScheduleEngine tick loop, not client-settable.
+	NextRunAt *time.Time `json:"next_run_at,omitempty" yaml:"next_run_at,omitempty"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty" yaml:"last_run_at,omitempty"`
+}
+
+// PolicyLanguage identifies which representation of a policy's rules is
+// authoritative for evaluation.
+type PolicyLanguage string
+
+const (
+	// PolicyLanguageDSL evaluates PolicyRules via engine.Simulate - the
+	// default, and the only option before RegoSource existed.
+	PolicyLanguageDSL PolicyLanguage = "dsl"
+	// PolicyLanguageRego evaluates RegoSource via internal/engine/rego,
+	// ignoring PolicyRules.
+	PolicyLanguageRego PolicyLanguage = "rego"
+	// PolicyLanguageCEL evaluates a CEL expression via RuleOpCEL. Reserved
+	// for a policy whose entire body is one CEL expression rather than a
+	// DSL tree with a RuleOpCEL leaf; today such a policy still sets
+	// PolicyRules to a single rule with one RuleOpCEL condition, so this
+	// value is accepted but not yet distinct from PolicyLanguageDSL.
+	PolicyLanguageCEL PolicyLanguage = "cel"
+)
+
 // UnifiedPolicy represents the authoritative policy entity
 // Maps to the `policy` table in the unified context
 type UnifiedPolicy struct {
 	// Primary identification
-	PolicyID   string `json:"policy_id" yaml:"policy_id"` // UUID, immutable
+	PolicyID   string `json:"policy_id" yaml:"policy_id"`                        // UUID, immutable
 	PolicyCode string `json:"policy_code" yaml:"policy_code" binding:"required"` // Human reference, unique
 
+	// PolicyLanguage selects which of PolicyRules or RegoSource below
+	// governs evaluation. Empty (the zero value) is treated as
+	// PolicyLanguageDSL, so policies created before this field existed
+	// keep evaluating exactly as before.
+	PolicyLanguage PolicyLanguage `json:"policy_language,omitempty" yaml:"policy_language,omitempty"`
+
 	// Policy rules (the DSL)
 	PolicyRules []PolicyRuleDSL `json:"policy_rules" yaml:"policy_rules"`
+	// RulesFormat records which authoring format PolicyRules came from, so
+	// GET .../export?format=iam-json knows whether it can round-trip the
+	// original IAM document exactly.
+	RulesFormat PolicyRulesFormat `json:"rules_format,omitempty" yaml:"rules_format,omitempty"`
+
+	// RegoSource holds a Rego module (expected to define `package policy`
+	// and a `decision` rule - see internal/engine/rego.Evaluate) when
+	// PolicyLanguage is PolicyLanguageRego. Ignored otherwise.
+	RegoSource string `json:"rego_source,omitempty" yaml:"rego_source,omitempty"`
 
 	// Lifecycle
 	Version int          `json:"version" yaml:"version"` // Monotonically increasing
@@ -110,8 +210,25 @@ type UnifiedPolicy struct {
 	EffectiveFrom *time.Time `json:"effective_from,omitempty" yaml:"effective_from,omitempty"`
 	EffectiveTo   *time.Time `json:"effective_to,omitempty" yaml:"effective_to,omitempty"`
 
+	// Recurring activation schedule on top of the effective period; nil
+	// means the policy is always eligible within EffectiveFrom/EffectiveTo.
+	Schedule *PolicySchedule `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	// IsEffectiveNow is computed by the ScheduleEngine each tick and only
+	// meaningful when Schedule is set; not persisted, recomputed on load.
+	IsEffectiveNow bool `json:"is_effective_now,omitempty" yaml:"-"`
+
+	// TemplateBinding is set when PolicyRules were rendered from a
+	// PolicyTemplate, so a later template Rollout can find and re-render
+	// this policy.
+	TemplateBinding *PolicyTemplateBinding `json:"template_binding,omitempty" yaml:"template_binding,omitempty"`
+
 	// Priority for conflict resolution
 	Priority int `json:"priority" yaml:"priority"`
+	// DenyOverride makes this policy's deny rules win an unresolved
+	// cross-policy conflict regardless of Priority, the suggested
+	// resolution engine.DetectConflicts offers for two same-priority rules
+	// it can't otherwise order.
+	DenyOverride bool `json:"deny_override,omitempty" yaml:"deny_override,omitempty"`
 
 	// Ownership and approval
 	OwnerID      string     `json:"owner_id,omitempty" yaml:"owner_id,omitempty"`
@@ -127,26 +244,130 @@ type UnifiedPolicy struct {
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	OrgID       string `json:"org_id,omitempty" yaml:"org_id,omitempty"`
 
+	// Annotations holds free-form key/value metadata not interpreted by
+	// the policy engine core, analogous to Kubernetes object annotations.
+	// Used by internal/reconcile to mark policies projected from an
+	// external Source (see reconcile.AnnotationSourceOrigin) so they can
+	// be distinguished from hand-authored policies.
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+
+	// Labels tags the policy itself (not a resource or principal binding)
+	// with free-form key/value metadata, e.g. {"pii":"true"} or
+	// {"team":"payments"}, so operators can find and group policies by
+	// label via UnifiedPolicyListFilter.LabelSelector without enumerating
+	// PolicyIDs. Distinct from PolicyResource.ResourceLabels and
+	// PolicyPrincipalScope.PrincipalLabels, which are selector criteria a
+	// binding matches against an external resource/principal's labels, not
+	// labels on the policy itself.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
 	// Related bindings (populated when loading with associations)
 	Resources []PolicyResource       `json:"resources,omitempty" yaml:"resources,omitempty"`
 	Scopes    []PolicyPrincipalScope `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+
+	// Subscriptions are webhook destinations notified by a notify.Dispatcher
+	// on lifecycle transitions and resource-binding changes. Managed via
+	// POST/GET /unified/policies/:id/notifications rather than the general
+	// update path.
+	Subscriptions []UnifiedNotificationSubscription `json:"subscriptions,omitempty" yaml:"subscriptions,omitempty"`
+
+	// Approvals records the two-person-rule sign-offs UnifiedStorage.Activate
+	// checks against an org's quorum before letting a draft policy go
+	// active. Managed via POST/GET /unified/policies/:id/approvals rather
+	// than the general update path, like Subscriptions above.
+	Approvals []PolicyApproval `json:"approvals,omitempty" yaml:"approvals,omitempty"`
 }
 
-// PolicyResource binds a policy to a governed resource
+// PolicyResource binds a policy to a governed resource, either a single
+// resource by exact ID or, when ResourceLabels is set, a label selector
+// matched against every resource of ResourceType (ResourceID is ignored in
+// that case).
 // Maps to the `policy_resource` table
 type PolicyResource struct {
 	PolicyID     string       `json:"policy_id" yaml:"policy_id"`
 	ResourceType ResourceType `json:"resource_type" yaml:"resource_type" binding:"required"`
-	ResourceID   string       `json:"resource_id" yaml:"resource_id" binding:"required"`
+	ResourceID   string       `json:"resource_id,omitempty" yaml:"resource_id,omitempty"`
+	// ResourceLabels, when non-empty, turns this binding into a label
+	// selector: it matches any resource of ResourceType whose labels
+	// (resolved via storage.ResourceLabelResolver) are a superset of
+	// ResourceLabels, using IsLabelMapSubset.
+	ResourceLabels map[string]string `json:"resource_labels,omitempty" yaml:"resource_labels,omitempty"`
 }
 
-// PolicyPrincipalScope declares applicability to principals
+// PolicyPrincipalScope declares applicability to principals, either a
+// single principal by exact ID or, when PrincipalLabels is set, a label
+// selector matched against the principal's labels (PrincipalID is ignored
+// in that case).
 // Maps to the `policy_scope` table
 // A global policy has no rows in this table
 type PolicyPrincipalScope struct {
 	PolicyID      string        `json:"policy_id" yaml:"policy_id"`
 	PrincipalType PrincipalType `json:"principal_type" yaml:"principal_type" binding:"required"`
-	PrincipalID   string        `json:"principal_id" yaml:"principal_id" binding:"required"`
+	PrincipalID   string        `json:"principal_id,omitempty" yaml:"principal_id,omitempty"`
+	// PrincipalLabels, when non-empty, turns this scope into a label
+	// selector using the same subset semantics as PolicyResource.ResourceLabels.
+	PrincipalLabels map[string]string `json:"principal_labels,omitempty" yaml:"principal_labels,omitempty"`
+}
+
+// PolicyApproval records one approver's sign-off on PolicyID at Version, as
+// required by the two-person-rule quorum UnifiedStorage.Activate enforces
+// before a draft policy can transition to active.
+// Maps to the `policy_approval` table / the policy's own Approvals slice.
+type PolicyApproval struct {
+	PolicyID   string    `json:"policy_id" yaml:"policy_id"`
+	Version    int       `json:"version" yaml:"version"`
+	ApproverID string    `json:"approver_id" yaml:"approver_id"`
+	ApprovedAt time.Time `json:"approved_at" yaml:"approved_at"`
+	// Signature is a detached, hex-encoded Ed25519 signature over a
+	// canonical PolicyApprovalPayload built from the policy's state at
+	// approval time, produced with the same signing key
+	// UnifiedStorage.SetSigning configures for bundle/history signing (this
+	// subsystem has no separate per-approver key material). Empty when
+	// signing is disabled.
+	Signature string `json:"signature,omitempty" yaml:"signature,omitempty"`
+	Comment   string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+// PolicyApprovalPayload is the canonical, deterministically-ordered content
+// a PolicyApproval.Signature covers: everything that governs evaluation
+// outcome. Editing PolicyRules, Resources, or Scopes after approval changes
+// this payload, so every prior approval's signature stops verifying against
+// the new content - caught at reload time (see UnifiedStorage.LoadAll).
+type PolicyApprovalPayload struct {
+	PolicyID    string                 `json:"policy_id"`
+	Version     int                    `json:"version"`
+	PolicyRules []PolicyRuleDSL        `json:"policy_rules"`
+	Resources   []PolicyResource       `json:"resources"`
+	Scopes      []PolicyPrincipalScope `json:"scopes"`
+}
+
+// UnifiedNotificationSubscription registers a webhook destination that wants
+// push updates about a UnifiedPolicy, delivered by a notify.Dispatcher as a
+// UnifiedPolicyNotification. Named differently from the main Policy
+// subsystem's NotificationSubscription (NotificationDestination vs URL) to
+// match this package's IAM-style field naming.
+type UnifiedNotificationSubscription struct {
+	ID                      string `json:"id" yaml:"id"`
+	NotificationDestination string `json:"notificationDestination" yaml:"notification_destination" binding:"required"`
+	Secret                  string `json:"secret,omitempty" yaml:"secret,omitempty"` // HMAC-SHA256 signing key, optional
+}
+
+// UnifiedPolicyNotification is the JSON body a notify.Dispatcher POSTs to a
+// UnifiedNotificationSubscription's NotificationDestination whenever the
+// policy's lifecycle status changes, a resource binding is added or removed,
+// or its computed EnforceState changes as a result.
+type UnifiedPolicyNotification struct {
+	PolicyID       string        `json:"policy_id"`
+	PolicyCode     string        `json:"policy_code"`
+	PreviousStatus PolicyStatus  `json:"previous_status"`
+	CurrentStatus  PolicyStatus  `json:"current_status"`
+	EnforceStatus  EnforceStatus `json:"enforce_status"`
+	EnforceReason  EnforceReason `json:"enforce_reason"`
+	// Revision is the policy's Version at the time of this notification,
+	// monotonically increasing across the lifecycle/resource-binding
+	// mutations that trigger a notification.
+	Revision  int       `json:"revision"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // PolicyDecision represents the computed decision from policy evaluation
@@ -186,38 +407,61 @@ type GetPoliciesByResourceRequest struct {
 
 // UnifiedPolicyCreateRequest for creating a new unified policy
 type UnifiedPolicyCreateRequest struct {
-	PolicyCode    string          `json:"policy_code" binding:"required"`
-	Name          string          `json:"name,omitempty"`
-	Description   string          `json:"description,omitempty"`
-	PolicyRules   []PolicyRuleDSL `json:"policy_rules" binding:"required"`
-	Status        PolicyStatus    `json:"status"`
-	Priority      int             `json:"priority"`
-	EffectiveFrom *time.Time      `json:"effective_from,omitempty"`
-	EffectiveTo   *time.Time      `json:"effective_to,omitempty"`
-	OwnerID       string          `json:"owner_id,omitempty"`
-	OrgID         string          `json:"org_id,omitempty"`
+	PolicyCode     string          `json:"policy_code" binding:"required"`
+	Name           string          `json:"name,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	PolicyLanguage PolicyLanguage  `json:"policy_language,omitempty"`
+	PolicyRules    []PolicyRuleDSL `json:"policy_rules"`
+	RegoSource     string          `json:"rego_source,omitempty"`
+	Status         PolicyStatus    `json:"status"`
+	Priority       int             `json:"priority"`
+	EffectiveFrom  *time.Time      `json:"effective_from,omitempty"`
+	EffectiveTo    *time.Time      `json:"effective_to,omitempty"`
+	Schedule       *PolicySchedule `json:"schedule,omitempty"`
+	OwnerID        string          `json:"owner_id,omitempty"`
+	OrgID          string          `json:"org_id,omitempty"`
+
+	// When TemplateRef is set, Create resolves the named PolicyTemplate and
+	// renders its RulesTemplate with TemplateParams, overriding PolicyRules
+	// above with the rendered result.
+	TemplateRef    string                 `json:"template_ref,omitempty"`
+	TemplateParams map[string]interface{} `json:"template_params,omitempty"`
+
+	// RulesFormat records the authoring format of PolicyRules; defaults to
+	// PolicyFormatYAMLNative when empty.
+	RulesFormat PolicyRulesFormat `json:"rules_format,omitempty"`
 
 	// Resource bindings
 	Resources []PolicyResource `json:"resources,omitempty"`
 	// Scope bindings (empty = global policy)
 	Scopes []PolicyPrincipalScope `json:"scopes,omitempty"`
+
+	// Annotations, e.g. the source.origin tag a reconcile.Reconciler
+	// stamps onto policies it manages.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // UnifiedPolicyUpdateRequest for updating an existing policy
 type UnifiedPolicyUpdateRequest struct {
-	PolicyCode    string          `json:"policy_code,omitempty"`
-	Name          string          `json:"name,omitempty"`
-	Description   string          `json:"description,omitempty"`
-	PolicyRules   []PolicyRuleDSL `json:"policy_rules,omitempty"`
-	Status        PolicyStatus    `json:"status,omitempty"`
-	Priority      int             `json:"priority,omitempty"`
-	EffectiveFrom *time.Time      `json:"effective_from,omitempty"`
-	EffectiveTo   *time.Time      `json:"effective_to,omitempty"`
+	PolicyCode     string          `json:"policy_code,omitempty"`
+	Name           string          `json:"name,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	PolicyLanguage PolicyLanguage  `json:"policy_language,omitempty"`
+	PolicyRules    []PolicyRuleDSL `json:"policy_rules,omitempty"`
+	RegoSource     string          `json:"rego_source,omitempty"`
+	Status         PolicyStatus    `json:"status,omitempty"`
+	Priority       int             `json:"priority,omitempty"`
+	EffectiveFrom  *time.Time      `json:"effective_from,omitempty"`
+	EffectiveTo    *time.Time      `json:"effective_to,omitempty"`
+	Schedule       *PolicySchedule `json:"schedule,omitempty"`
 
 	// Resource bindings (replaces existing)
 	Resources []PolicyResource `json:"resources,omitempty"`
 	// Scope bindings (replaces existing)
 	Scopes []PolicyPrincipalScope `json:"scopes,omitempty"`
+
+	// Annotations (replaces existing when non-nil)
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // UnifiedPolicyListFilter for filtering policies
@@ -227,6 +471,15 @@ type UnifiedPolicyListFilter struct {
 	OwnerID      string        `json:"owner_id,omitempty"`
 	ResourceType ResourceType  `json:"resource_type,omitempty"`
 	ResourceID   string        `json:"resource_id,omitempty"`
+	// Selector, when non-empty, restricts results to policies with at least
+	// one resource binding whose ResourceLabels is a subset of Selector
+	// (i.e. the binding would match a resource carrying these labels).
+	Selector map[string]string `json:"selector,omitempty"`
+	// LabelSelector, when non-nil, restricts results to policies whose own
+	// Labels satisfy it - e.g. "all policies tagged pii:true" - independent
+	// of Selector above, which matches against resource bindings rather
+	// than the policy's own Labels.
+	LabelSelector *labels.LabelSelector `json:"label_selector,omitempty"`
 }
 
 // IsGlobal returns true if the policy has no scope restrictions (applies to all)
@@ -249,24 +502,102 @@ func (p *UnifiedPolicy) IsActive() bool {
 	return true
 }
 
-// HasResource checks if the policy binds to a specific resource
-func (p *UnifiedPolicy) HasResource(resourceType ResourceType, resourceID string) bool {
+// IsScheduledEffective returns true when the policy has no recurring
+// Schedule (always eligible) or when the ScheduleEngine has marked it
+// effective for the current cron-opened window.
+func (p *UnifiedPolicy) IsScheduledEffective() bool {
+	if p.Schedule == nil {
+		return true
+	}
+	return p.IsEffectiveNow
+}
+
+// HasResource checks if the policy binds to a specific resource, either by
+// exact (resourceType, resourceID) or, when resourceLabels is non-nil, via a
+// label-selector binding whose ResourceLabels every one of resourceLabels'
+// matching ResourceType entries resolves against. resourceLabels is the
+// candidate resource's own labels (e.g. resolved through a
+// storage.ResourceLabelResolver), not the binding's selector.
+func (p *UnifiedPolicy) HasResource(resourceType ResourceType, resourceID string, resourceLabels map[string]string) bool {
+	for _, r := range p.Resources {
+		if r.ResourceType != resourceType {
+			continue
+		}
+		if r.ResourceID == resourceID {
+			return true
+		}
+		if len(r.ResourceLabels) > 0 && resourceLabels != nil &&
+			(&labels.LabelSelector{MatchLabels: r.ResourceLabels}).Matches(resourceLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesSelector reports whether the policy has at least one resource
+// binding whose ResourceLabels is a subset of selector, i.e. a resource
+// carrying selector's labels would be matched by that binding.
+func (p *UnifiedPolicy) MatchesSelector(selector map[string]string) bool {
 	for _, r := range p.Resources {
-		if r.ResourceType == resourceType && r.ResourceID == resourceID {
+		if len(r.ResourceLabels) > 0 && IsLabelMapSubset(r.ResourceLabels, selector) {
 			return true
 		}
 	}
 	return false
 }
 
-// AppliesToPrincipal checks if the policy applies to a specific principal
-// Returns true for global policies (no scopes) or if principal matches any scope
-func (p *UnifiedPolicy) AppliesToPrincipal(principalType PrincipalType, principalID string) bool {
+// MatchesLabelSelector reports whether the policy's own Labels satisfy sel.
+// A nil sel matches every policy (no filtering), the same "absent means
+// unfiltered" convention the rest of UnifiedPolicyListFilter's fields use -
+// note this differs from LabelSelector.Matches' own zero-value convention,
+// which treats an empty-but-non-nil selector as matching nothing.
+func (p *UnifiedPolicy) MatchesLabelSelector(sel *labels.LabelSelector) bool {
+	if sel == nil {
+		return true
+	}
+	return sel.Matches(p.Labels)
+}
+
+// EnforceState computes the policy's current runtime enforcement outcome
+// from its own stored state. Unlike the priority/chain engines'
+// notifySubscribers (internal/engine), which derive EnforceStatus from an
+// evaluated PolicyEvaluationRequest, the Unified subsystem has no evaluation
+// engine of its own, so this reports the closest static analog: ENFORCED
+// means the policy is active, within its effective/schedule window, bound to
+// at least one resource (or global), and has at least one rule that could
+// ever match; otherwise NOT_ENFORCED with the best-fitting reason.
+func (p *UnifiedPolicy) EnforceState() (EnforceStatus, EnforceReason) {
+	if !p.IsGlobal() && len(p.Resources) == 0 {
+		return EnforceStatusNotEnforced, EnforceReasonScopeNotApplicable
+	}
+	if len(p.PolicyRules) == 0 {
+		return EnforceStatusNotEnforced, EnforceReasonStatementNotApplicable
+	}
+	if !p.IsActive() || !p.IsScheduledEffective() {
+		return EnforceStatusNotEnforced, EnforceReasonOther
+	}
+	return EnforceStatusEnforced, EnforceReasonOther
+}
+
+// AppliesToPrincipal checks if the policy applies to a specific principal.
+// Returns true for global policies (no scopes), if principal matches any
+// scope by exact ID, or, when principalLabels is non-nil, if it satisfies a
+// scope's PrincipalLabels selector (e.g. "all users in org acme with role
+// analyst"). principalLabels is the candidate principal's own labels, not
+// the scope's selector.
+func (p *UnifiedPolicy) AppliesToPrincipal(principalType PrincipalType, principalID string, principalLabels map[string]string) bool {
 	if p.IsGlobal() {
 		return true
 	}
 	for _, s := range p.Scopes {
-		if s.PrincipalType == principalType && s.PrincipalID == principalID {
+		if s.PrincipalType != principalType {
+			continue
+		}
+		if s.PrincipalID == principalID {
+			return true
+		}
+		if len(s.PrincipalLabels) > 0 && principalLabels != nil &&
+			(&labels.LabelSelector{MatchLabels: s.PrincipalLabels}).Matches(principalLabels) {
 			return true
 		}
 	}
@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+// UnifiedBundleFilterType selects what field a UnifiedBundleFilter's Pattern
+// is matched against, the UnifiedPolicy analog of ReplicationFilterType.
+type UnifiedBundleFilterType string
+
+const (
+	UnifiedBundleFilterPolicyCode   UnifiedBundleFilterType = "policy_code"
+	UnifiedBundleFilterResourceType UnifiedBundleFilterType = "resource_type"
+	// UnifiedBundleFilterTag matches against Annotations values, since
+	// UnifiedPolicy has no dedicated tags list (see Harbor-style
+	// ReplicationFilterTag, which matches a Policy's Tags instead).
+	UnifiedBundleFilterTag UnifiedBundleFilterType = "tag"
+)
+
+// UnifiedBundleFilter includes a policy into an export/replication bundle
+// when its field matches Pattern, mirroring ReplicationFilter's glob
+// semantics for the main Policy subsystem. No filters means every policy
+// matches.
+type UnifiedBundleFilter struct {
+	Type    UnifiedBundleFilterType `json:"type" yaml:"type" binding:"required"`
+	Pattern string                  `json:"pattern" yaml:"pattern" binding:"required"`
+}
+
+// UnifiedPolicyImportMode selects how an import reconciles a bundle's
+// policies against ones already on disk, matched by PolicyCode.
+type UnifiedPolicyImportMode string
+
+const (
+	// UnifiedImportCreateOnly skips any policy whose PolicyCode already
+	// exists, leaving the local copy untouched.
+	UnifiedImportCreateOnly UnifiedPolicyImportMode = "create_only"
+	// UnifiedImportUpsert creates policies that don't exist yet and
+	// overwrites the rules/metadata of ones that do, keeping the local
+	// PolicyID and incrementing Version.
+	UnifiedImportUpsert UnifiedPolicyImportMode = "upsert"
+	// UnifiedImportReplace is like UnifiedImportUpsert but also overwrites
+	// resource/scope bindings and subscriptions wholesale with the bundle's
+	// copy, rather than leaving them untouched.
+	UnifiedImportReplace UnifiedPolicyImportMode = "replace"
+)
+
+// UnifiedPolicyBundle is a versioned, portable snapshot of UnifiedPolicy
+// entities (including their resource/scope bindings) produced by
+// POST /unified/replication/export and consumed by
+// POST /unified/replication/import - the bulk, cross-cluster analog of the
+// single-policy IAM import/export pair.
+type UnifiedPolicyBundle struct {
+	BundleVersion int             `json:"bundle_version"`
+	GeneratedAt   time.Time       `json:"generated_at"`
+	Policies      []UnifiedPolicy `json:"policies"`
+	// Manifest is an optional Ed25519 signature (hex-encoded) over Policies,
+	// populated when the exporting UnifiedStorage has signing configured via
+	// SetSigning; import verifies it the same way, gated by
+	// enforceSignatures.
+	Manifest string `json:"manifest,omitempty"`
+}
+
+// UnifiedReplicationTarget is a peer gateway that a
+// unifiedreplication.Manager pushes matching UnifiedPolicyBundles to, the
+// bulk-bundle analog of the main Policy subsystem's ReplicationTarget.
+type UnifiedReplicationTarget struct {
+	ID      string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name    string `json:"name" yaml:"name" binding:"required"`
+	URL     string `json:"url" yaml:"url" binding:"required"`
+	Token   string `json:"token,omitempty" yaml:"token,omitempty"`
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+
+	Filters []UnifiedBundleFilter `json:"filters,omitempty" yaml:"filters,omitempty"`
+	// ImportMode is the mode the target is asked to apply to the pushed
+	// bundle, via its own POST /unified/replication/import.
+	ImportMode UnifiedPolicyImportMode `json:"import_mode" yaml:"import_mode"`
+
+	Trigger ReplicationTriggerMode `json:"trigger" yaml:"trigger"`
+	// CronStr is a standard 5-field cron expression (see internal/schedule),
+	// required when Trigger is ReplicationTriggerCron.
+	CronStr string `json:"cron_str,omitempty" yaml:"cron_str,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// UnifiedReplicationExecution records one run of pushing a bundle to a
+// UnifiedReplicationTarget, whether triggered by a cron firing or a manual
+// POST /unified/replication/trigger/:target_id.
+type UnifiedReplicationExecution struct {
+	ID          string                     `json:"id"`
+	TargetID    string                     `json:"target_id"`
+	Trigger     ReplicationTriggerMode     `json:"trigger"`
+	Status      ReplicationExecutionStatus `json:"status"`
+	StartedAt   time.Time                  `json:"started_at"`
+	FinishedAt  *time.Time                 `json:"finished_at,omitempty"`
+	PolicyCount int                        `json:"policy_count"`
+	Error       string                     `json:"error,omitempty"`
+}
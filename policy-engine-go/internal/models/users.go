@@ -15,14 +15,17 @@ type User struct {
 	MFAEnabled bool     `json:"mfa_enabled" yaml:"mfa_enabled"`
 	CreatedAt  string   `json:"created_at" yaml:"created_at"`
 	LastLogin  string   `json:"last_login,omitempty" yaml:"last_login,omitempty"`
+	Source     string   `json:"source,omitempty" yaml:"source,omitempty"` // "" for file-defined, else the PrincipalSource name that synced it
 }
 
 // UserGroup represents a group of users
 type UserGroup struct {
-	ID          string `json:"id" yaml:"id"`
-	Name        string `json:"name" yaml:"name"`
-	Description string `json:"description" yaml:"description"`
-	MemberCount int    `json:"member_count" yaml:"member_count"`
+	ID          string   `json:"id" yaml:"id"`
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	MemberCount int      `json:"member_count" yaml:"member_count"`
+	Roles       []string `json:"roles,omitempty" yaml:"roles,omitempty"`     // role IDs inherited by every member of this group
+	Source      string   `json:"source,omitempty" yaml:"source,omitempty"` // "" for file-defined, else the PrincipalSource name that synced it
 }
 
 // UserRole represents a role that can be assigned to users
@@ -64,3 +67,74 @@ type AllPrincipalsResponse struct {
 	Groups []UserGroup `json:"groups"`
 	Roles  []UserRole  `json:"roles"`
 }
+
+// AuditEntry records a single mutation made to principal data (users, groups, roles)
+type AuditEntry struct {
+	Timestamp  string      `json:"timestamp"`
+	Actor      string      `json:"actor"`
+	Action     string      `json:"action"`
+	EntityType string      `json:"entity_type"`
+	EntityID   string      `json:"entity_id"`
+	Before     interface{} `json:"before,omitempty"`
+	After      interface{} `json:"after,omitempty"`
+}
+
+// GroupMembershipRequest is the request body for adding/removing a group member
+type GroupMembershipRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// RoleAssignmentRequest is the request body for assigning a role to a user
+type RoleAssignmentRequest struct {
+	RoleID string `json:"role_id" binding:"required"`
+}
+
+// PermissionSource records which role granted an effective permission, and
+// via which group, if inherited rather than directly assigned.
+type PermissionSource struct {
+	RoleID     string `json:"role_id"`
+	ViaGroupID string `json:"via_group_id,omitempty"`
+}
+
+// EffectivePermission is a single permission resolved for a user, along with
+// why they have it.
+type EffectivePermission struct {
+	Permission string           `json:"permission"`
+	Source     PermissionSource `json:"source"`
+}
+
+// EffectivePermissionsResponse is the response for GET /users/:id/effective-permissions
+type EffectivePermissionsResponse struct {
+	UserID      string                `json:"user_id"`
+	Permissions []EffectivePermission `json:"permissions"`
+}
+
+// CanResponse is the response for POST /users/:id/can
+type CanResponse struct {
+	Allowed bool     `json:"allowed"`
+	Reasons []string `json:"reasons"`
+}
+
+// ImportChange describes a principal that an import updated.
+type ImportChange struct {
+	ID     string      `json:"id"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// ImportConflict describes why a row in an import batch was rejected. Index
+// is the row's position in the submitted batch (0-based).
+type ImportConflict struct {
+	Index  int    `json:"index"`
+	ID     string `json:"id,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// ImportDiff is the result of a principals import, whether applied or
+// produced by a ?dry_run=true preview.
+type ImportDiff struct {
+	Created   []User           `json:"created"`
+	Updated   []ImportChange   `json:"updated"`
+	Deleted   []User           `json:"deleted"`
+	Conflicts []ImportConflict `json:"conflicts"`
+}
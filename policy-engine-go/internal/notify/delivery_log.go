@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type deliveryStatus string
+
+const (
+	statusPending   deliveryStatus = "pending"
+	statusDelivered deliveryStatus = "delivered"
+	statusFailed    deliveryStatus = "failed"
+)
+
+// delivery is one persisted webhook delivery attempt record.
+type delivery struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	URL            string          `json:"url"`
+	Secret         string          `json:"-"` // never persisted; a redelivery after restart goes out unsigned
+	Body           json.RawMessage `json:"body"`
+	Status         deliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+}
+
+// deliveryLog persists delivery records to policyDir/.notifications/ so a
+// pending delivery survives a process restart.
+type deliveryLog struct {
+	dir string
+}
+
+func newDeliveryLog(policyDir string) *deliveryLog {
+	return &deliveryLog{dir: filepath.Join(policyDir, ".notifications")}
+}
+
+func (l *deliveryLog) save(del *delivery) error {
+	if err := os.MkdirAll(l.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create delivery log directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(del, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal delivery record: %w", err)
+	}
+
+	path := filepath.Join(l.dir, del.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write delivery record: %w", err)
+	}
+	return nil
+}
+
+// pending returns every delivery record left in statusPending, for
+// redelivery after a restart. Secret is not persisted, so a redelivered
+// notification is sent unsigned even if the original subscription had one.
+func (l *deliveryLog) pending() ([]*delivery, error) {
+	entries, err := os.ReadDir(l.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read delivery log directory: %w", err)
+	}
+
+	var pending []*delivery
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(l.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read delivery record: %w", err)
+		}
+		var del delivery
+		if err := json.Unmarshal(data, &del); err != nil {
+			return nil, fmt.Errorf("failed to parse delivery record: %w", err)
+		}
+		if del.Status == statusPending {
+			pending = append(pending, &del)
+		}
+	}
+	return pending, nil
+}
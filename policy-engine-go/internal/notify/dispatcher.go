@@ -0,0 +1,248 @@
+// Package notify delivers PolicyNotification webhooks to the URLs a policy's
+// NotificationSubscriptions register, off a worker pool so a slow or dead
+// subscriber can't block policy evaluation. Each delivery is persisted to
+// disk before being attempted so a redelivery can pick up where a crash or
+// restart left off, retried with exponential backoff, and optionally
+// HMAC-SHA256 signed when the subscription has a Secret.
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// DispatcherOptions configures retry and concurrency behavior for a
+// Dispatcher.
+type DispatcherOptions struct {
+	Workers     int
+	Timeout     time.Duration
+	MaxRetries  int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	QueueSize   int
+	// AllowedHosts exempts these hostnames from the private-destination
+	// check isBlockedDestination otherwise applies to every delivery -
+	// for an internal subscriber that legitimately lives on a private
+	// address. Matched against the URL's hostname only, not the resolved
+	// IP. See config.Config.WebhookAllowedHosts.
+	AllowedHosts []string
+}
+
+// DefaultDispatcherOptions returns default options for a caller to pass to
+// NewDispatcher, which applies no defaults of its own.
+func DefaultDispatcherOptions() DispatcherOptions {
+	return DispatcherOptions{
+		Workers:     4,
+		Timeout:     5 * time.Second,
+		MaxRetries:  5,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		QueueSize:   1024,
+	}
+}
+
+// Dispatcher delivers PolicyNotification webhooks to subscribed URLs.
+type Dispatcher struct {
+	opts       DispatcherOptions
+	httpClient *http.Client
+	log        *deliveryLog
+	jobs       chan *delivery
+	stop       chan struct{}
+}
+
+// NewDispatcher creates a Dispatcher whose delivery log lives under
+// policyDir, starts its worker pool, and re-enqueues any delivery left
+// pending by a prior process.
+func NewDispatcher(policyDir string, opts DispatcherOptions) *Dispatcher {
+	d := &Dispatcher{
+		opts:       opts,
+		httpClient: &http.Client{Timeout: opts.Timeout},
+		log:        newDeliveryLog(policyDir),
+		jobs:       make(chan *delivery, opts.QueueSize),
+		stop:       make(chan struct{}),
+	}
+
+	for i := 0; i < opts.Workers; i++ {
+		go d.worker()
+	}
+
+	pending, err := d.log.pending()
+	if err != nil {
+		log.WithError(err).Warn("Failed to load pending webhook deliveries for redelivery")
+	}
+	for _, p := range pending {
+		d.enqueue(p)
+	}
+
+	return d
+}
+
+// Stop shuts down the worker pool. Queued deliveries remain in the delivery
+// log as pending and are redelivered by the next NewDispatcher.
+func (d *Dispatcher) Stop() {
+	close(d.stop)
+}
+
+// Name identifies this checker in health.Handler's readiness report.
+func (d *Dispatcher) Name() string { return "webhook_subscribers" }
+
+// CheckReady reports the dispatcher degraded once its delivery queue is
+// full, the observable sign that subscriber callbacks aren't draining -
+// whether from a dead endpoint or the worker pool falling behind - fast
+// enough to keep up with notifications.
+func (d *Dispatcher) CheckReady() error {
+	queued, capacity := len(d.jobs), cap(d.jobs)
+	if queued >= capacity {
+		return fmt.Errorf("webhook delivery queue full (%d/%d), subscriber callbacks are not draining", queued, capacity)
+	}
+	return nil
+}
+
+// Dispatch enqueues notification for delivery to sub. It returns once the
+// delivery is durably recorded, not once it is delivered. notification is
+// typically a models.PolicyNotification or models.UnifiedPolicyNotification,
+// but any JSON-marshalable value works - the dispatcher only cares about the
+// bytes it POSTs and signs.
+func (d *Dispatcher) Dispatch(sub models.NotificationSubscription, notification interface{}) {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		log.WithError(err).WithField("policy", notificationPolicyID(notification)).Warn("Failed to marshal policy notification")
+		return
+	}
+
+	del := &delivery{
+		ID:             fmt.Sprintf("%s-%d", sub.ID, time.Now().UnixNano()),
+		SubscriptionID: sub.ID,
+		URL:            sub.URL,
+		Secret:         sub.Secret,
+		Body:           body,
+		Status:         statusPending,
+	}
+	if err := d.log.save(del); err != nil {
+		log.WithError(err).WithField("subscription", sub.ID).Warn("Failed to persist webhook delivery record")
+	}
+	d.enqueue(del)
+}
+
+// notificationPolicyID extracts PolicyID for logging from notification,
+// which is typically a models.PolicyNotification or
+// models.UnifiedPolicyNotification - both happen to share that field, but
+// Dispatch's notification parameter is an untyped interface{}, so this has
+// to type-switch rather than a direct field access.
+func notificationPolicyID(notification interface{}) string {
+	switch n := notification.(type) {
+	case models.PolicyNotification:
+		return n.PolicyID
+	case *models.PolicyNotification:
+		return n.PolicyID
+	case models.UnifiedPolicyNotification:
+		return n.PolicyID
+	case *models.UnifiedPolicyNotification:
+		return n.PolicyID
+	default:
+		return ""
+	}
+}
+
+func (d *Dispatcher) enqueue(del *delivery) {
+	select {
+	case d.jobs <- del:
+	default:
+		log.WithField("subscription", del.SubscriptionID).Warn("Webhook delivery queue full; dropping notification")
+	}
+}
+
+func (d *Dispatcher) worker() {
+	for {
+		select {
+		case <-d.stop:
+			return
+		case del := <-d.jobs:
+			d.deliver(del)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(del *delivery) {
+	if blocked, err := isBlockedDestination(del.URL, d.opts.AllowedHosts); err != nil || blocked {
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{"subscription": del.SubscriptionID, "url": del.URL}).Warn("Webhook delivery refused: invalid destination")
+		} else {
+			log.WithFields(log.Fields{"subscription": del.SubscriptionID, "url": del.URL}).Warn("Webhook delivery refused: destination resolves to a private/internal address")
+		}
+		del.Status = statusFailed
+		if err := d.log.save(del); err != nil {
+			log.WithError(err).WithField("subscription", del.SubscriptionID).Warn("Failed to update webhook delivery record")
+		}
+		return
+	}
+
+	backoff := d.opts.BaseBackoff
+	for attempt := 0; attempt <= d.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(jitter(backoff))
+			backoff *= 2
+			if backoff > d.opts.MaxBackoff {
+				backoff = d.opts.MaxBackoff
+			}
+		}
+
+		if d.attempt(del) {
+			del.Status = statusDelivered
+			del.Attempts = attempt + 1
+			if err := d.log.save(del); err != nil {
+				log.WithError(err).WithField("subscription", del.SubscriptionID).Warn("Failed to update webhook delivery record")
+			}
+			return
+		}
+		del.Attempts = attempt + 1
+	}
+
+	del.Status = statusFailed
+	if err := d.log.save(del); err != nil {
+		log.WithError(err).WithField("subscription", del.SubscriptionID).Warn("Failed to update webhook delivery record")
+	}
+	log.WithFields(log.Fields{"subscription": del.SubscriptionID, "url": del.URL}).Warn("Webhook delivery exhausted retries")
+}
+
+func (d *Dispatcher) attempt(del *delivery) bool {
+	req, err := http.NewRequest(http.MethodPost, del.URL, bytes.NewReader(del.Body))
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if del.Secret != "" {
+		req.Header.Set("X-Signature", signBody(del.Body, del.Secret))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+func signBody(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+func TestSignBodyIsDeterministicHMACSHA256(t *testing.T) {
+	body := []byte(`{"policy_id":"p-1"}`)
+
+	sig := signBody(body, "s3cr3t")
+	if sig != signBody(body, "s3cr3t") {
+		t.Fatal("expected signBody to be deterministic for the same body and secret")
+	}
+	if sig == signBody(body, "different-secret") {
+		t.Fatal("expected a different secret to produce a different signature")
+	}
+	if _, err := hex.DecodeString(sig); err != nil {
+		t.Fatalf("expected signBody to return a hex-encoded digest, got %q: %v", sig, err)
+	}
+}
+
+func TestDispatchDeliversAndSignsToAllowedDestination(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	var bodyBytes []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ = io.ReadAll(r.Body)
+		received <- r
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	opts := DefaultDispatcherOptions()
+	opts.Workers = 1
+	opts.MaxRetries = 0
+	opts.AllowedHosts = []string{"127.0.0.1"}
+	d := NewDispatcher(t.TempDir(), opts)
+	defer d.Stop()
+
+	sub := models.NotificationSubscription{ID: "sub-1", URL: srv.URL, Secret: "s3cr3t"}
+	notification := models.PolicyNotification{PolicyID: "p-1"}
+	d.Dispatch(sub, notification)
+
+	select {
+	case r := <-received:
+		wantSig := signBody(bodyBytes, "s3cr3t")
+		if gotSig := r.Header.Get("X-Signature"); gotSig != wantSig {
+			t.Fatalf("X-Signature = %q, want %q", gotSig, wantSig)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for webhook delivery")
+	}
+}
+
+func TestDispatchRefusesPrivateDestinationWithNoAllowlist(t *testing.T) {
+	received := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	opts := DefaultDispatcherOptions()
+	opts.Workers = 1
+	opts.MaxRetries = 0
+	d := NewDispatcher(t.TempDir(), opts)
+	defer d.Stop()
+
+	sub := models.NotificationSubscription{ID: "sub-1", URL: srv.URL}
+	d.Dispatch(sub, models.PolicyNotification{PolicyID: "p-1"})
+
+	select {
+	case <-received:
+		t.Fatal("expected a loopback destination with no allowlist entry to be refused, not delivered")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
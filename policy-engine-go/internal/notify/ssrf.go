@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// isBlockedDestination reports whether rawURL must be refused delivery
+// because it resolves to a loopback, link-local, or RFC1918/ULA private
+// address - unless its host appears in allowedHosts. Subscription URLs
+// arrive verbatim on the same unauthenticated policy API that creates the
+// subscription in the first place, so without this check the dispatcher's
+// worker pool is a general-purpose SSRF primitive: an attacker registers a
+// subscription pointed at an internal service or the cloud metadata
+// endpoint and reads the response back out of the delivery's retry/failure
+// logging.
+func isBlockedDestination(rawURL string, allowedHosts []string) (bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false, fmt.Errorf("webhook URL scheme must be http or https, got %q", u.Scheme)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return false, fmt.Errorf("webhook URL has no host")
+	}
+	for _, allowed := range allowedHosts {
+		if strings.EqualFold(host, allowed) {
+			return false, nil
+		}
+	}
+
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		resolved, err := net.LookupIP(host)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve webhook host %q: %w", host, err)
+		}
+		ips = append(ips, resolved...)
+	}
+
+	for _, ip := range ips {
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsPrivate() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
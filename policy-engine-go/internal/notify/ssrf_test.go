@@ -0,0 +1,49 @@
+package notify
+
+import "testing"
+
+func TestIsBlockedDestinationRejectsLoopbackAndPrivateAddresses(t *testing.T) {
+	cases := []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.5/hook",
+		"http://192.168.1.1/hook",
+		"http://[::1]/hook",
+	}
+	for _, rawURL := range cases {
+		blocked, err := isBlockedDestination(rawURL, nil)
+		if err != nil {
+			t.Fatalf("isBlockedDestination(%q): unexpected error: %v", rawURL, err)
+		}
+		if !blocked {
+			t.Errorf("isBlockedDestination(%q) = false, want true (private/internal destination)", rawURL)
+		}
+	}
+}
+
+func TestIsBlockedDestinationAllowsPublicDestination(t *testing.T) {
+	blocked, err := isBlockedDestination("http://93.184.216.34/hook", nil)
+	if err != nil {
+		t.Fatalf("isBlockedDestination: unexpected error: %v", err)
+	}
+	if blocked {
+		t.Error("isBlockedDestination(public IP) = true, want false")
+	}
+}
+
+func TestIsBlockedDestinationHonorsAllowlist(t *testing.T) {
+	blocked, err := isBlockedDestination("http://127.0.0.1:8080/hook", []string{"127.0.0.1"})
+	if err != nil {
+		t.Fatalf("isBlockedDestination: unexpected error: %v", err)
+	}
+	if blocked {
+		t.Error("isBlockedDestination(allowlisted loopback) = true, want false")
+	}
+}
+
+func TestIsBlockedDestinationRejectsNonHTTPScheme(t *testing.T) {
+	if _, err := isBlockedDestination("file:///etc/passwd", nil); err == nil {
+		t.Fatal("expected a non-http(s) scheme to be rejected")
+	}
+}
@@ -0,0 +1,106 @@
+// Package policyformat translates IAM/S3-bucket-policy-style JSON policy
+// documents (the FrostFS/MinIO bucket-policy shape: Statement[] with
+// Effect, Action, Resource, Principal, Condition) into the internal
+// PolicyRuleDSL rule AST used by UnifiedPolicy, and back.
+package policyformat
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// IAMDocument is an IAM/S3-bucket-policy-style JSON policy document.
+type IAMDocument struct {
+	Version   string         `json:"Version,omitempty"`
+	Statement []IAMStatement `json:"Statement"`
+}
+
+// IAMStatement is a single statement within an IAMDocument.
+type IAMStatement struct {
+	Sid       string                            `json:"Sid,omitempty"`
+	Effect    string                            `json:"Effect"` // "Allow" or "Deny"
+	Principal json.RawMessage                   `json:"Principal,omitempty"`
+	Action    StringOrSlice                     `json:"Action"`
+	Resource  StringOrSlice                     `json:"Resource"`
+	Condition map[string]map[string]interface{} `json:"Condition,omitempty"`
+}
+
+// StringOrSlice unmarshals either a single JSON string or an array of
+// strings, the shape IAM documents use for Action/Resource, into []string.
+type StringOrSlice []string
+
+// UnmarshalJSON accepts either a JSON string or a JSON array of strings.
+func (s *StringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = StringOrSlice{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("expected a string or array of strings: %w", err)
+	}
+	*s = many
+	return nil
+}
+
+// MarshalJSON emits a bare string when there's exactly one value, matching
+// how hand-authored IAM documents are usually written.
+func (s StringOrSlice) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
+// ParseIAMDocument parses and minimally validates an IAM policy document.
+func ParseIAMDocument(data []byte) (*IAMDocument, error) {
+	var doc IAMDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("malformed IAM policy document: %w", err)
+	}
+	if len(doc.Statement) == 0 {
+		return nil, fmt.Errorf("policy document has no statements")
+	}
+	for i, stmt := range doc.Statement {
+		if stmt.Effect != "Allow" && stmt.Effect != "Deny" {
+			return nil, fmt.Errorf("statement %d: Effect must be \"Allow\" or \"Deny\", got %q", i, stmt.Effect)
+		}
+		if len(stmt.Action) == 0 {
+			return nil, fmt.Errorf("statement %d: Action is required", i)
+		}
+		if len(stmt.Resource) == 0 {
+			return nil, fmt.Errorf("statement %d: Resource is required", i)
+		}
+	}
+	return &doc, nil
+}
+
+// DefaultActionCatalog is the built-in set of recognized action names,
+// scoped to the resource kinds UnifiedPolicy governs. Deployments with
+// custom action names can build their own catalog and pass it to
+// ValidateActions instead.
+func DefaultActionCatalog() map[string]bool {
+	return map[string]bool{
+		"*":               true,
+		"mcp:Invoke":      true,
+		"mcp:Read":        true,
+		"mcp:Write":       true,
+		"mcp:Admin":       true,
+		"mcp:ListTools":   true,
+		"mcp:ListServers": true,
+	}
+}
+
+// ValidateActions checks every statement's Action entries against catalog,
+// which maps accepted action names (or "*") to true.
+func ValidateActions(doc *IAMDocument, catalog map[string]bool) error {
+	for i, stmt := range doc.Statement {
+		for _, action := range stmt.Action {
+			if !catalog[action] {
+				return fmt.Errorf("statement %d: unrecognized action %q", i, action)
+			}
+		}
+	}
+	return nil
+}
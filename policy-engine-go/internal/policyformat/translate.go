@@ -0,0 +1,274 @@
+package policyformat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// iamConditionOps maps IAM condition operator keywords to the internal
+// RuleOperator they correspond to.
+var iamConditionOps = map[string]models.RuleOperator{
+	"StringEquals":       models.RuleOpEquals,
+	"StringNotEquals":    models.RuleOpNotEquals,
+	"StringLike":         models.RuleOpContains,
+	"NumericEquals":      models.RuleOpEquals,
+	"NumericGreaterThan": models.RuleOpGt,
+	"NumericLessThan":    models.RuleOpLt,
+}
+
+// ToRules converts an IAMDocument's statements into the internal
+// PolicyRuleDSL rule AST, one rule per statement. Each rule's single action
+// carries the original Action/Resource strings in Params so FromRules can
+// round-trip them on export.
+func ToRules(doc *IAMDocument) ([]models.PolicyRuleDSL, error) {
+	rules := make([]models.PolicyRuleDSL, 0, len(doc.Statement))
+
+	for i, stmt := range doc.Statement {
+		actionType := models.RuleActionDeny
+		if stmt.Effect == "Allow" {
+			actionType = models.RuleActionAllow
+		}
+
+		conditions, err := conditionsFromIAM(stmt.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %w", i, err)
+		}
+
+		ruleID := stmt.Sid
+		if ruleID == "" {
+			ruleID = fmt.Sprintf("stmt-%d", i)
+		}
+
+		rules = append(rules, models.PolicyRuleDSL{
+			RuleID:     ruleID,
+			Priority:   len(doc.Statement) - i,
+			Conditions: conditions,
+			Actions: []models.RuleAction{
+				{
+					Type: actionType,
+					Params: map[string]interface{}{
+						"iam_action":   []string(stmt.Action),
+						"iam_resource": []string(stmt.Resource),
+					},
+				},
+			},
+		})
+	}
+
+	return rules, nil
+}
+
+// FromRules reverses ToRules for export. Rules that retained the
+// iam_action/iam_resource provenance ToRules attaches get their original
+// Action and Resource back exactly (a generated "stmt-<n>" rule ID comes
+// back as the Sid, and NumericEquals and StringEquals both map to
+// RuleOpEquals, so either may be exported for it); rules authored directly in the DSL are exported using their
+// RuleActionType as Effect and the policy's resolved resource bindings (or
+// "*" if there are none) as Resource.
+func FromRules(rules []models.PolicyRuleDSL, resources []models.PolicyResource) (*IAMDocument, error) {
+	doc := &IAMDocument{Version: "2012-10-17", Statement: make([]IAMStatement, 0, len(rules))}
+
+	for _, rule := range rules {
+		for _, action := range rule.Actions {
+			effect := "Deny"
+			if action.Type == models.RuleActionAllow {
+				effect = "Allow"
+			}
+
+			stmt := IAMStatement{Sid: rule.RuleID, Effect: effect}
+
+			if raw, ok := action.Params["iam_action"]; ok {
+				stmt.Action = toStringSlice(raw)
+			} else {
+				stmt.Action = StringOrSlice{"*"}
+			}
+			if raw, ok := action.Params["iam_resource"]; ok {
+				stmt.Resource = toStringSlice(raw)
+			} else {
+				stmt.Resource = resourcesToARNs(resources)
+			}
+
+			stmt.Condition = conditionsToIAM(rule.Conditions)
+			doc.Statement = append(doc.Statement, stmt)
+		}
+	}
+
+	return doc, nil
+}
+
+func toStringSlice(v interface{}) StringOrSlice {
+	switch val := v.(type) {
+	case []string:
+		return StringOrSlice(val)
+	case []interface{}:
+		out := make(StringOrSlice, 0, len(val))
+		for _, item := range val {
+			out = append(out, fmt.Sprint(item))
+		}
+		return out
+	case string:
+		return StringOrSlice{val}
+	default:
+		return StringOrSlice{fmt.Sprint(val)}
+	}
+}
+
+func conditionsFromIAM(cond map[string]map[string]interface{}) (*models.RuleCondition, error) {
+	if len(cond) == 0 {
+		return nil, nil
+	}
+
+	var leaves []models.RuleCondition
+	for opName, fields := range cond {
+		op, ok := iamConditionOps[opName]
+		if !ok {
+			return nil, fmt.Errorf("unsupported condition operator %q", opName)
+		}
+		for field, value := range fields {
+			leaves = append(leaves, models.RuleCondition{Field: field, Operator: op, Value: value})
+		}
+	}
+
+	if len(leaves) == 1 {
+		return &leaves[0], nil
+	}
+	return &models.RuleCondition{All: leaves}, nil
+}
+
+func conditionsToIAM(cond *models.RuleCondition) map[string]map[string]interface{} {
+	leaves := flattenConditions(cond)
+	if len(leaves) == 0 {
+		return nil
+	}
+
+	result := make(map[string]map[string]interface{})
+	for _, leaf := range leaves {
+		opName := reverseConditionOp(leaf.Operator)
+		if opName == "" {
+			continue
+		}
+		if result[opName] == nil {
+			result[opName] = make(map[string]interface{})
+		}
+		result[opName][leaf.Field] = leaf.Value
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// flattenConditions walks an "all"/"any" condition tree into its leaf
+// conditions; IAM's Condition block has no boolean composition of its own,
+// so nested conditions are flattened best-effort.
+func flattenConditions(cond *models.RuleCondition) []models.RuleCondition {
+	if cond == nil {
+		return nil
+	}
+	if len(cond.All) > 0 {
+		var out []models.RuleCondition
+		for _, c := range cond.All {
+			c := c
+			out = append(out, flattenConditions(&c)...)
+		}
+		return out
+	}
+	if len(cond.Any) > 0 {
+		var out []models.RuleCondition
+		for _, c := range cond.Any {
+			c := c
+			out = append(out, flattenConditions(&c)...)
+		}
+		return out
+	}
+	if cond.Field != "" {
+		return []models.RuleCondition{*cond}
+	}
+	return nil
+}
+
+func reverseConditionOp(op models.RuleOperator) string {
+	for name, mapped := range iamConditionOps {
+		if mapped == op {
+			return name
+		}
+	}
+	return ""
+}
+
+// ResolveResourceBindings parses each statement's Resource entries
+// (ARN-like strings of the form "mcp:<type>:<id>", e.g. "mcp:server:github")
+// into PolicyResource bindings for the storage resourceMap index. A bare
+// "*" resource is treated as a global policy and contributes no binding.
+func ResolveResourceBindings(doc *IAMDocument) ([]models.PolicyResource, error) {
+	seen := make(map[string]bool)
+	bindings := make([]models.PolicyResource, 0)
+
+	for i, stmt := range doc.Statement {
+		for _, res := range stmt.Resource {
+			if res == "*" {
+				continue
+			}
+			resourceType, resourceID, err := parseResourceARN(res)
+			if err != nil {
+				return nil, fmt.Errorf("statement %d: %w", i, err)
+			}
+			key := string(resourceType) + ":" + resourceID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			bindings = append(bindings, models.PolicyResource{ResourceType: resourceType, ResourceID: resourceID})
+		}
+	}
+
+	return bindings, nil
+}
+
+// parseResourceARN parses "mcp:<server|tool|resource>:<id>" into the
+// (ResourceType, ResourceID) pair UnifiedStorage indexes on.
+func parseResourceARN(arn string) (models.ResourceType, string, error) {
+	parts := strings.SplitN(arn, ":", 3)
+	if len(parts) != 3 || parts[0] != "mcp" {
+		return "", "", fmt.Errorf("invalid resource ARN %q, expected \"mcp:<type>:<id>\"", arn)
+	}
+
+	var resourceType models.ResourceType
+	switch parts[1] {
+	case "server":
+		resourceType = models.ResourceTypeMCPServer
+	case "tool":
+		resourceType = models.ResourceTypeTool
+	case "resource":
+		resourceType = models.ResourceTypeResource
+	default:
+		return "", "", fmt.Errorf("invalid resource ARN %q: unknown resource kind %q", arn, parts[1])
+	}
+
+	return resourceType, parts[2], nil
+}
+
+// resourcesToARNs reverses parseResourceARN for export.
+func resourcesToARNs(resources []models.PolicyResource) StringOrSlice {
+	if len(resources) == 0 {
+		return StringOrSlice{"*"}
+	}
+	arns := make(StringOrSlice, 0, len(resources))
+	for _, r := range resources {
+		var kind string
+		switch r.ResourceType {
+		case models.ResourceTypeMCPServer:
+			kind = "server"
+		case models.ResourceTypeTool:
+			kind = "tool"
+		case models.ResourceTypeResource:
+			kind = "resource"
+		default:
+			kind = string(r.ResourceType)
+		}
+		arns = append(arns, fmt.Sprintf("mcp:%s:%s", kind, r.ResourceID))
+	}
+	return arns
+}
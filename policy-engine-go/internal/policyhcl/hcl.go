@@ -0,0 +1,351 @@
+// Package policyhcl parses Consul-ACL-style HCL policy blocks into a
+// *models.Policy and renders one back to HCL, so an operator can author
+// policies like:
+//
+//	tool "github.*" { access = "deny" }
+//	user "role:admin" { access = "allow" }
+//	time { after = "09:00" before = "17:00" tz = "UTC" }
+//
+// instead of hand-writing the Condition/Action JSON/YAML tree.
+package policyhcl
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Diagnostic is a single parse/convert problem, reported with enough
+// position information for an editor or CLI to point at the offending line.
+type Diagnostic struct {
+	Severity string `json:"severity"` // "error" or "warning"
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// Diagnostics is a list of Diagnostic. HasErrors reports whether any entry
+// has Severity "error".
+type Diagnostics []Diagnostic
+
+func (d Diagnostics) HasErrors() bool {
+	for _, diag := range d {
+		if diag.Severity == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+func fromHCLDiagnostics(diags hcl.Diagnostics) Diagnostics {
+	out := make(Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		severity := "warning"
+		if d.Severity == hcl.DiagError {
+			severity = "error"
+		}
+		diag := Diagnostic{Severity: severity, Summary: d.Summary, Detail: d.Detail}
+		if d.Subject != nil {
+			diag.Line = d.Subject.Start.Line
+			diag.Column = d.Subject.Start.Column
+		}
+		out = append(out, diag)
+	}
+	return out
+}
+
+var rootSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "tool", LabelNames: []string{"pattern"}},
+		{Type: "tool_prefix", LabelNames: []string{"prefix"}},
+		{Type: "user", LabelNames: []string{"pattern"}},
+		{Type: "time", LabelNames: []string{}},
+	},
+}
+
+var matchBlockAttrs = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "access", Required: true},
+		{Name: "priority", Required: false},
+	},
+}
+
+var timeBlockAttrs = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "after", Required: false},
+		{Name: "before", Required: false},
+		{Name: "tz", Required: false},
+		{Name: "access", Required: true},
+		{Name: "priority", Required: false},
+	},
+}
+
+// Parse converts an HCL policy document into a *models.Policy. policyName
+// becomes the resulting policy's Name. A rule's priority is its reverse
+// document order (the first block gets the highest priority) unless the
+// block sets an explicit `priority` attribute. Diagnostics is non-empty on
+// any problem; the returned policy is nil only when diagnostics.HasErrors().
+func Parse(data []byte, filename, policyName string) (*models.Policy, Diagnostics, error) {
+	parser := hclparse.NewParser()
+	file, parseDiags := parser.ParseHCL(data, filename)
+	if parseDiags.HasErrors() {
+		return nil, fromHCLDiagnostics(parseDiags), fmt.Errorf("malformed HCL in %s", filename)
+	}
+
+	content, contentDiags := file.Body.Content(rootSchema)
+	diags := fromHCLDiagnostics(contentDiags)
+
+	policy := &models.Policy{Name: policyName, Enabled: true}
+	numBlocks := len(content.Blocks)
+
+	for i, block := range content.Blocks {
+		rule, blockDiags := ruleFromBlock(block, numBlocks-i)
+		diags = append(diags, blockDiags...)
+		if rule != nil {
+			policy.Rules = append(policy.Rules, *rule)
+		}
+	}
+
+	if diags.HasErrors() {
+		return nil, diags, fmt.Errorf("%d error(s) converting HCL policy", countErrors(diags))
+	}
+	if len(policy.Rules) == 0 {
+		diags = append(diags, Diagnostic{Severity: "error", Summary: "policy has no tool/user/time blocks"})
+		return nil, diags, fmt.Errorf("empty HCL policy")
+	}
+
+	return policy, diags, nil
+}
+
+func countErrors(diags Diagnostics) int {
+	n := 0
+	for _, d := range diags {
+		if d.Severity == "error" {
+			n++
+		}
+	}
+	return n
+}
+
+func ruleFromBlock(block *hcl.Block, defaultPriority int) (*models.PolicyRule, Diagnostics) {
+	ruleID := fmt.Sprintf("%s-%d", block.Type, defaultPriority)
+
+	switch block.Type {
+	case "tool", "user":
+		return matchRuleFromBlock(block, ruleID, defaultPriority, false)
+	case "tool_prefix":
+		return matchRuleFromBlock(block, ruleID, defaultPriority, true)
+	case "time":
+		return timeRuleFromBlock(block, ruleID, defaultPriority)
+	default:
+		return nil, Diagnostics{{Severity: "error", Summary: fmt.Sprintf("unknown block type %q", block.Type),
+			Line: block.DefRange.Start.Line, Column: block.DefRange.Start.Column}}
+	}
+}
+
+func matchRuleFromBlock(block *hcl.Block, ruleID string, defaultPriority int, prefix bool) (*models.PolicyRule, Diagnostics) {
+	body, bodyDiags := block.Body.Content(matchBlockAttrs)
+	diags := fromHCLDiagnostics(bodyDiags)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	conditionType := models.ConditionTypeTool
+	if block.Type == "user" {
+		conditionType = models.ConditionTypeUser
+	}
+
+	pattern := block.Labels[0]
+	condition := models.Condition{Type: conditionType, Operator: models.OperatorEq, Value: pattern}
+	if prefix {
+		condition.Operator = models.OperatorMatches
+		condition.Value = "^" + regexp.QuoteMeta(pattern)
+	}
+
+	action, priority, actionDiags := parseAccessAndPriority(body, defaultPriority)
+	diags = append(diags, actionDiags...)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &models.PolicyRule{
+		ID:         ruleID,
+		Conditions: []models.Condition{condition},
+		Actions:    []models.Action{action},
+		Priority:   priority,
+	}, diags
+}
+
+func timeRuleFromBlock(block *hcl.Block, ruleID string, defaultPriority int) (*models.PolicyRule, Diagnostics) {
+	body, bodyDiags := block.Body.Content(timeBlockAttrs)
+	diags := fromHCLDiagnostics(bodyDiags)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var conditions []models.Condition
+	// tz is accepted for readability but the engine currently compares the
+	// raw request timestamp, so it isn't applied to after/before yet.
+	if attr, ok := body.Attributes["after"]; ok {
+		val, valDiags := stringAttr(attr)
+		diags = append(diags, valDiags...)
+		conditions = append(conditions, models.Condition{Type: models.ConditionTypeTime, Operator: models.OperatorGte, Value: val})
+	}
+	if attr, ok := body.Attributes["before"]; ok {
+		val, valDiags := stringAttr(attr)
+		diags = append(diags, valDiags...)
+		conditions = append(conditions, models.Condition{Type: models.ConditionTypeTime, Operator: models.OperatorLte, Value: val})
+	}
+
+	action, priority, actionDiags := parseAccessAndPriority(body, defaultPriority)
+	diags = append(diags, actionDiags...)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &models.PolicyRule{
+		ID:         ruleID,
+		Conditions: conditions,
+		Actions:    []models.Action{action},
+		Priority:   priority,
+	}, diags
+}
+
+func parseAccessAndPriority(body *hcl.BodyContent, defaultPriority int) (models.Action, int, Diagnostics) {
+	var diags Diagnostics
+	priority := defaultPriority
+
+	accessAttr := body.Attributes["access"]
+	access, accessDiags := stringAttr(accessAttr)
+	diags = append(diags, accessDiags...)
+
+	action := models.Action{Type: models.ActionAllow}
+	switch access {
+	case "allow":
+		action.Type = models.ActionAllow
+	case "deny":
+		action.Type = models.ActionDeny
+	default:
+		diags = append(diags, Diagnostic{Severity: "error", Summary: fmt.Sprintf("access must be \"allow\" or \"deny\", got %q", access),
+			Line: accessAttr.Range.Start.Line, Column: accessAttr.Range.Start.Column})
+	}
+
+	if attr, ok := body.Attributes["priority"]; ok {
+		val, err := attr.Expr.Value(nil)
+		if err != nil || val.Type() != cty.Number {
+			diags = append(diags, Diagnostic{Severity: "error", Summary: "priority must be a number",
+				Line: attr.Range.Start.Line, Column: attr.Range.Start.Column})
+		} else {
+			n, _ := val.AsBigFloat().Int64()
+			priority = int(n)
+		}
+	}
+
+	return action, priority, diags
+}
+
+func stringAttr(attr *hcl.Attribute) (string, Diagnostics) {
+	val, err := attr.Expr.Value(nil)
+	if err != nil || val.Type() != cty.String {
+		return "", Diagnostics{{Severity: "error", Summary: fmt.Sprintf("%s must be a string", attr.Name),
+			Line: attr.Range.Start.Line, Column: attr.Range.Start.Column}}
+	}
+	return val.AsString(), nil
+}
+
+// Render renders policy back to HCL in the tool/tool_prefix/user/time block
+// form Parse accepts. It round-trips a policy Parse produced; a policy
+// authored directly against the Conditions/Actions tree is rendered on a
+// best-effort basis and rules it can't express as one of the four block
+// types are skipped with a comment explaining why.
+func Render(policy *models.Policy) []byte {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	for _, rule := range policy.Rules {
+		renderRule(body, &rule)
+	}
+
+	return f.Bytes()
+}
+
+func renderRule(body *hclwrite.Body, rule *models.PolicyRule) {
+	access := "allow"
+	if len(rule.Actions) > 0 && rule.Actions[0].Type == models.ActionDeny {
+		access = "deny"
+	}
+
+	if len(rule.Conditions) == 1 {
+		cond := rule.Conditions[0]
+		switch {
+		case cond.Type == models.ConditionTypeTool && cond.Operator == models.OperatorEq:
+			writeMatchBlock(body, "tool", fmt.Sprint(cond.Value), access, rule.Priority)
+			return
+		case cond.Type == models.ConditionTypeTool && cond.Operator == models.OperatorMatches:
+			writeMatchBlock(body, "tool_prefix", unanchor(fmt.Sprint(cond.Value)), access, rule.Priority)
+			return
+		case cond.Type == models.ConditionTypeUser && cond.Operator == models.OperatorEq:
+			writeMatchBlock(body, "user", fmt.Sprint(cond.Value), access, rule.Priority)
+			return
+		}
+	}
+
+	if isTimeOnlyConditions(rule.Conditions) {
+		block := body.AppendNewBlock("time", nil)
+		blockBody := block.Body()
+		for _, cond := range rule.Conditions {
+			switch cond.Operator {
+			case models.OperatorGte:
+				blockBody.SetAttributeValue("after", cty.StringVal(fmt.Sprint(cond.Value)))
+			case models.OperatorLte:
+				blockBody.SetAttributeValue("before", cty.StringVal(fmt.Sprint(cond.Value)))
+			}
+		}
+		blockBody.SetAttributeValue("access", cty.StringVal(access))
+		blockBody.SetAttributeValue("priority", cty.NumberIntVal(int64(rule.Priority)))
+		body.AppendNewline()
+		return
+	}
+
+	// rule doesn't fit the tool/tool_prefix/user/time block shape (e.g. it
+	// has multiple conditions of mixed types, or came from the Rego
+	// backend) - there's no lossless HCL form for it, so it's omitted.
+}
+
+func writeMatchBlock(body *hclwrite.Body, blockType, label, access string, priority int) {
+	block := body.AppendNewBlock(blockType, []string{label})
+	blockBody := block.Body()
+	blockBody.SetAttributeValue("access", cty.StringVal(access))
+	blockBody.SetAttributeValue("priority", cty.NumberIntVal(int64(priority)))
+	body.AppendNewline()
+}
+
+func isTimeOnlyConditions(conditions []models.Condition) bool {
+	if len(conditions) == 0 {
+		return false
+	}
+	for _, cond := range conditions {
+		if cond.Type != models.ConditionTypeTime {
+			return false
+		}
+	}
+	return true
+}
+
+var backslashEscape = regexp.MustCompile(`\\(.)`)
+
+// unanchor strips the "^" anchor and regexp.QuoteMeta escaping Parse adds so
+// a tool_prefix rendered back to HCL shows the original bare prefix string.
+func unanchor(pattern string) string {
+	trimmed := pattern
+	if len(trimmed) > 0 && trimmed[0] == '^' {
+		trimmed = trimmed[1:]
+	}
+	return backslashEscape.ReplaceAllString(trimmed, "$1")
+}
@@ -0,0 +1,216 @@
+// Package policyschema lets an administrator register, per the Hexa schema
+// concept, the shape of the User/Tool/Resource/Context entities a policy's
+// conditions reference: attribute names, types (Long, String, Set<String>,
+// Time), and whether they're required. management.Service.ValidatePolicy
+// consults the registry to reject a policy whose conditions reference an
+// unknown field or use an operator incompatible with the field's declared
+// type; engine.Engine consults it to coerce a condition's value to that
+// type (e.g. parsing an RFC3339 string into a time.Time) before comparing.
+package policyschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// FieldType names the declared type of an entity attribute.
+type FieldType string
+
+const (
+	TypeString    FieldType = "String"
+	TypeLong      FieldType = "Long"
+	TypeBoolean   FieldType = "Boolean"
+	TypeTime      FieldType = "Time"
+	TypeSetString FieldType = "Set<String>"
+)
+
+// FieldDef declares one attribute an EntitySchema's entity carries.
+type FieldDef struct {
+	Name     string    `json:"name" yaml:"name" binding:"required"`
+	Type     FieldType `json:"type" yaml:"type" binding:"required"`
+	Required bool      `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// EntitySchema declares every attribute one entity ("user", "tool",
+// "resource", or "context") may carry.
+type EntitySchema struct {
+	Entity string     `json:"entity" yaml:"entity"`
+	Fields []FieldDef `json:"fields" yaml:"fields"`
+}
+
+func (s *EntitySchema) fieldByName(name string) (FieldDef, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return FieldDef{}, false
+}
+
+// EntityForConditionType reports the schema entity a Condition.Type resolves
+// its Field against. ConditionTypeTime and ConditionTypeRate have no
+// schema-checked field (a time condition is always time-valued; a rate
+// condition's Value is a {window,limit,key} object, not a scalar field), so
+// ok is false for them.
+func EntityForConditionType(t models.ConditionType) (string, bool) {
+	switch t {
+	case models.ConditionTypeUser:
+		return "user", true
+	case models.ConditionTypeTool:
+		return "tool", true
+	case models.ConditionTypeResource:
+		return "resource", true
+	case models.ConditionTypeData:
+		return "context", true
+	default:
+		return "", false
+	}
+}
+
+// Registry holds the registered EntitySchema for each entity, persisted to
+// policyDir/.schema/<entity>.json so it survives a process restart.
+type Registry struct {
+	dir string
+
+	mu      sync.RWMutex
+	schemas map[string]*EntitySchema
+}
+
+// NewRegistry creates a Registry rooted at policyDir/.schema, loading any
+// previously registered entity schemas.
+func NewRegistry(policyDir string) (*Registry, error) {
+	r := &Registry{
+		dir:     filepath.Join(policyDir, ".schema"),
+		schemas: make(map[string]*EntitySchema),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Registry) load() error {
+	entries, err := os.ReadDir(r.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read schema directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(r.dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read entity schema: %w", err)
+		}
+		var schema EntitySchema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return fmt.Errorf("failed to parse entity schema: %w", err)
+		}
+		r.schemas[schema.Entity] = &schema
+	}
+	return nil
+}
+
+// Get retrieves the registered schema for entity, if any.
+func (r *Registry) Get(entity string) (*EntitySchema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schema, ok := r.schemas[entity]
+	return schema, ok
+}
+
+// Set registers schema for entity, persisting it to disk.
+func (r *Registry) Set(entity string, schema *EntitySchema) error {
+	schema.Entity = entity
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create schema directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal entity schema: %w", err)
+	}
+
+	path := filepath.Join(r.dir, entity+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write entity schema: %w", err)
+	}
+
+	r.schemas[entity] = schema
+	return nil
+}
+
+// FieldType reports the declared type of entity's field, if both the
+// entity's schema and the field are registered.
+func (r *Registry) FieldType(entity, field string) (FieldType, bool) {
+	schema, ok := r.Get(entity)
+	if !ok {
+		return "", false
+	}
+	def, ok := schema.fieldByName(field)
+	if !ok {
+		return "", false
+	}
+	return def.Type, true
+}
+
+// ValidateCondition rejects cond when its Field is unknown to the
+// registered schema for its entity, or when its Operator is incompatible
+// with the field's declared type. A condition whose type has no schema
+// entity (ConditionTypeTime, ConditionTypeRate) or whose entity has no
+// registered schema is accepted unconditionally - schema registration is
+// opt-in, not required.
+func (r *Registry) ValidateCondition(cond *models.Condition) error {
+	entity, ok := EntityForConditionType(cond.Type)
+	if !ok || cond.Field == "" {
+		return nil
+	}
+
+	schema, ok := r.Get(entity)
+	if !ok {
+		return nil
+	}
+
+	field, ok := schema.fieldByName(cond.Field)
+	if !ok {
+		return fmt.Errorf("unknown field %q for entity %q", cond.Field, entity)
+	}
+
+	switch cond.Operator {
+	case models.OperatorGt, models.OperatorLt, models.OperatorGte, models.OperatorLte:
+		if field.Type != TypeLong && field.Type != TypeTime {
+			return fmt.Errorf("operator %q requires a numeric or time field, but %q is %q", cond.Operator, cond.Field, field.Type)
+		}
+
+	case models.OperatorMatches:
+		pattern, ok := cond.Value.(string)
+		if !ok {
+			return fmt.Errorf("operator matches requires a string regex pattern for field %q", cond.Field)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("operator matches: field %q has an invalid regex pattern: %w", cond.Field, err)
+		}
+
+	case models.OperatorEq:
+		if field.Type == TypeSetString {
+			return fmt.Errorf("field %q is %q; use \"in\" instead of \"eq\"", cond.Field, TypeSetString)
+		}
+	}
+
+	return nil
+}
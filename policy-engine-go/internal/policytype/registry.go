@@ -0,0 +1,279 @@
+// Package policytype lets an administrator register a PolicyTypeDef: a JSON
+// Schema draft-07 document pair governing what a Policy's
+// Rules[].Conditions[].Value and Actions[].Params may contain, for policies
+// that opt in via Policy.TypeID. management.Service consults the registry to
+// reject a CreatePolicy/UpdatePolicy whose rules don't satisfy their type's
+// schema; cmd/evaluation's fail-fast startup check consults the decoupled
+// ValidateAll instead, since an evaluation-only process has no need for a
+// writable Registry.
+package policytype
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/datacline/policy-engine/internal/config"
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError reports every schema violation found validating a policy
+// against PolicyTypeID's registered schema, so a caller (management's HTTP
+// handler) can return them all at once instead of one error at a time.
+type ValidationError struct {
+	PolicyTypeID string
+	Violations   []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("policy does not satisfy policy type %q: %v", e.PolicyTypeID, e.Violations)
+}
+
+// compiledType holds a PolicyTypeDef's two schemas pre-compiled, so repeated
+// validation doesn't re-parse the same JSON Schema document each time.
+// ModifySchema is nil when the PolicyTypeDef didn't declare one, in which case
+// validatePolicy falls back to CreateSchema.
+type compiledType struct {
+	createSchema *gojsonschema.Schema
+	modifySchema *gojsonschema.Schema
+}
+
+// compile prepares pt's CreateSchema and ModifySchema, if set, into
+// *gojsonschema.Schema. An unset schema is not an error - both are
+// optional - and compiles to a nil *gojsonschema.Schema, which
+// validatePolicy treats as "anything is allowed".
+func compile(pt *models.PolicyTypeDef) (*compiledType, error) {
+	c := &compiledType{}
+	if len(pt.CreateSchema) > 0 {
+		schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(pt.CreateSchema))
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile create_schema: %w", err)
+		}
+		c.createSchema = schema
+	}
+	if len(pt.ModifySchema) > 0 {
+		schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(pt.ModifySchema))
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile modify_schema: %w", err)
+		}
+		c.modifySchema = schema
+	}
+	return c, nil
+}
+
+// ruleInstance is what a Policy's Rules are validated against: the
+// collected Conditions[].Value and Actions[].Params of every rule, in
+// order. A PolicyTypeDef's schema is written against this shape, not against
+// Policy itself, so it doesn't need to re-describe fields (ID, Name,
+// Enabled, ...) it has no opinion on.
+type ruleInstance struct {
+	Conditions []interface{}            `json:"conditions"`
+	Actions    []map[string]interface{} `json:"actions"`
+}
+
+func policyInstance(policy *models.Policy) map[string]interface{} {
+	rules := make([]ruleInstance, len(policy.Rules))
+	for i, rule := range policy.Rules {
+		ri := ruleInstance{}
+		for _, cond := range rule.Conditions {
+			ri.Conditions = append(ri.Conditions, cond.Value)
+		}
+		for _, action := range rule.Actions {
+			ri.Actions = append(ri.Actions, action.Params)
+		}
+		rules[i] = ri
+	}
+	return map[string]interface{}{"rules": rules}
+}
+
+// validatePolicy runs policy's rules through compiled's schema - modifySchema
+// when forUpdate and set, createSchema otherwise (falling back to
+// createSchema if modifySchema wasn't declared) - returning a
+// *ValidationError listing every violation found, or nil if compiled has no
+// applicable schema (schema registration is opt-in per PolicyTypeDef).
+func validatePolicy(policy *models.Policy, compiled *compiledType, forUpdate bool) error {
+	schema := compiled.createSchema
+	if forUpdate && compiled.modifySchema != nil {
+		schema = compiled.modifySchema
+	}
+	if schema == nil {
+		return nil
+	}
+
+	result, err := schema.Validate(gojsonschema.NewGoLoader(policyInstance(policy)))
+	if err != nil {
+		return fmt.Errorf("policy type validation error: %w", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	violations := make([]string, 0, len(result.Errors()))
+	for _, e := range result.Errors() {
+		violations = append(violations, fmt.Sprintf("%s: %s", e.Field(), e.Description()))
+	}
+	return &ValidationError{PolicyTypeID: policy.TypeID, Violations: violations}
+}
+
+// Registry holds the registered PolicyTypeDef and its compiled schemas, keyed
+// by ID, persisted to policyDir/policy-types/<id>.yaml so it survives a
+// process restart. YAML (rather than policyschema.Registry's JSON) matches
+// storage.Storage's on-disk Policy format, since config.LoadPolicyTypes
+// reads this same directory for the evaluation-only binary's startup check.
+type Registry struct {
+	dir string
+
+	mu       sync.RWMutex
+	types    map[string]*models.PolicyTypeDef
+	compiled map[string]*compiledType
+}
+
+// NewRegistry creates a Registry rooted at policyDir/policy-types, loading
+// any previously registered policy types.
+func NewRegistry(policyDir string) (*Registry, error) {
+	r := &Registry{
+		dir:      filepath.Join(policyDir, "policy-types"),
+		types:    make(map[string]*models.PolicyTypeDef),
+		compiled: make(map[string]*compiledType),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Registry) load() error {
+	types, err := config.LoadPolicyTypes(r.dir)
+	if err != nil {
+		return err
+	}
+	for _, pt := range types {
+		compiled, err := compile(pt)
+		if err != nil {
+			return fmt.Errorf("policy type %q: %w", pt.ID, err)
+		}
+		r.types[pt.ID] = pt
+		r.compiled[pt.ID] = compiled
+	}
+	return nil
+}
+
+// Get retrieves the registered PolicyTypeDef with id, if any.
+func (r *Registry) Get(id string) (*models.PolicyTypeDef, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pt, ok := r.types[id]
+	return pt, ok
+}
+
+// List returns every registered PolicyTypeDef.
+func (r *Registry) List() []*models.PolicyTypeDef {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]*models.PolicyTypeDef, 0, len(r.types))
+	for _, pt := range r.types {
+		types = append(types, pt)
+	}
+	return types
+}
+
+// Set registers pt, compiling its schemas and persisting it to disk.
+// Replaces any existing PolicyTypeDef with the same ID.
+func (r *Registry) Set(pt *models.PolicyTypeDef) error {
+	compiled, err := compile(pt)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create policy type directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(pt)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy type: %w", err)
+	}
+
+	path := filepath.Join(r.dir, pt.ID+".yaml")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write policy type: %w", err)
+	}
+
+	r.types[pt.ID] = pt
+	r.compiled[pt.ID] = compiled
+	return nil
+}
+
+// Delete removes the registered PolicyTypeDef with id, if any, from disk and
+// the registry.
+func (r *Registry) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := filepath.Join(r.dir, id+".yaml")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove policy type: %w", err)
+	}
+
+	delete(r.types, id)
+	delete(r.compiled, id)
+	return nil
+}
+
+// Validate rejects policy if it references a TypeID whose registered schema
+// its rules don't satisfy. A policy with no TypeID, or a TypeID unknown to
+// the registry, is accepted unconditionally - type enforcement is opt-in per
+// policy, and a dangling TypeID is only rejected by ValidateAll, not by
+// this call.
+func (r *Registry) Validate(policy *models.Policy, forUpdate bool) error {
+	if policy.TypeID == "" {
+		return nil
+	}
+
+	r.mu.RLock()
+	compiled, ok := r.compiled[policy.TypeID]
+	r.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	return validatePolicy(policy, compiled, forUpdate)
+}
+
+// ValidateAll checks every policy in policies against its referenced
+// PolicyTypeDef in types, failing on the first policy that either references a
+// TypeID missing from types or fails its schema. Used by cmd/evaluation at
+// startup, where a bad rollout should refuse to start rather than silently
+// mis-evaluate requests against an unenforced type.
+func ValidateAll(policies []*models.Policy, types []*models.PolicyTypeDef) error {
+	compiledByID := make(map[string]*compiledType, len(types))
+	for _, pt := range types {
+		compiled, err := compile(pt)
+		if err != nil {
+			return fmt.Errorf("policy type %q: %w", pt.ID, err)
+		}
+		compiledByID[pt.ID] = compiled
+	}
+
+	for _, policy := range policies {
+		if policy.TypeID == "" {
+			continue
+		}
+		compiled, ok := compiledByID[policy.TypeID]
+		if !ok {
+			return fmt.Errorf("policy %q references unknown policy type %q", policy.ID, policy.TypeID)
+		}
+		if err := validatePolicy(policy, compiled, false); err != nil {
+			return fmt.Errorf("policy %q: %w", policy.ID, err)
+		}
+	}
+	return nil
+}
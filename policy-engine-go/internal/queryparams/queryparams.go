@@ -0,0 +1,197 @@
+// Package queryparams implements the shared pagination, sorting, and filter
+// grammar used by list endpoints across the principals API.
+package queryparams
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Predicate is a single "field op value" clause from a ?filter= expression.
+type Predicate struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// supportedOps are the comparison keywords accepted in a filter expression,
+// e.g. "department eq \"Eng\" and mfa_enabled eq true".
+var supportedOps = map[string]bool{
+	"eq": true, "ne": true, "gt": true, "lt": true, "gte": true, "lte": true, "contains": true,
+}
+
+// ParseFilter parses a small filter DSL of the form
+// "field op value and field op value ...". Values may be quoted strings,
+// booleans, or numbers.
+func ParseFilter(expr string) ([]Predicate, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+
+	clauses := strings.Split(expr, " and ")
+	predicates := make([]Predicate, 0, len(clauses))
+
+	for _, clause := range clauses {
+		fields := strings.Fields(strings.TrimSpace(clause))
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid filter clause: %q", clause)
+		}
+
+		field := fields[0]
+		op := fields[1]
+		if !supportedOps[op] {
+			return nil, fmt.Errorf("unsupported filter operator: %q", op)
+		}
+		value := strings.Trim(strings.Join(fields[2:], " "), `"`)
+
+		predicates = append(predicates, Predicate{Field: field, Op: op, Value: value})
+	}
+
+	return predicates, nil
+}
+
+// toField reads field from item's generic JSON representation so
+// predicates can compare across types without reflection.
+func toField(item interface{}, field string) (interface{}, bool) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, false
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, false
+	}
+
+	v, ok := m[field]
+	return v, ok
+}
+
+// Match reports whether an item satisfies every predicate.
+func Match(item interface{}, predicates []Predicate) bool {
+	for _, p := range predicates {
+		actual, ok := toField(item, p.Field)
+		if !ok {
+			return false
+		}
+		if !matchOne(actual, p) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchOne(actual interface{}, p Predicate) bool {
+	actualStr := fmt.Sprintf("%v", actual)
+
+	switch p.Op {
+	case "eq":
+		return strings.EqualFold(actualStr, p.Value)
+	case "ne":
+		return !strings.EqualFold(actualStr, p.Value)
+	case "contains":
+		return strings.Contains(strings.ToLower(actualStr), strings.ToLower(p.Value))
+	case "gt", "lt", "gte", "lte":
+		actualNum, aErr := strconv.ParseFloat(actualStr, 64)
+		expectedNum, eErr := strconv.ParseFloat(p.Value, 64)
+		if aErr != nil || eErr != nil {
+			return false
+		}
+		switch p.Op {
+		case "gt":
+			return actualNum > expectedNum
+		case "lt":
+			return actualNum < expectedNum
+		case "gte":
+			return actualNum >= expectedNum
+		case "lte":
+			return actualNum <= expectedNum
+		}
+	}
+	return false
+}
+
+// Sort stable-sorts items in place by a field, using JSON round-tripping to
+// read field values of arbitrary structs.
+func Sort(items []interface{}, field string, desc bool) {
+	if field == "" {
+		return
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		vi, _ := toField(items[i], field)
+		vj, _ := toField(items[j], field)
+		si, sj := fmt.Sprintf("%v", vi), fmt.Sprintf("%v", vj)
+		if desc {
+			return sj < si
+		}
+		return si < sj
+	})
+}
+
+// ParseSort splits a "field:asc|desc" sort parameter into its parts.
+// Defaults to ascending when the direction is omitted.
+func ParseSort(sortParam string) (field string, desc bool) {
+	if sortParam == "" {
+		return "", false
+	}
+	parts := strings.SplitN(sortParam, ":", 2)
+	field = parts[0]
+	if len(parts) == 2 && strings.EqualFold(parts[1], "desc") {
+		desc = true
+	}
+	return field, desc
+}
+
+// Paginate slices items according to 1-indexed page/pageSize, returning the
+// page and the total item count before slicing.
+func Paginate(items []interface{}, page, pageSize int) (pageItems []interface{}, total int) {
+	total = len(items)
+	if pageSize <= 0 {
+		return items, total
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	start := (page - 1) * pageSize
+	if start >= total {
+		return []interface{}{}, total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return items[start:end], total
+}
+
+// Project reduces each item to only the requested top-level fields.
+func Project(items []interface{}, fields []string) []map[string]interface{} {
+	projected := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		var full map[string]interface{}
+		if err := json.Unmarshal(data, &full); err != nil {
+			continue
+		}
+		if len(fields) == 0 {
+			projected = append(projected, full)
+			continue
+		}
+		reduced := make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			if v, ok := full[f]; ok {
+				reduced[f] = v
+			}
+		}
+		projected = append(projected, reduced)
+	}
+	return projected
+}
@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// SlidingWindowLimiter tracks a timestamp per event and counts how many fall
+// within the trailing window, pruning older ones on each call. Precise but
+// O(events in window) per key.
+type SlidingWindowLimiter struct {
+	mu      sync.Mutex
+	buckets map[string][]time.Time
+}
+
+// NewSlidingWindowLimiter creates an empty SlidingWindowLimiter.
+func NewSlidingWindowLimiter() *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{buckets: make(map[string][]time.Time)}
+}
+
+func (l *SlidingWindowLimiter) Increment(key string, window time.Duration, limit int) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	kept := l.prune(key, window, now)
+	kept = append(kept, now)
+	l.buckets[key] = kept
+	return len(kept), nil
+}
+
+func (l *SlidingWindowLimiter) Get(key string) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets[key]), nil
+}
+
+func (l *SlidingWindowLimiter) Reset(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.buckets, key)
+	return nil
+}
+
+// prune must be called with l.mu held.
+func (l *SlidingWindowLimiter) prune(key string, window time.Duration, now time.Time) []time.Time {
+	cutoff := now.Add(-window)
+	events := l.buckets[key]
+	kept := events[:0]
+	for _, t := range events {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// TokenBucketLimiter refills limit tokens per window continuously and
+// reports how many of the bucket's capacity are currently consumed, so
+// Increment's return value still rises toward limit as the caller uses it
+// up.
+type TokenBucketLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+	window     time.Duration
+	limit      int
+}
+
+// NewTokenBucketLimiter creates an empty TokenBucketLimiter.
+func NewTokenBucketLimiter() *TokenBucketLimiter {
+	return &TokenBucketLimiter{buckets: make(map[string]*tokenBucket)}
+}
+
+func (l *TokenBucketLimiter) Increment(key string, window time.Duration, limit int) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(limit), lastRefill: now, window: window, limit: limit}
+		l.buckets[key] = b
+	}
+
+	l.refill(b, now)
+	if b.tokens >= 1 {
+		b.tokens--
+	}
+
+	return int(math.Ceil(float64(b.limit) - b.tokens)), nil
+}
+
+func (l *TokenBucketLimiter) Get(key string) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		return 0, nil
+	}
+	l.refill(b, time.Now())
+	return int(math.Ceil(float64(b.limit) - b.tokens)), nil
+}
+
+func (l *TokenBucketLimiter) Reset(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.buckets, key)
+	return nil
+}
+
+// refill must be called with l.mu held.
+func (l *TokenBucketLimiter) refill(b *tokenBucket, now time.Time) {
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	refillRate := float64(b.limit) / b.window.Seconds()
+	b.tokens = math.Min(float64(b.limit), b.tokens+elapsed*refillRate)
+	b.lastRefill = now
+}
@@ -0,0 +1,60 @@
+// Package ratelimit backs models.ConditionTypeRate with real counters
+// instead of the engine's old `actualValue = 0` placeholder. A Limiter
+// atomically increments and returns a key's current count within a rolling
+// window, so the engine can compare it against the condition's configured
+// limit with gt/gte/lt/lte. SlidingWindowLimiter and TokenBucketLimiter
+// serve a single replica; RedisLimiter coordinates the same bucket across a
+// fleet of gateway replicas.
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+)
+
+// Limiter tracks request counts per key within a rolling window.
+type Limiter interface {
+	// Increment records one event for key and returns the count within the
+	// trailing window, scoped to limit only insofar as an implementation
+	// needs it to size internal state (e.g. a token bucket's capacity).
+	Increment(key string, window time.Duration, limit int) (int, error)
+	// Get returns key's current count without recording a new event.
+	Get(key string) (int, error)
+	// Reset clears key's bucket.
+	Reset(key string) error
+}
+
+// Condition is the parsed form of a models.Condition.Value for
+// models.ConditionTypeRate: {window: "1m", limit: 100, key: "user.id"}.
+type Condition struct {
+	Window time.Duration
+	Limit  int
+	Key    string
+}
+
+// ParseCondition parses a rate Condition.Value, which arrives as
+// map[string]interface{} after JSON/YAML unmarshaling.
+func ParseCondition(value interface{}) (*Condition, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("rate condition value must be an object with window/limit/key")
+	}
+
+	windowStr, _ := m["window"].(string)
+	window, err := time.ParseDuration(windowStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate condition window %q: %w", windowStr, err)
+	}
+
+	limitFloat, ok := m["limit"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("rate condition missing numeric limit")
+	}
+
+	key, _ := m["key"].(string)
+	if key == "" {
+		return nil, fmt.Errorf("rate condition missing key")
+	}
+
+	return &Condition{Window: window, Limit: int(limitFloat), Key: key}, nil
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript atomically prunes expired members, adds the current
+// event, refreshes the key's TTL, and returns the resulting cardinality, so
+// concurrent gateway replicas sharing a Redis instance never race on the
+// read-increment-write sequence a plain GET/INCR pair would.
+const slidingWindowScript = `
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window_ms = tonumber(ARGV[2])
+local member = ARGV[3]
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window_ms)
+redis.call('ZADD', key, now, member)
+redis.call('PEXPIRE', key, window_ms)
+return redis.call('ZCARD', key)
+`
+
+// RedisLimiter is a sliding-window Limiter backed by a Redis sorted set, so
+// every gateway replica evaluating the same rate condition shares one count.
+type RedisLimiter struct {
+	client *redis.Client
+	script *redis.Script
+}
+
+// NewRedisLimiter creates a RedisLimiter using client.
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client: client, script: redis.NewScript(slidingWindowScript)}
+}
+
+func (l *RedisLimiter) Increment(key string, window time.Duration, limit int) (int, error) {
+	ctx := context.Background()
+	now := time.Now().UnixMilli()
+	member := fmt.Sprintf("%d-%d", now, rand.Int63())
+
+	count, err := l.script.Run(ctx, l.client, []string{key}, now, window.Milliseconds(), member).Int()
+	if err != nil {
+		return 0, fmt.Errorf("rate limit script failed: %w", err)
+	}
+	return count, nil
+}
+
+func (l *RedisLimiter) Get(key string) (int, error) {
+	ctx := context.Background()
+	count, err := l.client.ZCard(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read rate limit bucket: %w", err)
+	}
+	return int(count), nil
+}
+
+func (l *RedisLimiter) Reset(key string) error {
+	return l.client.Del(context.Background(), key).Err()
+}
@@ -0,0 +1,398 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// Well-known annotation keys the Reconciler uses to mark and track policies
+// it manages on behalf of a Source.
+const (
+	// AnnotationSourceOrigin names the Source a policy is managed by. Its
+	// presence is what makes a policy "managed" — reconcileOne never
+	// creates, updates, or deletes a policy whose AnnotationSourceOrigin
+	// doesn't match the source it's reconciling, so hand-authored policies
+	// are never touched.
+	AnnotationSourceOrigin = "source.origin"
+	// AnnotationSourceObject records the SourceObject.Name a policy was
+	// projected from, used to match a vanished source object back to its
+	// policy on the next List.
+	AnnotationSourceObject = "source.object"
+	// AnnotationSourceFingerprint caches the last-applied Source.Fingerprint
+	// value so reconcileOne can skip a no-op Update.
+	AnnotationSourceFingerprint = "source.fingerprint"
+)
+
+// ErrSourceNotFound is returned when a reconcile source name has no
+// registered Source.
+var ErrSourceNotFound = fmt.Errorf("reconcile source not found")
+
+var reconcileTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "policy_reconcile_total",
+		Help: "Total reconciliation operations performed, labeled by source and result",
+	},
+	[]string{"source", "result"},
+)
+
+func init() {
+	prometheus.MustRegister(reconcileTotal)
+}
+
+// SourceStatus reports the health of a configured Source, returned by the
+// GET /unified/sources admin endpoint.
+type SourceStatus struct {
+	Name         string `json:"name"`
+	LastSyncedAt string `json:"last_synced_at,omitempty"`
+	LastError    string `json:"last_error,omitempty"`
+	ManagedCount int    `json:"managed_count"`
+	SyncTotal    uint64 `json:"sync_total"`
+	RequeueCount uint64 `json:"requeue_count"`
+}
+
+// retryState tracks per-object requeue backoff after a failed reconcile.
+type retryState struct {
+	attempts int
+	nextTry  time.Time
+}
+
+// Reconciler drives a set of Sources to convergence against
+// UnifiedStorage: each tick it Lists every Source, projects its objects
+// into managed UnifiedPolicies via reconcileOne, and deletes any
+// previously-managed policy whose source object has disappeared — the same
+// "missing object => deletePolicyByName" semantics as the nsx-operator
+// NetworkPolicy controller.
+type Reconciler struct {
+	storage  *storage.UnifiedStorage
+	sources  map[string]Source
+	interval time.Duration
+
+	mu       sync.Mutex
+	statuses map[string]*SourceStatus
+	retries  map[string]*retryState // keyed by "source/object"
+
+	cancel context.CancelFunc
+}
+
+// NewReconciler creates a Reconciler over the given sources, ticking every
+// interval.
+func NewReconciler(us *storage.UnifiedStorage, interval time.Duration, sources ...Source) *Reconciler {
+	r := &Reconciler{
+		storage:  us,
+		sources:  make(map[string]Source),
+		interval: interval,
+		statuses: make(map[string]*SourceStatus),
+		retries:  make(map[string]*retryState),
+	}
+	for _, src := range sources {
+		r.sources[src.Name()] = src
+		r.statuses[src.Name()] = &SourceStatus{Name: src.Name()}
+	}
+	return r
+}
+
+// Start launches the background reconcile loop, ticking every interval and
+// also draining each Source's Watch channel (when non-nil) for
+// lower-latency convergence. Call Stop to terminate it.
+func (r *Reconciler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		r.ReconcileAll(ctx)
+
+		for name, src := range r.sources {
+			ch, err := src.Watch(ctx)
+			if err != nil {
+				log.WithField("source", name).WithError(err).Warn("Failed to start source watch, falling back to polling")
+				continue
+			}
+			if ch != nil {
+				go r.watchLoop(ctx, name, ch)
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.ReconcileAll(ctx)
+			}
+		}
+	}()
+}
+
+// Stop terminates the background reconcile loop.
+func (r *Reconciler) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// watchLoop drains a single Source's Watch channel, triggering an
+// immediate reconcile of that source on every notification.
+func (r *Reconciler) watchLoop(ctx context.Context, name string, ch <-chan string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+			r.ReconcileSource(ctx, name)
+		}
+	}
+}
+
+// Statuses returns the current status of every registered source.
+func (r *Reconciler) Statuses() []SourceStatus {
+	counts := r.managedCounts()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]SourceStatus, 0, len(r.statuses))
+	for name, st := range r.statuses {
+		snapshot := *st
+		snapshot.ManagedCount = counts[name]
+		result = append(result, snapshot)
+	}
+	return result
+}
+
+// managedCounts tallies currently-stored policies by their
+// AnnotationSourceOrigin.
+func (r *Reconciler) managedCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, p := range r.storage.GetAll() {
+		if origin := p.Annotations[AnnotationSourceOrigin]; origin != "" {
+			counts[origin]++
+		}
+	}
+	return counts
+}
+
+// ReconcileAll reconciles every registered source.
+func (r *Reconciler) ReconcileAll(ctx context.Context) {
+	for name := range r.sources {
+		r.ReconcileSource(ctx, name)
+	}
+}
+
+// ReconcileSource lists a single named source and converges UnifiedStorage
+// towards it: projects every listed object via reconcileOne, then deletes
+// any policy this source manages whose object no longer appears in the
+// list. Objects still within their requeue backoff window are skipped.
+func (r *Reconciler) ReconcileSource(ctx context.Context, name string) error {
+	src, ok := r.sources[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+	}
+
+	objects, err := src.List(ctx)
+	if err != nil {
+		r.recordResult(name, "list_error", err)
+		return err
+	}
+
+	seen := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		seen[obj.Name] = true
+		if r.shouldSkip(name, obj.Name) {
+			continue
+		}
+		if err := r.reconcileOne(src, obj); err != nil {
+			r.requeue(name, obj.Name)
+			log.WithFields(log.Fields{"source": name, "object": obj.Name}).WithError(err).Warn("Policy reconcile failed, will retry")
+			continue
+		}
+		r.clearRetry(name, obj.Name)
+	}
+
+	if err := r.deleteVanished(name, seen); err != nil {
+		r.recordResult(name, "delete_error", err)
+		return err
+	}
+
+	r.recordResult(name, "success", nil)
+	return nil
+}
+
+// reconcileOne computes the target UnifiedPolicy for obj, compares it
+// against the cached managed policy (if any) by fingerprint, and calls
+// Create or Update under UnifiedStorage's lock only when they differ. It
+// never touches a policy whose AnnotationSourceOrigin doesn't match src, so
+// user-created policies — and policies managed by a different source that
+// happen to reuse the same object name — are never mutated.
+func (r *Reconciler) reconcileOne(src Source, obj SourceObject) error {
+	fingerprint := src.Fingerprint(obj)
+	code := managedPolicyCode(src.Name(), obj.Name)
+
+	existing, err := r.storage.GetByCode(code)
+	if err != nil {
+		return r.create(src.Name(), obj, fingerprint, code)
+	}
+
+	if existing.Annotations[AnnotationSourceOrigin] != src.Name() {
+		return fmt.Errorf("policy code %q already exists and is not managed by source %q", code, src.Name())
+	}
+	if existing.Annotations[AnnotationSourceFingerprint] == fingerprint {
+		return nil // Nothing changed since the last reconcile.
+	}
+
+	return r.update(existing, obj, fingerprint)
+}
+
+// deleteVanished removes every policy managed by name whose source object
+// is no longer present in seen.
+func (r *Reconciler) deleteVanished(name string, seen map[string]bool) error {
+	for _, p := range r.storage.GetAll() {
+		if p.Annotations[AnnotationSourceOrigin] != name {
+			continue
+		}
+		object := p.Annotations[AnnotationSourceObject]
+		if seen[object] {
+			continue
+		}
+		if err := r.storage.Delete(p.PolicyID); err != nil {
+			return err
+		}
+		log.WithFields(log.Fields{"source": name, "object": object, "policy_id": p.PolicyID}).Info("Deleted policy for vanished source object")
+	}
+	return nil
+}
+
+func (r *Reconciler) create(sourceName string, obj SourceObject, fingerprint, code string) error {
+	p := obj.Policy
+	req := &models.UnifiedPolicyCreateRequest{
+		PolicyCode:    code,
+		Name:          p.Name,
+		Description:   p.Description,
+		PolicyRules:   p.PolicyRules,
+		Status:        p.Status,
+		Priority:      p.Priority,
+		EffectiveFrom: p.EffectiveFrom,
+		EffectiveTo:   p.EffectiveTo,
+		Schedule:      p.Schedule,
+		OwnerID:       p.OwnerID,
+		OrgID:         p.OrgID,
+		Resources:     p.Resources,
+		Scopes:        p.Scopes,
+		Annotations:   sourceAnnotations(sourceName, obj.Name, fingerprint),
+	}
+	if req.Status == "" {
+		req.Status = models.PolicyStatusActive
+	}
+	_, err := r.storage.Create(req, fmt.Sprintf("reconciler:%s", sourceName))
+	return err
+}
+
+func (r *Reconciler) update(existing *models.UnifiedPolicy, obj SourceObject, fingerprint string) error {
+	p := obj.Policy
+	req := &models.UnifiedPolicyUpdateRequest{
+		Name:          p.Name,
+		Description:   p.Description,
+		PolicyRules:   p.PolicyRules,
+		Status:        p.Status,
+		Priority:      p.Priority,
+		EffectiveFrom: p.EffectiveFrom,
+		EffectiveTo:   p.EffectiveTo,
+		Schedule:      p.Schedule,
+		Resources:     p.Resources,
+		Scopes:        p.Scopes,
+		Annotations:   sourceAnnotations(existing.Annotations[AnnotationSourceOrigin], obj.Name, fingerprint),
+	}
+	_, err := r.storage.Update(existing.PolicyID, req, fmt.Sprintf("reconciler:%s", existing.Annotations[AnnotationSourceOrigin]))
+	return err
+}
+
+func sourceAnnotations(source, objectName, fingerprint string) map[string]string {
+	return map[string]string{
+		AnnotationSourceOrigin:      source,
+		AnnotationSourceObject:      objectName,
+		AnnotationSourceFingerprint: fingerprint,
+	}
+}
+
+func managedPolicyCode(source, objectName string) string {
+	return fmt.Sprintf("src-%s-%s", source, objectName)
+}
+
+// shouldSkip reports whether object is still within its post-failure
+// backoff window.
+func (r *Reconciler) shouldSkip(source, object string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st, ok := r.retries[source+"/"+object]
+	return ok && time.Now().Before(st.nextTry)
+}
+
+// requeue records a failed reconcile attempt, doubling the backoff before
+// object is retried again, capped at ~1 minute.
+func (r *Reconciler) requeue(source, object string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := source + "/" + object
+	st, ok := r.retries[key]
+	if !ok {
+		st = &retryState{}
+		r.retries[key] = st
+	}
+	st.attempts++
+	backoff := time.Duration(1<<uint(capAttempts(st.attempts))) * time.Second
+	st.nextTry = time.Now().Add(backoff)
+
+	if status, ok := r.statuses[source]; ok {
+		status.RequeueCount++
+	}
+}
+
+// clearRetry drops any backoff state for object after a successful
+// reconcile.
+func (r *Reconciler) clearRetry(source, object string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.retries, source+"/"+object)
+}
+
+// capAttempts bounds the exponent used to compute backoff so it can't
+// overflow or grow past roughly a minute (1 << 6 == 64s).
+func capAttempts(attempts int) int {
+	if attempts > 6 {
+		return 6
+	}
+	return attempts
+}
+
+func (r *Reconciler) recordResult(source, result string, err error) {
+	reconcileTotal.WithLabelValues(source, result).Inc()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st, ok := r.statuses[source]
+	if !ok {
+		return
+	}
+	st.SyncTotal++
+	st.LastSyncedAt = time.Now().Format(time.RFC3339)
+	if err != nil {
+		st.LastError = err.Error()
+	} else {
+		st.LastError = ""
+	}
+}
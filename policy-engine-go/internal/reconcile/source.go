@@ -0,0 +1,48 @@
+// Package reconcile projects external policy sources — Kubernetes
+// NetworkPolicy/custom CRDs, an OPA bundle URL, a Git repo of YAML — into
+// storage.UnifiedStorage as managed policies, analogous to the
+// nsx-operator NetworkPolicy controller's reconcile loop.
+package reconcile
+
+import (
+	"context"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// SourceObject is a single external policy object observed by a Source,
+// already projected into the UnifiedPolicy shape the Reconciler should
+// converge storage towards.
+type SourceObject struct {
+	// Name stably identifies the object within its Source. It becomes part
+	// of the managed policy's PolicyCode and is how a vanished object is
+	// matched back to its policy on deletion.
+	Name string
+	// Policy carries the desired rules/resources/scopes/schedule; the
+	// Reconciler fills in PolicyID, PolicyCode and Annotations itself.
+	Policy *models.UnifiedPolicy
+}
+
+// Source is an external origin of policies the Reconciler keeps
+// UnifiedStorage converged against.
+type Source interface {
+	// Name identifies the source, used as the AnnotationSourceOrigin value
+	// and to address it via the /unified/sources API.
+	Name() string
+
+	// List returns every object currently known to the source. Used for
+	// the initial reconcile pass and as a fallback when Watch isn't
+	// available.
+	List(ctx context.Context) ([]SourceObject, error)
+
+	// Watch streams the Name of a changed or deleted object until ctx is
+	// cancelled, triggering an immediate ReconcileSource instead of
+	// waiting for the next poll interval. Sources that can't push changes
+	// may return a nil channel and a nil error; the Reconciler then relies
+	// on its own polling interval alone.
+	Watch(ctx context.Context) (<-chan string, error)
+
+	// Fingerprint computes a stable hash of obj's desired policy shape, so
+	// reconcileOne can detect a no-op reconcile without a full diff.
+	Fingerprint(obj SourceObject) string
+}
@@ -0,0 +1,42 @@
+package replication
+
+import (
+	"path/filepath"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// matches reports whether policy satisfies every filter in filters (an AND
+// of all of them); a target with no filters matches every policy. Pattern
+// is matched with filepath.Match glob semantics ("*" and "?").
+func matches(policy *models.Policy, filters []models.ReplicationFilter) bool {
+	for _, f := range filters {
+		if !matchesFilter(policy, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesFilter(policy *models.Policy, f models.ReplicationFilter) bool {
+	switch f.Type {
+	case models.ReplicationFilterName:
+		return globMatch(f.Pattern, policy.Name)
+	case models.ReplicationFilterProject:
+		return globMatch(f.Pattern, policy.OrgID)
+	case models.ReplicationFilterTag:
+		for _, tag := range policy.Tags {
+			if globMatch(f.Pattern, tag) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func globMatch(pattern, value string) bool {
+	ok, err := filepath.Match(pattern, value)
+	return err == nil && ok
+}
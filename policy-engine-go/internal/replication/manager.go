@@ -0,0 +1,314 @@
+// Package replication pushes a management.Service's policies to peer
+// gateways, borrowing the replication-policy model from Harbor: a
+// ReplicationTarget names a peer and the include filters (by name, tag, or
+// project/org) that decide which policies reach it, on a manual, immediate,
+// or cron trigger. Manager watches the CRUD events the management handler
+// emits for immediate targets and runs its own cron ticker for scheduled
+// ones; every run (of either kind, or a manual POST .../trigger) is recorded
+// as a ReplicationExecution.
+package replication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/schedule"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	tickInterval = time.Minute
+	maxRetries   = 3
+	baseBackoff  = 200 * time.Millisecond
+)
+
+// Manager owns the configured ReplicationTargets and replays policy changes
+// to them over HTTP.
+type Manager struct {
+	targets    *targetStore
+	executions *executionStore
+	httpClient *http.Client
+	lister     func() []*models.Policy
+
+	cancel context.CancelFunc
+}
+
+// NewManager creates a Manager whose targets/executions persist under
+// policyDir/.replication/. lister returns the current full policy set, used
+// to build a cron or manual trigger's replication batch.
+func NewManager(policyDir string, lister func() []*models.Policy) (*Manager, error) {
+	targets, err := newTargetStore(policyDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		targets:    targets,
+		executions: newExecutionStore(policyDir),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		lister:     lister,
+	}, nil
+}
+
+// Start launches the background cron tick loop. Call Stop to terminate it.
+func (m *Manager) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	m.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.cronTick(time.Now())
+			}
+		}
+	}()
+}
+
+// Stop terminates the background cron tick loop.
+func (m *Manager) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+}
+
+// CreateTarget registers a new ReplicationTarget, assigning it an ID.
+func (m *Manager) CreateTarget(target *models.ReplicationTarget) error {
+	if target.Trigger == "" {
+		target.Trigger = models.ReplicationTriggerManual
+	}
+	if err := validateTrigger(target); err != nil {
+		return err
+	}
+
+	target.ID = fmt.Sprintf("repl-%d", time.Now().UnixNano())
+	now := time.Now()
+	target.CreatedAt = &now
+	target.UpdatedAt = &now
+
+	if err := m.targets.create(target); err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{"id": target.ID, "url": target.URL, "trigger": target.Trigger}).Info("Replication target created")
+	return nil
+}
+
+// UpdateTarget replaces the ReplicationTarget stored at id.
+func (m *Manager) UpdateTarget(id string, target *models.ReplicationTarget) error {
+	existing, err := m.targets.get(id)
+	if err != nil {
+		return err
+	}
+	if err := validateTrigger(target); err != nil {
+		return err
+	}
+
+	target.ID = id
+	target.CreatedAt = existing.CreatedAt
+	now := time.Now()
+	target.UpdatedAt = &now
+
+	if err := m.targets.update(target); err != nil {
+		return err
+	}
+	log.WithField("id", id).Info("Replication target updated")
+	return nil
+}
+
+// GetTarget retrieves a ReplicationTarget by ID.
+func (m *Manager) GetTarget(id string) (*models.ReplicationTarget, error) {
+	return m.targets.get(id)
+}
+
+// ListTargets returns every configured ReplicationTarget.
+func (m *Manager) ListTargets() []*models.ReplicationTarget {
+	return m.targets.list()
+}
+
+// DeleteTarget removes a ReplicationTarget by ID.
+func (m *Manager) DeleteTarget(id string) error {
+	if err := m.targets.delete(id); err != nil {
+		return err
+	}
+	log.WithField("id", id).Info("Replication target deleted")
+	return nil
+}
+
+// GetExecution retrieves a previously recorded ReplicationExecution by ID.
+func (m *Manager) GetExecution(id string) (*models.ReplicationExecution, error) {
+	return m.executions.get(id)
+}
+
+// TriggerTarget replicates every policy currently matching target id's
+// filters, for POST /replication/targets/:id/trigger.
+func (m *Manager) TriggerTarget(id string) (*models.ReplicationExecution, error) {
+	target, err := m.targets.get(id)
+	if err != nil {
+		return nil, err
+	}
+	return m.replicateAll(target, models.ReplicationTriggerManual), nil
+}
+
+// OnPolicyEvent is called by the management handler after a policy
+// create/update/delete to replicate the change to every enabled immediate
+// target whose filters match. op is "upsert" or "delete".
+func (m *Manager) OnPolicyEvent(op string, policy *models.Policy) {
+	for _, target := range m.targets.list() {
+		if !target.Enabled || target.Trigger != models.ReplicationTriggerImmediate {
+			continue
+		}
+		if op == "delete" && !target.ReplicateDeletion {
+			continue
+		}
+		if !matches(policy, target.Filters) {
+			continue
+		}
+
+		go m.replicate(target, models.ReplicationTriggerImmediate, []policyChange{{op: op, policy: policy}})
+	}
+}
+
+// cronTick fires any enabled cron target whose schedule matched since the
+// previous tick.
+func (m *Manager) cronTick(now time.Time) {
+	for _, target := range m.targets.list() {
+		if !target.Enabled || target.Trigger != models.ReplicationTriggerCron {
+			continue
+		}
+
+		cron, err := schedule.ParseCron(target.CronStr, "UTC")
+		if err != nil {
+			log.WithError(err).WithField("target", target.ID).Warn("Invalid replication target cron expression, skipping")
+			continue
+		}
+		next, ok := cron.Next(now.Add(-tickInterval))
+		if !ok || next.After(now) {
+			continue
+		}
+
+		m.replicateAll(target, models.ReplicationTriggerCron)
+	}
+}
+
+// replicateAll replicates every policy in the lister that matches target's
+// filters, recording the run as trigger.
+func (m *Manager) replicateAll(target *models.ReplicationTarget, trigger models.ReplicationTriggerMode) *models.ReplicationExecution {
+	var changes []policyChange
+	for _, policy := range m.lister() {
+		if matches(policy, target.Filters) {
+			changes = append(changes, policyChange{op: "upsert", policy: policy})
+		}
+	}
+	return m.replicate(target, trigger, changes)
+}
+
+// policyChange is one policy create/update ("upsert") or delete to replay
+// to a target.
+type policyChange struct {
+	op     string
+	policy *models.Policy
+}
+
+func (m *Manager) replicate(target *models.ReplicationTarget, trigger models.ReplicationTriggerMode, changes []policyChange) *models.ReplicationExecution {
+	exec := &models.ReplicationExecution{
+		ID:        fmt.Sprintf("exec-%d", time.Now().UnixNano()),
+		TargetID:  target.ID,
+		Trigger:   trigger,
+		Status:    models.ReplicationStatusRunning,
+		StartedAt: time.Now(),
+		Total:     len(changes),
+	}
+	if err := m.executions.save(exec); err != nil {
+		log.WithError(err).WithField("target", target.ID).Warn("Failed to persist replication execution record")
+	}
+
+	for _, change := range changes {
+		if err := m.push(target, change); err != nil {
+			exec.Failed++
+			exec.Errors = append(exec.Errors, fmt.Sprintf("%s: %v", change.policy.ID, err))
+			log.WithError(err).WithFields(log.Fields{"target": target.ID, "policy": change.policy.ID, "op": change.op}).Warn("Policy replication failed")
+			continue
+		}
+		exec.Succeeded++
+	}
+
+	finished := time.Now()
+	exec.FinishedAt = &finished
+	exec.Status = models.ReplicationStatusSucceeded
+	if exec.Failed > 0 {
+		exec.Status = models.ReplicationStatusFailed
+	}
+	if err := m.executions.save(exec); err != nil {
+		log.WithError(err).WithField("target", target.ID).Warn("Failed to persist replication execution record")
+	}
+
+	return exec
+}
+
+func (m *Manager) push(target *models.ReplicationTarget, change policyChange) error {
+	if change.op == "delete" {
+		return m.sendWithRetry(target, http.MethodDelete, "/api/v1/policies/"+change.policy.ID, nil)
+	}
+
+	body, err := json.Marshal(change.policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy: %w", err)
+	}
+	return m.sendWithRetry(target, http.MethodPost, "/api/v1/policies", body)
+}
+
+// sendWithRetry sends method/path to target.URL, retrying with exponential
+// backoff up to maxRetries times.
+func (m *Manager) sendWithRetry(target *models.ReplicationTarget, method, path string, body []byte) error {
+	url := strings.TrimRight(target.URL, "/") + path
+
+	var lastErr error
+	backoff := baseBackoff
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		req, err := http.NewRequest(method, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if target.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+target.Token)
+		}
+
+		resp, err := m.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("target %s returned status %d", url, resp.StatusCode)
+	}
+	return lastErr
+}
+
+func validateTrigger(target *models.ReplicationTarget) error {
+	if target.Trigger == models.ReplicationTriggerCron {
+		if _, err := schedule.ParseCron(target.CronStr, "UTC"); err != nil {
+			return fmt.Errorf("invalid cron_str: %w", err)
+		}
+	}
+	return nil
+}
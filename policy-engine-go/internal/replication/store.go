@@ -0,0 +1,195 @@
+package replication
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// targetStore persists ReplicationTargets to policyDir/.replication/targets/
+// so configured peers survive a process restart.
+type targetStore struct {
+	dir string
+
+	mu      sync.RWMutex
+	targets map[string]*models.ReplicationTarget
+}
+
+func newTargetStore(policyDir string) (*targetStore, error) {
+	s := &targetStore{
+		dir:     filepath.Join(policyDir, ".replication", "targets"),
+		targets: make(map[string]*models.ReplicationTarget),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *targetStore) load() error {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read replication targets directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read replication target: %w", err)
+		}
+		var target models.ReplicationTarget
+		if err := json.Unmarshal(data, &target); err != nil {
+			return fmt.Errorf("failed to parse replication target: %w", err)
+		}
+		s.targets[target.ID] = &target
+	}
+	return nil
+}
+
+func (s *targetStore) save(target *models.ReplicationTarget) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create replication targets directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(target, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal replication target: %w", err)
+	}
+
+	path := filepath.Join(s.dir, target.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write replication target: %w", err)
+	}
+	return nil
+}
+
+func (s *targetStore) create(target *models.ReplicationTarget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.save(target); err != nil {
+		return err
+	}
+	s.targets[target.ID] = target
+	return nil
+}
+
+func (s *targetStore) update(target *models.ReplicationTarget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.targets[target.ID]; !ok {
+		return fmt.Errorf("replication target not found: %s", target.ID)
+	}
+	if err := s.save(target); err != nil {
+		return err
+	}
+	s.targets[target.ID] = target
+	return nil
+}
+
+func (s *targetStore) get(id string) (*models.ReplicationTarget, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	target, ok := s.targets[id]
+	if !ok {
+		return nil, fmt.Errorf("replication target not found: %s", id)
+	}
+	return target, nil
+}
+
+func (s *targetStore) list() []*models.ReplicationTarget {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*models.ReplicationTarget, 0, len(s.targets))
+	for _, target := range s.targets {
+		out = append(out, target)
+	}
+	return out
+}
+
+func (s *targetStore) delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.targets[id]; !ok {
+		return fmt.Errorf("replication target not found: %s", id)
+	}
+	if err := os.Remove(filepath.Join(s.dir, id+".json")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove replication target: %w", err)
+	}
+	delete(s.targets, id)
+	return nil
+}
+
+// executionStore persists ReplicationExecutions to
+// policyDir/.replication/executions/ so GET /replication/executions/:id
+// survives a process restart.
+type executionStore struct {
+	dir string
+
+	mu         sync.RWMutex
+	executions map[string]*models.ReplicationExecution
+}
+
+func newExecutionStore(policyDir string) *executionStore {
+	return &executionStore{
+		dir:        filepath.Join(policyDir, ".replication", "executions"),
+		executions: make(map[string]*models.ReplicationExecution),
+	}
+}
+
+func (s *executionStore) save(exec *models.ReplicationExecution) error {
+	s.mu.Lock()
+	s.executions[exec.ID] = exec
+	s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create replication executions directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(exec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal replication execution: %w", err)
+	}
+
+	path := filepath.Join(s.dir, exec.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write replication execution: %w", err)
+	}
+	return nil
+}
+
+func (s *executionStore) get(id string) (*models.ReplicationExecution, error) {
+	s.mu.RLock()
+	exec, ok := s.executions[id]
+	s.mu.RUnlock()
+	if ok {
+		return exec, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, id+".json"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("replication execution not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replication execution: %w", err)
+	}
+	var loaded models.ReplicationExecution
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return nil, fmt.Errorf("failed to parse replication execution: %w", err)
+	}
+	return &loaded, nil
+}
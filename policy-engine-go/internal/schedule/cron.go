@@ -0,0 +1,184 @@
+// Package schedule parses standard cron expressions and runs a background
+// engine that re-evaluates UnifiedPolicy schedules, toggling each policy's
+// computed "is effective now" state.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed standard 5-field cron expression
+// (minute hour day-of-month month day-of-week), evaluated in a fixed
+// time zone.
+type CronSchedule struct {
+	minute, hour, dom, month, dow fieldSet
+	domWildcard, dowWildcard      bool
+	loc                           *time.Location
+}
+
+// fieldSet is the set of values a cron field accepts.
+type fieldSet map[int]bool
+
+// ParseCron parses a standard 5-field cron expression (e.g. "*/15 9-17 * * 1-5")
+// evaluated in the named IANA time zone. An empty zone defaults to UTC.
+func ParseCron(expr, zone string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields (minute hour dom month dow), got %d: %q", len(fields), expr)
+	}
+
+	if zone == "" {
+		zone = "UTC"
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", zone, err)
+	}
+
+	minute, err := parseField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("minute field: %w", err)
+	}
+	hour, err := parseField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("hour field: %w", err)
+	}
+	dom, err := parseField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("day-of-month field: %w", err)
+	}
+	month, err := parseField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("month field: %w", err)
+	}
+	dow, err := parseField(fields[4], 0, 6)
+	if err != nil {
+		return nil, fmt.Errorf("day-of-week field: %w", err)
+	}
+
+	return &CronSchedule{
+		minute:      minute,
+		hour:        hour,
+		dom:         dom,
+		month:       month,
+		dow:         dow,
+		domWildcard: strings.TrimSpace(fields[2]) == "*",
+		dowWildcard: strings.TrimSpace(fields[4]) == "*",
+		loc:         loc,
+	}, nil
+}
+
+// parseField parses a single comma-separated cron field made up of "*",
+// single values, ranges ("a-b") and steps ("*/n" or "a-b/n").
+func parseField(raw string, min, max int) (fieldSet, error) {
+	set := make(fieldSet)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("empty value")
+		}
+
+		step := 1
+		base := part
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			base = part[:idx]
+			s, err := strconv.Atoi(part[idx+1:])
+			if err != nil || s <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			step = s
+		}
+
+		lo, hi := min, max
+		if base != "*" {
+			if idx := strings.Index(base, "-"); idx >= 0 {
+				l, errL := strconv.Atoi(base[:idx])
+				h, errH := strconv.Atoi(base[idx+1:])
+				if errL != nil || errH != nil {
+					return nil, fmt.Errorf("invalid range %q", base)
+				}
+				lo, hi = l, h
+			} else {
+				v, err := strconv.Atoi(base)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q", base)
+				}
+				lo, hi = v, v
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+		for i := lo; i <= hi; i += step {
+			set[i] = true
+		}
+	}
+	return set, nil
+}
+
+// matches reports whether t satisfies every field of the expression,
+// applying the standard cron rule for day-of-month/day-of-week: when both
+// are restricted (not "*"), a match on either is sufficient.
+func (c *CronSchedule) matches(t time.Time) bool {
+	t = t.In(c.loc)
+	if !c.minute[t.Minute()] || !c.hour[t.Hour()] || !c.month[int(t.Month())] {
+		return false
+	}
+
+	domMatch := c.dom[t.Day()]
+	dowMatch := c.dow[int(t.Weekday())]
+	if c.domWildcard || c.dowWildcard {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// maxLookahead bounds how far Next/mostRecentFiring will search before
+// giving up, so a pathological expression (e.g. Feb 30) can't spin forever.
+const maxLookahead = 366 * 24 * time.Hour
+
+// Next returns the first time strictly after from that matches the
+// expression. ok is false if no match was found within a year.
+func (c *CronSchedule) Next(from time.Time) (next time.Time, ok bool) {
+	t := from.In(c.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := from.Add(maxLookahead)
+	for t.Before(limit) {
+		if c.matches(t) {
+			return t, true
+		}
+		t = t.Add(time.Minute)
+	}
+	return time.Time{}, false
+}
+
+// NextN returns up to n successive firing times strictly after from.
+func (c *CronSchedule) NextN(from time.Time, n int) []time.Time {
+	result := make([]time.Time, 0, n)
+	cur := from
+	for len(result) < n {
+		next, ok := c.Next(cur)
+		if !ok {
+			break
+		}
+		result = append(result, next)
+		cur = next
+	}
+	return result
+}
+
+// mostRecentFiring returns the latest time at or before `at` that matches
+// the expression, searching back up to a year. ok is false if none is found.
+func (c *CronSchedule) mostRecentFiring(at time.Time) (last time.Time, ok bool) {
+	t := at.In(c.loc).Truncate(time.Minute)
+	limit := at.Add(-maxLookahead)
+	for t.After(limit) {
+		if c.matches(t) {
+			return t, true
+		}
+		t = t.Add(-time.Minute)
+	}
+	return time.Time{}, false
+}
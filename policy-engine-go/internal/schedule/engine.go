@@ -0,0 +1,126 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/storage"
+	log "github.com/sirupsen/logrus"
+)
+
+// Engine periodically re-evaluates every UnifiedPolicy carrying a Schedule:
+// it finds the most recent cron firing, checks whether "now" still falls
+// inside that firing's WindowDuration, and persists the resulting
+// IsEffectiveNow flag plus NextRunAt/LastRunAt back through UnifiedStorage.
+// GetActiveByResource and GetGlobalPolicies consult the flag via
+// UnifiedPolicy.IsScheduledEffective.
+type Engine struct {
+	storage  *storage.UnifiedStorage
+	interval time.Duration
+	cancel   context.CancelFunc
+}
+
+// NewEngine creates a ScheduleEngine that re-evaluates schedules every
+// interval.
+func NewEngine(s *storage.UnifiedStorage, interval time.Duration) *Engine {
+	return &Engine{storage: s, interval: interval}
+}
+
+// Start launches the background tick loop. Call Stop to terminate it.
+func (e *Engine) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		e.Tick()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				e.Tick()
+			}
+		}
+	}()
+}
+
+// Stop terminates the background tick loop.
+func (e *Engine) Stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+}
+
+// Tick re-evaluates every scheduled policy once.
+func (e *Engine) Tick() {
+	now := time.Now()
+	for _, p := range e.storage.GetAll() {
+		if p.Schedule == nil {
+			continue
+		}
+		if err := e.evaluate(p, now); err != nil {
+			log.WithField("policy_id", p.PolicyID).WithError(err).Warn("Failed to evaluate policy schedule")
+		}
+	}
+}
+
+func (e *Engine) evaluate(p *models.UnifiedPolicy, now time.Time) error {
+	cron, window, err := parseSchedule(p.Schedule)
+	if err != nil {
+		return err
+	}
+
+	var lastRun, nextRun *time.Time
+	effective := false
+	if firing, ok := cron.mostRecentFiring(now); ok {
+		t := firing
+		lastRun = &t
+		effective = now.Before(firing.Add(window))
+	}
+	if next, ok := cron.Next(now); ok {
+		t := next
+		nextRun = &t
+	}
+
+	return e.storage.UpdateScheduleState(p.PolicyID, nextRun, lastRun, effective)
+}
+
+// parseSchedule parses a policy's cron expression and window duration.
+func parseSchedule(s *models.PolicySchedule) (*CronSchedule, time.Duration, error) {
+	cron, err := ParseCron(s.CronExpression, s.TimeZone)
+	if err != nil {
+		return nil, 0, err
+	}
+	window, err := time.ParseDuration(s.WindowDuration)
+	if err != nil {
+		return nil, 0, err
+	}
+	return cron, window, nil
+}
+
+// DryRun computes the next n activation windows (start/end) for a schedule,
+// without touching stored state. Used by the schedule dry-run endpoint.
+func DryRun(s *models.PolicySchedule, from time.Time, n int) ([]Window, error) {
+	cron, window, err := parseSchedule(s)
+	if err != nil {
+		return nil, err
+	}
+
+	firings := cron.NextN(from, n)
+	windows := make([]Window, 0, len(firings))
+	for _, start := range firings {
+		windows = append(windows, Window{Start: start, End: start.Add(window)})
+	}
+	return windows, nil
+}
+
+// Window is a single computed activation window.
+type Window struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
@@ -0,0 +1,124 @@
+package evaluation
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// LoadTestOptions configures RunLoadTest.
+type LoadTestOptions struct {
+	// RequestCount is how many synthetic PolicyEvaluationRequests to run
+	// through each evaluation path. Defaults to 10000 if <= 0.
+	RequestCount int
+	// Workers bounds the concurrent path's worker pool, mirroring
+	// Handler.SetStreamWorkers/Server.SetWorkers. Defaults to
+	// runtime.GOMAXPROCS(0) if <= 0.
+	Workers int
+}
+
+// LoadTestResult reports RunLoadTest's measured throughput for both
+// evaluation paths, in requests/second.
+type LoadTestResult struct {
+	RequestCount       int
+	Workers            int
+	BatchDuration      time.Duration
+	BatchRPS           float64
+	ConcurrentDuration time.Duration
+	ConcurrentRPS      float64
+	// SpeedupFactor is ConcurrentRPS/BatchRPS - the number this harness
+	// exists to produce, demonstrating EvaluateStream's worker-pool
+	// concurrency model out-throughputs BatchEvaluate's single goroutine.
+	SpeedupFactor float64
+}
+
+// RunLoadTest compares svc.BatchEvaluate's single-goroutine throughput
+// against the same requests run through a bounded worker pool - the
+// concurrency model POST /evaluate/stream and grpcapi.Server.EvaluateStream
+// both use - over RequestCount synthetic requests. The policies loaded into
+// svc should be representative of a real deployment: a trivial policy set
+// understates the speedup concurrency buys once per-request evaluation work
+// (Rego, JWT verification, schema coercion) is non-trivial.
+func RunLoadTest(svc *Service, opts LoadTestOptions) LoadTestResult {
+	count := opts.RequestCount
+	if count <= 0 {
+		count = 10000
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	requests := make([]models.PolicyEvaluationRequest, count)
+	for i := range requests {
+		requests[i] = syntheticLoadTestRequest(i)
+	}
+
+	batchStart := time.Now()
+	if _, err := svc.BatchEvaluate(&models.BatchEvaluationRequest{Requests: requests}); err != nil {
+		// BatchEvaluate never actually returns a non-nil error today, but
+		// a load test shouldn't silently report a bogus duration if that
+		// ever changes.
+		return LoadTestResult{RequestCount: count, Workers: workers}
+	}
+	batchDuration := time.Since(batchStart)
+
+	concurrentStart := time.Now()
+	runConcurrentEvaluate(svc, requests, workers)
+	concurrentDuration := time.Since(concurrentStart)
+
+	result := LoadTestResult{
+		RequestCount:       count,
+		Workers:            workers,
+		BatchDuration:      batchDuration,
+		BatchRPS:           requestsPerSecond(count, batchDuration),
+		ConcurrentDuration: concurrentDuration,
+		ConcurrentRPS:      requestsPerSecond(count, concurrentDuration),
+	}
+	if result.BatchRPS > 0 {
+		result.SpeedupFactor = result.ConcurrentRPS / result.BatchRPS
+	}
+	return result
+}
+
+// runConcurrentEvaluate evaluates requests across a fixed-size worker pool,
+// the same jobs-channel-sized-to-workers shape EvaluateStream uses.
+func runConcurrentEvaluate(svc *Service, requests []models.PolicyEvaluationRequest, workers int) {
+	jobs := make(chan models.PolicyEvaluationRequest, workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for req := range jobs {
+				svc.Evaluate(&req)
+			}
+		}()
+	}
+
+	for _, req := range requests {
+		jobs <- req
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func requestsPerSecond(count int, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(count) / d.Seconds()
+}
+
+func syntheticLoadTestRequest(i int) models.PolicyEvaluationRequest {
+	return models.PolicyEvaluationRequest{
+		User:          fmt.Sprintf("load-test-user-%d", i),
+		Tool:          "load-test-tool",
+		Action:        "execute",
+		CorrelationID: fmt.Sprintf("%d", i),
+	}
+}
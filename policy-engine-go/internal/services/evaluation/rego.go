@@ -0,0 +1,501 @@
+package evaluation
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/engine"
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/storage/inmem"
+	log "github.com/sirupsen/logrus"
+)
+
+// Sentinel errors for the Rego runtime, wrapped with fmt.Errorf("%w: ...")
+// so callers can errors.Is against the class of failure instead of parsing
+// a message.
+var (
+	// ErrInvalidConfig is returned when a RegoConfig, or a single
+	// ConditionTypeRego condition's Value, is structurally unusable - no
+	// modules, or a source this runtime doesn't know how to resolve.
+	ErrInvalidConfig = errors.New("evaluation: invalid rego config")
+
+	// ErrEvaluatorCreationFailed wraps a module load or OPA compile
+	// failure.
+	ErrEvaluatorCreationFailed = errors.New("evaluation: failed to create rego evaluator")
+
+	// ErrEvaluatorNotFound is returned by Service methods that require a
+	// RegoEvaluator (see NewWithConfig) when none is configured.
+	ErrEvaluatorNotFound = errors.New("evaluation: rego evaluator not configured")
+)
+
+// defaultEntrypoint is the data document base path queried when a
+// RegoConfig or Condition doesn't override it: data.policy.allow and
+// data.policy.actions.
+const defaultEntrypoint = "data.policy"
+
+// RegoConfig configures a RegoEvaluator.
+type RegoConfig struct {
+	// Modules maps a module name (used only for compiler error messages
+	// and as the cache key component) to its source, each either:
+	//   - an inline Rego module body
+	//   - a "file://" path to load the module body from
+	//   - an "http://" or "https://" URL to an OPA bundle tarball
+	Modules map[string]string
+
+	// DataSeed optionally seeds the `data` document every module
+	// evaluates against (e.g. role hierarchies, CIDR allowlists), beyond
+	// what marshaling the PolicyEvaluationRequest as `input` provides.
+	DataSeed map[string]interface{}
+
+	// Entrypoint is the base data path queried as Entrypoint+".allow" and
+	// Entrypoint+".actions". Empty defaults to "data.policy".
+	Entrypoint string
+
+	// PolicyDir, if set, is the directory WatchRegoConfig re-resolves
+	// "file://" module sources from on every poll.
+	PolicyDir string
+}
+
+// regoActionEntry is one element of the `actions` array a Rego policy's
+// decision document may return, mapped onto a PolicyEvaluationResult's
+// MatchedRules/Action/Modifications the same way engine.regoDecision does
+// for a single PolicyRule.Rego rule's decision document.
+type regoActionEntry struct {
+	Rule          string                 `json:"rule"`
+	Action        string                 `json:"action"`
+	Modifications map[string]interface{} `json:"modifications"`
+	Message       string                 `json:"message"`
+}
+
+// regoCompiled is a prepared query shared by every RegoEvaluator whose
+// resolved modules/seed/entrypoint hash identically, so two NewWithConfig
+// calls with unchanged content - the common case across a WatchRegoConfig
+// poll that found nothing new - reuse the same compiled query instead of
+// recompiling.
+type regoCompiled struct {
+	query *rego.PreparedEvalQuery
+}
+
+var (
+	compiledMu    sync.RWMutex
+	compiledCache = make(map[string]*regoCompiled)
+)
+
+// unsafeRegoBuiltins blocks the OPA built-ins that would let an
+// attacker-supplied module reach outside the evaluation sandbox: http.send
+// (arbitrary outbound requests - SSRF against internal services, and a
+// channel to exfiltrate data via the response), net.lookup_ip_addr
+// (DNS-based network probing), and opa.runtime (leaks this process's
+// environment variables and OPA config). A ConditionTypeRego condition's
+// Value, like RegoSource, is plain attacker-reachable input, not a trusted
+// administrator's script.
+var unsafeRegoBuiltins = map[string]struct{}{
+	"http.send":          {},
+	"net.lookup_ip_addr": {},
+	"opa.runtime":        {},
+}
+
+// RegoEvaluator runs PolicyEvaluationRequests against a compiled Rego
+// module set, letting a policy express logic the six-operator Condition
+// DSL can't (graph traversal, set relations, time-windowed aggregates),
+// while the DSL stays the default path for everything else (see
+// models.ConditionTypeRego). It implements engine.RegoConditionEvaluator,
+// so it can also be injected into an engine.Engine to back individual
+// ConditionTypeRego conditions.
+type RegoEvaluator struct {
+	cfg        RegoConfig
+	entrypoint string
+	compiled   *regoCompiled
+}
+
+// newRegoEvaluator resolves and compiles cfg's modules into a
+// RegoEvaluator, reusing an already-compiled query from compiledCache if
+// an equivalent cfg was compiled before.
+func newRegoEvaluator(cfg RegoConfig) (*RegoEvaluator, error) {
+	if len(cfg.Modules) == 0 {
+		return nil, fmt.Errorf("%w: at least one module is required", ErrInvalidConfig)
+	}
+
+	entrypoint := cfg.Entrypoint
+	if entrypoint == "" {
+		entrypoint = defaultEntrypoint
+	}
+
+	resolved := make(map[string]string, len(cfg.Modules))
+	for name, source := range cfg.Modules {
+		body, err := loadRegoModuleSource(source)
+		if err != nil {
+			return nil, fmt.Errorf("%w: module %q: %v", ErrInvalidConfig, name, err)
+		}
+		resolved[name] = body
+	}
+
+	compiled, err := compiledForModules(resolved, cfg.DataSeed, entrypoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegoEvaluator{cfg: cfg, entrypoint: entrypoint, compiled: compiled}, nil
+}
+
+// compiledForModules looks up (or compiles and caches) the prepared query
+// for the given resolved module bodies, data seed and entrypoint.
+func compiledForModules(modules map[string]string, dataSeed map[string]interface{}, entrypoint string) (*regoCompiled, error) {
+	key, err := hashRegoModules(modules, dataSeed, entrypoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEvaluatorCreationFailed, err)
+	}
+
+	compiledMu.RLock()
+	compiled, ok := compiledCache[key]
+	compiledMu.RUnlock()
+	if ok {
+		return compiled, nil
+	}
+
+	compiled, err = compileRegoModules(modules, dataSeed, entrypoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEvaluatorCreationFailed, err)
+	}
+
+	compiledMu.Lock()
+	compiledCache[key] = compiled
+	compiledMu.Unlock()
+	return compiled, nil
+}
+
+// compileRegoModules builds a single prepared query returning
+// {"allow": <entrypoint>.allow, "actions": <entrypoint>.actions} over every
+// module in modules, seeding the `data` document with dataSeed if set.
+func compileRegoModules(modules map[string]string, dataSeed map[string]interface{}, entrypoint string) (*regoCompiled, error) {
+	queryStr := fmt.Sprintf(`{"allow": %s.allow, "actions": %s.actions}`, entrypoint, entrypoint)
+	opts := []func(*rego.Rego){rego.Query(queryStr), rego.UnsafeBuiltins(unsafeRegoBuiltins)}
+	for name, body := range modules {
+		opts = append(opts, rego.Module(name, body))
+	}
+	if len(dataSeed) > 0 {
+		opts = append(opts, rego.Store(inmem.NewFromObject(dataSeed)))
+	}
+
+	prepared, err := rego.New(opts...).PrepareForEval(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &regoCompiled{query: &prepared}, nil
+}
+
+// hashRegoModules derives a cache key covering everything compileRegoModules
+// would otherwise need to recompile for: module names and bodies (sorted,
+// so key order doesn't matter), the data seed, and the entrypoint.
+func hashRegoModules(modules map[string]string, dataSeed map[string]interface{}, entrypoint string) (string, error) {
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	h.Write([]byte(entrypoint))
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte(modules[name]))
+	}
+	if len(dataSeed) > 0 {
+		seedJSON, err := json.Marshal(dataSeed)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal data seed: %w", err)
+		}
+		h.Write(seedJSON)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// loadRegoModuleSource resolves a module source string into a Rego module
+// body: a "file://" path is read from disk, an "http://"/"https://" URL is
+// fetched as an OPA bundle tarball, and anything else is treated as an
+// inline module body already.
+func loadRegoModuleSource(source string) (string, error) {
+	switch {
+	case strings.HasPrefix(source, "file://"):
+		path := strings.TrimPrefix(source, "file://")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return string(data), nil
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		return loadRegoBundleURL(source)
+	default:
+		return source, nil
+	}
+}
+
+// loadRegoBundleURL downloads an OPA bundle tarball from url and
+// concatenates every Rego module it contains into a single module body.
+// Only the bundle's modules are used; a bundle's own embedded data.json is
+// out of scope here - use RegoConfig.DataSeed for seed data instead.
+func loadRegoBundleURL(url string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch bundle %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch bundle %s: unexpected status %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read bundle %s: %w", url, err)
+	}
+
+	b, err := bundle.NewReader(bytes.NewReader(data)).Read()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse bundle %s: %w", url, err)
+	}
+
+	var combined strings.Builder
+	for _, module := range b.Modules {
+		combined.Write(module.Raw)
+		combined.WriteByte('\n')
+	}
+	if combined.Len() == 0 {
+		return "", fmt.Errorf("bundle %s contains no rego modules", url)
+	}
+	return combined.String(), nil
+}
+
+// regoInput marshals req's fields a Rego policy can reasonably condition
+// on into the `input` document: user, tool, resource, action, parameters,
+// context, session and IP.
+func regoInput(req *models.PolicyEvaluationRequest) map[string]interface{} {
+	return map[string]interface{}{
+		"user":       req.User,
+		"tool":       req.Tool,
+		"resource":   req.Resource,
+		"action":     req.Action,
+		"parameters": req.Parameters,
+		"context":    req.Context,
+		"session_id": req.SessionID,
+		"ip_address": req.IPAddress,
+	}
+}
+
+// Evaluate runs req through e's compiled query and maps the resulting
+// decision document onto a PolicyEvaluationResult: allow=false becomes
+// ActionDeny; an `actions` array becomes MatchedRules, with its first
+// entry's action/modifications/message becoming the result's own - the
+// same first-match-wins convention engine's priority mode uses across
+// rules.
+func (e *RegoEvaluator) Evaluate(ctx context.Context, req *models.PolicyEvaluationRequest) (*models.PolicyEvaluationResult, error) {
+	results, err := e.compiled.query.Eval(ctx, rego.EvalInput(regoInput(req)))
+	if err != nil {
+		return nil, fmt.Errorf("rego evaluation failed: %w", err)
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return nil, fmt.Errorf("rego query produced no result")
+	}
+	doc, ok := results[0].Expressions[0].Value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("rego query result must be an object")
+	}
+
+	allow, _ := doc["allow"].(bool)
+	result := &models.PolicyEvaluationResult{
+		Matched:     true,
+		Action:      models.ActionDeny,
+		ShouldBlock: true,
+		Timestamp:   time.Now(),
+	}
+	if allow {
+		result.Action = models.ActionAllow
+		result.ShouldBlock = false
+	}
+
+	rawActions, _ := doc["actions"].([]interface{})
+	for _, raw := range rawActions {
+		entryMap, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		entry := decodeRegoActionEntry(entryMap)
+		if entry.Rule != "" {
+			result.MatchedRules = append(result.MatchedRules, entry.Rule)
+		}
+		if len(result.MatchedRules) == 1 {
+			if entry.Action != "" {
+				result.Action = models.ActionType(entry.Action)
+				result.ShouldBlock = result.Action == models.ActionDeny
+			}
+			result.Modifications = entry.Modifications
+			result.Message = entry.Message
+		}
+	}
+
+	return result, nil
+}
+
+// EvaluateCondition implements engine.RegoConditionEvaluator. cond.Value
+// holds its own ad hoc Rego module source - inline, a "file://" reference,
+// or a bundle URL, the same three forms RegoConfig.Modules accepts -
+// compiled and cached independently of e's own RegoConfig.Modules via the
+// same compiledCache; e only proves a Rego runtime is configured at all.
+// cond.Field, if set, overrides the entrypoint (default data.policy);
+// cond.Operator is ignored, the same simplification ConditionTypeRate and
+// ConditionTypeTime already make for condition shapes an OPA query has no
+// use for.
+func (e *RegoEvaluator) EvaluateCondition(cond *models.Condition, req *models.PolicyEvaluationRequest) (bool, error) {
+	source, ok := cond.Value.(string)
+	if !ok {
+		return false, fmt.Errorf("%w: rego condition value must be a string, got %T", ErrInvalidConfig, cond.Value)
+	}
+
+	entrypoint := defaultEntrypoint
+	if cond.Field != "" {
+		entrypoint = cond.Field
+	}
+
+	body, err := loadRegoModuleSource(source)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	compiled, err := compiledForModules(map[string]string{"condition": body}, nil, entrypoint)
+	if err != nil {
+		return false, err
+	}
+
+	results, err := compiled.query.Eval(context.Background(), rego.EvalInput(regoInput(req)))
+	if err != nil {
+		return false, fmt.Errorf("rego condition evaluation failed: %w", err)
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return false, nil
+	}
+	doc, ok := results[0].Expressions[0].Value.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("rego condition query result must be an object")
+	}
+
+	allow, _ := doc["allow"].(bool)
+	return allow, nil
+}
+
+// decodeRegoActionEntry decodes a single element of a decision document's
+// `actions` array via a JSON round-trip, tolerating unknown/missing fields
+// the same way decodeRegoDecision does for a PolicyRule.Rego decision.
+func decodeRegoActionEntry(m map[string]interface{}) regoActionEntry {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return regoActionEntry{}
+	}
+	var entry regoActionEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return regoActionEntry{}
+	}
+	return entry
+}
+
+// NewWithConfig creates an evaluation Service whose engine's
+// ConditionTypeRego conditions (and a direct EvaluateRego call) are backed
+// by a Rego runtime compiled from cfg. No classic Policy set is loaded yet
+// - supply one via Reload, the same as any other Service, once it's
+// available.
+func NewWithConfig(cfg RegoConfig) (*Service, error) {
+	evaluator, err := newRegoEvaluator(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{regoEvaluator: evaluator}
+	s.engine.Store(engine.NewEngineWithOptions(nil, engine.EngineOptions{
+		RegoEvaluator: evaluator,
+	}))
+	return s, nil
+}
+
+// EvaluateRego runs req directly against s's configured RegoEvaluator,
+// bypassing the classic Conditions/Actions engine entirely - useful for
+// testing a Rego policy set in isolation. Returns ErrEvaluatorNotFound if
+// none is configured (see NewWithConfig).
+func (s *Service) EvaluateRego(req *models.PolicyEvaluationRequest) (*models.PolicyEvaluationResult, error) {
+	s.mu.Lock()
+	evaluator := s.regoEvaluator
+	s.mu.Unlock()
+
+	if evaluator == nil {
+		return nil, ErrEvaluatorNotFound
+	}
+	return evaluator.Evaluate(context.Background(), req)
+}
+
+// WatchRegoConfig re-resolves cfg's modules from disk every interval and,
+// if anything changed, recompiles and swaps in a new RegoEvaluator without
+// disturbing the engine's classic Policy set (see Service.policies). Only
+// useful when cfg.Modules includes "file://" sources under cfg.PolicyDir;
+// inline and bundle-URL sources never change between polls by definition.
+// Runs until ctx is canceled.
+func (s *Service) WatchRegoConfig(ctx context.Context, cfg RegoConfig, interval time.Duration) {
+	if cfg.PolicyDir == "" {
+		log.Warn("WatchRegoConfig called with no PolicyDir configured, nothing to watch")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			evaluator, err := newRegoEvaluator(cfg)
+			if err != nil {
+				log.WithError(err).Warn("Rego policy dir reload rejected, keeping previous evaluator live")
+				continue
+			}
+
+			s.mu.Lock()
+			unchanged := s.regoEvaluator != nil && s.regoEvaluator.compiled == evaluator.compiled
+			s.mu.Unlock()
+			if unchanged {
+				continue
+			}
+
+			s.reloadRegoEvaluator(evaluator)
+			log.WithField("dir", cfg.PolicyDir).Info("Rego policy dir reloaded")
+		}
+	}
+}
+
+// reloadRegoEvaluator swaps in evaluator and rebuilds the engine from the
+// policy set it was last built from (see Service.policies), carrying every
+// other collaborator forward the same way Reload does.
+func (s *Service) reloadRegoEvaluator(evaluator *RegoEvaluator) {
+	s.mu.Lock()
+	s.regoEvaluator = evaluator
+	policies := s.policies
+	s.mu.Unlock()
+
+	s.engine.Store(engine.NewEngineWithOptions(policies, engine.EngineOptions{
+		Dispatcher:    s.dispatcher,
+		RateLimiter:   s.rateLimiter,
+		RegoEvaluator: evaluator,
+		Schema:        s.schema,
+		Mode:          s.mode,
+		JWTVerifier:   s.jwtVerifier,
+	}))
+}
@@ -0,0 +1,25 @@
+package evaluation
+
+import "testing"
+
+func TestNewRegoEvaluatorRejectsHTTPSend(t *testing.T) {
+	cfg := RegoConfig{Modules: map[string]string{"policy.rego": `package policy
+
+allow := true { http.send({"method": "GET", "url": "http://127.0.0.1:1/nope"}) }
+actions := []`}}
+
+	if _, err := newRegoEvaluator(cfg); err == nil {
+		t.Fatal("expected newRegoEvaluator to reject a module calling http.send, the SSRF/exfiltration primitive unsafeRegoBuiltins blocks")
+	}
+}
+
+func TestNewRegoEvaluatorAcceptsSafeModule(t *testing.T) {
+	cfg := RegoConfig{Modules: map[string]string{"policy.rego": `package policy
+
+allow := true
+actions := []`}}
+
+	if _, err := newRegoEvaluator(cfg); err != nil {
+		t.Fatalf("expected a module with no unsafe built-ins to compile, got: %v", err)
+	}
+}
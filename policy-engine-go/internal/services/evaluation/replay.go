@@ -0,0 +1,102 @@
+package evaluation
+
+import (
+	"sync"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// ReplayStore retains a bounded, PII-scrubbed sample of recent
+// PolicyEvaluationRequests, so SimulateDraft can validate a draft policy
+// set against the shape of real traffic instead of only synthetic inputs
+// an admin wrote by hand. A nil *ReplayStore is valid and every method is a
+// no-op against it, so callers don't need to branch on whether
+// ServiceOptions.ReplayCapacity opted in.
+type ReplayStore struct {
+	mu       sync.Mutex
+	capacity int
+	samples  []models.PolicyEvaluationRequest
+	next     int
+}
+
+// NewReplayStore creates a replay store holding at most capacity samples,
+// overwriting the oldest sample once full.
+func NewReplayStore(capacity int) *ReplayStore {
+	return &ReplayStore{capacity: capacity, samples: make([]models.PolicyEvaluationRequest, 0, capacity)}
+}
+
+// Record scrubs and appends req, evicting the oldest sample once the store
+// is at capacity.
+func (r *ReplayStore) Record(req *models.PolicyEvaluationRequest) {
+	if r == nil || r.capacity <= 0 {
+		return
+	}
+	scrubbed := scrubRequest(req)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.samples) < r.capacity {
+		r.samples = append(r.samples, scrubbed)
+		return
+	}
+	r.samples[r.next] = scrubbed
+	r.next = (r.next + 1) % r.capacity
+}
+
+// Sample returns up to n of the currently held samples, converted to
+// PolicyEvaluationInput for SimulateDraft. n <= 0 returns every sample
+// held.
+func (r *ReplayStore) Sample(n int) []models.PolicyEvaluationInput {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n <= 0 || n > len(r.samples) {
+		n = len(r.samples)
+	}
+
+	inputs := make([]models.PolicyEvaluationInput, n)
+	for i := 0; i < n; i++ {
+		inputs[i] = toEvaluationInput(r.samples[i])
+	}
+	return inputs
+}
+
+// scrubRequest drops the fields most likely to carry PII - Parameters (raw
+// tool call arguments), SessionID, IPAddress, UserAgent - before a request
+// ever enters the replay store, since samples may later be read back by
+// anyone who can call SimulateDraft, not just whoever issued the original
+// request.
+func scrubRequest(req *models.PolicyEvaluationRequest) models.PolicyEvaluationRequest {
+	return models.PolicyEvaluationRequest{
+		User:      req.User,
+		Tool:      req.Tool,
+		Resource:  req.Resource,
+		Action:    req.Action,
+		Context:   req.Context,
+		Timestamp: req.Timestamp,
+		OrgID:     req.OrgID,
+	}
+}
+
+// toEvaluationInput adapts a scrubbed PolicyEvaluationRequest (the older
+// model's evaluation shape) into a PolicyEvaluationInput (the unified
+// model's), so a replay sample can drive SimulateDraft the same way an
+// admin-authored input does.
+func toEvaluationInput(req models.PolicyEvaluationRequest) models.PolicyEvaluationInput {
+	var orgIDs []string
+	if req.OrgID != "" {
+		orgIDs = []string{req.OrgID}
+	}
+
+	return models.PolicyEvaluationInput{
+		UserID:       req.User,
+		OrgIDs:       orgIDs,
+		ResourceType: models.ResourceType(req.Resource),
+		ResourceID:   req.Resource,
+		Timestamp:    req.Timestamp,
+		Context:      req.Context,
+	}
+}
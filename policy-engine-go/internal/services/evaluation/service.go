@@ -1,27 +1,100 @@
 package evaluation
 
 import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/datacline/policy-engine/internal/chain"
 	"github.com/datacline/policy-engine/internal/engine"
+	"github.com/datacline/policy-engine/internal/jwtauth"
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/notify"
+	"github.com/datacline/policy-engine/internal/policyschema"
+	"github.com/datacline/policy-engine/internal/ratelimit"
 	log "github.com/sirupsen/logrus"
 )
 
 // Service handles policy evaluation operations
 type Service struct {
-	engine *engine.Engine
+	engine          atomic.Pointer[engine.Engine]
+	dispatcher      *notify.Dispatcher     // nil disables webhook notifications; carried across Reload
+	rateLimiter     ratelimit.Limiter      // nil disables ConditionTypeRate; carried across Reload
+	schema          *policyschema.Registry // nil leaves every field at its Go runtime type; carried across Reload
+	mode            engine.EvaluationMode  // "" defaults to priority mode; carried across Reload
+	jwtVerifier     jwtauth.Verifier       // nil disables ConditionTypeJWT; carried across Reload
+	replay          *ReplayStore           // nil disables replay sampling; carried across Reload
+	unifiedPolicies UnifiedPolicySource    // nil leaves SimulateDraft's "current" side empty; set via SetUnifiedPolicySource
+
+	mu            sync.Mutex       // guards policies and regoEvaluator, both writable after construction
+	policies      []*models.Policy // the set the current engine was built from; cached so reloadRegoEvaluator can rebuild it without the caller re-supplying it
+	regoEvaluator *RegoEvaluator   // nil disables ConditionTypeRego and EvaluateRego; carried across Reload, see rego.go
 }
 
-// NewService creates a new evaluation service
+// NewService creates a new evaluation service with webhook notifications and
+// rate limiting disabled.
 func NewService(policies []*models.Policy) *Service {
-	return &Service{
-		engine: engine.NewEngine(policies),
+	return NewServiceWithOptions(policies, ServiceOptions{})
+}
+
+// NewServiceWithDispatcher creates an evaluation service whose engine POSTs
+// policy notifications via dispatcher, carrying it forward across Reload.
+func NewServiceWithDispatcher(policies []*models.Policy, dispatcher *notify.Dispatcher) *Service {
+	return NewServiceWithOptions(policies, ServiceOptions{Dispatcher: dispatcher})
+}
+
+// ServiceOptions bundles the evaluation Service's optional collaborators so
+// adding one doesn't require another NewServiceWithX constructor.
+type ServiceOptions struct {
+	Dispatcher  *notify.Dispatcher
+	RateLimiter ratelimit.Limiter
+	Schema      *policyschema.Registry
+	Mode        engine.EvaluationMode
+	JWTVerifier jwtauth.Verifier
+	// ReplayCapacity, when > 0, opts into sampling recent (PII-scrubbed)
+	// evaluation requests so SimulateDraft can validate a draft policy set
+	// against real traffic shapes instead of only synthetic inputs. 0
+	// leaves replay sampling disabled.
+	ReplayCapacity int
+}
+
+// NewServiceWithOptions creates an evaluation service with the given
+// optional collaborators, carrying all of them forward across Reload.
+func NewServiceWithOptions(policies []*models.Policy, opts ServiceOptions) *Service {
+	s := &Service{dispatcher: opts.Dispatcher, rateLimiter: opts.RateLimiter, schema: opts.Schema, mode: opts.Mode, jwtVerifier: opts.JWTVerifier, policies: policies}
+	if opts.ReplayCapacity > 0 {
+		s.replay = NewReplayStore(opts.ReplayCapacity)
 	}
+	s.engine.Store(engine.NewEngineWithOptions(policies, engine.EngineOptions{
+		Dispatcher:  opts.Dispatcher,
+		RateLimiter: opts.RateLimiter,
+		Schema:      opts.Schema,
+		Mode:        opts.Mode,
+		JWTVerifier: opts.JWTVerifier,
+	}))
+	return s
+}
+
+// SetUnifiedPolicySource attaches the unified policy store SimulateDraft
+// reads its "current" (active) side from. Optional - without one,
+// SimulateDraft treats every input as having no currently active policies.
+func (s *Service) SetUnifiedPolicySource(source UnifiedPolicySource) {
+	s.unifiedPolicies = source
+}
+
+// ReplaySample returns up to n recently recorded, PII-scrubbed live
+// evaluation inputs for SimulateDraft, or every sample held if n <= 0. Nil
+// if replay sampling isn't enabled (see ServiceOptions.ReplayCapacity).
+func (s *Service) ReplaySample(n int) []models.PolicyEvaluationInput {
+	return s.replay.Sample(n)
 }
 
 // Evaluate evaluates a single policy request
 func (s *Service) Evaluate(req *models.PolicyEvaluationRequest) (*models.PolicyEvaluationResult, error) {
-	result := s.engine.Evaluate(req)
-	
+	s.replay.Record(req)
+
+	result := s.engine.Load().Evaluate(req)
+
 	log.WithFields(log.Fields{
 		"user":         req.User,
 		"tool":         req.Tool,
@@ -29,33 +102,78 @@ func (s *Service) Evaluate(req *models.PolicyEvaluationRequest) (*models.PolicyE
 		"should_block": result.ShouldBlock,
 		"matched":      result.Matched,
 	}).Debug("Policy evaluated")
-	
+
 	return result, nil
 }
 
+// Simulate compiles the policies in scope for req into a chain.Chain and
+// evaluates it, returning the full ordered trace of entries checked
+// (matched, skipped, decisive) regardless of which EvaluationMode is
+// actually deciding req. Used by POST /policies/simulate.
+func (s *Service) Simulate(req *models.PolicyEvaluationRequest) *chain.Result {
+	return s.engine.Load().Simulate(req)
+}
+
 // BatchEvaluate evaluates multiple policy requests
 func (s *Service) BatchEvaluate(req *models.BatchEvaluationRequest) (*models.BatchEvaluationResponse, error) {
 	results := make([]models.PolicyEvaluationResult, len(req.Requests))
-	
+	eng := s.engine.Load()
+
 	for i, evalReq := range req.Requests {
+		s.replay.Record(&evalReq)
+
 		// Pass pointer to evalReq and dereference the result
-		result := s.engine.Evaluate(&evalReq)
+		result := eng.Evaluate(&evalReq)
 		results[i] = *result
 	}
-	
+
 	log.WithField("count", len(results)).Debug("Batch evaluation completed")
-	
+
 	return &models.BatchEvaluationResponse{
 		Results: results,
 	}, nil
 }
 
-// Reload reloads the evaluation engine with new policies
+// Reload swaps in a new evaluation engine built from policies. It is safe to
+// call concurrently with Evaluate/BatchEvaluate: readers always see either
+// the old or the new engine, never a partially-built one.
 func (s *Service) Reload(policies []*models.Policy) {
-	s.engine = engine.NewEngine(policies)
+	s.mu.Lock()
+	s.policies = policies
+	regoEvaluator := s.regoEvaluator
+	s.mu.Unlock()
+
+	s.engine.Store(engine.NewEngineWithOptions(policies, engine.EngineOptions{
+		Dispatcher:    s.dispatcher,
+		RateLimiter:   s.rateLimiter,
+		RegoEvaluator: regoEvaluator,
+		Schema:        s.schema,
+		Mode:          s.mode,
+		JWTVerifier:   s.jwtVerifier,
+	}))
 	log.WithField("count", len(policies)).Info("Evaluation engine reloaded")
 }
 
+// RateLimitStatus returns key's current count in its rate-limit bucket
+// without recording a new event, for operators inspecting why a rate
+// condition is or isn't tripping. Returns an error if no rate limiter is
+// configured.
+func (s *Service) RateLimitStatus(key string) (int, error) {
+	if s.rateLimiter == nil {
+		return 0, fmt.Errorf("rate limiting is not configured")
+	}
+	return s.rateLimiter.Get(key)
+}
+
+// ResetRateLimit clears key's rate-limit bucket. Returns an error if no rate
+// limiter is configured.
+func (s *Service) ResetRateLimit(key string) error {
+	if s.rateLimiter == nil {
+		return fmt.Errorf("rate limiting is not configured")
+	}
+	return s.rateLimiter.Reset(key)
+}
+
 // GetPolicyCount returns the number of policies loaded
 func (s *Service) GetPolicyCount() int {
 	// This would need to be exposed from the engine
@@ -0,0 +1,159 @@
+package evaluation
+
+import (
+	"sort"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/engine"
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// UnifiedPolicySource supplies the currently active UnifiedPolicy set
+// SimulateDraft diffs proposed against, without this package (which
+// otherwise only knows about the older models.Policy engine) needing to
+// import internal/storage directly. Satisfied by *storage.UnifiedStorage's
+// List method.
+type UnifiedPolicySource interface {
+	List(filter *models.UnifiedPolicyListFilter) []*models.UnifiedPolicy
+}
+
+// PolicyDiffEntry is one input's current-vs-proposed comparison within a
+// SimulateDraft result.
+type PolicyDiffEntry struct {
+	Input      models.PolicyEvaluationInput `json:"input"`
+	Current    *models.PolicyDecision       `json:"current"`
+	Proposed   *models.PolicyDecision       `json:"proposed"`
+	Changed    bool                         `json:"changed"`
+	ChangeType string                       `json:"change_type,omitempty"` // newly_denied, newly_allowed, rule_changed
+}
+
+// PolicyDiffSummary aggregates a SimulateDraft result's entries, so a
+// reviewer can tell at a glance whether a draft policy set is safe to
+// promote without reading every entry.
+type PolicyDiffSummary struct {
+	Total        int `json:"total"`
+	Changed      int `json:"changed"`
+	NewlyDenied  int `json:"newly_denied"`
+	NewlyAllowed int `json:"newly_allowed"`
+}
+
+// PolicyDiffResult is SimulateDraft's return value.
+type PolicyDiffResult struct {
+	Entries []PolicyDiffEntry `json:"entries"`
+	Summary PolicyDiffSummary `json:"summary"`
+}
+
+// SimulateDraft evaluates inputs against both the currently active unified
+// policy set (via the UnifiedPolicySource set by SetUnifiedPolicySource)
+// and proposed - a caller-supplied, not-yet-promoted set, typically one or
+// more PolicyStatusDraft policies being reviewed before activation - and
+// reports the decision each set reaches per input plus a summary of what
+// changed. Mutates nothing: proposed is never written back to storage.
+//
+// Named distinctly from Simulate above rather than overloading it: that
+// method already evaluates a single models.PolicyEvaluationRequest against
+// the older models.Policy engine, an incompatible signature for this
+// unified-policy, batch-input, current-vs-proposed comparison.
+func (s *Service) SimulateDraft(proposed []*models.UnifiedPolicy, inputs []models.PolicyEvaluationInput) *PolicyDiffResult {
+	var current []*models.UnifiedPolicy
+	if s.unifiedPolicies != nil {
+		active := models.PolicyStatusActive
+		current = s.unifiedPolicies.List(&models.UnifiedPolicyListFilter{Status: &active})
+	}
+
+	result := &PolicyDiffResult{Entries: make([]PolicyDiffEntry, 0, len(inputs))}
+	for _, in := range inputs {
+		entry := PolicyDiffEntry{
+			Input:    in,
+			Current:  decideUnifiedPolicies(current, in),
+			Proposed: decideUnifiedPolicies(proposed, in),
+		}
+		entry.Changed, entry.ChangeType = diffDecisions(entry.Current, entry.Proposed)
+		if entry.Changed {
+			result.Summary.Changed++
+			switch entry.ChangeType {
+			case "newly_denied":
+				result.Summary.NewlyDenied++
+			case "newly_allowed":
+				result.Summary.NewlyAllowed++
+			}
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+	result.Summary.Total = len(inputs)
+
+	return result
+}
+
+// decideUnifiedPolicies picks the winning decision across policies for in,
+// the same DenyOverride-then-Priority tie-break ActivatePolicy's conflict
+// check reasons about: a DenyOverride policy that matches always wins
+// regardless of priority, otherwise the highest-Priority match wins.
+// Returns a deny decision with no matched policy when nothing matches.
+func decideUnifiedPolicies(policies []*models.UnifiedPolicy, in models.PolicyEvaluationInput) *models.PolicyDecision {
+	ctx := &engine.SimulationContext{
+		ResourceType: in.ResourceType,
+		ResourceID:   in.ResourceID,
+		Principal: map[string]interface{}{
+			"user_id": in.UserID,
+			"roles":   in.Roles,
+			"org_ids": in.OrgIDs,
+		},
+		Arguments: in.Context,
+	}
+
+	sorted := make([]*models.UnifiedPolicy, len(policies))
+	copy(sorted, policies)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].DenyOverride != sorted[j].DenyOverride {
+			return sorted[i].DenyOverride
+		}
+		return sorted[i].Priority > sorted[j].Priority
+	})
+
+	for _, p := range sorted {
+		res := engine.Simulate(p.PolicyID, p.Version, p.PolicyRules, ctx, false)
+		if !res.Matched || len(res.Actions) == 0 {
+			continue
+		}
+
+		matchedRule := ""
+		for _, rule := range res.Rules {
+			if rule.Matched {
+				matchedRule = rule.RuleID
+				break
+			}
+		}
+
+		return &models.PolicyDecision{
+			Decision:    res.Actions[0].Type,
+			PolicyIDs:   []string{p.PolicyID},
+			MatchedRule: matchedRule,
+			Timestamp:   time.Now(),
+		}
+	}
+
+	return &models.PolicyDecision{
+		Decision:  models.RuleActionDeny,
+		Reason:    "no rule matched",
+		Timestamp: time.Now(),
+	}
+}
+
+// diffDecisions reports whether proposed's decision differs from current's,
+// and if so, which of newly_denied/newly_allowed/rule_changed it is.
+// newly_denied/newly_allowed classify a flip in allow-vs-deny; any other
+// change (e.g. allow via a different rule, or a non-deny action type
+// changing) is reported as rule_changed.
+func diffDecisions(current, proposed *models.PolicyDecision) (bool, string) {
+	if current.Decision == proposed.Decision && current.MatchedRule == proposed.MatchedRule {
+		return false, ""
+	}
+	if proposed.Decision == models.RuleActionDeny && current.Decision != models.RuleActionDeny {
+		return true, "newly_denied"
+	}
+	if current.Decision == models.RuleActionDeny && proposed.Decision != models.RuleActionDeny {
+		return true, "newly_allowed"
+	}
+	return true, "rule_changed"
+}
@@ -1,27 +1,72 @@
 package management
 
 import (
+	"crypto/ed25519"
+	"fmt"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/admission"
+	"github.com/datacline/policy-engine/internal/engine"
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/policyschema"
+	"github.com/datacline/policy-engine/internal/policytype"
 	"github.com/datacline/policy-engine/internal/storage"
 	log "github.com/sirupsen/logrus"
 )
 
 // Service handles policy management operations (CRUD)
 type Service struct {
-	storage *storage.Storage
+	storage     *storage.Storage
+	schema      *policyschema.Registry // nil disables schema-aware ValidatePolicy checks
+	policyTypes *policytype.Registry   // nil disables PolicyType-schema checks on CreatePolicy/UpdatePolicy
+	constraints *admission.Registry    // ConstraintTemplate/Constraint storage for the admission webhook
+}
+
+// ServiceOptions bundles the Service's optional collaborators so adding one
+// doesn't require another NewServiceWithX constructor.
+type ServiceOptions struct {
+	SigningKey        ed25519.PrivateKey
+	EnforceSignatures bool
+	Schema            *policyschema.Registry
+	PolicyTypes       *policytype.Registry
 }
 
-// NewService creates a new management service
+// NewService creates a new management service with signing, signature
+// enforcement, and schema-aware validation all disabled.
 func NewService(policyDir string) (*Service, error) {
+	return NewServiceWithOptions(policyDir, ServiceOptions{})
+}
+
+// NewServiceWithSigning creates a new management service whose storage
+// signs saved policy bundles and their revision history with signingKey
+// (nil disables signing), refusing to load a missing or invalid signature
+// when enforceSignatures is true.
+func NewServiceWithSigning(policyDir string, signingKey ed25519.PrivateKey, enforceSignatures bool) (*Service, error) {
+	return NewServiceWithOptions(policyDir, ServiceOptions{SigningKey: signingKey, EnforceSignatures: enforceSignatures})
+}
+
+// NewServiceWithOptions creates a new management service with the given
+// optional collaborators. A nil Schema disables the schema-aware condition
+// checks ValidatePolicy otherwise runs.
+func NewServiceWithOptions(policyDir string, opts ServiceOptions) (*Service, error) {
 	store := storage.NewStorage(policyDir)
-	
+	store.SetSigning(opts.SigningKey, opts.EnforceSignatures)
+
 	// Load initial policies
 	if _, err := store.LoadAll(); err != nil {
 		return nil, err
 	}
-	
+
+	constraints, err := admission.NewRegistry(policyDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load constraint registry: %w", err)
+	}
+
 	return &Service{
-		storage: store,
+		storage:     store,
+		schema:      opts.Schema,
+		policyTypes: opts.PolicyTypes,
+		constraints: constraints,
 	}, nil
 }
 
@@ -30,63 +75,154 @@ func (s *Service) ListPolicies() []*models.Policy {
 	return s.storage.GetAll()
 }
 
+// ListPoliciesByScope retrieves all policies bound to the given scope level
+// and ID (scopeID is ignored for models.ScopeGlobal).
+func (s *Service) ListPoliciesByScope(scopeType models.PolicyScopeType, scopeID string) []*models.Policy {
+	return s.storage.GetByScope(scopeType, scopeID)
+}
+
 // GetPolicy retrieves a specific policy by ID
 func (s *Service) GetPolicy(id string) (*models.Policy, error) {
 	return s.storage.Get(id)
 }
 
-// CreatePolicy creates a new policy
-func (s *Service) CreatePolicy(policy *models.Policy) error {
+// CreatePolicy creates a new policy. author identifies who made the change
+// for the revision history (see History/Rollback).
+func (s *Service) CreatePolicy(policy *models.Policy, author string) error {
 	if err := s.storage.Validate(policy); err != nil {
 		return err
 	}
-	
-	if err := s.storage.Create(policy); err != nil {
+	if s.policyTypes != nil {
+		if err := s.policyTypes.Validate(policy, false); err != nil {
+			return err
+		}
+	}
+
+	if err := s.storage.Create(policy, author); err != nil {
 		return err
 	}
-	
+
 	log.WithFields(log.Fields{
 		"id":   policy.ID,
 		"name": policy.Name,
 	}).Info("Policy created via management service")
-	
+
 	return nil
 }
 
-// UpdatePolicy updates an existing policy
-func (s *Service) UpdatePolicy(id string, policy *models.Policy) error {
+// UpdatePolicy updates an existing policy. author identifies who made the
+// change for the revision history (see History/Rollback).
+func (s *Service) UpdatePolicy(id string, policy *models.Policy, author string) error {
 	if err := s.storage.Validate(policy); err != nil {
 		return err
 	}
-	
-	if err := s.storage.Update(id, policy); err != nil {
+	if s.policyTypes != nil {
+		if err := s.policyTypes.Validate(policy, true); err != nil {
+			return err
+		}
+	}
+
+	if err := s.storage.Update(id, policy, author); err != nil {
 		return err
 	}
-	
+
 	log.WithFields(log.Fields{
 		"id":      id,
 		"version": policy.Version,
 	}).Info("Policy updated via management service")
-	
+
 	return nil
 }
 
-// DeletePolicy deletes a policy
-func (s *Service) DeletePolicy(id string) error {
-	if err := s.storage.Delete(id); err != nil {
+// DeletePolicy deletes a policy. author identifies who made the change for
+// the revision history (see History/Rollback).
+func (s *Service) DeletePolicy(id, author string) error {
+	if err := s.storage.Delete(id, author); err != nil {
 		return err
 	}
-	
+
 	log.WithField("id", id).Info("Policy deleted via management service")
 	return nil
 }
 
+// PolicyHistory returns the recorded revision history for a policy, oldest
+// first.
+func (s *Service) PolicyHistory(id string) ([]*models.PolicyRevision, error) {
+	return s.storage.History(id)
+}
+
+// RollbackPolicy restores policy id to the snapshot captured in revisionID,
+// recording the rollback itself as a new revision.
+func (s *Service) RollbackPolicy(id, revisionID, author string) (*models.Policy, error) {
+	policy, err := s.storage.Rollback(id, revisionID, author)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{
+		"id":       id,
+		"revision": revisionID,
+	}).Info("Policy rolled back via management service")
+
+	return policy, nil
+}
+
+// AddSubscription registers a webhook subscription on policy id, assigning
+// it an ID. The engine notifies sub.URL with a PolicyNotification every time
+// it evaluates policy id, once an engine built with a notify.Dispatcher
+// reloads this change.
+func (s *Service) AddSubscription(id string, sub models.NotificationSubscription, author string) (*models.NotificationSubscription, error) {
+	policy, err := s.storage.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.ID = fmt.Sprintf("sub-%d", time.Now().UnixNano())
+	policy.Subscriptions = append(policy.Subscriptions, sub)
+
+	if err := s.storage.Update(id, policy, author); err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{"id": id, "subscription": sub.ID}).Info("Notification subscription added")
+	return &sub, nil
+}
+
+// RemoveSubscription removes subscription subID from policy id.
+func (s *Service) RemoveSubscription(id, subID, author string) error {
+	policy, err := s.storage.Get(id)
+	if err != nil {
+		return err
+	}
+
+	kept := make([]models.NotificationSubscription, 0, len(policy.Subscriptions))
+	found := false
+	for _, sub := range policy.Subscriptions {
+		if sub.ID == subID {
+			found = true
+			continue
+		}
+		kept = append(kept, sub)
+	}
+	if !found {
+		return fmt.Errorf("subscription not found: %s", subID)
+	}
+	policy.Subscriptions = kept
+
+	if err := s.storage.Update(id, policy, author); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{"id": id, "subscription": subID}).Info("Notification subscription removed")
+	return nil
+}
+
 // EnablePolicy enables a policy
 func (s *Service) EnablePolicy(id string) error {
 	if err := s.storage.Enable(id); err != nil {
 		return err
 	}
-	
+
 	log.WithField("id", id).Info("Policy enabled")
 	return nil
 }
@@ -96,14 +232,180 @@ func (s *Service) DisablePolicy(id string) error {
 	if err := s.storage.Disable(id); err != nil {
 		return err
 	}
-	
+
 	log.WithField("id", id).Info("Policy disabled")
 	return nil
 }
 
-// ValidatePolicy validates a policy without saving
+// ValidatePolicy validates a policy without saving. When a schema registry
+// is configured (see NewServiceWithOptions), it additionally walks every
+// non-Rego rule's conditions and rejects one referencing a field unknown to
+// the registered entity schema, or using an operator incompatible with that
+// field's declared type.
 func (s *Service) ValidatePolicy(policy *models.Policy) error {
-	return s.storage.Validate(policy)
+	if err := s.storage.Validate(policy); err != nil {
+		return err
+	}
+
+	if s.schema == nil {
+		return nil
+	}
+	for _, rule := range policy.Rules {
+		if rule.IsRego() {
+			continue
+		}
+		for _, cond := range rule.Conditions {
+			if err := s.schema.ValidateCondition(&cond); err != nil {
+				return fmt.Errorf("rule %s: %w", rule.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Schema returns the configured entity schema registry, or nil if schema-
+// aware validation is disabled.
+func (s *Service) Schema() *policyschema.Registry {
+	return s.schema
+}
+
+// PolicyTypes returns the configured policy type registry, or nil if
+// PolicyType-schema validation is disabled.
+func (s *Service) PolicyTypes() *policytype.Registry {
+	return s.policyTypes
+}
+
+// SetPolicyType registers pt, compiling and persisting its schemas so
+// subsequent CreatePolicy/UpdatePolicy calls referencing pt.ID are validated
+// against it.
+func (s *Service) SetPolicyType(pt *models.PolicyTypeDef) error {
+	if err := s.policyTypes.Set(pt); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{"id": pt.ID, "name": pt.Name}).Info("Policy type registered")
+	return nil
+}
+
+// DeletePolicyType removes a registered PolicyType.
+func (s *Service) DeletePolicyType(id string) error {
+	if err := s.policyTypes.Delete(id); err != nil {
+		return err
+	}
+
+	log.WithField("id", id).Info("Policy type removed")
+	return nil
+}
+
+// CreateConstraintTemplate registers a new ConstraintTemplate for the
+// admission webhook's Constraints to instantiate.
+func (s *Service) CreateConstraintTemplate(req *models.ConstraintTemplateCreateRequest) (*models.ConstraintTemplate, error) {
+	tmpl, err := s.constraints.CreateTemplate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{"id": tmpl.ID, "name": tmpl.Name}).Info("Constraint template created")
+	return tmpl, nil
+}
+
+// ListConstraintTemplates returns every registered ConstraintTemplate.
+func (s *Service) ListConstraintTemplates() []*models.ConstraintTemplate {
+	return s.constraints.ListTemplates()
+}
+
+// CreateConstraint instantiates a ConstraintTemplate with req's params,
+// compiling the result into a Policy (via admission.Compile) and saving it
+// through the normal CreatePolicy path, so the admission webhook's engine
+// is the same one every other Evaluate caller uses.
+func (s *Service) CreateConstraint(req *models.ConstraintCreateRequest, author string) (*models.Constraint, error) {
+	tmpl, err := s.constraints.GetTemplate(req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	constraint := &models.Constraint{
+		ID:          fmt.Sprintf("constraint-%d", now.UnixNano()),
+		TemplateID:  req.TemplateID,
+		Name:        req.Name,
+		Params:      req.Params,
+		Enforcement: req.Enforcement,
+		CreatedAt:   &now,
+		UpdatedAt:   &now,
+	}
+
+	policy, err := admission.Compile(tmpl, constraint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile constraint: %w", err)
+	}
+
+	if err := s.CreatePolicy(policy, author); err != nil {
+		return nil, err
+	}
+	constraint.PolicyID = policy.ID
+
+	if err := s.constraints.SaveConstraint(constraint); err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{"id": constraint.ID, "template_id": req.TemplateID, "policy_id": policy.ID}).Info("Constraint created")
+	return constraint, nil
+}
+
+// ListConstraints returns every instantiated Constraint.
+func (s *Service) ListConstraints() []*models.Constraint {
+	return s.constraints.ListConstraints()
+}
+
+// DeleteConstraint removes a Constraint and its compiled Policy.
+func (s *Service) DeleteConstraint(id, author string) error {
+	constraint, err := s.constraints.GetConstraint(id)
+	if err != nil {
+		return err
+	}
+	if constraint.PolicyID != "" {
+		if err := s.storage.Delete(constraint.PolicyID, author); err != nil {
+			return err
+		}
+	}
+	return s.constraints.DeleteConstraint(id)
+}
+
+// TestPolicy runs testCases against policy using a standalone engine built
+// only for this call, so it never touches storage or the running evaluation
+// engine. This mirrors a kyverno-style `validate` pass: CI can gate
+// CreatePolicy/UpdatePolicy on fixtures before the policy ever goes live.
+func (s *Service) TestPolicy(policy *models.Policy, testCases []models.PolicyTestCase) *models.PolicyTestReport {
+	candidatePolicy := *policy
+	candidatePolicy.Enabled = true
+	candidate := engine.NewEngine([]*models.Policy{&candidatePolicy})
+
+	report := &models.PolicyTestReport{Passed: true}
+	for _, tc := range testCases {
+		result := candidate.Evaluate(&tc.Request)
+
+		passed := result.Action == tc.ExpectedAction
+		if tc.ExpectedShouldBlock != nil {
+			passed = passed && result.ShouldBlock == *tc.ExpectedShouldBlock
+		}
+
+		caseResult := models.PolicyTestCaseResult{
+			Name:           tc.Name,
+			Passed:         passed,
+			MatchedRules:   result.MatchedRules,
+			ExpectedAction: tc.ExpectedAction,
+			ActualAction:   result.Action,
+		}
+		if !passed {
+			caseResult.Diff = fmt.Sprintf("expected action=%s, got action=%s (should_block=%v)", tc.ExpectedAction, result.Action, result.ShouldBlock)
+			report.Passed = false
+		}
+
+		report.Results = append(report.Results, caseResult)
+	}
+
+	return report
 }
 
 // ReloadFromDisk reloads all policies from disk
@@ -112,7 +414,7 @@ func (s *Service) ReloadFromDisk() ([]*models.Policy, error) {
 	if err != nil {
 		return nil, err
 	}
-	
+
 	log.WithField("count", len(policies)).Info("Policies reloaded from disk")
 	return policies, nil
 }
@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+)
+
+// redisKeyPrefix namespaces every key RedisStore writes, so a policy cache
+// can share a Redis instance with ratelimit.RedisLimiter's sliding-window
+// keys without colliding.
+const redisKeyPrefix = "policy-engine:policy:"
+
+// redisIndexKey is a Redis set of every policy ID RedisStore knows about,
+// since Redis has no native "list keys matching a prefix" that's safe to
+// rely on in production (KEYS blocks the server; SCAN is cursor-based and
+// awkward for a simple LoadAll).
+const redisIndexKey = "policy-engine:policies"
+
+// redisChangesChannel is the Pub/Sub channel every mutating RedisStore
+// method publishes to, so Watch (on this or any other instance sharing the
+// Redis instance) knows to reload.
+const redisChangesChannel = "policy-engine:policy-changes"
+
+// RedisStore is a PolicyStore backed by Redis, for deployments that want a
+// shared cache/cluster-sync backend instead of per-instance YAML files or a
+// SQL database. Unlike SQLStore, it has no normalized schema - each policy
+// is stored as a single JSON blob - since Redis has no query language to
+// normalize for.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a RedisStore using client.
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// LoadAll loads every policy ID in redisIndexKey, then fetches and
+// unmarshals each one.
+func (s *RedisStore) LoadAll() ([]*models.Policy, error) {
+	ctx := context.Background()
+	ids, err := s.client.SMembers(ctx, redisIndexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy IDs: %w", err)
+	}
+
+	policies := make([]*models.Policy, 0, len(ids))
+	for _, id := range ids {
+		policy, err := s.Get(id)
+		if err != nil {
+			log.WithError(err).WithField("id", id).Warn("Failed to load policy from Redis")
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// Get fetches and unmarshals a single policy by ID.
+func (s *RedisStore) Get(id string) (*models.Policy, error) {
+	ctx := context.Background()
+	data, err := s.client.Get(ctx, redisKeyPrefix+id).Bytes()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("policy not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy %s: %w", id, err)
+	}
+
+	var policy models.Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy %s: %w", id, err)
+	}
+	return &policy, nil
+}
+
+// Create stores a new policy, adds it to the index set, and publishes a
+// change notification.
+func (s *RedisStore) Create(policy *models.Policy, author string) error {
+	ctx := context.Background()
+
+	if exists, err := s.client.SIsMember(ctx, redisIndexKey, policy.ID).Result(); err == nil && exists {
+		return fmt.Errorf("policy already exists: %s", policy.ID)
+	}
+
+	now := time.Now()
+	policy.CreatedAt = &now
+	policy.UpdatedAt = &now
+	if policy.Version == 0 {
+		policy.Version = 1
+	}
+	if policy.Enforcement == "" {
+		policy.Enforcement = "blocking"
+	}
+
+	return s.save(ctx, policy)
+}
+
+// Update replaces an existing policy's stored JSON and publishes a change
+// notification.
+func (s *RedisStore) Update(id string, policy *models.Policy, author string) error {
+	existing, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+
+	policy.ID = id
+	policy.CreatedAt = existing.CreatedAt
+	policy.Version = existing.Version + 1
+	now := time.Now()
+	policy.UpdatedAt = &now
+
+	return s.save(context.Background(), policy)
+}
+
+// Delete removes a policy's key and its index entry, and publishes a change
+// notification.
+func (s *RedisStore) Delete(id string, author string) error {
+	ctx := context.Background()
+
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, redisKeyPrefix+id)
+	pipe.SRem(ctx, redisIndexKey, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete policy %s: %w", id, err)
+	}
+
+	s.publishChange(ctx)
+	return nil
+}
+
+// Enable sets a policy's enabled flag to true.
+func (s *RedisStore) Enable(id string) error { return s.setEnabled(id, true) }
+
+// Disable sets a policy's enabled flag to false.
+func (s *RedisStore) Disable(id string) error { return s.setEnabled(id, false) }
+
+func (s *RedisStore) setEnabled(id string, enabled bool) error {
+	policy, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	policy.Enabled = enabled
+	now := time.Now()
+	policy.UpdatedAt = &now
+	return s.save(context.Background(), policy)
+}
+
+func (s *RedisStore) save(ctx context.Context, policy *models.Policy) error {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy %s: %w", policy.ID, err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, redisKeyPrefix+policy.ID, data, 0)
+	pipe.SAdd(ctx, redisIndexKey, policy.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save policy %s: %w", policy.ID, err)
+	}
+
+	s.publishChange(ctx)
+	return nil
+}
+
+// publishChange notifies every Watch subscriber (on this or any other
+// instance) that the policy set changed. Publish failures are logged rather
+// than returned, since a notification failure shouldn't roll back an
+// otherwise successful write - the write already committed.
+func (s *RedisStore) publishChange(ctx context.Context) {
+	if err := s.client.Publish(ctx, redisChangesChannel, "changed").Err(); err != nil {
+		log.WithError(err).Warn("Failed to publish policy change notification")
+	}
+}
+
+// Watch subscribes to redisChangesChannel and emits a fresh LoadAll every
+// time any instance sharing this Redis instance writes a policy, so every
+// gateway node stays in sync without polling.
+func (s *RedisStore) Watch(ctx context.Context) (<-chan []*models.Policy, error) {
+	sub := s.client.Subscribe(ctx, redisChangesChannel)
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, fmt.Errorf("failed to subscribe to policy change notifications: %w", err)
+	}
+
+	out := make(chan []*models.Policy)
+	go func() {
+		defer sub.Close()
+		defer close(out)
+
+		ch := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-ch:
+				if !ok {
+					return
+				}
+				policies, err := s.LoadAll()
+				if err != nil {
+					log.WithError(err).Warn("Watch: failed to reload policies after change")
+					continue
+				}
+				select {
+				case out <- policies:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+var _ PolicyStore = (*RedisStore)(nil)
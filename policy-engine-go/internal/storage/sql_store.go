@@ -0,0 +1,496 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// sqlSchema normalizes a Policy the way Casbin's casbin_policy table
+// normalizes a ptype + v0..v5 rule: one row per policy, one child row per
+// rule/condition/action, so the backend can index, join, and transact on
+// them instead of round-tripping an opaque blob. policy_matches is separate
+// from policies so a high-frequency Evaluate hit doesn't lock the same row
+// migrations/updates contend on.
+//
+// Placeholders use "?", the database/sql convention MySQL and SQLite
+// drivers expect natively; a Postgres *sql.DB must go through a driver that
+// rebinds "?" to "$N" (pgx's stdlib adapter does not do this by default) -
+// this is a known limitation of reusing one query set across all three.
+const sqlSchema = `
+CREATE TABLE IF NOT EXISTS policies (
+	id              VARCHAR(255) PRIMARY KEY,
+	name            VARCHAR(255) NOT NULL,
+	description     TEXT,
+	org_id          VARCHAR(255),
+	version         INTEGER NOT NULL DEFAULT 1,
+	enabled         BOOLEAN NOT NULL DEFAULT TRUE,
+	enforcement     VARCHAR(32) NOT NULL DEFAULT 'blocking',
+	scope_type      VARCHAR(32),
+	scope_id        VARCHAR(255),
+	evaluation_mode VARCHAR(32),
+	created_by      VARCHAR(255),
+	created_at      TIMESTAMP,
+	updated_at      TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS policy_rules (
+	id          VARCHAR(255) NOT NULL,
+	policy_id   VARCHAR(255) NOT NULL REFERENCES policies(id) ON DELETE CASCADE,
+	priority    INTEGER NOT NULL DEFAULT 100,
+	rego        TEXT,
+	PRIMARY KEY (policy_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS policy_conditions (
+	policy_id   VARCHAR(255) NOT NULL,
+	rule_id     VARCHAR(255) NOT NULL,
+	seq         INTEGER NOT NULL,
+	type        VARCHAR(32) NOT NULL,
+	field       VARCHAR(255),
+	operator    VARCHAR(32) NOT NULL,
+	value_json  TEXT,
+	inverted    BOOLEAN NOT NULL DEFAULT FALSE,
+	PRIMARY KEY (policy_id, rule_id, seq)
+);
+
+CREATE TABLE IF NOT EXISTS policy_actions (
+	policy_id    VARCHAR(255) NOT NULL,
+	rule_id      VARCHAR(255) NOT NULL,
+	seq          INTEGER NOT NULL,
+	type         VARCHAR(32) NOT NULL,
+	params_json  TEXT,
+	PRIMARY KEY (policy_id, rule_id, seq)
+);
+
+CREATE TABLE IF NOT EXISTS policy_matches (
+	policy_id       VARCHAR(255) PRIMARY KEY,
+	last_matched_at TIMESTAMP,
+	match_count     BIGINT NOT NULL DEFAULT 0
+);
+`
+
+// SQLStore is a PolicyStore backed by a normalized SQL schema (see
+// sqlSchema), for deployments that need transactional writes and ad hoc
+// search across many policies instead of a directory of YAML files. The
+// caller opens db with whichever driver matches their DSN (postgres, mysql,
+// sqlite3, ...) and registers it the usual database/sql way; SQLStore only
+// issues portable SQL against it.
+type SQLStore struct {
+	db *sql.DB
+	// pollInterval governs how often Watch polls for a newer
+	// MAX(updated_at), since database/sql has no native change-notification
+	// primitive (unlike RedisStore's pubsub or Storage's fsnotify).
+	pollInterval time.Duration
+}
+
+// NewSQLStore creates a SQLStore against db, applying sqlSchema with
+// CREATE TABLE IF NOT EXISTS so it's safe to call against an
+// already-migrated database.
+func NewSQLStore(db *sql.DB) (*SQLStore, error) {
+	if _, err := db.Exec(sqlSchema); err != nil {
+		return nil, fmt.Errorf("failed to apply policy schema: %w", err)
+	}
+	return &SQLStore{db: db, pollInterval: 5 * time.Second}, nil
+}
+
+// LoadAll loads every policy, and its rules/conditions/actions, from the
+// database.
+func (s *SQLStore) LoadAll() ([]*models.Policy, error) {
+	rows, err := s.db.Query(`SELECT id, name, description, org_id, version, enabled, enforcement, scope_type, scope_id, evaluation_mode, created_by, created_at, updated_at FROM policies`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*models.Policy
+	for rows.Next() {
+		policy, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, policy := range policies {
+		rules, err := s.loadRules(policy.ID)
+		if err != nil {
+			return nil, err
+		}
+		policy.Rules = rules
+	}
+	return policies, nil
+}
+
+// Get returns a single policy, with its rules, by ID.
+func (s *SQLStore) Get(id string) (*models.Policy, error) {
+	row := s.db.QueryRow(`SELECT id, name, description, org_id, version, enabled, enforcement, scope_type, scope_id, evaluation_mode, created_by, created_at, updated_at FROM policies WHERE id = ?`, id)
+	policy, err := scanPolicy(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("policy not found: %s", id)
+		}
+		return nil, err
+	}
+	rules, err := s.loadRules(policy.ID)
+	if err != nil {
+		return nil, err
+	}
+	policy.Rules = rules
+	return policy, nil
+}
+
+// Create inserts a new policy and its rules/conditions/actions in a single
+// transaction. author is currently not persisted - SQLStore has no history
+// table equivalent to history.Store; it exists to satisfy PolicyStore.
+func (s *SQLStore) Create(policy *models.Policy, author string) error {
+	now := time.Now()
+	policy.CreatedAt = &now
+	policy.UpdatedAt = &now
+	if policy.Version == 0 {
+		policy.Version = 1
+	}
+	if policy.Enforcement == "" {
+		policy.Enforcement = "blocking"
+	}
+
+	return s.withTx(func(tx *sql.Tx) error {
+		if err := upsertPolicy(tx, policy); err != nil {
+			return err
+		}
+		return replaceRules(tx, policy.ID, policy.Rules)
+	})
+}
+
+// Update replaces an existing policy's row and its rules/conditions/actions.
+func (s *SQLStore) Update(id string, policy *models.Policy, author string) error {
+	existing, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+
+	policy.ID = id
+	policy.CreatedAt = existing.CreatedAt
+	policy.Version = existing.Version + 1
+	now := time.Now()
+	policy.UpdatedAt = &now
+
+	return s.withTx(func(tx *sql.Tx) error {
+		if err := upsertPolicy(tx, policy); err != nil {
+			return err
+		}
+		return replaceRules(tx, policy.ID, policy.Rules)
+	})
+}
+
+// Delete removes a policy; its rules/conditions/actions/match counter cascade
+// via ON DELETE CASCADE (or are removed explicitly for drivers that ignore
+// the constraint, e.g. SQLite without foreign_keys=ON).
+func (s *SQLStore) Delete(id string, author string) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM policy_actions WHERE policy_id = ?`, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM policy_conditions WHERE policy_id = ?`, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM policy_rules WHERE policy_id = ?`, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM policy_matches WHERE policy_id = ?`, id); err != nil {
+			return err
+		}
+		res, err := tx.Exec(`DELETE FROM policies WHERE id = ?`, id)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return fmt.Errorf("policy not found: %s", id)
+		}
+		return nil
+	})
+}
+
+// Enable sets a policy's enabled flag to true.
+func (s *SQLStore) Enable(id string) error { return s.setEnabled(id, true) }
+
+// Disable sets a policy's enabled flag to false.
+func (s *SQLStore) Disable(id string) error { return s.setEnabled(id, false) }
+
+func (s *SQLStore) setEnabled(id string, enabled bool) error {
+	res, err := s.db.Exec(`UPDATE policies SET enabled = ?, updated_at = ? WHERE id = ?`, enabled, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update policy: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("policy not found: %s", id)
+	}
+	return nil
+}
+
+// RecordMatch increments policy_matches' MatchCount and stamps
+// LastMatchedAt for policyID. It is not part of PolicyStore - nothing in
+// the evaluation engine calls it yet - but exists so a future hook can
+// populate models.Policy.LastMatchedAt/MatchCount without another schema
+// change.
+func (s *SQLStore) RecordMatch(policyID string) error {
+	now := time.Now()
+	_, err := s.db.Exec(`
+		INSERT INTO policy_matches (policy_id, last_matched_at, match_count) VALUES (?, ?, 1)
+		ON CONFLICT (policy_id) DO UPDATE SET last_matched_at = excluded.last_matched_at, match_count = policy_matches.match_count + 1
+	`, policyID, now)
+	if err != nil {
+		return fmt.Errorf("failed to record policy match: %w", err)
+	}
+	return nil
+}
+
+// Watch polls MAX(updated_at) every s.pollInterval (database/sql has no
+// portable change-notification primitive across Postgres/MySQL/SQLite) and
+// emits a fresh LoadAll whenever it advances, so any instance's write is
+// picked up by every other instance sharing the database.
+func (s *SQLStore) Watch(ctx context.Context) (<-chan []*models.Policy, error) {
+	out := make(chan []*models.Policy)
+	go func() {
+		defer close(out)
+
+		var lastSeen time.Time
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				var latest sql.NullTime
+				if err := s.db.QueryRow(`SELECT MAX(updated_at) FROM policies`).Scan(&latest); err != nil {
+					log.WithError(err).Warn("Watch: failed to poll for policy changes")
+					continue
+				}
+				if !latest.Valid || !latest.Time.After(lastSeen) {
+					continue
+				}
+				lastSeen = latest.Time
+
+				policies, err := s.LoadAll()
+				if err != nil {
+					log.WithError(err).Warn("Watch: failed to reload policies after change")
+					continue
+				}
+				select {
+				case out <- policies:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (s *SQLStore) withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows, so scanPolicy can
+// back both Get and LoadAll.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPolicy(row rowScanner) (*models.Policy, error) {
+	var policy models.Policy
+	var description, orgID, scopeType, scopeID, evaluationMode, createdBy sql.NullString
+	var createdAt, updatedAt sql.NullTime
+
+	if err := row.Scan(&policy.ID, &policy.Name, &description, &orgID, &policy.Version, &policy.Enabled,
+		&policy.Enforcement, &scopeType, &scopeID, &evaluationMode, &createdBy, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	policy.Description = description.String
+	policy.OrgID = orgID.String
+	policy.ScopeType = models.PolicyScopeType(scopeType.String)
+	policy.ScopeID = scopeID.String
+	policy.EvaluationMode = evaluationMode.String
+	policy.CreatedBy = createdBy.String
+	if createdAt.Valid {
+		t := createdAt.Time
+		policy.CreatedAt = &t
+	}
+	if updatedAt.Valid {
+		t := updatedAt.Time
+		policy.UpdatedAt = &t
+	}
+	return &policy, nil
+}
+
+func upsertPolicy(tx *sql.Tx, policy *models.Policy) error {
+	_, err := tx.Exec(`
+		INSERT INTO policies (id, name, description, org_id, version, enabled, enforcement, scope_type, scope_id, evaluation_mode, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name, description = excluded.description, org_id = excluded.org_id,
+			version = excluded.version, enabled = excluded.enabled, enforcement = excluded.enforcement,
+			scope_type = excluded.scope_type, scope_id = excluded.scope_id,
+			evaluation_mode = excluded.evaluation_mode, updated_at = excluded.updated_at
+	`, policy.ID, policy.Name, policy.Description, policy.OrgID, policy.Version, policy.Enabled,
+		policy.Enforcement, policy.ScopeType, policy.ScopeID, policy.EvaluationMode, policy.CreatedBy,
+		policy.CreatedAt, policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert policy: %w", err)
+	}
+	return nil
+}
+
+// replaceRules deletes and re-inserts every rule/condition/action row for
+// policyID, which is simpler and transactionally safer than diffing against
+// what's already stored given how infrequently policies are written.
+func replaceRules(tx *sql.Tx, policyID string, rules []models.PolicyRule) error {
+	if _, err := tx.Exec(`DELETE FROM policy_actions WHERE policy_id = ?`, policyID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM policy_conditions WHERE policy_id = ?`, policyID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM policy_rules WHERE policy_id = ?`, policyID); err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if _, err := tx.Exec(`INSERT INTO policy_rules (id, policy_id, priority, rego) VALUES (?, ?, ?, ?)`,
+			rule.ID, policyID, rule.Priority, rule.Rego); err != nil {
+			return fmt.Errorf("failed to insert rule %s: %w", rule.ID, err)
+		}
+
+		for i, cond := range rule.Conditions {
+			valueJSON, err := json.Marshal(cond.Value)
+			if err != nil {
+				return fmt.Errorf("failed to marshal condition value for rule %s: %w", rule.ID, err)
+			}
+			if _, err := tx.Exec(`INSERT INTO policy_conditions (policy_id, rule_id, seq, type, field, operator, value_json, inverted) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+				policyID, rule.ID, i, cond.Type, cond.Field, cond.Operator, string(valueJSON), cond.Inverted); err != nil {
+				return fmt.Errorf("failed to insert condition for rule %s: %w", rule.ID, err)
+			}
+		}
+
+		for i, action := range rule.Actions {
+			paramsJSON, err := json.Marshal(action.Params)
+			if err != nil {
+				return fmt.Errorf("failed to marshal action params for rule %s: %w", rule.ID, err)
+			}
+			if _, err := tx.Exec(`INSERT INTO policy_actions (policy_id, rule_id, seq, type, params_json) VALUES (?, ?, ?, ?, ?)`,
+				policyID, rule.ID, i, action.Type, string(paramsJSON)); err != nil {
+				return fmt.Errorf("failed to insert action for rule %s: %w", rule.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) loadRules(policyID string) ([]models.PolicyRule, error) {
+	rows, err := s.db.Query(`SELECT id, priority, rego FROM policy_rules WHERE policy_id = ? ORDER BY priority DESC`, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rules for %s: %w", policyID, err)
+	}
+	defer rows.Close()
+
+	var rules []models.PolicyRule
+	for rows.Next() {
+		var rule models.PolicyRule
+		var rego sql.NullString
+		if err := rows.Scan(&rule.ID, &rule.Priority, &rego); err != nil {
+			return nil, err
+		}
+		rule.Rego = rego.String
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		conditions, err := s.loadConditions(policyID, rules[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		rules[i].Conditions = conditions
+
+		actions, err := s.loadActions(policyID, rules[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		rules[i].Actions = actions
+	}
+	return rules, nil
+}
+
+func (s *SQLStore) loadConditions(policyID, ruleID string) ([]models.Condition, error) {
+	rows, err := s.db.Query(`SELECT type, field, operator, value_json, inverted FROM policy_conditions WHERE policy_id = ? AND rule_id = ? ORDER BY seq`, policyID, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conditions for rule %s: %w", ruleID, err)
+	}
+	defer rows.Close()
+
+	var conditions []models.Condition
+	for rows.Next() {
+		var cond models.Condition
+		var valueJSON string
+		if err := rows.Scan(&cond.Type, &cond.Field, &cond.Operator, &valueJSON, &cond.Inverted); err != nil {
+			return nil, err
+		}
+		if valueJSON != "" {
+			if err := json.Unmarshal([]byte(valueJSON), &cond.Value); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal condition value for rule %s: %w", ruleID, err)
+			}
+		}
+		conditions = append(conditions, cond)
+	}
+	return conditions, rows.Err()
+}
+
+func (s *SQLStore) loadActions(policyID, ruleID string) ([]models.Action, error) {
+	rows, err := s.db.Query(`SELECT type, params_json FROM policy_actions WHERE policy_id = ? AND rule_id = ? ORDER BY seq`, policyID, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query actions for rule %s: %w", ruleID, err)
+	}
+	defer rows.Close()
+
+	var actions []models.Action
+	for rows.Next() {
+		var action models.Action
+		var paramsJSON string
+		if err := rows.Scan(&action.Type, &paramsJSON); err != nil {
+			return nil, err
+		}
+		if paramsJSON != "" {
+			if err := json.Unmarshal([]byte(paramsJSON), &action.Params); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal action params for rule %s: %w", ruleID, err)
+			}
+		}
+		actions = append(actions, action)
+	}
+	return actions, rows.Err()
+}
+
+var _ PolicyStore = (*SQLStore)(nil)
@@ -1,22 +1,38 @@
 package storage
 
 import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/datacline/policy-engine/internal/engine"
+	"github.com/datacline/policy-engine/internal/history"
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/fsnotify/fsnotify"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
 
+// watchDebounce coalesces bursts of fs events from a single edit (e.g. an
+// editor's write-temp-then-rename) into one Watch emission, matching
+// config.Watcher's debounce.
+const watchDebounce = 500 * time.Millisecond
+
 // Storage handles policy persistence
 type Storage struct {
 	policies  map[string]*models.Policy
 	policyDir string
 	mu        sync.RWMutex
+
+	history *history.Store
+
+	signingKey        ed25519.PrivateKey // nil when signing is disabled
+	enforceSignatures bool
 }
 
 // NewStorage creates a new storage instance
@@ -24,9 +40,21 @@ func NewStorage(policyDir string) *Storage {
 	return &Storage{
 		policies:  make(map[string]*models.Policy),
 		policyDir: policyDir,
+		history:   history.NewStore(policyDir),
 	}
 }
 
+// SetSigning enables signed policy bundles: policy files are signed with
+// key on save, and loads refuse a missing or invalid signature when
+// enforce is true. A nil key disables signing and verification entirely.
+func (s *Storage) SetSigning(key ed25519.PrivateKey, enforce bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.signingKey = key
+	s.enforceSignatures = enforce
+}
+
 // LoadAll loads all policies from disk
 func (s *Storage) LoadAll() ([]*models.Policy, error) {
 	s.mu.Lock()
@@ -79,6 +107,25 @@ func (s *Storage) GetAll() []*models.Policy {
 	return policies
 }
 
+// GetByScope returns all policies bound to the given scope level and ID.
+// scopeID is ignored for models.ScopeGlobal.
+func (s *Storage) GetByScope(scopeType models.PolicyScopeType, scopeID string) []*models.Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matched []*models.Policy
+	for _, p := range s.policies {
+		if p.EffectiveScope() != scopeType {
+			continue
+		}
+		if scopeType != models.ScopeGlobal && p.ScopeID != scopeID {
+			continue
+		}
+		matched = append(matched, p)
+	}
+	return matched
+}
+
 // Get returns a policy by ID
 func (s *Storage) Get(id string) (*models.Policy, error) {
 	s.mu.RLock()
@@ -91,8 +138,9 @@ func (s *Storage) Get(id string) (*models.Policy, error) {
 	return policy, nil
 }
 
-// Create creates a new policy
-func (s *Storage) Create(policy *models.Policy) error {
+// Create creates a new policy. author is recorded on the resulting history
+// revision (see History/Rollback).
+func (s *Storage) Create(policy *models.Policy, author string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -126,6 +174,7 @@ func (s *Storage) Create(policy *models.Policy) error {
 
 	// Add to memory
 	s.policies[policy.ID] = policy
+	s.recordHistory(policy, "create", author)
 
 	log.WithFields(log.Fields{
 		"id":   policy.ID,
@@ -135,8 +184,9 @@ func (s *Storage) Create(policy *models.Policy) error {
 	return nil
 }
 
-// Update updates an existing policy
-func (s *Storage) Update(id string, policy *models.Policy) error {
+// Update updates an existing policy. author is recorded on the resulting
+// history revision (see History/Rollback).
+func (s *Storage) Update(id string, policy *models.Policy, author string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -149,7 +199,7 @@ func (s *Storage) Update(id string, policy *models.Policy) error {
 	// Preserve ID and created timestamp
 	policy.ID = id
 	policy.CreatedAt = existing.CreatedAt
-	
+
 	// Update timestamp
 	now := time.Now()
 	policy.UpdatedAt = &now
@@ -164,6 +214,7 @@ func (s *Storage) Update(id string, policy *models.Policy) error {
 
 	// Update in memory
 	s.policies[id] = policy
+	s.recordHistory(policy, "update", author)
 
 	log.WithFields(log.Fields{
 		"id":      policy.ID,
@@ -174,8 +225,9 @@ func (s *Storage) Update(id string, policy *models.Policy) error {
 	return nil
 }
 
-// Delete deletes a policy
-func (s *Storage) Delete(id string) error {
+// Delete deletes a policy. author is recorded on the resulting history
+// revision (see History/Rollback).
+func (s *Storage) Delete(id string, author string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -193,6 +245,7 @@ func (s *Storage) Delete(id string) error {
 
 	// Remove from memory
 	delete(s.policies, id)
+	s.recordHistory(policy, "delete", author)
 
 	log.WithFields(log.Fields{
 		"id":   policy.ID,
@@ -202,6 +255,107 @@ func (s *Storage) Delete(id string) error {
 	return nil
 }
 
+// History returns the recorded revision history for a policy, oldest first.
+func (s *Storage) History(id string) ([]*models.PolicyRevision, error) {
+	return s.history.History(id)
+}
+
+// Rollback restores policy id to the snapshot captured in revisionID,
+// saving it as a new revision so the rollback itself remains auditable and
+// nothing is lost from history. It refuses to restore from a revision
+// whose signature doesn't verify when signing is enabled.
+func (s *Storage) Rollback(id, revisionID, author string) (*models.Policy, error) {
+	rev, err := s.history.GetRevision(id, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if rev.Snapshot == nil {
+		return nil, fmt.Errorf("revision %s has no stored snapshot to roll back to", revisionID)
+	}
+
+	s.mu.RLock()
+	key := s.signingKey
+	enforce := s.enforceSignatures
+	s.mu.RUnlock()
+	if key != nil && !history.Verify(rev, key.Public().(ed25519.PublicKey)) {
+		if enforce {
+			return nil, fmt.Errorf("revision %s failed signature verification", revisionID)
+		}
+		log.WithField("revision", revisionID).Warn("Revision signature missing or invalid; rolling back anyway (ENFORCE_SIGNATURES=false)")
+	}
+
+	restored := *rev.Snapshot
+	if err := s.Update(id, &restored, author); err != nil {
+		return nil, fmt.Errorf("failed to roll back policy: %w", err)
+	}
+	return &restored, nil
+}
+
+// recordHistory appends a revision for policy. Failures are logged rather
+// than returned, since a history write failure shouldn't roll back an
+// otherwise successful CRUD operation.
+func (s *Storage) recordHistory(policy *models.Policy, op, author string) {
+	if _, err := s.history.Record(policy, op, author, s.signingKey); err != nil {
+		log.WithError(err).WithField("id", policy.ID).Warn("Failed to record policy revision history")
+	}
+}
+
+// Watch implements PolicyStore by watching s.policyDir for .yaml/.yml
+// changes with fsnotify, debouncing bursts into a single LoadAll per change.
+// This duplicates config.Watcher's fsnotify loop rather than depending on
+// it, since config.Watcher also wires in hot-reload bookkeeping (ReloadStatus,
+// CLI flag re-resolution) that's specific to cmd/server and cmd/evaluation,
+// not to the PolicyStore contract other backends also have to satisfy.
+func (s *Storage) Watch(ctx context.Context) (<-chan []*models.Policy, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy file watcher: %w", err)
+	}
+	if err := fsWatcher.Add(s.policyDir); err != nil {
+		fsWatcher.Close()
+		return nil, fmt.Errorf("failed to watch policy directory %s: %w", s.policyDir, err)
+	}
+
+	out := make(chan []*models.Policy)
+	go func() {
+		defer fsWatcher.Close()
+		defer close(out)
+
+		var debounceTimer *time.Timer
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-fsWatcher.Events:
+				if !ok {
+					return
+				}
+				if debounceTimer != nil {
+					debounceTimer.Stop()
+				}
+				debounceTimer = time.AfterFunc(watchDebounce, func() {
+					policies, err := s.LoadAll()
+					if err != nil {
+						log.WithError(err).Warn("Watch: failed to reload policies after change")
+						return
+					}
+					select {
+					case out <- policies:
+					case <-ctx.Done():
+					}
+				})
+			case err, ok := <-fsWatcher.Errors:
+				if !ok {
+					return
+				}
+				log.WithError(err).Warn("Policy directory watch error")
+			}
+		}
+	}()
+
+	return out, nil
+}
+
 // Enable enables a policy
 func (s *Storage) Enable(id string) error {
 	s.mu.Lock()
@@ -258,6 +412,12 @@ func (s *Storage) Validate(policy *models.Policy) error {
 		if rule.ID == "" {
 			return fmt.Errorf("rule %d: id is required", i)
 		}
+		if rule.IsRego() {
+			if _, err := engine.PrepareRegoQuery(rule.Rego); err != nil {
+				return fmt.Errorf("rule %s: invalid rego: %w", rule.ID, err)
+			}
+			continue
+		}
 		if len(rule.Conditions) == 0 {
 			return fmt.Errorf("rule %s: must have at least one condition", rule.ID)
 		}
@@ -265,6 +425,11 @@ func (s *Storage) Validate(policy *models.Policy) error {
 			return fmt.Errorf("rule %s: must have at least one action", rule.ID)
 		}
 	}
+	if policy.ScopeType == models.ScopeTenant || policy.ScopeType == models.ScopePrincipal {
+		if policy.ScopeID == "" {
+			return fmt.Errorf("scope_id is required for scope %s", policy.ScopeType)
+		}
+	}
 	return nil
 }
 
@@ -281,6 +446,10 @@ func (s *Storage) loadPolicyFromFile(path string) (*models.Policy, error) {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	if err := s.verifySignature(&policy, path); err != nil {
+		return nil, err
+	}
+
 	// Set defaults
 	if policy.Version == 0 {
 		policy.Version = 1
@@ -297,9 +466,50 @@ func (s *Storage) loadPolicyFromFile(path string) (*models.Policy, error) {
 	return &policy, nil
 }
 
+// verifySignature checks policy's bundle Signature against s.signingKey
+// when signing is configured, refusing to load a mismatch when
+// s.enforceSignatures is set. A nil signingKey skips verification entirely.
+func (s *Storage) verifySignature(policy *models.Policy, path string) error {
+	if s.signingKey == nil {
+		return nil
+	}
+
+	sig := policy.Signature
+	policy.Signature = ""
+	unsigned, err := yaml.Marshal(policy)
+	policy.Signature = sig
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy for verification: %w", err)
+	}
+
+	valid := false
+	if sig != "" {
+		if sigBytes, err := hex.DecodeString(sig); err == nil {
+			valid = ed25519.Verify(s.signingKey.Public().(ed25519.PublicKey), unsigned, sigBytes)
+		}
+	}
+
+	if !valid {
+		if s.enforceSignatures {
+			return fmt.Errorf("policy file %s failed signature verification", path)
+		}
+		log.WithField("file", path).Warn("Policy file signature missing or invalid; loading anyway (ENFORCE_SIGNATURES=false)")
+	}
+	return nil
+}
+
 func (s *Storage) savePolicyToFile(policy *models.Policy) error {
 	filename := filepath.Join(s.policyDir, fmt.Sprintf("%s.yaml", policy.ID))
 
+	policy.Signature = ""
+	if s.signingKey != nil {
+		unsigned, err := yaml.Marshal(policy)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		policy.Signature = hex.EncodeToString(ed25519.Sign(s.signingKey, unsigned))
+	}
+
 	data, err := yaml.Marshal(policy)
 	if err != nil {
 		return fmt.Errorf("failed to marshal YAML: %w", err)
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// PolicyStore is the common persistence contract every policy backend
+// implements: the YAML-directory Storage (the default), SQLStore (Postgres/
+// MySQL/SQLite via database/sql), and RedisStore (a shared cache/pubsub
+// backend for multi-instance deployments). It intentionally covers only the
+// CRUD + lifecycle + change-notification surface every backend can support;
+// backend-specific conveniences (Storage.GetByScope, Storage.Validate,
+// Storage.History/Rollback) stay on the concrete types rather than forcing
+// every implementation to carry them.
+type PolicyStore interface {
+	// LoadAll loads (or reloads) every policy from the backend.
+	LoadAll() ([]*models.Policy, error)
+	// Get returns a single policy by ID.
+	Get(id string) (*models.Policy, error)
+	// Create persists a new policy. author is recorded for audit/history
+	// purposes where the backend supports it.
+	Create(policy *models.Policy, author string) error
+	// Update persists changes to an existing policy.
+	Update(id string, policy *models.Policy, author string) error
+	// Delete removes a policy.
+	Delete(id string, author string) error
+	// Enable and Disable flip a policy's Enabled flag without touching its
+	// rules, bumping its version the way Update does.
+	Enable(id string) error
+	Disable(id string) error
+	// Watch returns a channel that receives the full, freshly loaded policy
+	// set every time the backend observes a change - from this process or,
+	// for SQLStore/RedisStore, from any other instance sharing the same
+	// backend. The channel is closed when ctx is cancelled. Callers
+	// typically wire it straight into a Service.Reload.
+	Watch(ctx context.Context) (<-chan []*models.Policy, error)
+}
+
+// var _ documents, at compile time, that Storage satisfies PolicyStore.
+var _ PolicyStore = (*Storage)(nil)
+
+// WatchAndReload starts store.Watch and forwards every emitted policy set
+// to reload (typically evaluation.Service.Reload) until ctx is cancelled,
+// so the evaluation engine stays in sync with whichever PolicyStore backend
+// is configured - including SQLStore and RedisStore, where the change may
+// have come from another gateway instance entirely.
+func WatchAndReload(ctx context.Context, store PolicyStore, reload func([]*models.Policy)) error {
+	changes, err := store.Watch(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for policies := range changes {
+			reload(policies)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// templateFuncs is a small, safe subset of sprig-style helpers available to
+// a PolicyTemplate's RulesTemplate body. Kept intentionally minimal (no env
+// or file access) since templates render into policy_rules, which decide
+// allow/deny outcomes.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"default": func(def, val interface{}) interface{} {
+		if val == nil || val == "" {
+			return def
+		}
+		return val
+	},
+	"quote": func(v interface{}) string { return fmt.Sprintf("%q", fmt.Sprint(v)) },
+}
+
+func (s *UnifiedStorage) loadTemplateFile(path string) (*models.PolicyTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tmpl models.PolicyTemplate
+	if err := yaml.Unmarshal(data, &tmpl); err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
+
+func (s *UnifiedStorage) saveTemplateFile(tmpl *models.PolicyTemplate) error {
+	filename := filepath.Join(s.templateDir, tmpl.TemplateID+".yaml")
+	data, err := yaml.Marshal(tmpl)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// GetTemplates returns every registered policy template
+func (s *UnifiedStorage) GetTemplates() []*models.PolicyTemplate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*models.PolicyTemplate, 0, len(s.templates))
+	for _, t := range s.templates {
+		result = append(result, t)
+	}
+	return result
+}
+
+// GetTemplateByID retrieves a policy template by ID
+func (s *UnifiedStorage) GetTemplateByID(id string) (*models.PolicyTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tmpl, ok := s.templates[id]
+	if !ok {
+		return nil, fmt.Errorf("template not found: %s", id)
+	}
+	return tmpl, nil
+}
+
+// CreateTemplate registers a new policy template
+func (s *UnifiedStorage) CreateTemplate(req *models.PolicyTemplateCreateRequest) (*models.PolicyTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range s.templates {
+		if strings.EqualFold(t.TemplateCode, req.TemplateCode) {
+			return nil, fmt.Errorf("template with code '%s' already exists", req.TemplateCode)
+		}
+	}
+
+	now := time.Now()
+	tmpl := &models.PolicyTemplate{
+		TemplateID:    uuid.New().String(),
+		TemplateCode:  req.TemplateCode,
+		Name:          req.Name,
+		Description:   req.Description,
+		Version:       1,
+		Parameters:    req.Parameters,
+		RulesTemplate: req.RulesTemplate,
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+
+	if err := s.saveTemplateFile(tmpl); err != nil {
+		return nil, fmt.Errorf("failed to save template: %w", err)
+	}
+
+	s.templates[tmpl.TemplateID] = tmpl
+	return tmpl, nil
+}
+
+// Instantiate renders a template with the supplied params and creates a
+// concrete policy from the result, the convenience shape used by
+// POST /templates/{id}/instantiate.
+func (s *UnifiedStorage) Instantiate(templateID string, req *models.PolicyTemplateInstantiateRequest, author string) (*models.UnifiedPolicy, error) {
+	return s.Create(&models.UnifiedPolicyCreateRequest{
+		PolicyCode:     req.PolicyCode,
+		Name:           req.Name,
+		Status:         req.Status,
+		Priority:       req.Priority,
+		OwnerID:        req.OwnerID,
+		OrgID:          req.OrgID,
+		Resources:      req.Resources,
+		Scopes:         req.Scopes,
+		TemplateRef:    templateID,
+		TemplateParams: req.Params,
+	}, author)
+}
+
+// Rollout re-renders every policy bound to a template using each policy's
+// originally-supplied params, and persists the result with a bumped
+// Version. Used after editing a template's RulesTemplate so dependent
+// policies pick up the change.
+func (s *UnifiedStorage) Rollout(templateID string) ([]*models.UnifiedPolicy, error) {
+	s.mu.Lock()
+
+	tmpl, ok := s.templates[templateID]
+	if !ok {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("template not found: %s", templateID)
+	}
+
+	updated := make([]*models.UnifiedPolicy, 0)
+	for _, p := range s.policies {
+		if p.TemplateBinding == nil || p.TemplateBinding.TemplateID != templateID {
+			continue
+		}
+
+		rules, _, err := renderTemplate(tmpl, p.TemplateBinding.Params)
+		if err != nil {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("failed to re-render policy %s: %w", p.PolicyID, err)
+		}
+
+		p.PolicyRules = rules
+		p.TemplateBinding.TemplateVersion = tmpl.Version
+		p.Version++
+		now := time.Now()
+		p.UpdatedAt = &now
+
+		if err := s.savePolicyFile(p); err != nil {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("failed to save policy %s: %w", p.PolicyID, err)
+		}
+		s.recordHistory(p, "rollout", "")
+		updated = append(updated, p)
+	}
+	s.mu.Unlock()
+
+	for _, p := range updated {
+		s.notifyPeers(p.PolicyID, p.Version, "rollout")
+	}
+
+	return updated, nil
+}
+
+// renderTemplate validates supplied params against a template's parameter
+// schema (filling in defaults, enforcing required fields and types), then
+// renders RulesTemplate with them and unmarshals the result into policy
+// rules. It returns the merged params alongside the rules so callers can
+// record them in a PolicyTemplateBinding.
+func renderTemplate(tmpl *models.PolicyTemplate, params map[string]interface{}) ([]models.PolicyRuleDSL, map[string]interface{}, error) {
+	merged, err := mergeTemplateParams(tmpl.Parameters, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t, err := template.New(tmpl.TemplateCode).Funcs(templateFuncs).Parse(tmpl.RulesTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid rules template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, merged); err != nil {
+		return nil, nil, fmt.Errorf("failed to render rules template: %w", err)
+	}
+
+	var rules []models.PolicyRuleDSL
+	if err := yaml.Unmarshal(buf.Bytes(), &rules); err != nil {
+		return nil, nil, fmt.Errorf("rendered rules are not valid policy_rules YAML: %w", err)
+	}
+
+	return rules, merged, nil
+}
+
+// mergeTemplateParams fills in declared defaults, enforces required
+// parameters, and type-checks supplied values against the schema.
+func mergeTemplateParams(schema []models.TemplateParameter, supplied map[string]interface{}) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(schema))
+	for _, p := range schema {
+		val, ok := supplied[p.Name]
+		if !ok || val == nil {
+			if p.Required && p.Default == nil {
+				return nil, fmt.Errorf("missing required template parameter: %s", p.Name)
+			}
+			val = p.Default
+		}
+		if val != nil {
+			if err := checkParamType(p, val); err != nil {
+				return nil, err
+			}
+		}
+		merged[p.Name] = val
+	}
+	return merged, nil
+}
+
+func checkParamType(p models.TemplateParameter, val interface{}) error {
+	switch p.Type {
+	case models.TemplateParamString:
+		if _, ok := val.(string); !ok {
+			return fmt.Errorf("parameter %q must be a string", p.Name)
+		}
+	case models.TemplateParamBool:
+		if _, ok := val.(bool); !ok {
+			return fmt.Errorf("parameter %q must be a bool", p.Name)
+		}
+	case models.TemplateParamInt:
+		switch v := val.(type) {
+		case int, int64:
+		case float64:
+			if v != float64(int64(v)) {
+				return fmt.Errorf("parameter %q must be an int", p.Name)
+			}
+		default:
+			return fmt.Errorf("parameter %q must be an int", p.Name)
+		}
+	case models.TemplateParamFloat:
+		switch val.(type) {
+		case float64, float32, int, int64:
+		default:
+			return fmt.Errorf("parameter %q must be a number", p.Name)
+		}
+	default:
+		return fmt.Errorf("unknown parameter type %q for parameter %q", p.Type, p.Name)
+	}
+	return nil
+}
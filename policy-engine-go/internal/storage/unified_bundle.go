@@ -0,0 +1,251 @@
+package storage
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const bundleVersion = 1
+
+// ExportBundle builds a UnifiedPolicyBundle of every policy matching every
+// filter in filters (an AND of all of them, no filters meaning every
+// policy), for POST /unified/replication/export and for a
+// unifiedreplication.Manager pushing to a target. When signing is
+// configured via SetSigning, the bundle's Manifest is an Ed25519 signature
+// over its Policies.
+func (s *UnifiedStorage) ExportBundle(filters []models.UnifiedBundleFilter) (*models.UnifiedPolicyBundle, error) {
+	s.mu.RLock()
+	policies := make([]models.UnifiedPolicy, 0, len(s.policies))
+	for _, p := range s.policies {
+		if matchesBundleFilters(p, filters) {
+			policies = append(policies, *p)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(policies, func(i, j int) bool { return policies[i].PolicyCode < policies[j].PolicyCode })
+
+	bundle := &models.UnifiedPolicyBundle{
+		BundleVersion: bundleVersion,
+		GeneratedAt:   time.Now(),
+		Policies:      policies,
+	}
+
+	if s.signingKey != nil {
+		unsigned, err := json.Marshal(bundle.Policies)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal bundle for signing: %w", err)
+		}
+		bundle.Manifest = hex.EncodeToString(ed25519.Sign(s.signingKey, unsigned))
+	}
+
+	return bundle, nil
+}
+
+// UnifiedImportResult tallies what ImportBundle did with each policy in a
+// bundle, for the POST /unified/replication/import response.
+type UnifiedImportResult struct {
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Skipped int      `json:"skipped"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// ImportBundle reconciles bundle's policies against this storage's, matched
+// by PolicyCode, applying mode to each. A bundle whose Manifest fails
+// signature verification is refused when s.enforceSignatures is set; a
+// missing or invalid Manifest is otherwise only logged. With no signing key
+// configured (see SetSigning), the Manifest is not checked at all.
+func (s *UnifiedStorage) ImportBundle(bundle *models.UnifiedPolicyBundle, mode models.UnifiedPolicyImportMode) (*UnifiedImportResult, error) {
+	if err := s.verifyBundleSignature(bundle); err != nil {
+		return nil, err
+	}
+
+	result := &UnifiedImportResult{}
+	for i := range bundle.Policies {
+		incoming := bundle.Policies[i]
+
+		existing, err := s.GetByCode(incoming.PolicyCode)
+		if err != nil {
+			if createErr := s.createFromBundle(&incoming); createErr != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", incoming.PolicyCode, createErr))
+				continue
+			}
+			result.Created++
+			continue
+		}
+
+		if mode == models.UnifiedImportCreateOnly {
+			result.Skipped++
+			continue
+		}
+
+		if err := s.updateFromBundle(existing.PolicyID, &incoming, mode == models.UnifiedImportReplace); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", incoming.PolicyCode, err))
+			continue
+		}
+		result.Updated++
+	}
+
+	return result, nil
+}
+
+func (s *UnifiedStorage) verifyBundleSignature(bundle *models.UnifiedPolicyBundle) error {
+	if s.signingKey == nil {
+		return nil
+	}
+
+	unsigned, err := json.Marshal(bundle.Policies)
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle for verification: %w", err)
+	}
+
+	valid := false
+	if bundle.Manifest != "" {
+		if sigBytes, err := hex.DecodeString(bundle.Manifest); err == nil {
+			valid = ed25519.Verify(s.signingKey.Public().(ed25519.PublicKey), unsigned, sigBytes)
+		}
+	}
+
+	if !valid {
+		if s.enforceSignatures {
+			return fmt.Errorf("bundle failed manifest signature verification")
+		}
+		log.Warn("Bundle manifest missing or invalid; importing anyway (ENFORCE_SIGNATURES=false)")
+	}
+	return nil
+}
+
+// createFromBundle creates a new local policy from a bundle entry, assigning
+// it a fresh PolicyID the way Create does (PolicyID is local-only; the
+// portable identity across clusters is PolicyCode).
+func (s *UnifiedStorage) createFromBundle(incoming *models.UnifiedPolicy) error {
+	s.mu.Lock()
+
+	now := time.Now()
+	policy := *incoming
+	policy.PolicyID = uuid.New().String()
+	policy.Version = 1
+	policy.CreatedAt = &now
+	policy.UpdatedAt = &now
+	for i := range policy.Resources {
+		policy.Resources[i].PolicyID = policy.PolicyID
+	}
+	for i := range policy.Scopes {
+		policy.Scopes[i].PolicyID = policy.PolicyID
+	}
+
+	if err := s.savePolicyFile(&policy); err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("failed to save policy: %w", err)
+	}
+	s.policies[policy.PolicyID] = &policy
+	s.indexPolicy(&policy)
+	s.mu.Unlock()
+
+	s.notifyPeers(policy.PolicyID, policy.Version, "create")
+	return nil
+}
+
+// updateFromBundle overwrites an existing local policy's rules/metadata with
+// incoming's. replaceBindings additionally overwrites resource/scope
+// bindings and subscriptions (UnifiedImportReplace); otherwise they're left
+// untouched (UnifiedImportUpsert).
+func (s *UnifiedStorage) updateFromBundle(id string, incoming *models.UnifiedPolicy, replaceBindings bool) error {
+	s.mu.Lock()
+
+	policy, ok := s.policies[id]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("policy not found: %s", id)
+	}
+
+	oldPolicy := *policy
+	policy.Name = incoming.Name
+	policy.Description = incoming.Description
+	policy.PolicyRules = incoming.PolicyRules
+	policy.RulesFormat = incoming.RulesFormat
+	policy.Status = incoming.Status
+	policy.Priority = incoming.Priority
+	policy.EffectiveFrom = incoming.EffectiveFrom
+	policy.EffectiveTo = incoming.EffectiveTo
+	policy.Schedule = incoming.Schedule
+	policy.Annotations = incoming.Annotations
+
+	if replaceBindings {
+		policy.Resources = make([]models.PolicyResource, len(incoming.Resources))
+		copy(policy.Resources, incoming.Resources)
+		for i := range policy.Resources {
+			policy.Resources[i].PolicyID = policy.PolicyID
+		}
+		policy.Scopes = make([]models.PolicyPrincipalScope, len(incoming.Scopes))
+		copy(policy.Scopes, incoming.Scopes)
+		for i := range policy.Scopes {
+			policy.Scopes[i].PolicyID = policy.PolicyID
+		}
+		policy.Subscriptions = incoming.Subscriptions
+		s.reindexPolicy(&oldPolicy, policy)
+	}
+
+	policy.Version++
+	now := time.Now()
+	policy.UpdatedAt = &now
+
+	if err := s.savePolicyFile(policy); err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("failed to save policy: %w", err)
+	}
+	version := policy.Version
+	s.mu.Unlock()
+
+	s.notifyPeers(id, version, "update")
+	return nil
+}
+
+// matchesBundleFilters reports whether policy satisfies every filter in
+// filters (an AND of all of them); no filters matches every policy.
+func matchesBundleFilters(policy *models.UnifiedPolicy, filters []models.UnifiedBundleFilter) bool {
+	for _, f := range filters {
+		if !matchesBundleFilter(policy, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesBundleFilter(policy *models.UnifiedPolicy, f models.UnifiedBundleFilter) bool {
+	switch f.Type {
+	case models.UnifiedBundleFilterPolicyCode:
+		return globMatch(f.Pattern, policy.PolicyCode)
+	case models.UnifiedBundleFilterResourceType:
+		for _, r := range policy.Resources {
+			if globMatch(f.Pattern, string(r.ResourceType)) {
+				return true
+			}
+		}
+		return false
+	case models.UnifiedBundleFilterTag:
+		for _, tag := range policy.Annotations {
+			if globMatch(f.Pattern, tag) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func globMatch(pattern, value string) bool {
+	ok, err := filepath.Match(pattern, value)
+	return err == nil && ok
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// diffUnifiedPolicy compares two UnifiedPolicy snapshots field by field,
+// reporting PolicyRules/Resources/Scopes as add/remove/replace ops keyed by
+// RuleID / (ResourceType,ResourceID) / (PrincipalType,PrincipalID) so a
+// reorder alone doesn't show up as spurious churn, for
+// GET /unified/policies/:id/revisions/:a/diff/:b.
+func diffUnifiedPolicy(a, b *models.UnifiedPolicy) []models.UnifiedPolicyDiffOp {
+	var ops []models.UnifiedPolicyDiffOp
+
+	diffScalar(&ops, "/policy_code", a.PolicyCode, b.PolicyCode)
+	diffScalar(&ops, "/name", a.Name, b.Name)
+	diffScalar(&ops, "/description", a.Description, b.Description)
+	diffScalar(&ops, "/status", string(a.Status), string(b.Status))
+	diffScalar(&ops, "/priority", a.Priority, b.Priority)
+	diffScalar(&ops, "/rules_format", string(a.RulesFormat), string(b.RulesFormat))
+	diffScalar(&ops, "/owner_id", a.OwnerID, b.OwnerID)
+	diffScalar(&ops, "/org_id", a.OrgID, b.OrgID)
+	if !reflect.DeepEqual(a.EffectiveFrom, b.EffectiveFrom) {
+		ops = append(ops, models.UnifiedPolicyDiffOp{Op: "replace", Path: "/effective_from", Value: b.EffectiveFrom})
+	}
+	if !reflect.DeepEqual(a.EffectiveTo, b.EffectiveTo) {
+		ops = append(ops, models.UnifiedPolicyDiffOp{Op: "replace", Path: "/effective_to", Value: b.EffectiveTo})
+	}
+	if !reflect.DeepEqual(a.Schedule, b.Schedule) {
+		ops = append(ops, models.UnifiedPolicyDiffOp{Op: "replace", Path: "/schedule", Value: b.Schedule})
+	}
+	if !reflect.DeepEqual(a.Annotations, b.Annotations) {
+		ops = append(ops, models.UnifiedPolicyDiffOp{Op: "replace", Path: "/annotations", Value: b.Annotations})
+	}
+
+	diffRules(&ops, a.PolicyRules, b.PolicyRules)
+	diffResources(&ops, a.Resources, b.Resources)
+	diffScopes(&ops, a.Scopes, b.Scopes)
+
+	return ops
+}
+
+func diffScalar(ops *[]models.UnifiedPolicyDiffOp, path string, a, b interface{}) {
+	if a != b {
+		*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "replace", Path: path, Value: b})
+	}
+}
+
+func diffRules(ops *[]models.UnifiedPolicyDiffOp, a, b []models.PolicyRuleDSL) {
+	before := make(map[string]models.PolicyRuleDSL, len(a))
+	for _, r := range a {
+		before[r.RuleID] = r
+	}
+	after := make(map[string]models.PolicyRuleDSL, len(b))
+	for _, r := range b {
+		after[r.RuleID] = r
+	}
+
+	for id, r := range after {
+		if old, ok := before[id]; !ok {
+			*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "add", Path: "/policy_rules/" + id, Value: r})
+		} else if !reflect.DeepEqual(old, r) {
+			*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "replace", Path: "/policy_rules/" + id, Value: r})
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "remove", Path: "/policy_rules/" + id})
+		}
+	}
+}
+
+func diffResources(ops *[]models.UnifiedPolicyDiffOp, a, b []models.PolicyResource) {
+	before := make(map[string]models.PolicyResource, len(a))
+	for _, r := range a {
+		before[makeResourceKey(r.ResourceType, r.ResourceID)] = r
+	}
+	after := make(map[string]models.PolicyResource, len(b))
+	for _, r := range b {
+		after[makeResourceKey(r.ResourceType, r.ResourceID)] = r
+	}
+
+	for key, r := range after {
+		if old, ok := before[key]; !ok {
+			*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "add", Path: "/resources/" + key, Value: r})
+		} else if !reflect.DeepEqual(old, r) {
+			*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "replace", Path: "/resources/" + key, Value: r})
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "remove", Path: "/resources/" + key})
+		}
+	}
+}
+
+func diffScopes(ops *[]models.UnifiedPolicyDiffOp, a, b []models.PolicyPrincipalScope) {
+	before := make(map[string]models.PolicyPrincipalScope, len(a))
+	for _, sc := range a {
+		before[scopeKey(sc)] = sc
+	}
+	after := make(map[string]models.PolicyPrincipalScope, len(b))
+	for _, sc := range b {
+		after[scopeKey(sc)] = sc
+	}
+
+	for key, sc := range after {
+		if old, ok := before[key]; !ok {
+			*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "add", Path: "/scopes/" + key, Value: sc})
+		} else if !reflect.DeepEqual(old, sc) {
+			*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "replace", Path: "/scopes/" + key, Value: sc})
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			*ops = append(*ops, models.UnifiedPolicyDiffOp{Op: "remove", Path: "/scopes/" + key})
+		}
+	}
+}
+
+func scopeKey(sc models.PolicyPrincipalScope) string {
+	return fmt.Sprintf("%s:%s", sc.PrincipalType, sc.PrincipalID)
+}
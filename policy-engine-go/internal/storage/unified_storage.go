@@ -1,6 +1,9 @@
 package storage
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,24 +11,207 @@ import (
 	"sync"
 	"time"
 
+	"github.com/datacline/policy-engine/internal/cluster"
+	"github.com/datacline/policy-engine/internal/engine"
+	regoengine "github.com/datacline/policy-engine/internal/engine/rego"
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/notify"
+	"github.com/datacline/policy-engine/internal/policyformat"
+	"github.com/datacline/policy-engine/internal/unifiedhistory"
 	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
 
+// ResourceLabelResolver resolves the current labels for a governed resource,
+// so label-selector PolicyResource bindings can be matched against live
+// metadata instead of a label snapshot baked into the policy. Typically
+// implemented by the gateway proxy against the Java MCP gateway's
+// server/tool metadata.
+type ResourceLabelResolver interface {
+	ResolveLabels(resourceType models.ResourceType, resourceID string) (map[string]string, error)
+}
+
 // UnifiedStorage handles persistence of unified policies
 type UnifiedStorage struct {
-	policyDir    string
-	resourceDir  string
-	policies     map[string]*models.UnifiedPolicy
-	resourceMap  map[string][]string // resourceKey -> []policyID
-	mu           sync.RWMutex
+	policyDir   string
+	resourceDir string
+	templateDir string
+	policies    map[string]*models.UnifiedPolicy
+	resourceMap map[string][]string // resourceKey -> []policyID, exact-match bindings only
+	templates   map[string]*models.PolicyTemplate
+	// labelSelectorPolicies holds the IDs of policies with at least one
+	// label-selector PolicyResource binding (ResourceLabels set). Expected
+	// to stay small, so GetByResource scans it directly rather than
+	// indexing by label.
+	labelSelectorPolicies []string
+	labelResolver         ResourceLabelResolver
+	// approvalQuorum maps an OrgID to the number of distinct approvers
+	// Activate requires before a draft policy in that org can go active.
+	// An OrgID with no entry falls back to defaultApprovalQuorum.
+	approvalQuorum    map[string]int
+	notifier          *cluster.PeerNotifier
+	dispatcher        *notify.Dispatcher // nil disables webhook notifications
+	signingKey        ed25519.PrivateKey // nil when bundle signing is disabled
+	enforceSignatures bool
+	history           *unifiedhistory.Store
+	mu                sync.RWMutex
+	// lastLoadErrors records the per-file errors from the most recent
+	// LoadAll, read by CheckReady so readiness reporting doesn't re-hit the
+	// filesystem on every probe.
+	lastLoadErrors []string
+}
+
+// SetLabelResolver attaches the resolver GetByResource uses to evaluate
+// label-selector policy bindings. Without one, such bindings are indexed
+// but never match.
+func (s *UnifiedStorage) SetLabelResolver(resolver ResourceLabelResolver) {
+	s.labelResolver = resolver
+}
+
+// SetNotifier attaches a PeerNotifier so subsequent mutations are pushed to
+// cluster peers after each successful local write.
+func (s *UnifiedStorage) SetNotifier(notifier *cluster.PeerNotifier) {
+	s.notifier = notifier
+}
+
+// SetDispatcher attaches a notify.Dispatcher so subsequent lifecycle
+// transitions and resource-binding changes push a UnifiedPolicyNotification
+// to every affected policy's Subscriptions.
+func (s *UnifiedStorage) SetDispatcher(dispatcher *notify.Dispatcher) {
+	s.dispatcher = dispatcher
+}
+
+// SetSigning enables signed export bundles: ExportBundle signs its Policies
+// with key, and ImportBundle verifies a bundle's Manifest against key,
+// refusing a mismatch when enforce is true. Mirrors Storage.SetSigning for
+// the main Policy subsystem's policy-file signatures.
+func (s *UnifiedStorage) SetSigning(key ed25519.PrivateKey, enforce bool) {
+	s.signingKey = key
+	s.enforceSignatures = enforce
+}
+
+// defaultApprovalQuorum is the number of distinct approvers Activate
+// requires for an org with no quorum configured via SetApprovalQuorum - the
+// two-person rule the approval workflow is named for. Raise it per org with
+// SetApprovalQuorum, e.g. via the /unified/orgs/:org_id/approval-quorum
+// admin endpoint.
+const defaultApprovalQuorum = 2
+
+// SetApprovalQuorum configures the number of distinct approvers Activate
+// requires before a draft policy owned by orgID can go active. n <= 0 is
+// rejected silently by requiredApprovals falling back to
+// defaultApprovalQuorum, the same "absent means default" convention
+// SetLabelResolver/SetSigning's zero-value fields use. Called from
+// unified.Handler.SetApprovalQuorum, which the
+// POST /unified/orgs/:org_id/approval-quorum admin endpoint reaches.
+func (s *UnifiedStorage) SetApprovalQuorum(orgID string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.approvalQuorum[orgID] = n
+}
+
+// requiredApprovals resolves orgID's configured quorum, or
+// defaultApprovalQuorum when none is configured or the configured value
+// isn't a usable quorum size.
+func (s *UnifiedStorage) requiredApprovals(orgID string) int {
+	if n, ok := s.approvalQuorum[orgID]; ok && n > 0 {
+		return n
+	}
+	return defaultApprovalQuorum
+}
+
+// notificationSnapshot builds the UnifiedPolicyNotification for policy's
+// current state (already mutated and saved by the caller) plus a copy of its
+// Subscriptions, while s.mu is still held - so dispatchNotification never
+// touches policy after the lock is released.
+func (s *UnifiedStorage) notificationSnapshot(policy *models.UnifiedPolicy, previousStatus models.PolicyStatus) ([]models.UnifiedNotificationSubscription, models.UnifiedPolicyNotification) {
+	enforceStatus, enforceReason := policy.EnforceState()
+	notification := models.UnifiedPolicyNotification{
+		PolicyID:       policy.PolicyID,
+		PolicyCode:     policy.PolicyCode,
+		PreviousStatus: previousStatus,
+		CurrentStatus:  policy.Status,
+		EnforceStatus:  enforceStatus,
+		EnforceReason:  enforceReason,
+		Revision:       policy.Version,
+		Timestamp:      time.Now(),
+	}
+	subs := make([]models.UnifiedNotificationSubscription, len(policy.Subscriptions))
+	copy(subs, policy.Subscriptions)
+	return subs, notification
+}
+
+// dispatchNotification pushes notification to every sub, best-effort. This
+// is a no-op without a configured dispatcher, same as notifyPeers without a
+// configured PeerNotifier.
+func (s *UnifiedStorage) dispatchNotification(subs []models.UnifiedNotificationSubscription, notification models.UnifiedPolicyNotification) {
+	if s.dispatcher == nil {
+		return
+	}
+	for _, sub := range subs {
+		s.dispatcher.Dispatch(models.NotificationSubscription{
+			ID:     sub.ID,
+			URL:    sub.NotificationDestination,
+			Secret: sub.Secret,
+		}, notification)
+	}
+}
+
+// notifyPeers pushes a policy-change event to configured peers, if any. This
+// is best-effort: the local write has already succeeded by the time this is
+// called, so peer sync failures are logged rather than returned.
+func (s *UnifiedStorage) notifyPeers(policyID string, version int, op string) {
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.Notify(cluster.PolicyEvent{PolicyID: policyID, Version: version, Op: op}); err != nil {
+		log.WithFields(log.Fields{"policy_id": policyID, "op": op}).WithError(err).Warn("Cluster peer sync failed")
+	}
+}
+
+// recordHistory appends a UnifiedPolicyRevision for policy's just-completed
+// mutation. Failures are logged rather than returned, so a history-write
+// error never fails the surrounding CRUD operation.
+func (s *UnifiedStorage) recordHistory(policy *models.UnifiedPolicy, op, author string) {
+	if _, err := s.history.Record(policy, op, author, s.signingKey); err != nil {
+		log.WithError(err).WithField("policy_id", policy.PolicyID).Warn("Failed to record unified policy revision history")
+	}
+}
+
+// ReloadOne reloads a single policy from its file on disk into the
+// in-memory cache, or removes it from the cache if the file no longer
+// exists. Used by the /internal/v1/policies/reload endpoint that peers call
+// in response to a PeerNotifier event.
+func (s *UnifiedStorage) ReloadOne(policyID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(s.policyDir, policyID+".yaml")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if old, ok := s.policies[policyID]; ok {
+			s.reindexPolicy(old, nil)
+			delete(s.policies, policyID)
+		}
+		return nil
+	}
+
+	policy, err := s.loadPolicyFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to reload policy file: %w", err)
+	}
+
+	old := s.policies[policy.PolicyID]
+	s.policies[policy.PolicyID] = policy
+	s.reindexPolicy(old, policy)
+	return nil
 }
 
 // NewUnifiedStorage creates a new unified storage instance
 func NewUnifiedStorage(baseDir string) (*UnifiedStorage, error) {
 	policyDir := filepath.Join(baseDir, "unified")
 	resourceDir := filepath.Join(baseDir, "resources")
+	templateDir := filepath.Join(baseDir, "templates")
 
 	// Create directories if they don't exist
 	if err := os.MkdirAll(policyDir, 0755); err != nil {
@@ -34,12 +220,19 @@ func NewUnifiedStorage(baseDir string) (*UnifiedStorage, error) {
 	if err := os.MkdirAll(resourceDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create resource directory: %w", err)
 	}
+	if err := os.MkdirAll(templateDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create template directory: %w", err)
+	}
 
 	s := &UnifiedStorage{
-		policyDir:   policyDir,
-		resourceDir: resourceDir,
-		policies:    make(map[string]*models.UnifiedPolicy),
-		resourceMap: make(map[string][]string),
+		policyDir:      policyDir,
+		resourceDir:    resourceDir,
+		templateDir:    templateDir,
+		policies:       make(map[string]*models.UnifiedPolicy),
+		resourceMap:    make(map[string][]string),
+		templates:      make(map[string]*models.PolicyTemplate),
+		history:        unifiedhistory.NewStore(baseDir),
+		approvalQuorum: make(map[string]int),
 	}
 
 	if err := s.LoadAll(); err != nil {
@@ -61,6 +254,9 @@ func (s *UnifiedStorage) LoadAll() error {
 
 	s.policies = make(map[string]*models.UnifiedPolicy)
 	s.resourceMap = make(map[string][]string)
+	s.templates = make(map[string]*models.PolicyTemplate)
+	s.labelSelectorPolicies = nil
+	var loadErrors []string
 
 	// Load policy files
 	files, err := filepath.Glob(filepath.Join(s.policyDir, "*.yaml"))
@@ -71,25 +267,142 @@ func (s *UnifiedStorage) LoadAll() error {
 	ymlFiles, _ := filepath.Glob(filepath.Join(s.policyDir, "*.yml"))
 	files = append(files, ymlFiles...)
 
+	jsonFiles, _ := filepath.Glob(filepath.Join(s.policyDir, "*.json"))
+	files = append(files, jsonFiles...)
+
 	for _, file := range files {
 		policy, err := s.loadPolicyFile(file)
 		if err != nil {
 			fmt.Printf("Warning: failed to load policy file %s: %v\n", file, err)
+			loadErrors = append(loadErrors, fmt.Sprintf("%s: %v", filepath.Base(file), err))
 			continue
 		}
+		if s.signingKey != nil {
+			if err := verifyApprovals(policy, s.signingKey.Public().(ed25519.PublicKey)); err != nil {
+				if s.enforceSignatures {
+					fmt.Printf("Warning: failed to load policy file %s: %v\n", file, err)
+					loadErrors = append(loadErrors, fmt.Sprintf("%s: %v", filepath.Base(file), err))
+					continue
+				}
+				log.WithField("policy_id", policy.PolicyID).WithError(err).Warn("Policy approval signature missing or invalid; loading anyway (ENFORCE_SIGNATURES=false)")
+			}
+		}
 		s.policies[policy.PolicyID] = policy
 		s.indexPolicy(policy)
 	}
 
+	// Load template files
+	templateFiles, err := filepath.Glob(filepath.Join(s.templateDir, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to glob template files: %w", err)
+	}
+	templateYmlFiles, _ := filepath.Glob(filepath.Join(s.templateDir, "*.yml"))
+	templateFiles = append(templateFiles, templateYmlFiles...)
+
+	for _, file := range templateFiles {
+		tmpl, err := s.loadTemplateFile(file)
+		if err != nil {
+			fmt.Printf("Warning: failed to load template file %s: %v\n", file, err)
+			loadErrors = append(loadErrors, fmt.Sprintf("%s: %v", filepath.Base(file), err))
+			continue
+		}
+		s.templates[tmpl.TemplateID] = tmpl
+	}
+
+	s.lastLoadErrors = loadErrors
+	return nil
+}
+
+// Name identifies this checker in health.Handler's readiness report.
+func (s *UnifiedStorage) Name() string { return "unified_storage" }
+
+// CheckReady reports whether every policy/template file on disk parsed
+// during the most recent LoadAll, without re-reading the filesystem on
+// every probe. A ReloadOne failure is not reflected here - it affects only
+// the one policy it targets, not the overall disk-load outcome LoadAll
+// established at startup.
+func (s *UnifiedStorage) CheckReady() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.lastLoadErrors) > 0 {
+		return fmt.Errorf("%d file(s) failed to load: %s", len(s.lastLoadErrors), strings.Join(s.lastLoadErrors, "; "))
+	}
+	return nil
+}
+
+// PolicyCompilerChecker is a health.ReadinessChecker verifying that every
+// Active policy's rule conditions still compile - e.g. a "cel" or
+// "semver_range" expression that parsed fine at save time but broke after a
+// models/engine upgrade. It wraps a UnifiedStorage rather than living in
+// internal/engine so it can walk the live policy set; engine.
+// CompileRuleExpression does the actual per-condition work.
+type PolicyCompilerChecker struct {
+	storage *UnifiedStorage
+}
+
+// NewPolicyCompilerChecker wraps storage as a health.ReadinessChecker.
+func NewPolicyCompilerChecker(storage *UnifiedStorage) *PolicyCompilerChecker {
+	return &PolicyCompilerChecker{storage: storage}
+}
+
+// Name identifies this checker in health.Handler's readiness report.
+func (c *PolicyCompilerChecker) Name() string { return "policy_compiler" }
+
+// CheckReady recompiles every condition of every Active policy's rules.
+// CompileRuleExpression is a parse-and-validate step, not a stateful one, so
+// rerunning it on demand (rather than caching a pass/fail from load time) is
+// cheap and always reflects the policy set's current state.
+func (c *PolicyCompilerChecker) CheckReady() error {
+	var failures []string
+	for _, p := range c.storage.GetAll() {
+		if p.Status != models.PolicyStatusActive {
+			continue
+		}
+		for i := range p.PolicyRules {
+			rule := &p.PolicyRules[i]
+			if rule.Conditions == nil {
+				continue
+			}
+			if err := compileConditionTree(rule.Conditions); err != nil {
+				failures = append(failures, fmt.Sprintf("%s/%s: %v", p.PolicyCode, rule.RuleID, err))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d rule(s) failed to compile: %s", len(failures), strings.Join(failures, "; "))
+	}
 	return nil
 }
 
+// compileConditionTree recurses through cond's All/Any composition down to
+// its leaves, compiling each one via engine.CompileRuleExpression.
+func compileConditionTree(cond *models.RuleCondition) error {
+	for i := range cond.All {
+		if err := compileConditionTree(&cond.All[i]); err != nil {
+			return err
+		}
+	}
+	for i := range cond.Any {
+		if err := compileConditionTree(&cond.Any[i]); err != nil {
+			return err
+		}
+	}
+	if len(cond.All) > 0 || len(cond.Any) > 0 {
+		return nil
+	}
+	return engine.CompileRuleExpression(cond)
+}
+
 func (s *UnifiedStorage) loadPolicyFile(path string) (*models.UnifiedPolicy, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return s.loadIAMPolicyFile(path, data)
+	}
+
 	var policy models.UnifiedPolicy
 	if err := yaml.Unmarshal(data, &policy); err != nil {
 		return nil, err
@@ -98,6 +411,40 @@ func (s *UnifiedStorage) loadPolicyFile(path string) (*models.UnifiedPolicy, err
 	return &policy, nil
 }
 
+// loadIAMPolicyFile parses a bare IAM-style policy document (no
+// UnifiedPolicy envelope) dropped directly into policyDir as a .json file,
+// and synthesizes a policy around it keyed by the file's base name so
+// reloads are idempotent.
+func (s *UnifiedStorage) loadIAMPolicyFile(path string, data []byte) (*models.UnifiedPolicy, error) {
+	doc, err := policyformat.ParseIAMDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IAM policy document: %w", err)
+	}
+
+	rules, err := policyformat.ToRules(doc)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := policyformat.ResolveResourceBindings(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	now := time.Now()
+	return &models.UnifiedPolicy{
+		PolicyID:    base,
+		PolicyCode:  base,
+		PolicyRules: rules,
+		RulesFormat: models.PolicyFormatIAMJSON,
+		Version:     1,
+		Status:      models.PolicyStatusActive,
+		CreatedAt:   &now,
+		UpdatedAt:   &now,
+		Resources:   resources,
+	}, nil
+}
+
 func (s *UnifiedStorage) savePolicyFile(policy *models.UnifiedPolicy) error {
 	filename := filepath.Join(s.policyDir, policy.PolicyID+".yaml")
 	data, err := yaml.Marshal(policy)
@@ -107,12 +454,22 @@ func (s *UnifiedStorage) savePolicyFile(policy *models.UnifiedPolicy) error {
 	return os.WriteFile(filename, data, 0644)
 }
 
-// indexPolicy updates the resource map for a policy
+// indexPolicy updates the resource map for a policy. Bindings with
+// ResourceLabels set are label selectors rather than exact matches, so they
+// go into labelSelectorPolicies instead of resourceMap.
 func (s *UnifiedStorage) indexPolicy(policy *models.UnifiedPolicy) {
+	hasSelector := false
 	for _, r := range policy.Resources {
+		if len(r.ResourceLabels) > 0 {
+			hasSelector = true
+			continue
+		}
 		key := makeResourceKey(r.ResourceType, r.ResourceID)
 		s.resourceMap[key] = append(s.resourceMap[key], policy.PolicyID)
 	}
+	if hasSelector {
+		s.labelSelectorPolicies = append(s.labelSelectorPolicies, policy.PolicyID)
+	}
 }
 
 // reindexPolicy removes old index entries and adds new ones
@@ -120,6 +477,9 @@ func (s *UnifiedStorage) reindexPolicy(oldPolicy, newPolicy *models.UnifiedPolic
 	// Remove old entries
 	if oldPolicy != nil {
 		for _, r := range oldPolicy.Resources {
+			if len(r.ResourceLabels) > 0 {
+				continue
+			}
 			key := makeResourceKey(r.ResourceType, r.ResourceID)
 			ids := s.resourceMap[key]
 			for i, id := range ids {
@@ -129,6 +489,12 @@ func (s *UnifiedStorage) reindexPolicy(oldPolicy, newPolicy *models.UnifiedPolic
 				}
 			}
 		}
+		for i, id := range s.labelSelectorPolicies {
+			if id == oldPolicy.PolicyID {
+				s.labelSelectorPolicies = append(s.labelSelectorPolicies[:i], s.labelSelectorPolicies[i+1:]...)
+				break
+			}
+		}
 	}
 	// Add new entries
 	if newPolicy != nil {
@@ -173,23 +539,48 @@ func (s *UnifiedStorage) GetByCode(code string) (*models.UnifiedPolicy, error) {
 	return nil, fmt.Errorf("policy not found with code: %s", code)
 }
 
-// GetByResource retrieves all policies bound to a specific resource
+// GetByResource retrieves all policies bound to a specific resource, either
+// directly by exact (type, id) or via a label-selector binding resolved
+// through the configured ResourceLabelResolver.
 func (s *UnifiedStorage) GetByResource(resourceType models.ResourceType, resourceID string) ([]*models.UnifiedPolicy, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	seen := make(map[string]bool)
+	result := make([]*models.UnifiedPolicy, 0)
+
 	key := makeResourceKey(resourceType, resourceID)
-	policyIDs, ok := s.resourceMap[key]
-	if !ok {
-		return []*models.UnifiedPolicy{}, nil
+	for _, id := range s.resourceMap[key] {
+		if policy, ok := s.policies[id]; ok && !seen[id] {
+			seen[id] = true
+			result = append(result, policy)
+		}
 	}
 
-	result := make([]*models.UnifiedPolicy, 0, len(policyIDs))
-	for _, id := range policyIDs {
-		if policy, ok := s.policies[id]; ok {
-			result = append(result, policy)
+	if len(s.labelSelectorPolicies) > 0 && s.labelResolver != nil {
+		labels, err := s.labelResolver.ResolveLabels(resourceType, resourceID)
+		if err != nil {
+			log.WithFields(log.Fields{"resource_type": resourceType, "resource_id": resourceID}).WithError(err).Warn("Failed to resolve resource labels for policy lookup")
+		} else if len(labels) > 0 {
+			for _, id := range s.labelSelectorPolicies {
+				if seen[id] {
+					continue
+				}
+				policy, ok := s.policies[id]
+				if !ok {
+					continue
+				}
+				for _, r := range policy.Resources {
+					if r.ResourceType == resourceType && len(r.ResourceLabels) > 0 && models.IsLabelMapSubset(r.ResourceLabels, labels) {
+						seen[id] = true
+						result = append(result, policy)
+						break
+					}
+				}
+			}
 		}
 	}
+
 	return result, nil
 }
 
@@ -202,7 +593,7 @@ func (s *UnifiedStorage) GetActiveByResource(resourceType models.ResourceType, r
 
 	result := make([]*models.UnifiedPolicy, 0)
 	for _, p := range policies {
-		if p.IsActive() {
+		if p.IsActive() && p.IsScheduledEffective() {
 			result = append(result, p)
 		}
 	}
@@ -216,7 +607,7 @@ func (s *UnifiedStorage) GetGlobalPolicies() []*models.UnifiedPolicy {
 
 	result := make([]*models.UnifiedPolicy, 0)
 	for _, p := range s.policies {
-		if p.IsGlobal() && p.IsActive() {
+		if p.IsGlobal() && p.IsActive() && p.IsScheduledEffective() {
 			result = append(result, p)
 		}
 	}
@@ -252,43 +643,84 @@ func (s *UnifiedStorage) matchesFilter(p *models.UnifiedPolicy, filter *models.U
 		return false
 	}
 	if filter.ResourceType != "" && filter.ResourceID != "" {
-		if !p.HasResource(filter.ResourceType, filter.ResourceID) {
+		if !p.HasResource(filter.ResourceType, filter.ResourceID, nil) {
 			return false
 		}
 	}
+	if len(filter.Selector) > 0 && !p.MatchesSelector(filter.Selector) {
+		return false
+	}
+	if !p.MatchesLabelSelector(filter.LabelSelector) {
+		return false
+	}
 	return true
 }
 
 // Create creates a new policy
-func (s *UnifiedStorage) Create(req *models.UnifiedPolicyCreateRequest) (*models.UnifiedPolicy, error) {
+func (s *UnifiedStorage) Create(req *models.UnifiedPolicyCreateRequest, author string) (*models.UnifiedPolicy, error) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 
 	// Check for duplicate policy_code
 	for _, p := range s.policies {
 		if strings.EqualFold(p.PolicyCode, req.PolicyCode) {
+			s.mu.Unlock()
 			return nil, fmt.Errorf("policy with code '%s' already exists", req.PolicyCode)
 		}
 	}
 
+	// Resolve and render a PolicyTemplate if one was referenced, overriding
+	// PolicyRules with the rendered result and recording a back-reference
+	// so a later template Rollout can find this policy again.
+	rules := req.PolicyRules
+	var binding *models.PolicyTemplateBinding
+	if req.TemplateRef != "" {
+		tmpl, ok := s.templates[req.TemplateRef]
+		if !ok {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("template not found: %s", req.TemplateRef)
+		}
+		rendered, merged, err := renderTemplate(tmpl, req.TemplateParams)
+		if err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		rules = rendered
+		binding = &models.PolicyTemplateBinding{
+			TemplateID:      tmpl.TemplateID,
+			TemplateVersion: tmpl.Version,
+			Params:          merged,
+		}
+	}
+
+	rulesFormat := req.RulesFormat
+	if rulesFormat == "" {
+		rulesFormat = models.PolicyFormatYAMLNative
+	}
+
 	now := time.Now()
 	policy := &models.UnifiedPolicy{
-		PolicyID:      uuid.New().String(),
-		PolicyCode:    req.PolicyCode,
-		Name:          req.Name,
-		Description:   req.Description,
-		PolicyRules:   req.PolicyRules,
-		Version:       1,
-		Status:        req.Status,
-		Priority:      req.Priority,
-		EffectiveFrom: req.EffectiveFrom,
-		EffectiveTo:   req.EffectiveTo,
-		OwnerID:       req.OwnerID,
-		OrgID:         req.OrgID,
-		CreatedAt:     &now,
-		UpdatedAt:     &now,
-		Resources:     make([]models.PolicyResource, 0),
-		Scopes:        make([]models.PolicyPrincipalScope, 0),
+		PolicyID:        uuid.New().String(),
+		PolicyCode:      req.PolicyCode,
+		Name:            req.Name,
+		Description:     req.Description,
+		PolicyLanguage:  req.PolicyLanguage,
+		PolicyRules:     rules,
+		RulesFormat:     rulesFormat,
+		RegoSource:      req.RegoSource,
+		Version:         1,
+		Status:          req.Status,
+		Priority:        req.Priority,
+		EffectiveFrom:   req.EffectiveFrom,
+		EffectiveTo:     req.EffectiveTo,
+		Schedule:        req.Schedule,
+		OwnerID:         req.OwnerID,
+		OrgID:           req.OrgID,
+		CreatedAt:       &now,
+		UpdatedAt:       &now,
+		Resources:       make([]models.PolicyResource, 0),
+		Scopes:          make([]models.PolicyPrincipalScope, 0),
+		TemplateBinding: binding,
+		Annotations:     req.Annotations,
 	}
 
 	// Set default status if not provided
@@ -299,40 +731,54 @@ func (s *UnifiedStorage) Create(req *models.UnifiedPolicyCreateRequest) (*models
 	// Add resources with policy ID
 	for _, r := range req.Resources {
 		policy.Resources = append(policy.Resources, models.PolicyResource{
-			PolicyID:     policy.PolicyID,
-			ResourceType: r.ResourceType,
-			ResourceID:   r.ResourceID,
+			PolicyID:       policy.PolicyID,
+			ResourceType:   r.ResourceType,
+			ResourceID:     r.ResourceID,
+			ResourceLabels: r.ResourceLabels,
 		})
 	}
 
 	// Add scopes with policy ID
 	for _, sc := range req.Scopes {
 		policy.Scopes = append(policy.Scopes, models.PolicyPrincipalScope{
-			PolicyID:      policy.PolicyID,
-			PrincipalType: sc.PrincipalType,
-			PrincipalID:   sc.PrincipalID,
+			PolicyID:        policy.PolicyID,
+			PrincipalType:   sc.PrincipalType,
+			PrincipalID:     sc.PrincipalID,
+			PrincipalLabels: sc.PrincipalLabels,
 		})
 	}
 
 	// Save to disk
 	if err := s.savePolicyFile(policy); err != nil {
+		s.mu.Unlock()
 		return nil, fmt.Errorf("failed to save policy: %w", err)
 	}
 
 	// Update in-memory cache
 	s.policies[policy.PolicyID] = policy
 	s.indexPolicy(policy)
+	s.recordHistory(policy, "create", author)
+	s.mu.Unlock()
+
+	s.notifyPeers(policy.PolicyID, policy.Version, "create")
 
 	return policy, nil
 }
 
 // Update updates an existing policy
-func (s *UnifiedStorage) Update(id string, req *models.UnifiedPolicyUpdateRequest) (*models.UnifiedPolicy, error) {
+func (s *UnifiedStorage) Update(id string, req *models.UnifiedPolicyUpdateRequest, author string) (*models.UnifiedPolicy, error) {
+	return s.applyUpdate(id, req, author, "update")
+}
+
+// applyUpdate is Update's shared implementation, parameterized by the op
+// recorded in history so Rollback's restore is distinguishable from an
+// ordinary edit in the revision trail.
+func (s *UnifiedStorage) applyUpdate(id string, req *models.UnifiedPolicyUpdateRequest, author, op string) (*models.UnifiedPolicy, error) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 
 	policy, ok := s.policies[id]
 	if !ok {
+		s.mu.Unlock()
 		return nil, fmt.Errorf("policy not found: %s", id)
 	}
 
@@ -340,6 +786,7 @@ func (s *UnifiedStorage) Update(id string, req *models.UnifiedPolicyUpdateReques
 	if req.PolicyCode != "" && req.PolicyCode != policy.PolicyCode {
 		for _, p := range s.policies {
 			if p.PolicyID != id && strings.EqualFold(p.PolicyCode, req.PolicyCode) {
+				s.mu.Unlock()
 				return nil, fmt.Errorf("policy with code '%s' already exists", req.PolicyCode)
 			}
 		}
@@ -353,9 +800,15 @@ func (s *UnifiedStorage) Update(id string, req *models.UnifiedPolicyUpdateReques
 	if req.Description != "" {
 		policy.Description = req.Description
 	}
+	if req.PolicyLanguage != "" {
+		policy.PolicyLanguage = req.PolicyLanguage
+	}
 	if req.PolicyRules != nil {
 		policy.PolicyRules = req.PolicyRules
 	}
+	if req.RegoSource != "" {
+		policy.RegoSource = req.RegoSource
+	}
 	if req.Status != "" {
 		policy.Status = req.Status
 	}
@@ -368,6 +821,12 @@ func (s *UnifiedStorage) Update(id string, req *models.UnifiedPolicyUpdateReques
 	if req.EffectiveTo != nil {
 		policy.EffectiveTo = req.EffectiveTo
 	}
+	if req.Schedule != nil {
+		policy.Schedule = req.Schedule
+	}
+	if req.Annotations != nil {
+		policy.Annotations = req.Annotations
+	}
 
 	// Update resources if provided
 	if req.Resources != nil {
@@ -375,9 +834,10 @@ func (s *UnifiedStorage) Update(id string, req *models.UnifiedPolicyUpdateReques
 		policy.Resources = make([]models.PolicyResource, 0)
 		for _, r := range req.Resources {
 			policy.Resources = append(policy.Resources, models.PolicyResource{
-				PolicyID:     policy.PolicyID,
-				ResourceType: r.ResourceType,
-				ResourceID:   r.ResourceID,
+				PolicyID:       policy.PolicyID,
+				ResourceType:   r.ResourceType,
+				ResourceID:     r.ResourceID,
+				ResourceLabels: r.ResourceLabels,
 			})
 		}
 		s.reindexPolicy(&oldPolicy, policy)
@@ -388,9 +848,10 @@ func (s *UnifiedStorage) Update(id string, req *models.UnifiedPolicyUpdateReques
 		policy.Scopes = make([]models.PolicyPrincipalScope, 0)
 		for _, sc := range req.Scopes {
 			policy.Scopes = append(policy.Scopes, models.PolicyPrincipalScope{
-				PolicyID:      policy.PolicyID,
-				PrincipalType: sc.PrincipalType,
-				PrincipalID:   sc.PrincipalID,
+				PolicyID:        policy.PolicyID,
+				PrincipalType:   sc.PrincipalType,
+				PrincipalID:     sc.PrincipalID,
+				PrincipalLabels: sc.PrincipalLabels,
 			})
 		}
 	}
@@ -402,8 +863,14 @@ func (s *UnifiedStorage) Update(id string, req *models.UnifiedPolicyUpdateReques
 
 	// Save to disk
 	if err := s.savePolicyFile(policy); err != nil {
+		s.mu.Unlock()
 		return nil, fmt.Errorf("failed to save policy: %w", err)
 	}
+	version := policy.Version
+	s.recordHistory(policy, op, author)
+	s.mu.Unlock()
+
+	s.notifyPeers(id, version, op)
 
 	return policy, nil
 }
@@ -411,10 +878,10 @@ func (s *UnifiedStorage) Update(id string, req *models.UnifiedPolicyUpdateReques
 // Delete removes a policy
 func (s *UnifiedStorage) Delete(id string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 
 	policy, ok := s.policies[id]
 	if !ok {
+		s.mu.Unlock()
 		return fmt.Errorf("policy not found: %s", id)
 	}
 
@@ -424,60 +891,104 @@ func (s *UnifiedStorage) Delete(id string) error {
 	// Delete file
 	filename := filepath.Join(s.policyDir, id+".yaml")
 	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		s.mu.Unlock()
 		return fmt.Errorf("failed to delete policy file: %w", err)
 	}
 
 	// Remove from cache
 	delete(s.policies, id)
+	regoengine.Purge(id)
+	engine.PurgeConditionExpressions(id)
+	version := policy.Version
+	s.mu.Unlock()
+
+	s.notifyPeers(id, version, "delete")
 
 	return nil
 }
 
-// Activate activates a policy
-func (s *UnifiedStorage) Activate(id string) error {
-	return s.setStatus(id, models.PolicyStatusActive)
+// Activate activates a policy. When the policy is currently a draft, this
+// first enforces the two-person-rule quorum: at least requiredApprovals(
+// policy.OrgID) distinct approvers must have a PolicyApproval recorded
+// against the policy's current Version, or activation is refused.
+func (s *UnifiedStorage) Activate(id, author string) error {
+	s.mu.RLock()
+	policy, ok := s.policies[id]
+	if !ok {
+		s.mu.RUnlock()
+		return fmt.Errorf("policy not found: %s", id)
+	}
+	if policy.Status == models.PolicyStatusDraft {
+		required := s.requiredApprovals(policy.OrgID)
+		approvers := make(map[string]bool)
+		for _, a := range policy.Approvals {
+			if a.Version == policy.Version {
+				approvers[a.ApproverID] = true
+			}
+		}
+		if len(approvers) < required {
+			s.mu.RUnlock()
+			return fmt.Errorf("policy %s requires %d approval(s) for version %d, has %d", id, required, policy.Version, len(approvers))
+		}
+	}
+	s.mu.RUnlock()
+
+	return s.setStatus(id, models.PolicyStatusActive, "activate", author)
 }
 
 // Suspend suspends a policy
-func (s *UnifiedStorage) Suspend(id string) error {
-	return s.setStatus(id, models.PolicyStatusSuspended)
+func (s *UnifiedStorage) Suspend(id, author string) error {
+	return s.setStatus(id, models.PolicyStatusSuspended, "suspend", author)
 }
 
 // Retire retires a policy
-func (s *UnifiedStorage) Retire(id string) error {
-	return s.setStatus(id, models.PolicyStatusRetired)
+func (s *UnifiedStorage) Retire(id, author string) error {
+	return s.setStatus(id, models.PolicyStatusRetired, "retire", author)
 }
 
-func (s *UnifiedStorage) setStatus(id string, status models.PolicyStatus) error {
+func (s *UnifiedStorage) setStatus(id string, status models.PolicyStatus, op, author string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 
 	policy, ok := s.policies[id]
 	if !ok {
+		s.mu.Unlock()
 		return fmt.Errorf("policy not found: %s", id)
 	}
 
+	previousStatus := policy.Status
 	policy.Status = status
 	policy.Version++
 	now := time.Now()
 	policy.UpdatedAt = &now
 
-	return s.savePolicyFile(policy)
+	if err := s.savePolicyFile(policy); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	version := policy.Version
+	subs, notification := s.notificationSnapshot(policy, previousStatus)
+	s.recordHistory(policy, op, author)
+	s.mu.Unlock()
+
+	s.notifyPeers(id, version, op)
+	s.dispatchNotification(subs, notification)
+	return nil
 }
 
 // AddResource adds a resource binding to a policy
-func (s *UnifiedStorage) AddResource(policyID string, resourceType models.ResourceType, resourceID string) error {
+func (s *UnifiedStorage) AddResource(policyID string, resourceType models.ResourceType, resourceID string, author string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 
 	policy, ok := s.policies[policyID]
 	if !ok {
+		s.mu.Unlock()
 		return fmt.Errorf("policy not found: %s", policyID)
 	}
 
 	// Check if already exists
 	for _, r := range policy.Resources {
 		if r.ResourceType == resourceType && r.ResourceID == resourceID {
+			s.mu.Unlock()
 			return nil // Already exists
 		}
 	}
@@ -491,19 +1002,31 @@ func (s *UnifiedStorage) AddResource(policyID string, resourceType models.Resour
 	key := makeResourceKey(resourceType, resourceID)
 	s.resourceMap[key] = append(s.resourceMap[key], policyID)
 
+	policy.Version++
 	now := time.Now()
 	policy.UpdatedAt = &now
 
-	return s.savePolicyFile(policy)
+	if err := s.savePolicyFile(policy); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	version := policy.Version
+	subs, notification := s.notificationSnapshot(policy, policy.Status)
+	s.recordHistory(policy, "add_resource", author)
+	s.mu.Unlock()
+
+	s.notifyPeers(policyID, version, "add_resource")
+	s.dispatchNotification(subs, notification)
+	return nil
 }
 
 // RemoveResource removes a resource binding from a policy
-func (s *UnifiedStorage) RemoveResource(policyID string, resourceType models.ResourceType, resourceID string) error {
+func (s *UnifiedStorage) RemoveResource(policyID string, resourceType models.ResourceType, resourceID string, author string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 
 	policy, ok := s.policies[policyID]
 	if !ok {
+		s.mu.Unlock()
 		return fmt.Errorf("policy not found: %s", policyID)
 	}
 
@@ -525,13 +1048,271 @@ func (s *UnifiedStorage) RemoveResource(policyID string, resourceType models.Res
 		}
 	}
 
+	policy.Version++
 	now := time.Now()
 	policy.UpdatedAt = &now
 
-	return s.savePolicyFile(policy)
+	if err := s.savePolicyFile(policy); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	version := policy.Version
+	subs, notification := s.notificationSnapshot(policy, policy.Status)
+	s.recordHistory(policy, "remove_resource", author)
+	s.mu.Unlock()
+
+	s.notifyPeers(policyID, version, "remove_resource")
+	s.dispatchNotification(subs, notification)
+	return nil
+}
+
+// History returns the recorded revision history for a policy, oldest first.
+func (s *UnifiedStorage) History(id string) ([]*models.UnifiedPolicyRevision, error) {
+	return s.history.History(id)
+}
+
+// GetRevision returns a single recorded revision for a policy.
+func (s *UnifiedStorage) GetRevision(id, revisionID string) (*models.UnifiedPolicyRevision, error) {
+	return s.history.GetRevision(id, revisionID)
+}
+
+// Diff reports the field-by-field changes between two recorded revisions of
+// a policy, from revision a to revision b.
+func (s *UnifiedStorage) Diff(id, revA, revB string) ([]models.UnifiedPolicyDiffOp, error) {
+	a, err := s.history.GetRevision(id, revA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.history.GetRevision(id, revB)
+	if err != nil {
+		return nil, err
+	}
+	if a.Snapshot == nil || b.Snapshot == nil {
+		return nil, fmt.Errorf("revision %s or %s has no stored snapshot to diff", revA, revB)
+	}
+	return diffUnifiedPolicy(a.Snapshot, b.Snapshot), nil
+}
+
+// Rollback restores policy id to the snapshot captured in revisionID,
+// saving it as a new "rollback" revision so the rollback itself remains
+// auditable and nothing is lost from history. It refuses to restore from a
+// revision whose signature doesn't verify when signing is enabled.
+func (s *UnifiedStorage) Rollback(id, revisionID, author string) (*models.UnifiedPolicy, error) {
+	rev, err := s.history.GetRevision(id, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if rev.Snapshot == nil {
+		return nil, fmt.Errorf("revision %s has no stored snapshot to roll back to", revisionID)
+	}
+
+	s.mu.RLock()
+	key := s.signingKey
+	enforce := s.enforceSignatures
+	s.mu.RUnlock()
+	if key != nil && !unifiedhistory.Verify(rev, key.Public().(ed25519.PublicKey)) {
+		if enforce {
+			return nil, fmt.Errorf("revision %s failed signature verification", revisionID)
+		}
+		log.WithField("revision", revisionID).Warn("Revision signature missing or invalid; rolling back anyway (ENFORCE_SIGNATURES=false)")
+	}
+
+	restored := rev.Snapshot
+	req := &models.UnifiedPolicyUpdateRequest{
+		PolicyCode:    restored.PolicyCode,
+		Name:          restored.Name,
+		Description:   restored.Description,
+		PolicyRules:   restored.PolicyRules,
+		Status:        restored.Status,
+		Priority:      restored.Priority,
+		EffectiveFrom: restored.EffectiveFrom,
+		EffectiveTo:   restored.EffectiveTo,
+		Schedule:      restored.Schedule,
+		Resources:     restored.Resources,
+		Scopes:        restored.Scopes,
+		Annotations:   restored.Annotations,
+	}
+	return s.applyUpdate(id, req, author, "rollback")
 }
 
 // GetResourcePolicies is an alias for GetByResource for clarity
 func (s *UnifiedStorage) GetResourcePolicies(resourceType models.ResourceType, resourceID string) ([]*models.UnifiedPolicy, error) {
 	return s.GetByResource(resourceType, resourceID)
 }
+
+// UpdateScheduleState persists the computed schedule state for a policy —
+// its next/last cron firing and whether "now" falls inside the window that
+// firing opened — as reported by a schedule.Engine tick. It does not
+// increment Version or notify cluster peers: this is derived state
+// recomputed independently by every node, not a user-initiated write.
+func (s *UnifiedStorage) UpdateScheduleState(policyID string, nextRun, lastRun *time.Time, effective bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, ok := s.policies[policyID]
+	if !ok {
+		return fmt.Errorf("policy not found: %s", policyID)
+	}
+	if policy.Schedule == nil {
+		return fmt.Errorf("policy has no schedule configured: %s", policyID)
+	}
+
+	policy.Schedule.NextRunAt = nextRun
+	policy.Schedule.LastRunAt = lastRun
+	policy.IsEffectiveNow = effective
+
+	return s.savePolicyFile(policy)
+}
+
+// AddSubscription registers a webhook destination on policyID, assigning it
+// an ID. Like AddResource, this does not bump policy.Version - a
+// subscription is delivery metadata, not part of the policy's evaluated
+// content.
+func (s *UnifiedStorage) AddSubscription(policyID string, sub models.UnifiedNotificationSubscription) (*models.UnifiedNotificationSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, ok := s.policies[policyID]
+	if !ok {
+		return nil, fmt.Errorf("policy not found: %s", policyID)
+	}
+
+	sub.ID = fmt.Sprintf("sub-%d", time.Now().UnixNano())
+	policy.Subscriptions = append(policy.Subscriptions, sub)
+
+	now := time.Now()
+	policy.UpdatedAt = &now
+
+	if err := s.savePolicyFile(policy); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// ListSubscriptions returns the webhook subscriptions registered on
+// policyID.
+func (s *UnifiedStorage) ListSubscriptions(policyID string) ([]models.UnifiedNotificationSubscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policy, ok := s.policies[policyID]
+	if !ok {
+		return nil, fmt.Errorf("policy not found: %s", policyID)
+	}
+	return policy.Subscriptions, nil
+}
+
+// approvalSigningPayload builds the canonical bytes a PolicyApproval.Signature
+// covers: policy's PolicyID, Version, PolicyRules, Resources, and Scopes,
+// JSON-marshaled for a deterministic field order.
+func approvalSigningPayload(policy *models.UnifiedPolicy) ([]byte, error) {
+	return json.Marshal(models.PolicyApprovalPayload{
+		PolicyID:    policy.PolicyID,
+		Version:     policy.Version,
+		PolicyRules: policy.PolicyRules,
+		Resources:   policy.Resources,
+		Scopes:      policy.Scopes,
+	})
+}
+
+// verifyApprovals checks every signed PolicyApproval recorded against
+// policy's current Version against pub, so a PolicyRules/Resources/Scopes
+// edit made directly to a file on disk - bypassing Update, which would bump
+// Version and age the approval out of this check on its own - is still
+// caught when it happens to leave Version unchanged. Approvals recorded
+// against an earlier Version are skipped: Activate's quorum count already
+// ignores them the same way, and this package keeps no historical snapshot
+// to verify an old approval's signature against. An unsigned approval
+// (Signature == "") always verifies, mirroring unifiedhistory.Verify's
+// treatment of unsigned revisions.
+func verifyApprovals(policy *models.UnifiedPolicy, pub ed25519.PublicKey) error {
+	for _, a := range policy.Approvals {
+		if a.Version != policy.Version || a.Signature == "" {
+			continue
+		}
+		sig, err := hex.DecodeString(a.Signature)
+		if err != nil {
+			return fmt.Errorf("approval by %s has malformed signature: %w", a.ApproverID, err)
+		}
+		payload, err := approvalSigningPayload(policy)
+		if err != nil {
+			return fmt.Errorf("failed to build approval signing payload: %w", err)
+		}
+		if !ed25519.Verify(pub, payload, sig) {
+			return fmt.Errorf("approval by %s failed signature verification", a.ApproverID)
+		}
+	}
+	return nil
+}
+
+// AddApproval records approverID's sign-off on policyID's current Version,
+// enforcing the two-person rule by rejecting self-approval (approverID ==
+// the policy's OwnerID). Like AddSubscription, this does not bump
+// policy.Version - an approval attests to the content at its current
+// Version rather than changing it.
+func (s *UnifiedStorage) AddApproval(policyID, approverID, comment string) (*models.PolicyApproval, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy, ok := s.policies[policyID]
+	if !ok {
+		return nil, fmt.Errorf("policy not found: %s", policyID)
+	}
+	if approverID == "" {
+		return nil, fmt.Errorf("approver_id is required")
+	}
+	if approverID == policy.OwnerID {
+		return nil, fmt.Errorf("policy owner %s cannot approve their own policy", approverID)
+	}
+
+	approval := models.PolicyApproval{
+		PolicyID:   policyID,
+		Version:    policy.Version,
+		ApproverID: approverID,
+		ApprovedAt: time.Now(),
+		Comment:    comment,
+	}
+	if s.signingKey != nil {
+		payload, err := approvalSigningPayload(policy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build approval signing payload: %w", err)
+		}
+		approval.Signature = hex.EncodeToString(ed25519.Sign(s.signingKey, payload))
+	}
+
+	policy.Approvals = append(policy.Approvals, approval)
+	now := time.Now()
+	policy.UpdatedAt = &now
+
+	if err := s.savePolicyFile(policy); err != nil {
+		return nil, err
+	}
+	return &approval, nil
+}
+
+// ListApprovals returns the approvals recorded on policyID, in the order
+// they were granted.
+func (s *UnifiedStorage) ListApprovals(policyID string) ([]models.PolicyApproval, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policy, ok := s.policies[policyID]
+	if !ok {
+		return nil, fmt.Errorf("policy not found: %s", policyID)
+	}
+	return policy.Approvals, nil
+}
+
+// GetScheduled returns every policy that carries a recurring Schedule.
+func (s *UnifiedStorage) GetScheduled() []*models.UnifiedPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*models.UnifiedPolicy, 0)
+	for _, p := range s.policies {
+		if p.Schedule != nil {
+			result = append(result, p)
+		}
+	}
+	return result
+}
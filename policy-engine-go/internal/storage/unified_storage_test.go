@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+func newTestDraftPolicy(t *testing.T, s *UnifiedStorage, orgID string) *models.UnifiedPolicy {
+	t.Helper()
+	policy, err := s.Create(&models.UnifiedPolicyCreateRequest{
+		PolicyCode: "test-policy-" + orgID,
+		Status:     models.PolicyStatusDraft,
+		OwnerID:    "owner",
+		OrgID:      orgID,
+	}, "author")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return policy
+}
+
+func TestActivateRequiresDefaultQuorumOfTwoApprovals(t *testing.T) {
+	s, err := NewUnifiedStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewUnifiedStorage: %v", err)
+	}
+	policy := newTestDraftPolicy(t, s, "org-1")
+
+	if err := s.Activate(policy.PolicyID, "author"); err == nil {
+		t.Fatal("expected Activate to refuse a draft with no approvals")
+	}
+
+	if _, err := s.AddApproval(policy.PolicyID, "approver-1", ""); err != nil {
+		t.Fatalf("AddApproval: %v", err)
+	}
+	if err := s.Activate(policy.PolicyID, "author"); err == nil {
+		t.Fatal("expected Activate to refuse a draft with only one approval under the default two-person quorum")
+	}
+
+	if _, err := s.AddApproval(policy.PolicyID, "approver-2", ""); err != nil {
+		t.Fatalf("AddApproval: %v", err)
+	}
+	if err := s.Activate(policy.PolicyID, "author"); err != nil {
+		t.Fatalf("expected Activate to succeed once two distinct approvals are recorded, got: %v", err)
+	}
+}
+
+func TestAddApprovalRejectsSelfApproval(t *testing.T) {
+	s, err := NewUnifiedStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewUnifiedStorage: %v", err)
+	}
+	policy := newTestDraftPolicy(t, s, "org-1")
+
+	if _, err := s.AddApproval(policy.PolicyID, policy.OwnerID, ""); err == nil {
+		t.Fatal("expected AddApproval to reject the policy owner approving their own policy")
+	}
+}
+
+func TestAddApprovalDoesNotCountRepeatApproverTwice(t *testing.T) {
+	s, err := NewUnifiedStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewUnifiedStorage: %v", err)
+	}
+	policy := newTestDraftPolicy(t, s, "org-1")
+
+	if _, err := s.AddApproval(policy.PolicyID, "approver-1", ""); err != nil {
+		t.Fatalf("AddApproval: %v", err)
+	}
+	if _, err := s.AddApproval(policy.PolicyID, "approver-1", "a second look"); err != nil {
+		t.Fatalf("AddApproval: %v", err)
+	}
+
+	if err := s.Activate(policy.PolicyID, "author"); err == nil {
+		t.Fatal("expected Activate to refuse two approvals from the same approver under a quorum of two")
+	}
+}
+
+func TestSetApprovalQuorumOverridesDefaultPerOrg(t *testing.T) {
+	s, err := NewUnifiedStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewUnifiedStorage: %v", err)
+	}
+	policy := newTestDraftPolicy(t, s, "org-strict")
+	s.SetApprovalQuorum("org-strict", 3)
+
+	if _, err := s.AddApproval(policy.PolicyID, "approver-1", ""); err != nil {
+		t.Fatalf("AddApproval: %v", err)
+	}
+	if _, err := s.AddApproval(policy.PolicyID, "approver-2", ""); err != nil {
+		t.Fatalf("AddApproval: %v", err)
+	}
+	if err := s.Activate(policy.PolicyID, "author"); err == nil {
+		t.Fatal("expected Activate to enforce the org's raised quorum of 3, not the default of 2")
+	}
+
+	if _, err := s.AddApproval(policy.PolicyID, "approver-3", ""); err != nil {
+		t.Fatalf("AddApproval: %v", err)
+	}
+	if err := s.Activate(policy.PolicyID, "author"); err != nil {
+		t.Fatalf("expected Activate to succeed once the org's quorum of 3 is met, got: %v", err)
+	}
+}
@@ -1,21 +1,39 @@
 package storage
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/datacline/policy-engine/internal/models"
+	"github.com/fsnotify/fsnotify"
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 )
 
+// ErrSourceNotFound is returned when an IdP source name has no registered syncer.
+var ErrSourceNotFound = fmt.Errorf("idp source not found")
+
+// usersWatchDebounce coalesces a burst of fs events from a single save of
+// users.json into one reload, matching EnhancedStorage's policy file watch.
+const usersWatchDebounce = 200 * time.Millisecond
+
 // UsersStorage handles loading and querying users data
 type UsersStorage struct {
 	dataDir string
 	data    *models.UsersData
+	index   *usersIndex
 	mu      sync.RWMutex
+
+	watchStatus atomic.Pointer[UsersWatchStatus]
 }
 
 // NewUsersStorage creates a new users storage instance
@@ -32,11 +50,15 @@ func NewUsersStorage(dataDir string) (*UsersStorage, error) {
 	return s, nil
 }
 
-// LoadAll loads the users data from JSON file
+// LoadAll loads the users data from JSON file. The new data is parsed into a
+// local variable and only assigned to s.data once parsing succeeds, so a
+// malformed users.json (e.g. mid-write) leaves the previously-loaded data in
+// place instead of wiping it.
 func (s *UsersStorage) LoadAll() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	before := s.data
 	usersFile := filepath.Join(s.dataDir, "users.json")
 
 	// Check if file exists
@@ -47,6 +69,7 @@ func (s *UsersStorage) LoadAll() error {
 			Groups: []models.UserGroup{},
 			Roles:  []models.UserRole{},
 		}
+		s.reindexLocked()
 		return nil
 	}
 
@@ -63,11 +86,18 @@ func (s *UsersStorage) LoadAll() error {
 	}
 
 	s.data = &data
-	log.WithFields(log.Fields{
+	s.reindexLocked()
+	fields := log.Fields{
 		"users":  len(data.Users),
 		"groups": len(data.Groups),
 		"roles":  len(data.Roles),
-	}).Info("Loaded users data")
+	}
+	if before != nil {
+		fields["users_before"] = len(before.Users)
+		fields["groups_before"] = len(before.Groups)
+		fields["roles_before"] = len(before.Roles)
+	}
+	log.WithFields(fields).Info("Loaded users data")
 
 	return nil
 }
@@ -119,13 +149,12 @@ func (s *UsersStorage) GetUserByID(id string) *models.User {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	for _, u := range s.data.Users {
-		if u.ID == id {
-			user := u
-			return &user
-		}
+	u, ok := s.index.userByID[id]
+	if !ok {
+		return nil
 	}
-	return nil
+	user := *u
+	return &user
 }
 
 // GetUserByEmail returns a user by email
@@ -133,14 +162,12 @@ func (s *UsersStorage) GetUserByEmail(email string) *models.User {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	emailLower := strings.ToLower(email)
-	for _, u := range s.data.Users {
-		if strings.ToLower(u.Email) == emailLower {
-			user := u
-			return &user
-		}
+	u, ok := s.index.userByEmail[strings.ToLower(email)]
+	if !ok {
+		return nil
 	}
-	return nil
+	user := *u
+	return &user
 }
 
 // GetGroupByID returns a group by ID
@@ -148,13 +175,12 @@ func (s *UsersStorage) GetGroupByID(id string) *models.UserGroup {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	for _, g := range s.data.Groups {
-		if g.ID == id {
-			group := g
-			return &group
-		}
+	g, ok := s.index.groupByID[id]
+	if !ok {
+		return nil
 	}
-	return nil
+	group := *g
+	return &group
 }
 
 // GetRoleByID returns a role by ID
@@ -162,16 +188,19 @@ func (s *UsersStorage) GetRoleByID(id string) *models.UserRole {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	for _, r := range s.data.Roles {
-		if r.ID == id {
-			role := r
-			return &role
-		}
+	r, ok := s.index.roleByID[id]
+	if !ok {
+		return nil
 	}
-	return nil
+	role := *r
+	return &role
 }
 
-// SearchUsers searches users by name, email, or department
+// SearchUsers searches users by name, email, or department. Queries of 3 or
+// more characters are narrowed to a candidate set via the trigram index
+// before the exact Contains check runs, so this stays cheap against a large
+// directory; shorter queries fall back to a full scan since they can't form
+// a single trigram.
 func (s *UsersStorage) SearchUsers(query string) []models.User {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -179,22 +208,37 @@ func (s *UsersStorage) SearchUsers(query string) []models.User {
 	if query == "" {
 		return s.GetAllUsers()
 	}
-
 	queryLower := strings.ToLower(query)
-	var result []models.User
 
-	for _, u := range s.data.Users {
-		if strings.Contains(strings.ToLower(u.Name), queryLower) ||
+	matches := func(u *models.User) bool {
+		return strings.Contains(strings.ToLower(u.Name), queryLower) ||
 			strings.Contains(strings.ToLower(u.Email), queryLower) ||
-			strings.Contains(strings.ToLower(u.Department), queryLower) {
-			result = append(result, u)
+			strings.Contains(strings.ToLower(u.Department), queryLower)
+	}
+
+	candidates, ok := trigramCandidates(s.index.userTrigrams, queryLower)
+	if !ok {
+		var result []models.User
+		for i := range s.data.Users {
+			if u := &s.data.Users[i]; matches(u) {
+				result = append(result, *u)
+			}
 		}
+		return result
 	}
 
+	var result []models.User
+	for id := range candidates {
+		if u := s.index.userByID[id]; u != nil && matches(u) {
+			result = append(result, *u)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
 	return result
 }
 
-// SearchGroups searches groups by name or description
+// SearchGroups searches groups by name or description; see SearchUsers for
+// the trigram pre-filter this uses.
 func (s *UsersStorage) SearchGroups(query string) []models.UserGroup {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -202,21 +246,36 @@ func (s *UsersStorage) SearchGroups(query string) []models.UserGroup {
 	if query == "" {
 		return s.GetAllGroups()
 	}
-
 	queryLower := strings.ToLower(query)
-	var result []models.UserGroup
 
-	for _, g := range s.data.Groups {
-		if strings.Contains(strings.ToLower(g.Name), queryLower) ||
-			strings.Contains(strings.ToLower(g.Description), queryLower) {
-			result = append(result, g)
+	matches := func(g *models.UserGroup) bool {
+		return strings.Contains(strings.ToLower(g.Name), queryLower) ||
+			strings.Contains(strings.ToLower(g.Description), queryLower)
+	}
+
+	candidates, ok := trigramCandidates(s.index.groupTrigrams, queryLower)
+	if !ok {
+		var result []models.UserGroup
+		for i := range s.data.Groups {
+			if g := &s.data.Groups[i]; matches(g) {
+				result = append(result, *g)
+			}
 		}
+		return result
 	}
 
+	var result []models.UserGroup
+	for id := range candidates {
+		if g := s.index.groupByID[id]; g != nil && matches(g) {
+			result = append(result, *g)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
 	return result
 }
 
-// SearchRoles searches roles by name or description
+// SearchRoles searches roles by name or description; see SearchUsers for the
+// trigram pre-filter this uses.
 func (s *UsersStorage) SearchRoles(query string) []models.UserRole {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -224,17 +283,31 @@ func (s *UsersStorage) SearchRoles(query string) []models.UserRole {
 	if query == "" {
 		return s.GetAllRoles()
 	}
-
 	queryLower := strings.ToLower(query)
-	var result []models.UserRole
 
-	for _, r := range s.data.Roles {
-		if strings.Contains(strings.ToLower(r.Name), queryLower) ||
-			strings.Contains(strings.ToLower(r.Description), queryLower) {
-			result = append(result, r)
+	matches := func(r *models.UserRole) bool {
+		return strings.Contains(strings.ToLower(r.Name), queryLower) ||
+			strings.Contains(strings.ToLower(r.Description), queryLower)
+	}
+
+	candidates, ok := trigramCandidates(s.index.roleTrigrams, queryLower)
+	if !ok {
+		var result []models.UserRole
+		for i := range s.data.Roles {
+			if r := &s.data.Roles[i]; matches(r) {
+				result = append(result, *r)
+			}
 		}
+		return result
 	}
 
+	var result []models.UserRole
+	for id := range candidates {
+		if r := s.index.roleByID[id]; r != nil && matches(r) {
+			result = append(result, *r)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
 	return result
 }
 
@@ -243,12 +316,17 @@ func (s *UsersStorage) GetUsersByRole(role string) []models.User {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var result []models.User
-	for _, u := range s.data.Users {
-		if u.Role == role {
-			result = append(result, u)
+	ids := s.index.usersByRole[role]
+	if len(ids) == 0 {
+		return nil
+	}
+	result := make([]models.User, 0, len(ids))
+	for id := range ids {
+		if u := s.index.userByID[id]; u != nil {
+			result = append(result, *u)
 		}
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
 	return result
 }
 
@@ -257,15 +335,17 @@ func (s *UsersStorage) GetUsersByGroup(groupID string) []models.User {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var result []models.User
-	for _, u := range s.data.Users {
-		for _, g := range u.Groups {
-			if g == groupID {
-				result = append(result, u)
-				break
-			}
+	ids := s.index.usersByGroup[groupID]
+	if len(ids) == 0 {
+		return nil
+	}
+	result := make([]models.User, 0, len(ids))
+	for id := range ids {
+		if u := s.index.userByID[id]; u != nil {
+			result = append(result, *u)
 		}
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
 	return result
 }
 
@@ -282,3 +362,849 @@ func (s *UsersStorage) GetActiveUsers() []models.User {
 	}
 	return result
 }
+
+// CreateUser adds a new user and persists the dataset
+func (s *UsersStorage) CreateUser(actor string, user *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user.Email == "" {
+		return fmt.Errorf("user email is required")
+	}
+	if user.ID == "" {
+		user.ID = uuid.New().String()
+	}
+	for _, u := range s.data.Users {
+		if u.ID == user.ID {
+			return fmt.Errorf("user already exists: %s", user.ID)
+		}
+	}
+	if user.CreatedAt == "" {
+		user.CreatedAt = time.Now().Format(time.RFC3339)
+	}
+
+	s.data.Users = append(s.data.Users, *user)
+	s.reindexLocked()
+	if err := s.saveAll(); err != nil {
+		return err
+	}
+	s.recordAudit(actor, "create", "user", user.ID, nil, user)
+
+	log.WithFields(log.Fields{"id": user.ID, "email": user.Email}).Info("User created")
+	return nil
+}
+
+// UpdateUser updates an existing user and persists the dataset
+func (s *UsersStorage) UpdateUser(actor string, id string, user *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, u := range s.data.Users {
+		if u.ID == id {
+			before := u
+			user.ID = id
+			user.CreatedAt = u.CreatedAt
+			s.data.Users[i] = *user
+			s.reindexLocked()
+			if err := s.saveAll(); err != nil {
+				return err
+			}
+			s.recordAudit(actor, "update", "user", id, before, user)
+			log.WithField("id", id).Info("User updated")
+			return nil
+		}
+	}
+	return fmt.Errorf("user not found: %s", id)
+}
+
+// DeleteUser removes a user and persists the dataset
+func (s *UsersStorage) DeleteUser(actor string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, u := range s.data.Users {
+		if u.ID == id {
+			s.data.Users = append(s.data.Users[:i], s.data.Users[i+1:]...)
+			s.reindexLocked()
+			if err := s.saveAll(); err != nil {
+				return err
+			}
+			s.recordAudit(actor, "delete", "user", id, u, nil)
+			log.WithField("id", id).Info("User deleted")
+			return nil
+		}
+	}
+	return fmt.Errorf("user not found: %s", id)
+}
+
+// CreateGroup adds a new group and persists the dataset
+func (s *UsersStorage) CreateGroup(actor string, group *models.UserGroup) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if group.Name == "" {
+		return fmt.Errorf("group name is required")
+	}
+	if group.ID == "" {
+		group.ID = uuid.New().String()
+	}
+	for _, g := range s.data.Groups {
+		if g.ID == group.ID {
+			return fmt.Errorf("group already exists: %s", group.ID)
+		}
+	}
+
+	s.data.Groups = append(s.data.Groups, *group)
+	s.reindexLocked()
+	if err := s.saveAll(); err != nil {
+		return err
+	}
+	s.recordAudit(actor, "create", "group", group.ID, nil, group)
+
+	log.WithFields(log.Fields{"id": group.ID, "name": group.Name}).Info("Group created")
+	return nil
+}
+
+// UpdateGroup updates an existing group and persists the dataset
+func (s *UsersStorage) UpdateGroup(actor string, id string, group *models.UserGroup) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, g := range s.data.Groups {
+		if g.ID == id {
+			before := g
+			group.ID = id
+			s.data.Groups[i] = *group
+			s.reindexLocked()
+			if err := s.saveAll(); err != nil {
+				return err
+			}
+			s.recordAudit(actor, "update", "group", id, before, group)
+			log.WithField("id", id).Info("Group updated")
+			return nil
+		}
+	}
+	return fmt.Errorf("group not found: %s", id)
+}
+
+// DeleteGroup removes a group and persists the dataset
+func (s *UsersStorage) DeleteGroup(actor string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, g := range s.data.Groups {
+		if g.ID == id {
+			s.data.Groups = append(s.data.Groups[:i], s.data.Groups[i+1:]...)
+			s.reindexLocked()
+			if err := s.saveAll(); err != nil {
+				return err
+			}
+			s.recordAudit(actor, "delete", "group", id, g, nil)
+			log.WithField("id", id).Info("Group deleted")
+			return nil
+		}
+	}
+	return fmt.Errorf("group not found: %s", id)
+}
+
+// CreateRole adds a new role and persists the dataset
+func (s *UsersStorage) CreateRole(actor string, role *models.UserRole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if role.Name == "" {
+		return fmt.Errorf("role name is required")
+	}
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+	for _, r := range s.data.Roles {
+		if r.ID == role.ID {
+			return fmt.Errorf("role already exists: %s", role.ID)
+		}
+	}
+
+	s.data.Roles = append(s.data.Roles, *role)
+	s.reindexLocked()
+	if err := s.saveAll(); err != nil {
+		return err
+	}
+	s.recordAudit(actor, "create", "role", role.ID, nil, role)
+
+	log.WithFields(log.Fields{"id": role.ID, "name": role.Name}).Info("Role created")
+	return nil
+}
+
+// UpdateRole updates an existing role and persists the dataset
+func (s *UsersStorage) UpdateRole(actor string, id string, role *models.UserRole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.data.Roles {
+		if r.ID == id {
+			before := r
+			role.ID = id
+			s.data.Roles[i] = *role
+			s.reindexLocked()
+			if err := s.saveAll(); err != nil {
+				return err
+			}
+			s.recordAudit(actor, "update", "role", id, before, role)
+			log.WithField("id", id).Info("Role updated")
+			return nil
+		}
+	}
+	return fmt.Errorf("role not found: %s", id)
+}
+
+// DeleteRole removes a role and persists the dataset
+func (s *UsersStorage) DeleteRole(actor string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.data.Roles {
+		if r.ID == id {
+			s.data.Roles = append(s.data.Roles[:i], s.data.Roles[i+1:]...)
+			s.reindexLocked()
+			if err := s.saveAll(); err != nil {
+				return err
+			}
+			s.recordAudit(actor, "delete", "role", id, r, nil)
+			log.WithField("id", id).Info("Role deleted")
+			return nil
+		}
+	}
+	return fmt.Errorf("role not found: %s", id)
+}
+
+// AddGroupMember adds a user to a group, updating the group's member count
+func (s *UsersStorage) AddGroupMember(actor string, groupID string, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groupIdx := -1
+	for i, g := range s.data.Groups {
+		if g.ID == groupID {
+			groupIdx = i
+			break
+		}
+	}
+	if groupIdx == -1 {
+		return fmt.Errorf("group not found: %s", groupID)
+	}
+
+	userIdx := -1
+	for i, u := range s.data.Users {
+		if u.ID == userID {
+			userIdx = i
+			break
+		}
+	}
+	if userIdx == -1 {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	for _, g := range s.data.Users[userIdx].Groups {
+		if g == groupID {
+			return fmt.Errorf("user %s is already a member of group %s", userID, groupID)
+		}
+	}
+
+	s.data.Users[userIdx].Groups = append(s.data.Users[userIdx].Groups, groupID)
+	s.data.Groups[groupIdx].MemberCount++
+	s.reindexLocked()
+	if err := s.saveAll(); err != nil {
+		return err
+	}
+	s.recordAudit(actor, "add_member", "group", groupID, nil, s.data.Users[userIdx])
+
+	log.WithFields(log.Fields{"group_id": groupID, "user_id": userID}).Info("Group member added")
+	return nil
+}
+
+// RemoveGroupMember removes a user from a group, updating the group's member count
+func (s *UsersStorage) RemoveGroupMember(actor string, groupID string, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groupIdx := -1
+	for i, g := range s.data.Groups {
+		if g.ID == groupID {
+			groupIdx = i
+			break
+		}
+	}
+	if groupIdx == -1 {
+		return fmt.Errorf("group not found: %s", groupID)
+	}
+
+	userIdx := -1
+	for i, u := range s.data.Users {
+		if u.ID == userID {
+			userIdx = i
+			break
+		}
+	}
+	if userIdx == -1 {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	groups := s.data.Users[userIdx].Groups
+	removed := false
+	for i, g := range groups {
+		if g == groupID {
+			s.data.Users[userIdx].Groups = append(groups[:i], groups[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	if !removed {
+		return fmt.Errorf("user %s is not a member of group %s", userID, groupID)
+	}
+
+	if s.data.Groups[groupIdx].MemberCount > 0 {
+		s.data.Groups[groupIdx].MemberCount--
+	}
+	s.reindexLocked()
+	if err := s.saveAll(); err != nil {
+		return err
+	}
+	s.recordAudit(actor, "remove_member", "group", groupID, s.data.Users[userIdx], nil)
+
+	log.WithFields(log.Fields{"group_id": groupID, "user_id": userID}).Info("Group member removed")
+	return nil
+}
+
+// AssignUserRole assigns a role to a user
+func (s *UsersStorage) AssignUserRole(actor string, userID string, roleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userIdx := -1
+	for i, u := range s.data.Users {
+		if u.ID == userID {
+			userIdx = i
+			break
+		}
+	}
+	if userIdx == -1 {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	roleFound := false
+	for _, r := range s.data.Roles {
+		if r.ID == roleID {
+			roleFound = true
+			break
+		}
+	}
+	if !roleFound {
+		return fmt.Errorf("role not found: %s", roleID)
+	}
+
+	before := s.data.Users[userIdx].Role
+	s.data.Users[userIdx].Role = roleID
+	s.reindexLocked()
+	if err := s.saveAll(); err != nil {
+		return err
+	}
+	s.recordAudit(actor, "assign_role", "user", userID, before, roleID)
+
+	log.WithFields(log.Fields{"user_id": userID, "role_id": roleID}).Info("Role assigned to user")
+	return nil
+}
+
+// RemoveUserRole removes a role from a user if currently assigned
+func (s *UsersStorage) RemoveUserRole(actor string, userID string, roleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userIdx := -1
+	for i, u := range s.data.Users {
+		if u.ID == userID {
+			userIdx = i
+			break
+		}
+	}
+	if userIdx == -1 {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	if s.data.Users[userIdx].Role != roleID {
+		return fmt.Errorf("user %s does not have role %s", userID, roleID)
+	}
+
+	before := s.data.Users[userIdx].Role
+	s.data.Users[userIdx].Role = ""
+	s.reindexLocked()
+	if err := s.saveAll(); err != nil {
+		return err
+	}
+	s.recordAudit(actor, "remove_role", "user", userID, before, "")
+
+	log.WithFields(log.Fields{"user_id": userID, "role_id": roleID}).Info("Role removed from user")
+	return nil
+}
+
+// MergeFromSource replaces all users and groups previously tagged with the
+// given source with the freshly fetched set, leaving file-defined (untagged)
+// and other-source principals untouched.
+func (s *UsersStorage) MergeFromSource(source string, users []models.User, groups []models.UserGroup) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := make([]models.User, 0, len(s.data.Users))
+	for _, u := range s.data.Users {
+		if u.Source != source {
+			kept = append(kept, u)
+		}
+	}
+	s.data.Users = append(kept, users...)
+
+	keptGroups := make([]models.UserGroup, 0, len(s.data.Groups))
+	for _, g := range s.data.Groups {
+		if g.Source != source {
+			keptGroups = append(keptGroups, g)
+		}
+	}
+	s.data.Groups = append(keptGroups, groups...)
+	s.reindexLocked()
+
+	return s.saveAll()
+}
+
+// ImportUsers validates and applies a batch of users against the current
+// dataset, matching existing users by ID first and falling back to email.
+// mode controls how matches are resolved: "upsert" overwrites the matched
+// user outright, "merge" only overwrites fields the incoming row sets, and
+// "replace" additionally deletes any existing user absent from the batch.
+// The whole batch is rejected (no storage mutation) if any row fails
+// validation, or if dryRun is true; in both cases the computed diff is
+// still returned so the caller can inspect what would have changed.
+func (s *UsersStorage) ImportUsers(actor, mode string, incoming []models.User, dryRun bool) (*models.ImportDiff, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	diff := &models.ImportDiff{
+		Created:   []models.User{},
+		Updated:   []models.ImportChange{},
+		Deleted:   []models.User{},
+		Conflicts: []models.ImportConflict{},
+	}
+
+	seenEmail := make(map[string]bool, len(incoming))
+	for i, u := range incoming {
+		if u.Email == "" {
+			diff.Conflicts = append(diff.Conflicts, models.ImportConflict{Index: i, ID: u.ID, Reason: "email is required"})
+			continue
+		}
+		emailLower := strings.ToLower(u.Email)
+		if seenEmail[emailLower] {
+			diff.Conflicts = append(diff.Conflicts, models.ImportConflict{Index: i, ID: u.ID, Reason: "duplicate email within import batch: " + u.Email})
+			continue
+		}
+		seenEmail[emailLower] = true
+
+		for _, g := range u.Groups {
+			if !s.groupExists(g) {
+				diff.Conflicts = append(diff.Conflicts, models.ImportConflict{Index: i, ID: u.ID, Reason: "unknown group: " + g})
+			}
+		}
+		if u.Role != "" && !s.roleExists(u.Role) {
+			diff.Conflicts = append(diff.Conflicts, models.ImportConflict{Index: i, ID: u.ID, Reason: "unknown role: " + u.Role})
+		}
+	}
+	if len(diff.Conflicts) > 0 {
+		return diff, fmt.Errorf("import rejected: %d conflict(s) found", len(diff.Conflicts))
+	}
+
+	matchedIncoming := make([]bool, len(incoming))
+	findMatch := func(existing models.User) (models.User, bool) {
+		for i, inc := range incoming {
+			if matchedIncoming[i] {
+				continue
+			}
+			if (inc.ID != "" && inc.ID == existing.ID) || strings.EqualFold(inc.Email, existing.Email) {
+				matchedIncoming[i] = true
+				return inc, true
+			}
+		}
+		return models.User{}, false
+	}
+
+	result := make([]models.User, 0, len(s.data.Users)+len(incoming))
+	for _, existing := range s.data.Users {
+		inc, ok := findMatch(existing)
+		if !ok {
+			if mode == "replace" {
+				diff.Deleted = append(diff.Deleted, existing)
+				continue
+			}
+			result = append(result, existing)
+			continue
+		}
+
+		after := inc
+		if mode == "merge" {
+			after = mergeUser(existing, inc)
+		}
+		after.ID = existing.ID
+		after.CreatedAt = existing.CreatedAt
+
+		if !reflect.DeepEqual(existing, after) {
+			diff.Updated = append(diff.Updated, models.ImportChange{ID: existing.ID, Before: existing, After: after})
+		}
+		result = append(result, after)
+	}
+
+	for i, inc := range incoming {
+		if matchedIncoming[i] {
+			continue
+		}
+		created := inc
+		if created.ID == "" {
+			created.ID = uuid.New().String()
+		}
+		if created.CreatedAt == "" {
+			created.CreatedAt = time.Now().Format(time.RFC3339)
+		}
+		diff.Created = append(diff.Created, created)
+		result = append(result, created)
+	}
+
+	if dryRun {
+		return diff, nil
+	}
+
+	previous := s.data.Users
+	s.data.Users = result
+	s.reindexLocked()
+	if err := s.saveAll(); err != nil {
+		s.data.Users = previous
+		s.reindexLocked()
+		return diff, err
+	}
+	s.recordAudit(actor, "import", "user", "", nil, diff)
+
+	log.WithFields(log.Fields{
+		"mode":    mode,
+		"created": len(diff.Created),
+		"updated": len(diff.Updated),
+		"deleted": len(diff.Deleted),
+	}).Info("Users imported")
+
+	return diff, nil
+}
+
+// mergeUser overlays the non-empty fields of incoming onto existing, leaving
+// fields incoming didn't set untouched.
+func mergeUser(existing, incoming models.User) models.User {
+	merged := existing
+	if incoming.Email != "" {
+		merged.Email = incoming.Email
+	}
+	if incoming.Name != "" {
+		merged.Name = incoming.Name
+	}
+	if incoming.FirstName != "" {
+		merged.FirstName = incoming.FirstName
+	}
+	if incoming.LastName != "" {
+		merged.LastName = incoming.LastName
+	}
+	if incoming.Role != "" {
+		merged.Role = incoming.Role
+	}
+	if incoming.Department != "" {
+		merged.Department = incoming.Department
+	}
+	if incoming.Title != "" {
+		merged.Title = incoming.Title
+	}
+	if len(incoming.Groups) > 0 {
+		merged.Groups = incoming.Groups
+	}
+	if incoming.Status != "" {
+		merged.Status = incoming.Status
+	}
+	if incoming.LastLogin != "" {
+		merged.LastLogin = incoming.LastLogin
+	}
+	merged.MFAEnabled = incoming.MFAEnabled
+	return merged
+}
+
+// groupExists reports whether a group ID exists. Callers must hold s.mu.
+func (s *UsersStorage) groupExists(id string) bool {
+	_, ok := s.index.groupByID[id]
+	return ok
+}
+
+// roleExists reports whether a role ID exists. Callers must hold s.mu.
+func (s *UsersStorage) roleExists(id string) bool {
+	_, ok := s.index.roleByID[id]
+	return ok
+}
+
+// saveAll persists the in-memory dataset back to users.json. Callers must hold s.mu.
+func (s *UsersStorage) saveAll() error {
+	usersFile := filepath.Join(s.dataDir, "users.json")
+
+	data, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal users data: %w", err)
+	}
+
+	if err := os.WriteFile(usersFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write users file: %w", err)
+	}
+
+	return nil
+}
+
+// recordAudit appends an audit log entry for a principal mutation. Callers must hold s.mu.
+func (s *UsersStorage) recordAudit(actor, action, entityType, entityID string, before, after interface{}) {
+	entry := models.AuditEntry{
+		Timestamp:  time.Now().Format(time.RFC3339),
+		Actor:      actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+		After:      after,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.WithError(err).Warn("Failed to marshal audit entry")
+		return
+	}
+
+	auditFile := filepath.Join(s.dataDir, "audit.log")
+	f, err := os.OpenFile(auditFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.WithError(err).Warn("Failed to open audit log")
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		log.WithError(err).Warn("Failed to write audit entry")
+	}
+}
+
+// UsersWatchStatus reports UsersStorage's current hot-reload watcher state,
+// mirroring enhanced.WatchStatus so operators can tell a silently-stalled
+// watcher apart from a users.json edit that's failing to parse.
+type UsersWatchStatus struct {
+	Watching      bool      `json:"watching"`
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorAt   time.Time `json:"last_error_at,omitempty"`
+	LastSuccessAt time.Time `json:"last_success_at,omitempty"`
+}
+
+// WatchStatus returns a snapshot of s's hot-reload watcher state. The zero
+// value (Watching: false) means Watch has never been called.
+func (s *UsersStorage) WatchStatus() UsersWatchStatus {
+	if status := s.watchStatus.Load(); status != nil {
+		return *status
+	}
+	return UsersWatchStatus{}
+}
+
+// Watch watches dataDir/users.json for changes with fsnotify, debouncing a
+// burst of events from a single save into one reload (usersWatchDebounce)
+// instead of re-parsing on every event. A changed file that fails to parse
+// is logged and left alone - LoadAll already only swaps s.data in after a
+// successful unmarshal, so the previous snapshot stays live. Runs until ctx
+// is done; callers that don't need to stop it early can pass
+// context.Background().
+func (s *UsersStorage) Watch(ctx context.Context) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create users file watcher: %w", err)
+	}
+	if err := fsWatcher.Add(s.dataDir); err != nil {
+		fsWatcher.Close()
+		return fmt.Errorf("failed to watch users data directory %s: %w", s.dataDir, err)
+	}
+
+	s.watchStatus.Store(&UsersWatchStatus{Watching: true})
+	usersFile := filepath.Join(s.dataDir, "users.json")
+
+	go func() {
+		defer fsWatcher.Close()
+		defer func() {
+			status := s.WatchStatus()
+			status.Watching = false
+			s.watchStatus.Store(&status)
+		}()
+
+		var debounceTimer *time.Timer
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-fsWatcher.Events:
+				if !ok {
+					return
+				}
+				if event.Name != usersFile {
+					continue
+				}
+
+				if debounceTimer != nil {
+					debounceTimer.Stop()
+				}
+				debounceTimer = time.AfterFunc(usersWatchDebounce, func() {
+					if err := s.LoadAll(); err != nil {
+						log.WithError(err).WithField("file", usersFile).Warn("Hot-reload: users.json change rejected, keeping previous version live")
+						s.watchStatus.Store(&UsersWatchStatus{Watching: true, LastError: err.Error(), LastErrorAt: time.Now()})
+						return
+					}
+					s.watchStatus.Store(&UsersWatchStatus{Watching: true, LastSuccessAt: time.Now()})
+				})
+			case err, ok := <-fsWatcher.Errors:
+				if !ok {
+					return
+				}
+				log.WithError(err).Warn("Users data directory watch error")
+			}
+		}
+	}()
+
+	return nil
+}
+
+// usersIndex holds UsersStorage's lookup/search acceleration structures, so
+// GetUserByEmail, GetUsersByRole, GetUsersByGroup, and the Search* methods
+// don't each scan the full dataset under lock. Rebuilt wholesale by
+// reindexLocked whenever s.data changes - LoadAll, hot-reload, or any CRUD
+// mutation - so it's never more than one mutation out of date with s.data.
+type usersIndex struct {
+	userByID  map[string]*models.User
+	groupByID map[string]*models.UserGroup
+	roleByID  map[string]*models.UserRole
+
+	userByEmail  map[string]*models.User        // key: strings.ToLower(email)
+	usersByRole  map[string]map[string]struct{} // role -> user IDs
+	usersByGroup map[string]map[string]struct{} // group ID -> user IDs
+
+	userTrigrams  map[string]map[string]struct{} // trigram -> user IDs
+	groupTrigrams map[string]map[string]struct{} // trigram -> group IDs
+	roleTrigrams  map[string]map[string]struct{} // trigram -> role IDs
+}
+
+// reindexLocked rebuilds s.index from the current s.data. Callers must hold
+// s.mu for writing.
+func (s *UsersStorage) reindexLocked() {
+	idx := &usersIndex{
+		userByID:      make(map[string]*models.User, len(s.data.Users)),
+		groupByID:     make(map[string]*models.UserGroup, len(s.data.Groups)),
+		roleByID:      make(map[string]*models.UserRole, len(s.data.Roles)),
+		userByEmail:   make(map[string]*models.User, len(s.data.Users)),
+		usersByRole:   make(map[string]map[string]struct{}),
+		usersByGroup:  make(map[string]map[string]struct{}),
+		userTrigrams:  make(map[string]map[string]struct{}),
+		groupTrigrams: make(map[string]map[string]struct{}),
+		roleTrigrams:  make(map[string]map[string]struct{}),
+	}
+
+	for i := range s.data.Users {
+		u := &s.data.Users[i]
+		idx.userByID[u.ID] = u
+		idx.userByEmail[strings.ToLower(u.Email)] = u
+		if u.Role != "" {
+			if idx.usersByRole[u.Role] == nil {
+				idx.usersByRole[u.Role] = make(map[string]struct{})
+			}
+			idx.usersByRole[u.Role][u.ID] = struct{}{}
+		}
+		for _, g := range u.Groups {
+			if idx.usersByGroup[g] == nil {
+				idx.usersByGroup[g] = make(map[string]struct{})
+			}
+			idx.usersByGroup[g][u.ID] = struct{}{}
+		}
+		addTrigrams(idx.userTrigrams, u.ID, u.Name, u.Email, u.Department)
+	}
+
+	for i := range s.data.Groups {
+		g := &s.data.Groups[i]
+		idx.groupByID[g.ID] = g
+		addTrigrams(idx.groupTrigrams, g.ID, g.Name, g.Description)
+	}
+
+	for i := range s.data.Roles {
+		r := &s.data.Roles[i]
+		idx.roleByID[r.ID] = r
+		addTrigrams(idx.roleTrigrams, r.ID, r.Name, r.Description)
+	}
+
+	s.index = idx
+}
+
+// trigramLen is the substring length indexed and queried for Search* -
+// queries shorter than this can't form a single trigram, so callers fall
+// back to a full scan instead.
+const trigramLen = 3
+
+// addTrigrams indexes id under every distinct 3-gram of each of fields,
+// lowercased, one field at a time - not joined - so a trigram spanning a
+// field boundary (e.g. the end of Name and the start of Email) is never
+// indexed.
+func addTrigrams(index map[string]map[string]struct{}, id string, fields ...string) {
+	for _, field := range fields {
+		for _, g := range trigramsOf(strings.ToLower(field)) {
+			if index[g] == nil {
+				index[g] = make(map[string]struct{})
+			}
+			index[g][id] = struct{}{}
+		}
+	}
+}
+
+// trigramsOf returns the distinct trigramLen-character substrings of s, or
+// nil if s is shorter than that.
+func trigramsOf(s string) []string {
+	if len(s) < trigramLen {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(s))
+	grams := make([]string, 0, len(s))
+	for i := 0; i+trigramLen <= len(s); i++ {
+		g := s[i : i+trigramLen]
+		if _, ok := seen[g]; ok {
+			continue
+		}
+		seen[g] = struct{}{}
+		grams = append(grams, g)
+	}
+	return grams
+}
+
+// trigramCandidates intersects index[g] over every trigram of query - the
+// fast pre-filter Search* runs before the exact Contains check. The bool
+// return is false when query is too short to form a trigram, signalling the
+// caller to fall back to a full scan instead of treating "no match" as "no
+// candidates".
+func trigramCandidates(index map[string]map[string]struct{}, query string) (map[string]struct{}, bool) {
+	grams := trigramsOf(query)
+	if grams == nil {
+		return nil, false
+	}
+
+	candidates := make(map[string]struct{}, len(index[grams[0]]))
+	for id := range index[grams[0]] {
+		candidates[id] = struct{}{}
+	}
+	for _, g := range grams[1:] {
+		ids := index[g]
+		for id := range candidates {
+			if _, ok := ids[id]; !ok {
+				delete(candidates, id)
+			}
+		}
+	}
+	return candidates, true
+}
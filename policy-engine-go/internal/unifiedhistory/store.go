@@ -0,0 +1,165 @@
+// Package unifiedhistory persists an immutable, optionally Ed25519-signed
+// revision trail for UnifiedPolicy writes, the UnifiedPolicy-subsystem
+// analog of internal/history. storage.UnifiedStorage appends one revision
+// per Create/Update/Activate/Suspend/Retire/AddResource/RemoveResource/
+// Rollout/Rollback call; the unified API surfaces it via the
+// /unified/policies/:id/revisions... endpoints.
+package unifiedhistory
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// Store records policy revisions under baseDir/.unified_history/<policy-id>/.
+type Store struct {
+	historyDir string
+}
+
+// NewStore creates a revision history store rooted at
+// baseDir/.unified_history.
+func NewStore(baseDir string) *Store {
+	return &Store{historyDir: filepath.Join(baseDir, ".unified_history")}
+}
+
+// Record appends a new immutable revision for policy and returns it. When
+// signingKey is non-nil the revision's identifying fields are signed with
+// it; pass nil to record an unsigned revision.
+func (s *Store) Record(policy *models.UnifiedPolicy, op, author string, signingKey ed25519.PrivateKey) (*models.UnifiedPolicyRevision, error) {
+	dir := filepath.Join(s.historyDir, policy.PolicyID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create unified history directory: %w", err)
+	}
+
+	snapshot := *policy
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy snapshot: %w", err)
+	}
+	sum := sha256.Sum256(data)
+
+	prior, err := s.latest(policy.PolicyID)
+	if err != nil {
+		return nil, err
+	}
+	priorHash := ""
+	if prior != nil {
+		priorHash = prior.Hash
+	}
+
+	rev := &models.UnifiedPolicyRevision{
+		ID:        fmt.Sprintf("%d-%s", policy.Version, op),
+		PolicyID:  policy.PolicyID,
+		Op:        op,
+		Hash:      hex.EncodeToString(sum[:]),
+		PriorHash: priorHash,
+		Author:    author,
+		Timestamp: time.Now(),
+		Snapshot:  &snapshot,
+	}
+	if signingKey != nil {
+		rev.Signature = hex.EncodeToString(ed25519.Sign(signingKey, signingPayload(rev)))
+	}
+
+	out, err := json.MarshalIndent(rev, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal revision: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s.json", rev.ID))
+	if err := os.WriteFile(path, out, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write revision: %w", err)
+	}
+
+	return rev, nil
+}
+
+// History returns every revision recorded for policyID, oldest first. A
+// policy with no recorded revisions returns an empty slice, not an error.
+func (s *Store) History(policyID string) ([]*models.UnifiedPolicyRevision, error) {
+	dir := filepath.Join(s.historyDir, policyID)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read unified history directory: %w", err)
+	}
+
+	var revisions []*models.UnifiedPolicyRevision
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		rev, err := s.readRevision(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Timestamp.Before(revisions[j].Timestamp)
+	})
+	return revisions, nil
+}
+
+// GetRevision returns a single recorded revision for policyID.
+func (s *Store) GetRevision(policyID, revisionID string) (*models.UnifiedPolicyRevision, error) {
+	path := filepath.Join(s.historyDir, policyID, fmt.Sprintf("%s.json", revisionID))
+	rev, err := s.readRevision(path)
+	if err != nil {
+		return nil, fmt.Errorf("revision not found: %s/%s", policyID, revisionID)
+	}
+	return rev, nil
+}
+
+func (s *Store) latest(policyID string) (*models.UnifiedPolicyRevision, error) {
+	revisions, err := s.History(policyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+	return revisions[len(revisions)-1], nil
+}
+
+func (s *Store) readRevision(path string) (*models.UnifiedPolicyRevision, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revision: %w", err)
+	}
+	var rev models.UnifiedPolicyRevision
+	if err := json.Unmarshal(data, &rev); err != nil {
+		return nil, fmt.Errorf("failed to parse revision: %w", err)
+	}
+	return &rev, nil
+}
+
+// signingPayload is the byte string an Ed25519 signature covers: a
+// revision's identifying fields, excluding the signature itself.
+func signingPayload(rev *models.UnifiedPolicyRevision) []byte {
+	return []byte(fmt.Sprintf("%s|%s|%s|%s|%s|%d", rev.ID, rev.PolicyID, rev.Op, rev.Hash, rev.PriorHash, rev.Timestamp.UnixNano()))
+}
+
+// Verify reports whether rev's signature is valid for pub. An unsigned
+// revision (Signature == "") always verifies, since signing is optional.
+func Verify(rev *models.UnifiedPolicyRevision, pub ed25519.PublicKey) bool {
+	if rev.Signature == "" {
+		return true
+	}
+	sig, err := hex.DecodeString(rev.Signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(pub, signingPayload(rev), sig)
+}
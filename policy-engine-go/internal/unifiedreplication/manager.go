@@ -0,0 +1,334 @@
+// Package unifiedreplication pushes UnifiedPolicyBundles (see
+// storage.UnifiedStorage.ExportBundle) to peer gateways, the bundle-level
+// analog of internal/replication's per-Policy push: a
+// UnifiedReplicationTarget names a peer, the UnifiedBundleFilters that
+// decide which policies reach it, and the UnifiedPolicyImportMode its
+// POST /unified/replication/import should apply, on a manual, cron, or
+// on-demand trigger. Manager runs its own cron ticker for cron targets;
+// every run (cron or a manual POST .../trigger/:target_id) is recorded as a
+// UnifiedReplicationExecution.
+package unifiedreplication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/schedule"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	tickInterval = time.Minute
+	maxRetries   = 3
+	baseBackoff  = 200 * time.Millisecond
+)
+
+// Exporter builds the bundle a target's filters select, typically
+// storage.UnifiedStorage.ExportBundle.
+type Exporter func(filters []models.UnifiedBundleFilter) (*models.UnifiedPolicyBundle, error)
+
+// importRequest is the body a Manager POSTs to a target's
+// /api/v1/unified/replication/import.
+type importRequest struct {
+	Mode   models.UnifiedPolicyImportMode `json:"mode"`
+	Bundle models.UnifiedPolicyBundle     `json:"bundle"`
+}
+
+// Manager owns the configured UnifiedReplicationTargets and pushes exported
+// bundles to them over HTTP.
+type Manager struct {
+	targets    *targetStore
+	executions *executionStore
+	httpClient *http.Client
+	export     Exporter
+
+	cancel context.CancelFunc
+}
+
+// NewManager creates a Manager whose targets/executions persist under
+// policyDir/.unified_replication/. export builds the bundle pushed to a
+// target on each trigger.
+func NewManager(policyDir string, export Exporter) (*Manager, error) {
+	targets, err := newTargetStore(policyDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		targets:    targets,
+		executions: newExecutionStore(policyDir),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		export:     export,
+	}, nil
+}
+
+// Start launches the background cron tick loop. Call Stop to terminate it.
+func (m *Manager) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	m.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.cronTick(time.Now())
+			}
+		}
+	}()
+}
+
+// Stop terminates the background cron tick loop.
+func (m *Manager) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+}
+
+// CreateTarget registers a new UnifiedReplicationTarget, assigning it an ID.
+func (m *Manager) CreateTarget(target *models.UnifiedReplicationTarget) error {
+	if target.Trigger == "" {
+		target.Trigger = models.ReplicationTriggerManual
+	}
+	if target.ImportMode == "" {
+		target.ImportMode = models.UnifiedImportUpsert
+	}
+	if err := validateTrigger(target); err != nil {
+		return err
+	}
+
+	target.ID = fmt.Sprintf("unified-repl-%d", time.Now().UnixNano())
+	now := time.Now()
+	target.CreatedAt = &now
+	target.UpdatedAt = &now
+
+	if err := m.targets.create(target); err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{"id": target.ID, "url": target.URL, "trigger": target.Trigger}).Info("Unified replication target created")
+	return nil
+}
+
+// UpdateTarget replaces the UnifiedReplicationTarget stored at id.
+func (m *Manager) UpdateTarget(id string, target *models.UnifiedReplicationTarget) error {
+	existing, err := m.targets.get(id)
+	if err != nil {
+		return err
+	}
+	if target.ImportMode == "" {
+		target.ImportMode = models.UnifiedImportUpsert
+	}
+	if err := validateTrigger(target); err != nil {
+		return err
+	}
+
+	target.ID = id
+	target.CreatedAt = existing.CreatedAt
+	now := time.Now()
+	target.UpdatedAt = &now
+
+	if err := m.targets.update(target); err != nil {
+		return err
+	}
+	log.WithField("id", id).Info("Unified replication target updated")
+	return nil
+}
+
+// GetTarget retrieves a UnifiedReplicationTarget by ID.
+func (m *Manager) GetTarget(id string) (*models.UnifiedReplicationTarget, error) {
+	return m.targets.get(id)
+}
+
+// ListTargets returns every configured UnifiedReplicationTarget.
+func (m *Manager) ListTargets() []*models.UnifiedReplicationTarget {
+	return m.targets.list()
+}
+
+// DeleteTarget removes a UnifiedReplicationTarget by ID.
+func (m *Manager) DeleteTarget(id string) error {
+	if err := m.targets.delete(id); err != nil {
+		return err
+	}
+	log.WithField("id", id).Info("Unified replication target deleted")
+	return nil
+}
+
+// GetExecution retrieves a previously recorded UnifiedReplicationExecution by
+// ID.
+func (m *Manager) GetExecution(id string) (*models.UnifiedReplicationExecution, error) {
+	return m.executions.get(id)
+}
+
+// ListExecutions returns every recorded UnifiedReplicationExecution.
+func (m *Manager) ListExecutions() []*models.UnifiedReplicationExecution {
+	return m.executions.list()
+}
+
+// TargetSummary tallies how many of a target's past executions succeeded or
+// failed, for GET /unified/replication/executions.
+type TargetSummary struct {
+	TargetID  string `json:"target_id"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+}
+
+// Summary aggregates ListExecutions into a per-target success/failure count.
+func (m *Manager) Summary() []TargetSummary {
+	byTarget := make(map[string]*TargetSummary)
+	var order []string
+	for _, exec := range m.executions.list() {
+		s, ok := byTarget[exec.TargetID]
+		if !ok {
+			s = &TargetSummary{TargetID: exec.TargetID}
+			byTarget[exec.TargetID] = s
+			order = append(order, exec.TargetID)
+		}
+		switch exec.Status {
+		case models.ReplicationStatusSucceeded:
+			s.Succeeded++
+		case models.ReplicationStatusFailed:
+			s.Failed++
+		}
+	}
+
+	out := make([]TargetSummary, 0, len(order))
+	for _, id := range order {
+		out = append(out, *byTarget[id])
+	}
+	return out
+}
+
+// TriggerTarget pushes the bundle matching target id's filters, for
+// POST /unified/replication/trigger/:target_id.
+func (m *Manager) TriggerTarget(id string) (*models.UnifiedReplicationExecution, error) {
+	target, err := m.targets.get(id)
+	if err != nil {
+		return nil, err
+	}
+	return m.replicate(target, models.ReplicationTriggerManual), nil
+}
+
+// cronTick fires any enabled cron target whose schedule matched since the
+// previous tick.
+func (m *Manager) cronTick(now time.Time) {
+	for _, target := range m.targets.list() {
+		if !target.Enabled || target.Trigger != models.ReplicationTriggerCron {
+			continue
+		}
+
+		cron, err := schedule.ParseCron(target.CronStr, "UTC")
+		if err != nil {
+			log.WithError(err).WithField("target", target.ID).Warn("Invalid unified replication target cron expression, skipping")
+			continue
+		}
+		next, ok := cron.Next(now.Add(-tickInterval))
+		if !ok || next.After(now) {
+			continue
+		}
+
+		m.replicate(target, models.ReplicationTriggerCron)
+	}
+}
+
+// replicate exports the bundle matching target's filters and pushes it,
+// recording the run as trigger.
+func (m *Manager) replicate(target *models.UnifiedReplicationTarget, trigger models.ReplicationTriggerMode) *models.UnifiedReplicationExecution {
+	exec := &models.UnifiedReplicationExecution{
+		ID:        fmt.Sprintf("unified-exec-%d", time.Now().UnixNano()),
+		TargetID:  target.ID,
+		Trigger:   trigger,
+		Status:    models.ReplicationStatusRunning,
+		StartedAt: time.Now(),
+	}
+	if err := m.executions.save(exec); err != nil {
+		log.WithError(err).WithField("target", target.ID).Warn("Failed to persist unified replication execution record")
+	}
+
+	bundle, err := m.export(target.Filters)
+	if err != nil {
+		m.finish(exec, 0, fmt.Errorf("failed to export bundle: %w", err))
+		return exec
+	}
+
+	err = m.push(target, bundle)
+	m.finish(exec, len(bundle.Policies), err)
+	return exec
+}
+
+func (m *Manager) finish(exec *models.UnifiedReplicationExecution, policyCount int, err error) {
+	finished := time.Now()
+	exec.FinishedAt = &finished
+	exec.PolicyCount = policyCount
+	exec.Status = models.ReplicationStatusSucceeded
+	if err != nil {
+		exec.Status = models.ReplicationStatusFailed
+		exec.Error = err.Error()
+		log.WithError(err).WithField("target", exec.TargetID).Warn("Unified policy bundle replication failed")
+	}
+	if saveErr := m.executions.save(exec); saveErr != nil {
+		log.WithError(saveErr).WithField("target", exec.TargetID).Warn("Failed to persist unified replication execution record")
+	}
+}
+
+func (m *Manager) push(target *models.UnifiedReplicationTarget, bundle *models.UnifiedPolicyBundle) error {
+	body, err := json.Marshal(importRequest{Mode: target.ImportMode, Bundle: *bundle})
+	if err != nil {
+		return fmt.Errorf("failed to marshal import request: %w", err)
+	}
+	return m.sendWithRetry(target, "/api/v1/unified/replication/import", body)
+}
+
+// sendWithRetry POSTs body to target.URL+path, retrying with exponential
+// backoff up to maxRetries times.
+func (m *Manager) sendWithRetry(target *models.UnifiedReplicationTarget, path string, body []byte) error {
+	url := strings.TrimRight(target.URL, "/") + path
+
+	var lastErr error
+	backoff := baseBackoff
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if target.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+target.Token)
+		}
+
+		resp, err := m.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("target %s returned status %d", url, resp.StatusCode)
+	}
+	return lastErr
+}
+
+func validateTrigger(target *models.UnifiedReplicationTarget) error {
+	if target.Trigger == models.ReplicationTriggerCron {
+		if _, err := schedule.ParseCron(target.CronStr, "UTC"); err != nil {
+			return fmt.Errorf("invalid cron_str: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package unifiedreplication
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// targetStore persists UnifiedReplicationTargets to
+// policyDir/.unified_replication/targets/ so configured peers survive a
+// process restart.
+type targetStore struct {
+	dir string
+
+	mu      sync.RWMutex
+	targets map[string]*models.UnifiedReplicationTarget
+}
+
+func newTargetStore(policyDir string) (*targetStore, error) {
+	s := &targetStore{
+		dir:     filepath.Join(policyDir, ".unified_replication", "targets"),
+		targets: make(map[string]*models.UnifiedReplicationTarget),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *targetStore) load() error {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read unified replication targets directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read unified replication target: %w", err)
+		}
+		var target models.UnifiedReplicationTarget
+		if err := json.Unmarshal(data, &target); err != nil {
+			return fmt.Errorf("failed to parse unified replication target: %w", err)
+		}
+		s.targets[target.ID] = &target
+	}
+	return nil
+}
+
+func (s *targetStore) save(target *models.UnifiedReplicationTarget) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create unified replication targets directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(target, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal unified replication target: %w", err)
+	}
+
+	path := filepath.Join(s.dir, target.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write unified replication target: %w", err)
+	}
+	return nil
+}
+
+func (s *targetStore) create(target *models.UnifiedReplicationTarget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.save(target); err != nil {
+		return err
+	}
+	s.targets[target.ID] = target
+	return nil
+}
+
+func (s *targetStore) update(target *models.UnifiedReplicationTarget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.targets[target.ID]; !ok {
+		return fmt.Errorf("unified replication target not found: %s", target.ID)
+	}
+	if err := s.save(target); err != nil {
+		return err
+	}
+	s.targets[target.ID] = target
+	return nil
+}
+
+func (s *targetStore) get(id string) (*models.UnifiedReplicationTarget, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	target, ok := s.targets[id]
+	if !ok {
+		return nil, fmt.Errorf("unified replication target not found: %s", id)
+	}
+	return target, nil
+}
+
+func (s *targetStore) list() []*models.UnifiedReplicationTarget {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*models.UnifiedReplicationTarget, 0, len(s.targets))
+	for _, target := range s.targets {
+		out = append(out, target)
+	}
+	return out
+}
+
+func (s *targetStore) delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.targets[id]; !ok {
+		return fmt.Errorf("unified replication target not found: %s", id)
+	}
+	if err := os.Remove(filepath.Join(s.dir, id+".json")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove unified replication target: %w", err)
+	}
+	delete(s.targets, id)
+	return nil
+}
+
+// executionStore persists UnifiedReplicationExecutions to
+// policyDir/.unified_replication/executions/ so GET
+// /unified/replication/executions survives a process restart.
+type executionStore struct {
+	dir string
+
+	mu         sync.RWMutex
+	executions map[string]*models.UnifiedReplicationExecution
+}
+
+func newExecutionStore(policyDir string) *executionStore {
+	return &executionStore{
+		dir:        filepath.Join(policyDir, ".unified_replication", "executions"),
+		executions: make(map[string]*models.UnifiedReplicationExecution),
+	}
+}
+
+func (s *executionStore) save(exec *models.UnifiedReplicationExecution) error {
+	s.mu.Lock()
+	s.executions[exec.ID] = exec
+	s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create unified replication executions directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(exec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal unified replication execution: %w", err)
+	}
+
+	path := filepath.Join(s.dir, exec.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write unified replication execution: %w", err)
+	}
+	return nil
+}
+
+func (s *executionStore) get(id string) (*models.UnifiedReplicationExecution, error) {
+	s.mu.RLock()
+	exec, ok := s.executions[id]
+	s.mu.RUnlock()
+	if ok {
+		return exec, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, id+".json"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("unified replication execution not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read unified replication execution: %w", err)
+	}
+	var loaded models.UnifiedReplicationExecution
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return nil, fmt.Errorf("failed to parse unified replication execution: %w", err)
+	}
+	return &loaded, nil
+}
+
+func (s *executionStore) list() []*models.UnifiedReplicationExecution {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*models.UnifiedReplicationExecution, 0, len(s.executions))
+	for _, exec := range s.executions {
+		out = append(out, exec)
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// breakerState is the gobreaker-style state machine backing circuitBreaker:
+// Closed allows calls, Open rejects them outright, and HalfOpen allows a
+// single probe call to decide whether to close again.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker trips to Open after failureThreshold consecutive failures
+// (5xx responses or connection errors), and stays there until resetTimeout
+// elapses, at which point a single call is let through to probe recovery.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	state            breakerState
+	failures         int
+	failureThreshold int
+	resetTimeout     time.Duration
+	openedAt         time.Time
+}
+
+func newCircuitBreaker(failureThreshold int, resetTimeout time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+	}
+}
+
+// allow reports whether a call should be attempted, transitioning Open to
+// HalfOpen once resetTimeout has elapsed.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		// The single probe is already in flight.
+		return false
+	}
+	if b.state != breakerOpen {
+		return true
+	}
+	if time.Since(b.openedAt) < b.resetTimeout {
+		return false
+	}
+	b.state = breakerHalfOpen
+	return true
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.state = breakerClosed
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.failureThreshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
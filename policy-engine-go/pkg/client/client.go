@@ -1,44 +1,183 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"time"
 
 	"github.com/datacline/policy-engine/internal/models"
 )
 
+// FailMode decides the fallback decision Evaluate/BatchEvaluate hand back
+// when the policy engine is unreachable after retries are exhausted or the
+// circuit breaker is open, so a downstream gateway can keep serving traffic
+// instead of blocking on a dead dependency.
+type FailMode string
+
+const (
+	// FailClosed denies traffic (the safer default) when the engine is
+	// unreachable.
+	FailClosed FailMode = "fail_closed"
+	// FailOpen allows traffic when the engine is unreachable.
+	FailOpen FailMode = "fail_open"
+)
+
+// ClientOptions configures retry, circuit breaking and fail-open/fail-closed
+// behavior for a Client.
+type ClientOptions struct {
+	// Timeout is the per-request HTTP timeout.
+	Timeout time.Duration
+	// MaxRetries is the number of additional attempts after the first,
+	// using exponential backoff with jitter between them.
+	MaxRetries  int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// FailureThreshold is the number of consecutive 5xx responses or
+	// connection errors that trip the circuit breaker open.
+	FailureThreshold int
+	// BreakerResetTimeout is how long the breaker stays open before
+	// letting a single probe request through.
+	BreakerResetTimeout time.Duration
+	// FailMode decides the decision Evaluate/BatchEvaluate return once
+	// retries are exhausted or the breaker is open.
+	FailMode FailMode
+	// OnAttempt, if set, is called after every HTTP attempt (including
+	// retries) with its 0-based attempt number and resulting error (nil on
+	// success), so callers can wire tracing such as OpenTelemetry spans.
+	OnAttempt func(attempt int, err error)
+}
+
+// DefaultClientOptions returns the options used by NewClient.
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		Timeout:             5 * time.Second,
+		MaxRetries:          2,
+		BaseBackoff:         100 * time.Millisecond,
+		MaxBackoff:          2 * time.Second,
+		FailureThreshold:    5,
+		BreakerResetTimeout: 30 * time.Second,
+		FailMode:            FailClosed,
+	}
+}
+
 // Client is a policy engine HTTP client
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL      string
+	httpClient   *http.Client
+	streamClient *http.Client // separate client forcing HTTP/2 for EvaluateStream
+	opts         ClientOptions
+	breaker      *circuitBreaker
 }
 
-// NewClient creates a new policy engine client
+// NewClient creates a new policy engine client using DefaultClientOptions.
 func NewClient(baseURL string) *Client {
+	return NewClientWithOptions(baseURL, DefaultClientOptions())
+}
+
+// NewClientWithOptions creates a policy engine client with custom retry,
+// circuit breaker and fail-mode behavior.
+func NewClientWithOptions(baseURL string, opts ClientOptions) *Client {
 	return &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: 5 * time.Second,
+			Timeout: opts.Timeout,
+		},
+		streamClient: &http.Client{
+			Transport: &http.Transport{ForceAttemptHTTP2: true},
 		},
+		opts:    opts,
+		breaker: newCircuitBreaker(opts.FailureThreshold, opts.BreakerResetTimeout),
 	}
 }
 
+// postWithResilience POSTs jsonData to url, retrying with exponential
+// backoff and jitter on connection errors or 5xx responses, short-circuiting
+// immediately while the circuit breaker is open.
+func (c *Client) postWithResilience(url string, jsonData []byte) (*http.Response, error) {
+	if !c.breaker.allow() {
+		return nil, fmt.Errorf("circuit breaker open for %s", c.baseURL)
+	}
+
+	var lastErr error
+	backoff := c.opts.BaseBackoff
+	for attempt := 0; attempt <= c.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(jitter(backoff))
+			backoff *= 2
+			if backoff > c.opts.MaxBackoff {
+				backoff = c.opts.MaxBackoff
+			}
+		}
+
+		resp, err := c.httpClient.Post(url, "application/json", bytes.NewReader(jsonData))
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			if c.opts.OnAttempt != nil {
+				c.opts.OnAttempt(attempt, nil)
+			}
+			c.breaker.recordSuccess()
+			return resp, nil
+		}
+
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("server error: status %d", resp.StatusCode)
+			resp.Body.Close()
+		}
+		if c.opts.OnAttempt != nil {
+			c.opts.OnAttempt(attempt, lastErr)
+		}
+	}
+
+	c.breaker.recordFailure()
+	return nil, lastErr
+}
+
+// jitter returns d plus up to d/2 of random jitter, to keep retrying clients
+// from synchronizing on the same backoff schedule.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
+
+// failModeResult returns the configured fallback decision for cause, the
+// error the caller should still be able to inspect and log.
+func (c *Client) failModeResult(cause error) (*models.PolicyEvaluationResult, error) {
+	action := models.ActionDeny
+	shouldBlock := true
+	if c.opts.FailMode == FailOpen {
+		action = models.ActionAllow
+		shouldBlock = false
+	}
+
+	return &models.PolicyEvaluationResult{
+		Action:      action,
+		ShouldBlock: shouldBlock,
+		Message:     fmt.Sprintf("policy engine unreachable (%s): %v", c.opts.FailMode, cause),
+		Timestamp:   time.Now(),
+	}, cause
+}
+
 // Evaluate sends a policy evaluation request
 func (c *Client) Evaluate(req *models.PolicyEvaluationRequest) (*models.PolicyEvaluationResult, error) {
 	url := fmt.Sprintf("%s/api/v1/evaluate", c.baseURL)
-	
+
 	jsonData, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := c.postWithResilience(url, jsonData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return c.failModeResult(err)
 	}
 	defer resp.Body.Close()
 
@@ -58,16 +197,21 @@ func (c *Client) Evaluate(req *models.PolicyEvaluationRequest) (*models.PolicyEv
 // BatchEvaluate sends a batch policy evaluation request
 func (c *Client) BatchEvaluate(requests []models.PolicyEvaluationRequest) ([]models.PolicyEvaluationResult, error) {
 	url := fmt.Sprintf("%s/api/v1/evaluate/batch", c.baseURL)
-	
+
 	batchReq := models.BatchEvaluationRequest{Requests: requests}
 	jsonData, err := json.Marshal(batchReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := c.postWithResilience(url, jsonData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		fallback, _ := c.failModeResult(err)
+		results := make([]models.PolicyEvaluationResult, len(requests))
+		for i := range results {
+			results[i] = *fallback
+		}
+		return results, err
 	}
 	defer resp.Body.Close()
 
@@ -84,10 +228,66 @@ func (c *Client) BatchEvaluate(requests []models.PolicyEvaluationRequest) ([]mod
 	return batchResp.Results, nil
 }
 
+// EvaluateStream streams evaluation requests to POST /api/v1/evaluate/stream
+// as NDJSON and delivers results on out as they arrive, so a caller can
+// pipeline request production with evaluation instead of waiting for a whole
+// batch to complete. It closes out and returns once in is closed and drained
+// or the request fails; ctx cancellation aborts the stream early. Unlike
+// Evaluate/BatchEvaluate it does not retry or consult the circuit breaker —
+// callers needing that should fall back to BatchEvaluate.
+func (c *Client) EvaluateStream(ctx context.Context, in <-chan *models.PolicyEvaluationRequest, out chan<- *models.PolicyEvaluationResult) error {
+	defer close(out)
+
+	pr, pw := io.Pipe()
+	go func() {
+		encoder := json.NewEncoder(pw)
+		for req := range in {
+			if err := encoder.Encode(req); err != nil {
+				pw.CloseWithError(fmt.Errorf("failed to encode request: %w", err))
+				return
+			}
+		}
+		pw.Close()
+	}()
+
+	url := fmt.Sprintf("%s/api/v1/evaluate/stream", c.baseURL)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := c.streamClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to start evaluation stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var result models.PolicyEvaluationResult
+		if err := json.Unmarshal(line, &result); err != nil {
+			return fmt.Errorf("failed to decode result: %w", err)
+		}
+		out <- &result
+	}
+	return scanner.Err()
+}
+
 // Reload triggers a policy reload
 func (c *Client) Reload() error {
 	url := fmt.Sprintf("%s/api/v1/reload", c.baseURL)
-	
+
 	resp, err := c.httpClient.Post(url, "application/json", nil)
 	if err != nil {
 		return fmt.Errorf("failed to send reload request: %w", err)
@@ -105,7 +305,7 @@ func (c *Client) Reload() error {
 // HealthCheck checks if the service is healthy
 func (c *Client) HealthCheck() error {
 	url := fmt.Sprintf("%s/health", c.baseURL)
-	
+
 	resp, err := c.httpClient.Get(url)
 	if err != nil {
 		return fmt.Errorf("failed to send health check: %w", err)
@@ -126,7 +326,7 @@ func (c *Client) HealthCheck() error {
 // ListPolicies retrieves all policies
 func (c *Client) ListPolicies() ([]*models.Policy, error) {
 	url := fmt.Sprintf("%s/api/v1/policies", c.baseURL)
-	
+
 	resp, err := c.httpClient.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list policies: %w", err)
@@ -152,7 +352,7 @@ func (c *Client) ListPolicies() ([]*models.Policy, error) {
 // GetPolicy retrieves a specific policy by ID
 func (c *Client) GetPolicy(id string) (*models.Policy, error) {
 	url := fmt.Sprintf("%s/api/v1/policies/%s", c.baseURL, id)
-	
+
 	resp, err := c.httpClient.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get policy: %w", err)
@@ -178,7 +378,7 @@ func (c *Client) GetPolicy(id string) (*models.Policy, error) {
 // CreatePolicy creates a new policy
 func (c *Client) CreatePolicy(policy *models.Policy) (*models.Policy, error) {
 	url := fmt.Sprintf("%s/api/v1/policies", c.baseURL)
-	
+
 	jsonData, err := json.Marshal(policy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal policy: %w", err)
@@ -206,7 +406,7 @@ func (c *Client) CreatePolicy(policy *models.Policy) (*models.Policy, error) {
 // UpdatePolicy updates an existing policy
 func (c *Client) UpdatePolicy(id string, policy *models.Policy) (*models.Policy, error) {
 	url := fmt.Sprintf("%s/api/v1/policies/%s", c.baseURL, id)
-	
+
 	jsonData, err := json.Marshal(policy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal policy: %w", err)
@@ -240,7 +440,7 @@ func (c *Client) UpdatePolicy(id string, policy *models.Policy) (*models.Policy,
 // DeletePolicy deletes a policy
 func (c *Client) DeletePolicy(id string) error {
 	url := fmt.Sprintf("%s/api/v1/policies/%s", c.baseURL, id)
-	
+
 	req, err := http.NewRequest(http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -260,10 +460,122 @@ func (c *Client) DeletePolicy(id string) error {
 	return nil
 }
 
+// ListScopedPolicies retrieves all policies bound to a scope. scopeID is
+// ignored for models.ScopeGlobal.
+func (c *Client) ListScopedPolicies(scopeType models.PolicyScopeType, scopeID string) ([]*models.Policy, error) {
+	url := fmt.Sprintf("%s%s", c.baseURL, scopedPoliciesPath(scopeType, scopeID))
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scoped policies: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Policies []*models.Policy `json:"policies"`
+		Count    int              `json:"count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Policies, nil
+}
+
+// CreateScopedPolicy creates a new policy bound to a scope. scopeID is
+// ignored for models.ScopeGlobal.
+func (c *Client) CreateScopedPolicy(scopeType models.PolicyScopeType, scopeID string, policy *models.Policy) (*models.Policy, error) {
+	url := fmt.Sprintf("%s%s", c.baseURL, scopedPoliciesPath(scopeType, scopeID))
+
+	jsonData, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy: %w", err)
+	}
+
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scoped policy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var created models.Policy
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &created, nil
+}
+
+func scopedPoliciesPath(scopeType models.PolicyScopeType, scopeID string) string {
+	if scopeType != models.ScopeTenant && scopeType != models.ScopePrincipal {
+		return "/api/v1/scopes/global/policies"
+	}
+	return fmt.Sprintf("/api/v1/scopes/%s/%s/policies", scopeType, scopeID)
+}
+
+// PolicyHistory retrieves the recorded revision history for a policy,
+// oldest first.
+func (c *Client) PolicyHistory(id string) ([]*models.PolicyRevision, error) {
+	url := fmt.Sprintf("%s/api/v1/policies/%s/history", c.baseURL, id)
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy history: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Revisions []*models.PolicyRevision `json:"revisions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Revisions, nil
+}
+
+// RollbackPolicy restores a policy to a prior revision.
+func (c *Client) RollbackPolicy(id, revisionID string) (*models.Policy, error) {
+	url := fmt.Sprintf("%s/api/v1/policies/%s/rollback/%s", c.baseURL, id, revisionID)
+
+	resp, err := c.httpClient.Post(url, "application/json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back policy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var policy models.Policy
+	if err := json.NewDecoder(resp.Body).Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &policy, nil
+}
+
 // EnablePolicy enables a policy
 func (c *Client) EnablePolicy(id string) error {
 	url := fmt.Sprintf("%s/api/v1/policies/%s/enable", c.baseURL, id)
-	
+
 	resp, err := c.httpClient.Post(url, "application/json", nil)
 	if err != nil {
 		return fmt.Errorf("failed to enable policy: %w", err)
@@ -281,7 +593,7 @@ func (c *Client) EnablePolicy(id string) error {
 // DisablePolicy disables a policy
 func (c *Client) DisablePolicy(id string) error {
 	url := fmt.Sprintf("%s/api/v1/policies/%s/disable", c.baseURL, id)
-	
+
 	resp, err := c.httpClient.Post(url, "application/json", nil)
 	if err != nil {
 		return fmt.Errorf("failed to disable policy: %w", err)
@@ -296,10 +608,40 @@ func (c *Client) DisablePolicy(id string) error {
 	return nil
 }
 
+// TestPolicy runs testCases against a candidate policy without creating it,
+// mirroring the kyverno `validate` CLI flow so CI can gate CreatePolicy calls
+// on regression tests before the policy ever goes live.
+func (c *Client) TestPolicy(policy *models.Policy, testCases []models.PolicyTestCase) (*models.PolicyTestReport, error) {
+	url := fmt.Sprintf("%s/api/v1/policies/test", c.baseURL)
+
+	jsonData, err := json.Marshal(models.PolicyTestRequest{Policy: *policy, TestCases: testCases})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal test request: %w", err)
+	}
+
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send test request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var report models.PolicyTestReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &report, nil
+}
+
 // ValidatePolicy validates a policy without creating it
 func (c *Client) ValidatePolicy(policy *models.Policy) (bool, error) {
 	url := fmt.Sprintf("%s/api/v1/policies/validate", c.baseURL)
-	
+
 	jsonData, err := json.Marshal(policy)
 	if err != nil {
 		return false, fmt.Errorf("failed to marshal policy: %w", err)
@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// ParseCasbin parses Casbin-style `p, sub, obj, act[, eft]` policy CSV rows
+// into EnhancedPolicy objects, one per row. `eft` is optional and defaults
+// to "allow"; a value other than "allow" or "deny" is rejected. A row that isn't a "p" policy
+// row of exactly 4 or 5 fields - Casbin's "g" grouping rows and custom
+// matcher functions have no EnhancedPolicy equivalent - is rejected rather
+// than guessed at.
+func ParseCasbin(data []byte) ([]*models.EnhancedPolicy, error) {
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var policies []*models.EnhancedPolicy
+	rowNo := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNo+1, err)
+		}
+		rowNo++
+
+		if len(record) == 0 {
+			continue
+		}
+		policy, err := casbinRecordToPolicy(record)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNo, err)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+func casbinRecordToPolicy(record []string) (*models.EnhancedPolicy, error) {
+	if record[0] != "p" {
+		return nil, fmt.Errorf("unsupported Casbin record type %q (only \"p\" policy rows are supported)", record[0])
+	}
+	if len(record) != 4 && len(record) != 5 {
+		return nil, fmt.Errorf("expected \"p, sub, obj, act[, eft]\", got %d fields", len(record))
+	}
+
+	sub := strings.TrimSpace(record[1])
+	obj := strings.TrimSpace(record[2])
+	act := strings.TrimSpace(record[3])
+
+	action := models.PolicyActionAllow
+	if len(record) == 5 {
+		switch eft := strings.TrimSpace(record[4]); eft {
+		case "allow":
+			action = models.PolicyActionAllow
+		case "deny":
+			action = models.PolicyActionDeny
+		default:
+			return nil, fmt.Errorf("unknown eft %q (want allow or deny)", eft)
+		}
+	}
+
+	subject := models.Subject{Type: models.SubjectTypeAll}
+	if sub != "*" {
+		subject = models.Subject{Type: models.SubjectTypeUser, Values: []string{sub}}
+	}
+
+	scope, conditions, err := scopeFromResourcesAndTools([]string{obj}, []string{act})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EnhancedPolicy{
+		Name:       fmt.Sprintf("casbin: p, %s, %s, %s", sub, obj, act),
+		Type:       models.PolicyTypeServerLevel,
+		Action:     action,
+		Enabled:    true,
+		AppliesTo:  subject,
+		Scope:      scope,
+		Conditions: conditions,
+	}, nil
+}
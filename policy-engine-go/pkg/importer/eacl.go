@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// ParseEACL parses FrostFS-style textual eACL rules, one per line, of the
+// form:
+//
+//	<allow|deny> <operation> [where <user|group|role|ip> <in|eq> <value>] to resource:<target|*>
+//
+// e.g. `allow object.get where user in {alice,bob} to resource:*`. Blank
+// lines and lines starting with '#' are skipped. Each line becomes one
+// EnhancedPolicy; a line whose grammar or where-field has no EnhancedPolicy
+// equivalent is rejected rather than approximated.
+func ParseEACL(data []byte) ([]*models.EnhancedPolicy, error) {
+	var policies []*models.EnhancedPolicy
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		policy, err := parseEACLLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		policies = append(policies, policy)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func parseEACLLine(line string) (*models.EnhancedPolicy, error) {
+	tokens := strings.Fields(line)
+	if len(tokens) < 2 {
+		return nil, fmt.Errorf("expected \"<allow|deny> <operation> ...\", got %q", line)
+	}
+
+	var action models.PolicyAction
+	switch tokens[0] {
+	case "allow":
+		action = models.PolicyActionAllow
+	case "deny":
+		action = models.PolicyActionDeny
+	default:
+		return nil, fmt.Errorf("unknown effect %q (want allow or deny)", tokens[0])
+	}
+	operation := tokens[1]
+	rest := tokens[2:]
+
+	subject := models.Subject{Type: models.SubjectTypeAll}
+	var conditions []models.PolicyConditionEnhanced
+	if len(rest) > 0 && rest[0] == "where" {
+		if len(rest) < 4 {
+			return nil, fmt.Errorf("incomplete where clause in %q", line)
+		}
+		s, c, err := eaclWhereClause(rest[1], rest[2], rest[3])
+		if err != nil {
+			return nil, err
+		}
+		subject, conditions = s, c
+		rest = rest[4:]
+	}
+
+	if len(rest) != 2 || rest[0] != "to" {
+		return nil, fmt.Errorf("expected trailing \"to resource:<target>\" in %q", line)
+	}
+	if !strings.HasPrefix(rest[1], "resource:") {
+		return nil, fmt.Errorf("expected \"resource:<target>\", got %q", rest[1])
+	}
+	target := strings.TrimPrefix(rest[1], "resource:")
+	if target == "" {
+		return nil, fmt.Errorf("empty resource target in %q", line)
+	}
+
+	scope, scopeConditions, err := scopeFromResourcesAndTools([]string{target}, []string{operation})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EnhancedPolicy{
+		Name:       fmt.Sprintf("eACL: %s", line),
+		Type:       models.PolicyTypeServerLevel,
+		Action:     action,
+		Enabled:    true,
+		AppliesTo:  subject,
+		Scope:      scope,
+		Conditions: append(conditions, scopeConditions...),
+	}, nil
+}
+
+func eaclWhereClause(field, op, value string) (models.Subject, []models.PolicyConditionEnhanced, error) {
+	switch field {
+	case "user":
+		return eaclSubjectClause(models.SubjectTypeUser, op, value)
+	case "group":
+		return eaclSubjectClause(models.SubjectTypeGroup, op, value)
+	case "role":
+		return eaclSubjectClause(models.SubjectTypeRole, op, value)
+	case "ip":
+		cond, err := eaclIPCondition(op, value)
+		if err != nil {
+			return models.Subject{}, nil, err
+		}
+		return models.Subject{Type: models.SubjectTypeAll}, []models.PolicyConditionEnhanced{cond}, nil
+	default:
+		return models.Subject{}, nil, fmt.Errorf("unsupported where-field %q (want user, group, role, or ip)", field)
+	}
+}
+
+func eaclSubjectClause(subjectType models.SubjectType, op, value string) (models.Subject, []models.PolicyConditionEnhanced, error) {
+	if op != "in" {
+		return models.Subject{}, nil, fmt.Errorf("unsupported where-operator %q for %s (want in)", op, subjectType)
+	}
+	values, err := eaclSet(value)
+	if err != nil {
+		return models.Subject{}, nil, err
+	}
+	return models.Subject{Type: subjectType, Values: values}, nil, nil
+}
+
+func eaclIPCondition(op, value string) (models.PolicyConditionEnhanced, error) {
+	switch op {
+	case "in":
+		ranges, err := eaclSet(value)
+		if err != nil {
+			return models.PolicyConditionEnhanced{}, err
+		}
+		values := make([]interface{}, len(ranges))
+		for i, r := range ranges {
+			values[i] = r
+		}
+		return models.PolicyConditionEnhanced{Field: models.MetadataRequestIP, Operator: models.ConditionOpInIPRange, Value: values}, nil
+	case "eq":
+		return models.PolicyConditionEnhanced{Field: models.MetadataRequestIP, Operator: models.ConditionOpEquals, Value: value}, nil
+	default:
+		return models.PolicyConditionEnhanced{}, fmt.Errorf("unsupported where-operator %q for ip (want in or eq)", op)
+	}
+}
+
+// eaclSet parses a `{a,b,c}` set literal into its elements.
+func eaclSet(value string) ([]string, error) {
+	if !strings.HasPrefix(value, "{") || !strings.HasSuffix(value, "}") {
+		return nil, fmt.Errorf("expected a {a,b,c} set, got %q", value)
+	}
+	parts := strings.Split(value[1:len(value)-1], ",")
+	values := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return nil, fmt.Errorf("empty element in set %q", value)
+		}
+		values = append(values, p)
+	}
+	return values, nil
+}
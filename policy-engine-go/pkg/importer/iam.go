@@ -0,0 +1,185 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+	"github.com/datacline/policy-engine/internal/policyformat"
+)
+
+// iamConditionOps maps an IAM condition operator keyword to the closest
+// ConditionOperatorEnhanced. StringLike's glob pattern is translated to an
+// equivalent regex (see iamWildcardToRegex) rather than approximated by
+// ConditionOpContains, so the match stays exact.
+var iamConditionOps = map[string]models.ConditionOperatorEnhanced{
+	"StringEquals":       models.ConditionOpEquals,
+	"StringNotEquals":    models.ConditionOpNotEquals,
+	"StringLike":         models.ConditionOpMatches,
+	"IpAddress":          models.ConditionOpInIPRange,
+	"NotIpAddress":       models.ConditionOpNotInIPRange,
+	"NumericGreaterThan": models.ConditionOpGreaterThan,
+	"NumericLessThan":    models.ConditionOpLessThan,
+}
+
+// iamConditionFields maps the well-known "aws:*" condition keys to their
+// MetadataConditionField equivalent. Any other "aws:*" key is rejected; a
+// key outside the "aws:" namespace is treated as a payload field
+// ("payload.<key>"), the same convention getFieldValue already uses
+// for tool arguments.
+var iamConditionFields = map[string]models.MetadataConditionField{
+	"aws:SourceIp":  models.MetadataRequestIP,
+	"aws:UserAgent": models.MetadataRequestUserAgent,
+}
+
+// ParseIAM parses an AWS IAM-style JSON policy document (Version +
+// Statement[]) into one EnhancedPolicy per statement. Sid becomes the
+// policy Name when set. Principal must be absent, "*", or a single/array
+// "AWS" principal - any other shape (Service principals, NotPrincipal,
+// federated principals) is rejected rather than approximated. A Condition
+// operator or "aws:*" key outside the tables above is similarly rejected.
+func ParseIAM(data []byte) ([]*models.EnhancedPolicy, error) {
+	doc, err := policyformat.ParseIAMDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]*models.EnhancedPolicy, 0, len(doc.Statement))
+	for i, stmt := range doc.Statement {
+		policy, err := iamStatementToPolicy(stmt)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %w", i, err)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+func iamStatementToPolicy(stmt policyformat.IAMStatement) (*models.EnhancedPolicy, error) {
+	var action models.PolicyAction
+	switch stmt.Effect {
+	case "Allow":
+		action = models.PolicyActionAllow
+	case "Deny":
+		action = models.PolicyActionDeny
+	default:
+		return nil, fmt.Errorf("unknown Effect %q (want Allow or Deny)", stmt.Effect)
+	}
+
+	subject, err := iamPrincipalToSubject(stmt.Principal)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, scopeConditions, err := scopeFromResourcesAndTools([]string(stmt.Resource), []string(stmt.Action))
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := iamConditions(stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	name := stmt.Sid
+	if name == "" {
+		name = fmt.Sprintf("iam: %s %v on %v", stmt.Effect, []string(stmt.Action), []string(stmt.Resource))
+	}
+
+	return &models.EnhancedPolicy{
+		Name:       name,
+		Type:       models.PolicyTypeServerLevel,
+		Action:     action,
+		Enabled:    true,
+		AppliesTo:  subject,
+		Scope:      scope,
+		Conditions: append(conditions, scopeConditions...),
+	}, nil
+}
+
+func iamPrincipalToSubject(raw json.RawMessage) (models.Subject, error) {
+	if len(raw) == 0 {
+		return models.Subject{Type: models.SubjectTypeAll}, nil
+	}
+
+	var wildcard string
+	if err := json.Unmarshal(raw, &wildcard); err == nil {
+		if wildcard != "*" {
+			return models.Subject{}, fmt.Errorf("unsupported bare Principal %q (only \"*\" is)", wildcard)
+		}
+		return models.Subject{Type: models.SubjectTypeAll}, nil
+	}
+
+	var byType map[string]policyformat.StringOrSlice
+	if err := json.Unmarshal(raw, &byType); err != nil {
+		return models.Subject{}, fmt.Errorf("unsupported Principal shape: %w", err)
+	}
+	aws, ok := byType["AWS"]
+	if !ok || len(byType) != 1 {
+		return models.Subject{}, fmt.Errorf("only a Principal.AWS list has a lossless mapping, got keys %v", principalKeys(byType))
+	}
+	return models.Subject{Type: models.SubjectTypeUser, Values: []string(aws)}, nil
+}
+
+func principalKeys(m map[string]policyformat.StringOrSlice) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func iamConditions(cond map[string]map[string]interface{}) ([]models.PolicyConditionEnhanced, error) {
+	var conditions []models.PolicyConditionEnhanced
+	for opName, fields := range cond {
+		op, ok := iamConditionOps[opName]
+		if !ok {
+			return nil, fmt.Errorf("unsupported condition operator %q", opName)
+		}
+		for field, value := range fields {
+			metaField, ok := iamConditionFields[field]
+			if !ok {
+				if strings.HasPrefix(field, "aws:") {
+					return nil, fmt.Errorf("unsupported condition key %q", field)
+				}
+				metaField = models.MetadataConditionField("payload." + field)
+			}
+			if op == models.ConditionOpMatches {
+				pattern, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("condition %s on %q: StringLike requires a string pattern", opName, field)
+				}
+				value = iamWildcardToRegex(pattern)
+			}
+			conditions = append(conditions, models.PolicyConditionEnhanced{
+				Field:    metaField,
+				Operator: op,
+				Value:    value,
+			})
+		}
+	}
+	return conditions, nil
+}
+
+// iamWildcardToRegex translates an IAM StringLike glob ('*' matches any run
+// of characters, '?' matches exactly one) into the equivalent regexp, so
+// ConditionOpMatches (a regex match) is an exact stand-in rather than an
+// approximation.
+func iamWildcardToRegex(pattern string) string {
+	var b strings.Builder
+	b.WriteByte('^')
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteByte('$')
+	return b.String()
+}
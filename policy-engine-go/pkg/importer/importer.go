@@ -0,0 +1,42 @@
+// Package importer converts external policy formats into EnhancedPolicy
+// objects that enhanced.Storage.CreatePolicy can persist, so an operator
+// migrating from another access-control system can hand it a file instead
+// of hand-authoring YAML. Three formats are supported: FrostFS-style eACL
+// rules (ParseEACL), AWS IAM-style JSON policy documents (ParseIAM), and
+// Casbin policy CSV (ParseCasbin).
+//
+// Every parser rejects a construct it can't map onto EnhancedPolicy losslessly
+// instead of silently dropping or approximating it - a Principal shape IAM
+// doesn't define a mapping for, an eACL where-field with no EnhancedPolicy
+// equivalent, a Casbin row of the wrong shape - so a migration either
+// reproduces the source system's access control exactly or fails loudly.
+package importer
+
+import (
+	"fmt"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// Format names an external policy format Parse can dispatch to.
+type Format string
+
+const (
+	FormatEACL   Format = "eacl"
+	FormatIAM    Format = "iam"
+	FormatCasbin Format = "casbin"
+)
+
+// Parse converts data, in the given format, into EnhancedPolicy objects.
+func Parse(format Format, data []byte) ([]*models.EnhancedPolicy, error) {
+	switch format {
+	case FormatEACL:
+		return ParseEACL(data)
+	case FormatIAM:
+		return ParseIAM(data)
+	case FormatCasbin:
+		return ParseCasbin(data)
+	default:
+		return nil, fmt.Errorf("unknown import format %q (want eacl, iam, or casbin)", format)
+	}
+}
@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/datacline/policy-engine/internal/models"
+)
+
+// scopeFromResourcesAndTools maps a set of resources (IAM Resource, Casbin
+// obj, eACL resource target) and tools (IAM Action, Casbin act, eACL
+// operation) onto the closest AccessScope. AccessScope has no "any server"
+// wildcard of its own for PolicyScopeSpecificTools - ServerIDs must be
+// non-empty for evaluateScope to match at all - so a specific tool scoped to
+// any resource falls back to PolicyScopeAllServers plus an extra
+// ConditionOpExpression condition matching tool.name exactly, rather than
+// silently dropping the tool restriction.
+func scopeFromResourcesAndTools(resources, tools []string) (models.AccessScope, []models.PolicyConditionEnhanced, error) {
+	anyResource := len(resources) == 1 && resources[0] == "*"
+	anyTool := len(tools) == 1 && tools[0] == "*"
+
+	switch {
+	case anyResource && anyTool:
+		return models.AccessScope{Type: models.PolicyScopeAllServers}, nil, nil
+	case !anyResource && anyTool:
+		return models.AccessScope{Type: models.PolicyScopeEntireServer, ServerIDs: resources}, nil, nil
+	case !anyResource && !anyTool:
+		return models.AccessScope{Type: models.PolicyScopeSpecificTools, ServerIDs: resources, ToolNames: tools}, nil, nil
+	default:
+		expr, err := toolNameExpression(tools)
+		if err != nil {
+			return models.AccessScope{}, nil, err
+		}
+		return models.AccessScope{Type: models.PolicyScopeAllServers}, []models.PolicyConditionEnhanced{
+			{Operator: models.ConditionOpExpression, Value: expr},
+		}, nil
+	}
+}
+
+// toolNameExpression builds a CEL expression matching tool.name against
+// tools exactly, for the any-resource/specific-tool scope fallback.
+func toolNameExpression(tools []string) (string, error) {
+	if len(tools) == 0 {
+		return "", fmt.Errorf("no tool name given to scope to")
+	}
+	quoted := make([]string, len(tools))
+	for i, t := range tools {
+		quoted[i] = strconv.Quote(t)
+	}
+	return fmt.Sprintf("tool.name in [%s]", strings.Join(quoted, ", ")), nil
+}